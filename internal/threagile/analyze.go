@@ -2,11 +2,13 @@ package threagile
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/threagile/threagile/pkg/model"
 	"github.com/threagile/threagile/pkg/report"
 	"github.com/threagile/threagile/pkg/risks"
+	"github.com/threagile/threagile/pkg/types"
 )
 
 func (what *Threagile) initAnalyze() *Threagile {
@@ -19,6 +21,12 @@ func (what *Threagile) initAnalyze() *Threagile {
 			commands := what.readCommands()
 			progressReporter := DefaultProgressReporter{Verbose: what.config.GetVerbose()}
 
+			// the baseline is read before any report is written, as it is commonly the risks.json of the previous run in the output folder
+			gate, err := what.newRiskGate()
+			if err != nil {
+				return err
+			}
+
 			r, err := model.ReadAndAnalyzeModel(what.config, risks.GetBuiltInRiskRules(), progressReporter)
 			if err != nil {
 				return fmt.Errorf("failed to read and analyze model: %w", err)
@@ -28,7 +36,8 @@ func (what *Threagile) initAnalyze() *Threagile {
 			if err != nil {
 				return fmt.Errorf("failed to generate reports: %w", err)
 			}
-			return nil
+
+			return what.checkFailOn(cmd, gate, r)
 		},
 		CompletionOptions: cobra.CompletionOptions{
 			DisableDefaultCmd: true,
@@ -39,3 +48,40 @@ func (what *Threagile) initAnalyze() *Threagile {
 
 	return what
 }
+
+// newRiskGate builds the gate for the --fail-on settings including its baseline, it returns nil if --fail-on is not set
+func (what *Threagile) newRiskGate() (*model.RiskGate, error) {
+	if len(what.config.GetFailOnSeverity()) == 0 {
+		return nil, nil
+	}
+
+	var baseline []*types.Risk
+	if len(what.config.GetBaselineFile()) > 0 {
+		var baselineError error
+		baseline, baselineError = report.ReadRisksJSON(what.config.GetBaselineFile())
+		if baselineError != nil {
+			return nil, fmt.Errorf("failed to read baseline: %w", baselineError)
+		}
+	}
+
+	return model.NewRiskGate(what.config.GetFailOnSeverity(), what.config.GetFailOnStatus(), baseline)
+}
+
+// checkFailOn fails the analysis if the model contains new or worsened risks violating the --fail-on settings
+func (what *Threagile) checkFailOn(cmd *cobra.Command, gate *model.RiskGate, r *model.ReadResult) error {
+	if gate == nil {
+		return nil
+	}
+
+	failingRisks := gate.FailingRisks(r.ParsedModel)
+	if len(failingRisks) == 0 {
+		return nil
+	}
+
+	cmd.Printf("Found %d new or worsened risks with severity %v or above:\n", len(failingRisks), gate.Severity.Title())
+	for _, risk := range failingRisks {
+		cmd.Printf("  [%v, %v] %v\n", risk.Severity.Title(), risk.RiskStatus.Title(), risk.SyntheticId)
+	}
+
+	return fmt.Errorf("%d new or worsened risks of severity %v or above are %v", len(failingRisks), gate.Severity.Title(), strings.Join(what.config.GetFailOnStatus(), " or "))
+}
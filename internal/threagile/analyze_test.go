@@ -0,0 +1,70 @@
+package threagile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeFailsOnNewRisksWithPreviousOutputAsBaseline(t *testing.T) {
+	outputFolder := t.TempDir()
+	baselineFile := filepath.Join(outputFolder, "risks.json")
+
+	// the previous run found no risks at all, this run overwrites the file with the current risks
+	assert.NoError(t, os.WriteFile(baselineFile, []byte("[]"), 0600))
+
+	err := runAnalyze(t, outputFolder, "--"+failOnFlagName, "low", "--"+baselineFlagName, baselineFile)
+
+	assert.Error(t, err)
+}
+
+func TestAnalyzeRejectsInvalidFailOnBeforeWritingReports(t *testing.T) {
+	outputFolder := t.TempDir()
+
+	err := runAnalyze(t, outputFolder, "--"+failOnFlagName, "very-bad")
+
+	assert.Error(t, err)
+	assert.NoFileExists(t, filepath.Join(outputFolder, "risks.json"))
+}
+
+func runAnalyze(t *testing.T, outputFolder string, args ...string) error {
+	t.Helper()
+
+	// flags are taken from the command line when initializing, just like when running the executable
+	defer func(osArgs []string) { os.Args = osArgs }(os.Args)
+	os.Args = append([]string{"threagile", AnalyzeModelCommand,
+		"--" + inputFileFlagName, filepath.Join("..", "..", "test", "main.yaml"),
+		"--" + outputFlagName, outputFolder,
+		"--" + tempDirFlagName, t.TempDir(),
+		"--" + skipDataFlowDiagramFlagName, "--" + skipDataAssetDiagramFlagName, "--" + skipRiskOverlayDiagramFlagName,
+		"--" + skipTechnicalAssetDiagramsFlagName, "--" + skipRisksSARIFFlagName, "--" + skipTechnicalAssetsJSONFlagName,
+		"--" + skipStatsJSONFlagName, "--" + skipAttackPathsJSONFlagName, "--" + skipAttackTreesFlagName,
+		"--" + skipLossExposureJSONFlagName, "--" + skipRisksExcelFlagName, "--" + skipTagsExcelFlagName,
+		"--" + skipReportPDFFlagName, "--" + skipReportADOCFlagName, "--" + skipReportHTMLFlagName,
+	}, args...)
+
+	what := new(Threagile).Init("")
+	what.rootCmd.SetOut(new(discardWriter))
+	what.rootCmd.SetArgs(os.Args[1:])
+
+	return what.rootCmd.Execute()
+}
+
+type discardWriter struct{}
+
+func (what *discardWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func TestAnalyzeRejectsInvalidDataFlowDiagramScopesBeforeWritingReports(t *testing.T) {
+	outputFolder := t.TempDir()
+
+	assert.Error(t, runAnalyze(t, outputFolder, "--"+dataFlowDiagramTechnicalAssetsFlagName, "bogus"))
+	assert.Error(t, runAnalyze(t, outputFolder, "--"+dataFlowDiagramHopsFlagName, "-1"))
+	assert.NoFileExists(t, filepath.Join(outputFolder, "risks.json"))
+
+	assert.NoError(t, runAnalyze(t, outputFolder, "--"+dataFlowDiagramTechnicalAssetsFlagName, "apache-webserver", "--"+dataFlowDiagramHopsFlagName, "2"))
+	assert.FileExists(t, filepath.Join(outputFolder, "risks.json"))
+}
@@ -32,27 +32,42 @@ type Config struct {
 	TempFolderValue   string `json:"TempFolder,omitempty" yaml:"TempFolder"`
 	KeyFolderValue    string `json:"KeyFolder,omitempty" yaml:"KeyFolder"`
 
-	InputFileValue                   string `json:"InputFile,omitempty" yaml:"InputFile"`
-	ImportedInputFileValue           string `json:"ImportedInputFile,omitempty" yaml:"ImportedInputFile"`
-	DataFlowDiagramFilenamePNGValue  string `json:"DataFlowDiagramFilenamePNG,omitempty" yaml:"DataFlowDiagramFilenamePNG"`
-	DataAssetDiagramFilenamePNGValue string `json:"DataAssetDiagramFilenamePNG,omitempty" yaml:"DataAssetDiagramFilenamePNG"`
-	DataFlowDiagramFilenameDOTValue  string `json:"DataFlowDiagramFilenameDOT,omitempty" yaml:"DataFlowDiagramFilenameDOT"`
-	DataAssetDiagramFilenameDOTValue string `json:"DataAssetDiagramFilenameDOT,omitempty" yaml:"DataAssetDiagramFilenameDOT"`
-	ReportFilenameValue              string `json:"ReportFilename,omitempty" yaml:"ReportFilename"`
-	ExcelRisksFilenameValue          string `json:"ExcelRisksFilename,omitempty" yaml:"ExcelRisksFilename"`
-	ExcelTagsFilenameValue           string `json:"ExcelTagsFilename,omitempty" yaml:"ExcelTagsFilename"`
-	JsonRisksFilenameValue           string `json:"JsonRisksFilename,omitempty" yaml:"JsonRisksFilename"`
-	JsonTechnicalAssetsFilenameValue string `json:"JsonTechnicalAssetsFilename,omitempty" yaml:"JsonTechnicalAssetsFilename"`
-	JsonStatsFilenameValue           string `json:"JsonStatsFilename,omitempty" yaml:"JsonStatsFilename"`
-	TemplateFilenameValue            string `json:"TemplateFilename,omitempty" yaml:"TemplateFilename"`
-	ReportLogoImagePathValue         string `json:"ReportLogoImagePath,omitempty" yaml:"ReportLogoImagePath"`
-	TechnologyFilenameValue          string `json:"TechnologyFilename,omitempty" yaml:"TechnologyFilename"`
+	InputFileValue                     string `json:"InputFile,omitempty" yaml:"InputFile"`
+	ImportedInputFileValue             string `json:"ImportedInputFile,omitempty" yaml:"ImportedInputFile"`
+	DataFlowDiagramFilenamePNGValue    string `json:"DataFlowDiagramFilenamePNG,omitempty" yaml:"DataFlowDiagramFilenamePNG"`
+	DataAssetDiagramFilenamePNGValue   string `json:"DataAssetDiagramFilenamePNG,omitempty" yaml:"DataAssetDiagramFilenamePNG"`
+	DataFlowDiagramFilenameDOTValue    string `json:"DataFlowDiagramFilenameDOT,omitempty" yaml:"DataFlowDiagramFilenameDOT"`
+	DataAssetDiagramFilenameDOTValue   string `json:"DataAssetDiagramFilenameDOT,omitempty" yaml:"DataAssetDiagramFilenameDOT"`
+	RiskOverlayDiagramFilenamePNGValue string `json:"RiskOverlayDiagramFilenamePNG,omitempty" yaml:"RiskOverlayDiagramFilenamePNG"`
+	RiskOverlayDiagramFilenameDOTValue string `json:"RiskOverlayDiagramFilenameDOT,omitempty" yaml:"RiskOverlayDiagramFilenameDOT"`
+	ReportFilenameValue                string `json:"ReportFilename,omitempty" yaml:"ReportFilename"`
+	HtmlReportFilenameValue            string `json:"HtmlReportFilename,omitempty" yaml:"HtmlReportFilename"`
+	ExcelRisksFilenameValue            string `json:"ExcelRisksFilename,omitempty" yaml:"ExcelRisksFilename"`
+	ExcelTagsFilenameValue             string `json:"ExcelTagsFilename,omitempty" yaml:"ExcelTagsFilename"`
+	JsonRisksFilenameValue             string `json:"JsonRisksFilename,omitempty" yaml:"JsonRisksFilename"`
+	SarifRisksFilenameValue            string `json:"SarifRisksFilename,omitempty" yaml:"SarifRisksFilename"`
+	JsonTechnicalAssetsFilenameValue   string `json:"JsonTechnicalAssetsFilename,omitempty" yaml:"JsonTechnicalAssetsFilename"`
+	JsonStatsFilenameValue             string `json:"JsonStatsFilename,omitempty" yaml:"JsonStatsFilename"`
+	JsonAttackPathsFilenameValue       string `json:"JsonAttackPathsFilename,omitempty" yaml:"JsonAttackPathsFilename"`
+	JsonAttackTreesFilenameValue       string `json:"JsonAttackTreesFilename,omitempty" yaml:"JsonAttackTreesFilename"`
+	JsonLossExposureFilenameValue      string `json:"JsonLossExposureFilename,omitempty" yaml:"JsonLossExposureFilename"`
+	TemplateFilenameValue              string `json:"TemplateFilename,omitempty" yaml:"TemplateFilename"`
+	ReportLogoImagePathValue           string `json:"ReportLogoImagePath,omitempty" yaml:"ReportLogoImagePath"`
+	TechnologyFilenameValue            string `json:"TechnologyFilename,omitempty" yaml:"TechnologyFilename"`
+	ComplianceFilenameValue            string `json:"ComplianceFilename,omitempty" yaml:"ComplianceFilename"`
 
 	RiskRulePluginsValue   []string        `json:"RiskRulePlugins,omitempty" yaml:"RiskRulePlugins"`
+	ScriptRiskRulesValue   []string        `json:"ScriptRiskRules,omitempty" yaml:"ScriptRiskRules"`
 	SkipRiskRulesValue     []string        `json:"SkipRiskRules,omitempty" yaml:"SkipRiskRules"`
 	ExecuteModelMacroValue string          `json:"ExecuteModelMacro,omitempty" yaml:"ExecuteModelMacro"`
 	RiskExcelValue         RiskExcelConfig `json:"RiskExcel" yaml:"RiskExcel"`
 
+	SeverityMatrixValue *types.SeverityMatrix `json:"SeverityMatrix,omitempty" yaml:"SeverityMatrix"`
+
+	FailOnSeverityValue string   `json:"FailOn,omitempty" yaml:"FailOn"`
+	FailOnStatusValue   []string `json:"FailOnStatus,omitempty" yaml:"FailOnStatus"`
+	BaselineFileValue   string   `json:"BaselineFile,omitempty" yaml:"BaselineFile"`
+
 	ServerModeValue               bool `json:"ServerMode,omitempty" yaml:"ServerMode"`
 	ServerPortValue               int  `json:"ServerPort,omitempty" yaml:"ServerPort"`
 	DiagramDPIValue               int  `json:"DiagramDPI,omitempty" yaml:"DiagramDPI"`
@@ -60,20 +75,34 @@ type Config struct {
 	MaxGraphvizDPIValue           int  `json:"MaxGraphvizDPI,omitempty" yaml:"MaxGraphvizDPI"`
 	BackupHistoryFilesToKeepValue int  `json:"BackupHistoryFilesToKeep,omitempty" yaml:"BackupHistoryFilesToKeep"`
 
+	DiagramRendererValue   string `json:"DiagramRenderer,omitempty" yaml:"DiagramRenderer"`
+	RiskOverlaySTRIDEValue string `json:"RiskOverlaySTRIDE,omitempty" yaml:"RiskOverlaySTRIDE"`
+
+	DataFlowDiagramPerTrustBoundaryValue bool     `json:"DataFlowDiagramPerTrustBoundary,omitempty" yaml:"DataFlowDiagramPerTrustBoundary"`
+	DataFlowDiagramTechnicalAssetsValue  []string `json:"DataFlowDiagramTechnicalAssets,omitempty" yaml:"DataFlowDiagramTechnicalAssets"`
+	DataFlowDiagramHopsValue             int      `json:"DataFlowDiagramHops,omitempty" yaml:"DataFlowDiagramHops"`
+
 	AddModelTitleValue              bool `json:"AddModelTitle,omitempty" yaml:"AddModelTitle"`
 	AddLegendValue                  bool `json:"AddLegend,omitempty" yaml:"AddLegend"`
 	KeepDiagramSourceFilesValue     bool `json:"KeepDiagramSourceFiles,omitempty" yaml:"KeepDiagramSourceFiles"`
 	IgnoreOrphanedRiskTrackingValue bool `json:"IgnoreOrphanedRiskTracking,omitempty" yaml:"IgnoreOrphanedRiskTracking"`
 
-	SkipDataFlowDiagramValue     bool `json:"SkipDataFlowDiagram,omitempty" yaml:"SkipDataFlowDiagram"`
-	SkipDataAssetDiagramValue    bool `json:"SkipDataAssetDiagram,omitempty" yaml:"SkipDataAssetDiagram"`
-	SkipRisksJSONValue           bool `json:"SkipRisksJSON,omitempty" yaml:"SkipRisksJSON"`
-	SkipTechnicalAssetsJSONValue bool `json:"SkipTechnicalAssetsJSON,omitempty" yaml:"SkipTechnicalAssetsJSON"`
-	SkipStatsJSONValue           bool `json:"SkipStatsJSON,omitempty" yaml:"SkipStatsJSON"`
-	SkipRisksExcelValue          bool `json:"SkipRisksExcel,omitempty" yaml:"SkipRisksExcel"`
-	SkipTagsExcelValue           bool `json:"SkipTagsExcel,omitempty" yaml:"SkipTagsExcel"`
-	SkipReportPDFValue           bool `json:"SkipReportPDF,omitempty" yaml:"SkipReportPDF"`
-	SkipReportADOCValue          bool `json:"SkipReportADOC,omitempty" yaml:"SkipReportADOC"`
+	SkipDataFlowDiagramValue        bool `json:"SkipDataFlowDiagram,omitempty" yaml:"SkipDataFlowDiagram"`
+	SkipDataAssetDiagramValue       bool `json:"SkipDataAssetDiagram,omitempty" yaml:"SkipDataAssetDiagram"`
+	SkipRiskOverlayDiagramValue     bool `json:"SkipRiskOverlayDiagram,omitempty" yaml:"SkipRiskOverlayDiagram"`
+	SkipTechnicalAssetDiagramsValue bool `json:"SkipTechnicalAssetDiagrams,omitempty" yaml:"SkipTechnicalAssetDiagrams"`
+	SkipRisksJSONValue              bool `json:"SkipRisksJSON,omitempty" yaml:"SkipRisksJSON"`
+	SkipRisksSARIFValue             bool `json:"SkipRisksSARIF,omitempty" yaml:"SkipRisksSARIF"`
+	SkipTechnicalAssetsJSONValue    bool `json:"SkipTechnicalAssetsJSON,omitempty" yaml:"SkipTechnicalAssetsJSON"`
+	SkipStatsJSONValue              bool `json:"SkipStatsJSON,omitempty" yaml:"SkipStatsJSON"`
+	SkipAttackPathsJSONValue        bool `json:"SkipAttackPathsJSON,omitempty" yaml:"SkipAttackPathsJSON"`
+	SkipAttackTreesValue            bool `json:"SkipAttackTrees,omitempty" yaml:"SkipAttackTrees"`
+	SkipLossExposureJSONValue       bool `json:"SkipLossExposureJSON,omitempty" yaml:"SkipLossExposureJSON"`
+	SkipRisksExcelValue             bool `json:"SkipRisksExcel,omitempty" yaml:"SkipRisksExcel"`
+	SkipTagsExcelValue              bool `json:"SkipTagsExcel,omitempty" yaml:"SkipTagsExcel"`
+	SkipReportPDFValue              bool `json:"SkipReportPDF,omitempty" yaml:"SkipReportPDF"`
+	SkipReportADOCValue             bool `json:"SkipReportADOC,omitempty" yaml:"SkipReportADOC"`
+	SkipReportHTMLValue             bool `json:"SkipReportHTML,omitempty" yaml:"SkipReportHTML"`
 
 	AttractivenessValue Attractiveness `json:"Attractiveness" yaml:"Attractiveness"`
 
@@ -92,20 +121,29 @@ type ConfigGetter interface {
 	GetTempFolder() string
 	GetKeyFolder() string
 	GetTechnologyFilename() string
+	GetComplianceFilename() string
 	GetInputFile() string
 	GetDataFlowDiagramFilenamePNG() string
 	GetDataAssetDiagramFilenamePNG() string
 	GetDataFlowDiagramFilenameDOT() string
 	GetDataAssetDiagramFilenameDOT() string
+	GetRiskOverlayDiagramFilenamePNG() string
+	GetRiskOverlayDiagramFilenameDOT() string
 	GetReportFilename() string
+	GetHtmlReportFilename() string
 	GetExcelRisksFilename() string
 	GetExcelTagsFilename() string
 	GetJsonRisksFilename() string
+	GetSarifRisksFilename() string
 	GetJsonTechnicalAssetsFilename() string
 	GetJsonStatsFilename() string
+	GetJsonAttackPathsFilename() string
+	GetJsonAttackTreesFilename() string
+	GetJsonLossExposureFilename() string
 	GetReportLogoImagePath() string
 	GetTemplateFilename() string
 	GetRiskRulePlugins() []string
+	GetScriptRiskRules() []string
 	GetSkipRiskRules() []string
 	GetExecuteModelMacro() string
 	GetRiskExcelConfigHideColumns() []string
@@ -114,6 +152,10 @@ type ConfigGetter interface {
 	GetRiskExcelWrapText() bool
 	GetRiskExcelShrinkColumnsToFit() bool
 	GetRiskExcelColorText() bool
+	GetSeverityMatrix() *types.SeverityMatrix
+	GetFailOnSeverity() string
+	GetFailOnStatus() []string
+	GetBaselineFile() string
 	GetServerMode() bool
 	GetServerPort() int
 	GetDiagramDPI() int
@@ -121,19 +163,31 @@ type ConfigGetter interface {
 	GetMinGraphvizDPI() int
 	GetMaxGraphvizDPI() int
 	GetBackupHistoryFilesToKeep() int
+	GetDiagramRenderer() string
+	GetRiskOverlaySTRIDE() string
+	GetDataFlowDiagramPerTrustBoundary() bool
+	GetDataFlowDiagramTechnicalAssets() []string
+	GetDataFlowDiagramHops() int
 	GetAddModelTitle() bool
 	GetAddLegend() bool
 	GetKeepDiagramSourceFiles() bool
 	GetIgnoreOrphanedRiskTracking() bool
 	GetSkipDataFlowDiagram() bool
 	GetSkipDataAssetDiagram() bool
+	GetSkipRiskOverlayDiagram() bool
+	GetSkipTechnicalAssetDiagrams() bool
 	GetSkipRisksJSON() bool
+	GetSkipRisksSARIF() bool
 	GetSkipTechnicalAssetsJSON() bool
 	GetSkipStatsJSON() bool
+	GetSkipAttackPathsJSON() bool
+	GetSkipAttackTrees() bool
+	GetSkipLossExposureJSON() bool
 	GetSkipRisksExcel() bool
 	GetSkipTagsExcel() bool
 	GetSkipReportPDF() bool
 	GetSkipReportADOC() bool
+	GetSkipReportHTML() bool
 	GetAttractiveness() Attractiveness
 	GetReportConfiguration() report.ReportConfiguation
 	GetThreagileVersion() string
@@ -172,22 +226,31 @@ func (c *Config) Defaults(buildTimestamp string) *Config {
 		TempFolderValue:   TempDir,
 		KeyFolderValue:    KeyDir,
 
-		InputFileValue:                   InputFile,
-		DataFlowDiagramFilenamePNGValue:  DataFlowDiagramFilenamePNG,
-		DataAssetDiagramFilenamePNGValue: DataAssetDiagramFilenamePNG,
-		DataFlowDiagramFilenameDOTValue:  DataFlowDiagramFilenameDOT,
-		DataAssetDiagramFilenameDOTValue: DataAssetDiagramFilenameDOT,
-		ReportFilenameValue:              ReportFilename,
-		ExcelRisksFilenameValue:          ExcelRisksFilename,
-		ExcelTagsFilenameValue:           ExcelTagsFilename,
-		JsonRisksFilenameValue:           JsonRisksFilename,
-		JsonTechnicalAssetsFilenameValue: JsonTechnicalAssetsFilename,
-		JsonStatsFilenameValue:           JsonStatsFilename,
-		TemplateFilenameValue:            TemplateFilename,
-		ReportLogoImagePathValue:         ReportLogoImagePath,
-		TechnologyFilenameValue:          "",
+		InputFileValue:                     InputFile,
+		DataFlowDiagramFilenamePNGValue:    DataFlowDiagramFilenamePNG,
+		DataAssetDiagramFilenamePNGValue:   DataAssetDiagramFilenamePNG,
+		DataFlowDiagramFilenameDOTValue:    DataFlowDiagramFilenameDOT,
+		DataAssetDiagramFilenameDOTValue:   DataAssetDiagramFilenameDOT,
+		RiskOverlayDiagramFilenamePNGValue: RiskOverlayDiagramFilenamePNG,
+		RiskOverlayDiagramFilenameDOTValue: RiskOverlayDiagramFilenameDOT,
+		ReportFilenameValue:                ReportFilename,
+		HtmlReportFilenameValue:            HtmlReportFilename,
+		ExcelRisksFilenameValue:            ExcelRisksFilename,
+		ExcelTagsFilenameValue:             ExcelTagsFilename,
+		JsonRisksFilenameValue:             JsonRisksFilename,
+		SarifRisksFilenameValue:            SarifRisksFilename,
+		JsonTechnicalAssetsFilenameValue:   JsonTechnicalAssetsFilename,
+		JsonStatsFilenameValue:             JsonStatsFilename,
+		JsonAttackPathsFilenameValue:       JsonAttackPathsFilename,
+		JsonAttackTreesFilenameValue:       JsonAttackTreesFilename,
+		JsonLossExposureFilenameValue:      JsonLossExposureFilename,
+		TemplateFilenameValue:              TemplateFilename,
+		ReportLogoImagePathValue:           ReportLogoImagePath,
+		TechnologyFilenameValue:            "",
+		ComplianceFilenameValue:            "",
 
 		RiskRulePluginsValue:   make([]string, 0),
+		ScriptRiskRulesValue:   make([]string, 0),
 		SkipRiskRulesValue:     make([]string, 0),
 		ExecuteModelMacroValue: "",
 		RiskExcelValue: RiskExcelConfig{
@@ -199,6 +262,10 @@ func (c *Config) Defaults(buildTimestamp string) *Config {
 			ColorText:          true,
 		},
 
+		FailOnSeverityValue: "",
+		FailOnStatusValue:   []string{types.Unchecked.String(), types.InDiscussion.String()},
+		BaselineFileValue:   "",
+
 		ServerModeValue:               false,
 		DiagramDPIValue:               DefaultDiagramDPI,
 		ServerPortValue:               DefaultServerPort,
@@ -206,6 +273,13 @@ func (c *Config) Defaults(buildTimestamp string) *Config {
 		MaxGraphvizDPIValue:           MaxGraphvizDPI,
 		BackupHistoryFilesToKeepValue: DefaultBackupHistoryFilesToKeep,
 
+		DiagramRendererValue:   report.DiagramRendererAuto,
+		RiskOverlaySTRIDEValue: "",
+
+		DataFlowDiagramPerTrustBoundaryValue: false,
+		DataFlowDiagramTechnicalAssetsValue:  make([]string, 0),
+		DataFlowDiagramHopsValue:             DefaultDataFlowDiagramHops,
+
 		AddModelTitleValue:              false,
 		AddLegendValue:                  false,
 		KeepDiagramSourceFilesValue:     false,
@@ -310,6 +384,21 @@ func (c *Config) Load(configFilename string) error {
 		c.TechnologyFilenameValue = c.CleanPath(c.TechnologyFilenameValue)
 	}
 
+	for i, scriptRiskRule := range c.ScriptRiskRulesValue {
+		if scriptRiskRule != "" {
+			c.ScriptRiskRulesValue[i] = c.CleanPath(scriptRiskRule)
+		}
+	}
+
+	if c.ComplianceFilenameValue != "" {
+		c.ComplianceFilenameValue = c.CleanPath(c.ComplianceFilenameValue)
+	}
+
+	severityMatrixError := c.SeverityMatrixValue.Validate()
+	if severityMatrixError != nil {
+		errorList = append(errorList, fmt.Errorf("invalid severity matrix: %w", severityMatrixError))
+	}
+
 	serverFolderError := c.CheckServerFolder()
 	if serverFolderError != nil {
 		errorList = append(errorList, serverFolderError)
@@ -390,9 +479,18 @@ func (c *Config) Merge(config Config, values map[string]any) {
 		case strings.ToLower("DataAssetDiagramFilenameDOT"):
 			c.DataAssetDiagramFilenameDOTValue = config.DataAssetDiagramFilenameDOTValue
 
+		case strings.ToLower("RiskOverlayDiagramFilenamePNG"):
+			c.RiskOverlayDiagramFilenamePNGValue = config.RiskOverlayDiagramFilenamePNGValue
+
+		case strings.ToLower("RiskOverlayDiagramFilenameDOT"):
+			c.RiskOverlayDiagramFilenameDOTValue = config.RiskOverlayDiagramFilenameDOTValue
+
 		case strings.ToLower("ReportFilename"):
 			c.ReportFilenameValue = config.ReportFilenameValue
 
+		case strings.ToLower("HtmlReportFilename"):
+			c.HtmlReportFilenameValue = config.HtmlReportFilenameValue
+
 		case strings.ToLower("ExcelRisksFilename"):
 			c.ExcelRisksFilenameValue = config.ExcelRisksFilenameValue
 
@@ -402,12 +500,24 @@ func (c *Config) Merge(config Config, values map[string]any) {
 		case strings.ToLower("JsonRisksFilename"):
 			c.JsonRisksFilenameValue = config.JsonRisksFilenameValue
 
+		case strings.ToLower("SarifRisksFilename"):
+			c.SarifRisksFilenameValue = config.SarifRisksFilenameValue
+
 		case strings.ToLower("JsonTechnicalAssetsFilename"):
 			c.JsonTechnicalAssetsFilenameValue = config.JsonTechnicalAssetsFilenameValue
 
 		case strings.ToLower("JsonStatsFilename"):
 			c.JsonStatsFilenameValue = config.JsonStatsFilenameValue
 
+		case strings.ToLower("JsonAttackPathsFilename"):
+			c.JsonAttackPathsFilenameValue = config.JsonAttackPathsFilenameValue
+
+		case strings.ToLower("JsonAttackTreesFilename"):
+			c.JsonAttackTreesFilenameValue = config.JsonAttackTreesFilenameValue
+
+		case strings.ToLower("JsonLossExposureFilename"):
+			c.JsonLossExposureFilenameValue = config.JsonLossExposureFilenameValue
+
 		case strings.ToLower("TemplateFilename"):
 			c.TemplateFilenameValue = config.TemplateFilenameValue
 
@@ -417,15 +527,33 @@ func (c *Config) Merge(config Config, values map[string]any) {
 		case strings.ToLower("TechnologyFilename"):
 			c.TechnologyFilenameValue = config.TechnologyFilenameValue
 
+		case strings.ToLower("ComplianceFilename"):
+			c.ComplianceFilenameValue = config.ComplianceFilenameValue
+
 		case strings.ToLower("RiskRulePlugins"):
 			c.RiskRulePluginsValue = config.RiskRulePluginsValue
 
+		case strings.ToLower("ScriptRiskRules"):
+			c.ScriptRiskRulesValue = config.ScriptRiskRulesValue
+
 		case strings.ToLower("SkipRiskRules"):
 			c.SkipRiskRulesValue = config.SkipRiskRulesValue
 
 		case strings.ToLower("ExecuteModelMacro"):
 			c.ExecuteModelMacroValue = config.ExecuteModelMacroValue
 
+		case strings.ToLower("SeverityMatrix"):
+			c.SeverityMatrixValue = config.SeverityMatrixValue
+
+		case strings.ToLower("FailOn"):
+			c.FailOnSeverityValue = config.FailOnSeverityValue
+
+		case strings.ToLower("FailOnStatus"):
+			c.FailOnStatusValue = config.FailOnStatusValue
+
+		case strings.ToLower("BaselineFile"):
+			c.BaselineFileValue = c.CleanPath(config.BaselineFileValue)
+
 		case strings.ToLower("RiskExcel"):
 			configMap, mapOk := values[key].(map[string]any)
 			if !mapOk {
@@ -478,6 +606,21 @@ func (c *Config) Merge(config Config, values map[string]any) {
 		case strings.ToLower("BackupHistoryFilesToKeep"):
 			c.BackupHistoryFilesToKeepValue = config.BackupHistoryFilesToKeepValue
 
+		case strings.ToLower("DiagramRenderer"):
+			c.DiagramRendererValue = config.DiagramRendererValue
+
+		case strings.ToLower("RiskOverlaySTRIDE"):
+			c.RiskOverlaySTRIDEValue = config.RiskOverlaySTRIDEValue
+
+		case strings.ToLower("DataFlowDiagramPerTrustBoundary"):
+			c.DataFlowDiagramPerTrustBoundaryValue = config.DataFlowDiagramPerTrustBoundaryValue
+
+		case strings.ToLower("DataFlowDiagramTechnicalAssets"):
+			c.DataFlowDiagramTechnicalAssetsValue = config.DataFlowDiagramTechnicalAssetsValue
+
+		case strings.ToLower("DataFlowDiagramHops"):
+			c.DataFlowDiagramHopsValue = config.DataFlowDiagramHopsValue
+
 		case strings.ToLower("AddModelTitle"):
 			c.AddModelTitleValue = config.AddModelTitleValue
 
@@ -634,6 +777,10 @@ func (c *Config) GetTechnologyFilename() string {
 	return c.TechnologyFilenameValue
 }
 
+func (c *Config) GetComplianceFilename() string {
+	return c.ComplianceFilenameValue
+}
+
 func (c *Config) GetInputFile() string {
 	return c.InputFileValue
 }
@@ -666,10 +813,22 @@ func (c *Config) GetDataAssetDiagramFilenameDOT() string {
 	return c.DataAssetDiagramFilenameDOTValue
 }
 
+func (c *Config) GetRiskOverlayDiagramFilenamePNG() string {
+	return c.RiskOverlayDiagramFilenamePNGValue
+}
+
+func (c *Config) GetRiskOverlayDiagramFilenameDOT() string {
+	return c.RiskOverlayDiagramFilenameDOTValue
+}
+
 func (c *Config) GetReportFilename() string {
 	return c.ReportFilenameValue
 }
 
+func (c *Config) GetHtmlReportFilename() string {
+	return c.HtmlReportFilenameValue
+}
+
 func (c *Config) GetExcelRisksFilename() string {
 	return c.ExcelRisksFilenameValue
 }
@@ -682,6 +841,10 @@ func (c *Config) GetJsonRisksFilename() string {
 	return c.JsonRisksFilenameValue
 }
 
+func (c *Config) GetSarifRisksFilename() string {
+	return c.SarifRisksFilenameValue
+}
+
 func (c *Config) GetJsonTechnicalAssetsFilename() string {
 	return c.JsonTechnicalAssetsFilenameValue
 }
@@ -690,6 +853,18 @@ func (c *Config) GetJsonStatsFilename() string {
 	return c.JsonStatsFilenameValue
 }
 
+func (c *Config) GetJsonAttackPathsFilename() string {
+	return c.JsonAttackPathsFilenameValue
+}
+
+func (c *Config) GetJsonAttackTreesFilename() string {
+	return c.JsonAttackTreesFilenameValue
+}
+
+func (c *Config) GetJsonLossExposureFilename() string {
+	return c.JsonLossExposureFilenameValue
+}
+
 func (c *Config) GetReportLogoImagePath() string {
 	return c.ReportLogoImagePathValue
 }
@@ -706,6 +881,10 @@ func (c *Config) GetRiskRulePlugins() []string {
 	return c.RiskRulePluginsValue
 }
 
+func (c *Config) GetScriptRiskRules() []string {
+	return c.ScriptRiskRulesValue
+}
+
 func (c *Config) SetRiskRulePlugins(riskRulePlugins []string) {
 	c.RiskRulePluginsValue = riskRulePlugins
 }
@@ -722,6 +901,22 @@ func (c *Config) GetExecuteModelMacro() string {
 	return c.ExecuteModelMacroValue
 }
 
+func (c *Config) GetSeverityMatrix() *types.SeverityMatrix {
+	return c.SeverityMatrixValue
+}
+
+func (c *Config) GetFailOnSeverity() string {
+	return c.FailOnSeverityValue
+}
+
+func (c *Config) GetFailOnStatus() []string {
+	return c.FailOnStatusValue
+}
+
+func (c *Config) GetBaselineFile() string {
+	return c.BaselineFileValue
+}
+
 func (c *Config) GetRiskExcelConfigHideColumns() []string {
 	return c.RiskExcelValue.HideColumns
 }
@@ -786,6 +981,26 @@ func (c *Config) GetBackupHistoryFilesToKeep() int {
 	return c.BackupHistoryFilesToKeepValue
 }
 
+func (c *Config) GetDiagramRenderer() string {
+	return c.DiagramRendererValue
+}
+
+func (c *Config) GetRiskOverlaySTRIDE() string {
+	return c.RiskOverlaySTRIDEValue
+}
+
+func (c *Config) GetDataFlowDiagramPerTrustBoundary() bool {
+	return c.DataFlowDiagramPerTrustBoundaryValue
+}
+
+func (c *Config) GetDataFlowDiagramTechnicalAssets() []string {
+	return c.DataFlowDiagramTechnicalAssetsValue
+}
+
+func (c *Config) GetDataFlowDiagramHops() int {
+	return c.DataFlowDiagramHopsValue
+}
+
 func (c *Config) GetAddModelTitle() bool {
 	return c.AddModelTitleValue
 }
@@ -814,10 +1029,22 @@ func (c *Config) GetSkipDataAssetDiagram() bool {
 	return c.SkipDataAssetDiagramValue
 }
 
+func (c *Config) GetSkipRiskOverlayDiagram() bool {
+	return c.SkipRiskOverlayDiagramValue
+}
+
+func (c *Config) GetSkipTechnicalAssetDiagrams() bool {
+	return c.SkipTechnicalAssetDiagramsValue
+}
+
 func (c *Config) GetSkipRisksJSON() bool {
 	return c.SkipRisksJSONValue
 }
 
+func (c *Config) GetSkipRisksSARIF() bool {
+	return c.SkipRisksSARIFValue
+}
+
 func (c *Config) GetSkipTechnicalAssetsJSON() bool {
 	return c.SkipTechnicalAssetsJSONValue
 }
@@ -826,6 +1053,18 @@ func (c *Config) GetSkipStatsJSON() bool {
 	return c.SkipStatsJSONValue
 }
 
+func (c *Config) GetSkipAttackPathsJSON() bool {
+	return c.SkipAttackPathsJSONValue
+}
+
+func (c *Config) GetSkipAttackTrees() bool {
+	return c.SkipAttackTreesValue
+}
+
+func (c *Config) GetSkipLossExposureJSON() bool {
+	return c.SkipLossExposureJSONValue
+}
+
 func (c *Config) GetSkipRisksExcel() bool {
 	return c.SkipRisksExcelValue
 }
@@ -842,6 +1081,10 @@ func (c *Config) GetSkipReportADOC() bool {
 	return c.SkipReportADOCValue
 }
 
+func (c *Config) GetSkipReportHTML() bool {
+	return c.SkipReportHTMLValue
+}
+
 func (c *Config) GetAttractiveness() Attractiveness {
 	return c.AttractivenessValue
 }
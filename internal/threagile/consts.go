@@ -11,21 +11,31 @@ const (
 
 	DefaultServerPort = 8080
 
-	InputFile                   = "threagile.yaml"
-	ReportFilename              = "report.pdf"
-	ExcelRisksFilename          = "risks.xlsx"
-	ExcelTagsFilename           = "tags.xlsx"
-	JsonRisksFilename           = "risks.json"
-	JsonTechnicalAssetsFilename = "technical-assets.json"
-	JsonStatsFilename           = "stats.json"
-	TemplateFilename            = "background.pdf"
-	ReportLogoImagePath         = "report/threagile-logo.png"
-	DataFlowDiagramFilenameDOT  = "data-flow-diagram.gv"
-	DataFlowDiagramFilenamePNG  = "data-flow-diagram.png"
-	DataAssetDiagramFilenameDOT = "data-asset-diagram.gv"
-	DataAssetDiagramFilenamePNG = "data-asset-diagram.png"
+	InputFile                     = "threagile.yaml"
+	ReportFilename                = "report.pdf"
+	HtmlReportFilename            = "report.html"
+	ExcelRisksFilename            = "risks.xlsx"
+	ExcelTagsFilename             = "tags.xlsx"
+	JsonRisksFilename             = "risks.json"
+	SarifRisksFilename            = "risks.sarif"
+	JsonTechnicalAssetsFilename   = "technical-assets.json"
+	JsonStatsFilename             = "stats.json"
+	JsonAttackPathsFilename       = "attack-paths.json"
+	JsonAttackTreesFilename       = "attack-trees.json"
+	JsonLossExposureFilename      = "loss-exposure.json"
+	TemplateFilename              = "background.pdf"
+	ReportLogoImagePath           = "report/threagile-logo.png"
+	DataFlowDiagramFilenameDOT    = "data-flow-diagram.gv"
+	DataFlowDiagramFilenamePNG    = "data-flow-diagram.png"
+	DataAssetDiagramFilenameDOT   = "data-asset-diagram.gv"
+	DataAssetDiagramFilenamePNG   = "data-asset-diagram.png"
+	RiskOverlayDiagramFilenameDOT = "risk-overlay-diagram.gv"
+	RiskOverlayDiagramFilenamePNG = "risk-overlay-diagram.png"
+	ImportedModelFilename         = "threagile-imported-model.yaml"
+	OTMFilename                   = "threagile.otm.json"
 
 	DefaultDiagramDPI               = 100
+	DefaultDataFlowDiagramHops      = 1
 	DefaultGraphvizDPI              = 120
 	MinGraphvizDPI                  = 20
 	MaxGraphvizDPI                  = 300
@@ -37,6 +47,16 @@ const (
 	CreateExampleModelCommand   = "create-example-model"
 	CreateStubModelCommand      = "create-stub-model"
 	CreateEditingSupportCommand = "create-editing-support"
+	DiffModelCommand            = "diff"
+	ExportOTMCommand            = "export-otm"
+	ExportDiagramCommand        = "export-diagram"
+	ImportComposeCommand        = "import-compose"
+	ImportKubernetesCommand     = "import-kubernetes"
+	ImportTerraformCommand      = "import-terraform"
+	ImportOpenAPICommand        = "import-openapi"
+	ImportThreatDragonCommand   = "import-threat-dragon"
+	ImportTMTCommand            = "import-tmt"
+	ImportOTMCommand            = "import-otm"
 	ImportModelCommand         	= "import-model"
 	ListTypesCommand            = "list-types"
 	ListRiskRulesCommand        = "list-risk-rules"
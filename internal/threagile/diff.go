@@ -0,0 +1,77 @@
+package threagile
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/model"
+	"github.com/threagile/threagile/pkg/report"
+	"github.com/threagile/threagile/pkg/risks"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+func (what *Threagile) initDiff() *Threagile {
+	diffCmd := &cobra.Command{
+		Use:   DiffModelCommand,
+		Short: "Compare two versions of a model and the risks generated for them",
+		Long: "\n" + Logo + "\n\n" + fmt.Sprintf(VersionText, what.buildTimestamp) + "\n\n" +
+			"Compares the model given via --" + diffBaseFlagName + " with the model given via --" + inputFileFlagName + ".\n" +
+			"Reports added, removed and changed technical assets, communication links, data assets and trust boundaries\n" +
+			"as well as new, resolved and re-rated risks (matched by their synthetic risk id).",
+		RunE: what.diffModels,
+	}
+
+	what.rootCmd.AddCommand(diffCmd)
+
+	return what
+}
+
+func (what *Threagile) diffModels(cmd *cobra.Command, args []string) error {
+	what.processArgs(cmd, args)
+
+	if len(what.flags.diffBaseValue) == 0 {
+		return fmt.Errorf("missing base model, please specify it via --%v", diffBaseFlagName)
+	}
+
+	progressReporter := DefaultProgressReporter{Verbose: what.config.GetVerbose()}
+	builtinRiskRules := risks.GetBuiltInRiskRules()
+	customRiskRules := model.LoadCustomRiskRules(what.config.GetPluginFolder(), what.config.GetRiskRulePlugins(), progressReporter)
+
+	base, baseError := what.analyzeModelFile(what.flags.diffBaseValue, builtinRiskRules, customRiskRules, progressReporter)
+	if baseError != nil {
+		return fmt.Errorf("failed to read and analyze base model: %w", baseError)
+	}
+
+	current, currentError := what.analyzeModelFile(what.config.GetInputFile(), builtinRiskRules, customRiskRules, progressReporter)
+	if currentError != nil {
+		return fmt.Errorf("failed to read and analyze model: %w", currentError)
+	}
+
+	var writer io.Writer = cmd.OutOrStdout()
+	if len(what.flags.diffOutputValue) > 0 {
+		file, createError := os.Create(what.flags.diffOutputValue)
+		if createError != nil {
+			return fmt.Errorf("failed to create diff output file: %w", createError)
+		}
+		defer func() { _ = file.Close() }()
+
+		writer = file
+	}
+
+	return report.WriteModelDiff(model.DiffModels(base.ParsedModel, current.ParsedModel), what.flags.diffFormatValue, writer)
+}
+
+func (what *Threagile) analyzeModelFile(filename string, builtinRiskRules types.RiskRules, customRiskRules types.RiskRules, progressReporter types.ProgressReporter) (*model.ReadResult, error) {
+	progressReporter.Infof("Parsing model: %v", filename)
+
+	modelInput := new(input.Model).Defaults()
+	loadError := modelInput.Load(filename)
+	if loadError != nil {
+		return nil, fmt.Errorf("unable to load model yaml %q: %w", filename, loadError)
+	}
+
+	return model.AnalyzeModel(modelInput, what.config, builtinRiskRules, customRiskRules, progressReporter)
+}
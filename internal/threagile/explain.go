@@ -61,10 +61,21 @@ func (what *Threagile) explainRisk(cmd *cobra.Command, args []string) error {
 		return runError
 	}
 
-	// todo: implement this
+	for n, riskId := range args {
+		if n > 0 {
+			cmd.Println()
+			cmd.Println("----------------------")
+			cmd.Println()
+		}
 
-	_ = result
-	return fmt.Errorf("not implemented yet")
+		explainError := result.ExplainRisk(what.config, riskId, cmd)
+		if explainError != nil {
+			cmd.Printf("Failed to explain risk %q: %v\n", riskId, explainError)
+			return explainError
+		}
+	}
+
+	return nil
 }
 
 func (what *Threagile) explainRules(cmd *cobra.Command, args []string) error {
@@ -77,6 +88,7 @@ func (what *Threagile) explainRules(cmd *cobra.Command, args []string) error {
 	cmd.Println("Custom risk rules:")
 	cmd.Println("----------------------")
 	customRiskRules := model.LoadCustomRiskRules(what.config.GetPluginFolder(), what.config.GetRiskRulePlugins(), DefaultProgressReporter{Verbose: what.config.GetVerbose()})
+	customRiskRules.Merge(model.LoadScriptRiskRules(what.config.GetPluginFolder(), what.config.GetScriptRiskRules(), DefaultProgressReporter{Verbose: what.config.GetVerbose()}))
 	for _, rule := range customRiskRules {
 		cmd.Printf("%v: %v\n", rule.Category().ID, rule.Category().Description)
 	}
@@ -0,0 +1,65 @@
+package threagile
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/threagile/threagile/pkg/model"
+	"github.com/threagile/threagile/pkg/report"
+	"github.com/threagile/threagile/pkg/risks"
+)
+
+func (what *Threagile) initExportDiagram() *Threagile {
+	formats := make([]string, 0)
+	for format := range report.DataFlowDiagramFormats {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+
+	what.rootCmd.AddCommand(&cobra.Command{
+		Use:   ExportDiagramCommand + " <" + strings.Join(formats, "|") + "> [file]",
+		Short: "Export the data flow diagram as Mermaid, PlantUML or Structurizr DSL",
+		Long: "\n" + Logo + "\n\n" + fmt.Sprintf(VersionText, what.buildTimestamp) + "\n\n" +
+			"Analyzes the model given via --" + inputFileFlagName + " and writes its data flow diagram as Mermaid flowchart, PlantUML or\n" +
+			"Structurizr DSL, by default next to the graphviz diagram in the output directory (data-flow-diagram.mmd, .puml or .dsl).\n" +
+			"Trust boundaries keep their nesting, technical assets their shapes by type and links are colored by the encryption\n" +
+			"of their protocol, so that the diagram can be embedded into wikis and readmes rendering these formats natively.",
+		Args:      cobra.RangeArgs(1, 2),
+		ValidArgs: formats,
+		RunE:      what.exportDiagram,
+	})
+
+	return what
+}
+
+func (what *Threagile) exportDiagram(cmd *cobra.Command, args []string) error {
+	what.processArgs(cmd, args)
+
+	extension, ok := report.DataFlowDiagramFormats[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown data flow diagram format %q", args[0])
+	}
+
+	progressReporter := DefaultProgressReporter{Verbose: what.config.GetVerbose()}
+	result, analyzeError := model.ReadAndAnalyzeModel(what.config, risks.GetBuiltInRiskRules(), progressReporter)
+	if analyzeError != nil {
+		return fmt.Errorf("failed to read and analyze model: %w", analyzeError)
+	}
+
+	dotFilename := what.config.GetDataFlowDiagramFilenameDOT()
+	filename := filepath.Join(what.config.GetOutputFolder(), strings.TrimSuffix(dotFilename, filepath.Ext(dotFilename))+extension)
+	if len(args) > 1 {
+		filename = args[1]
+	}
+
+	writeError := report.WriteDataFlowDiagram(result.ParsedModel, args[0], filename, what.config.GetAddModelTitle())
+	if writeError != nil {
+		return writeError
+	}
+
+	cmd.Printf("Exported data flow diagram as %v to %q\n", args[0], filename)
+	return nil
+}
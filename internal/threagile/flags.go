@@ -21,45 +21,71 @@ const (
 	tempDirFlagName   = "temp-dir"
 	keyDirFlagName    = "key-dir"
 
-	inputFileFlagName               = "model"
-	importedFileFlagName            = "imported-model"
-	dataFlowDiagramPNGFileFlagName  = "data-flow-diagram-png"
-	dataAssetDiagramPNGFileFlagName = "data-asset-diagram-png"
-	dataFlowDiagramDOTFileFlagName  = "data-flow-diagram-dot"
-	dataAssetDiagramDOTFileFlagName = "data-asset-diagram-dot"
-	reportFileFlagName              = "report"
-	risksExcelFileFlagName          = "risks-excel"
-	tagsExcelFileFlagName           = "tags-excel"
-	risksJsonFileFlagName           = "risks-json"
-	technicalAssetsJsonFileFlagName = "technical-assets-json"
-	statsJsonFileFlagName           = "stats-json"
-	templateFileNameFlagName        = "background"
-	reportLogoImagePathFlagName     = "reportLogoImagePath"
-	technologyFileFlagName          = "technology"
+	inputFileFlagName                 = "model"
+	importedFileFlagName              = "imported-model"
+	dataFlowDiagramPNGFileFlagName    = "data-flow-diagram-png"
+	dataAssetDiagramPNGFileFlagName   = "data-asset-diagram-png"
+	dataFlowDiagramDOTFileFlagName    = "data-flow-diagram-dot"
+	dataAssetDiagramDOTFileFlagName   = "data-asset-diagram-dot"
+	riskOverlayDiagramPNGFileFlagName = "risk-overlay-diagram-png"
+	riskOverlayDiagramDOTFileFlagName = "risk-overlay-diagram-dot"
+	reportFileFlagName                = "report"
+	reportHtmlFileFlagName            = "report-html"
+	risksExcelFileFlagName            = "risks-excel"
+	tagsExcelFileFlagName             = "tags-excel"
+	risksJsonFileFlagName             = "risks-json"
+	risksSarifFileFlagName            = "risks-sarif"
+	technicalAssetsJsonFileFlagName   = "technical-assets-json"
+	statsJsonFileFlagName             = "stats-json"
+	attackPathsJsonFileFlagName       = "attack-paths-json"
+	attackTreesJsonFileFlagName       = "attack-trees-json"
+	lossExposureJsonFileFlagName      = "loss-exposure-json"
+	templateFileNameFlagName          = "background"
+	reportLogoImagePathFlagName       = "reportLogoImagePath"
+	technologyFileFlagName            = "technology"
+	complianceFileFlagName            = "compliance"
 
 	customRiskRulesPluginFlagName = "custom-risk-rules-plugin"
+	scriptRiskRulesFlagName       = "script-rules"
 	skipRiskRulesFlagName         = "skip-risk-rules"
 	executeModelMacroFlagName     = "execute-model-macro"
 
+	failOnFlagName       = "fail-on"
+	failOnStatusFlagName = "fail-on-status"
+	baselineFlagName     = "baseline"
+
 	serverModeFlagName               = "server-mode"
 	serverPortFlagName               = "server-port"
 	diagramDpiFlagName               = "diagram-dpi"
 	graphvizDpiFlagName              = "graphviz-dpi"
 	backupHistoryFilesToKeepFlagName = "backup-history-files-to-keep"
+	diagramRendererFlagName          = "diagram-renderer"
+	riskOverlaySTRIDEFlagName        = "risk-overlay-stride"
+
+	dataFlowDiagramPerTrustBoundaryFlagName = "data-flow-diagram-per-trust-boundary"
+	dataFlowDiagramTechnicalAssetsFlagName  = "data-flow-diagram-assets"
+	dataFlowDiagramHopsFlagName             = "data-flow-diagram-hops"
 
 	addModelTitleFlagName              = "add-model-title"
 	keepDiagramSourceFilesFlagName     = "keep-diagram-source-files"
 	ignoreOrphanedRiskTrackingFlagName = "ignore-orphaned-risk-tracking"
 
-	skipDataFlowDiagramFlagName     = "skip-data-flow-diagram"
-	skipDataAssetDiagramFlagName    = "skip-data-asset-diagram"
-	skipRisksJSONFlagName           = "skip-risks-json"
-	skipTechnicalAssetsJSONFlagName = "skip-technical-assets-json"
-	skipStatsJSONFlagName           = "skip-stats-json"
-	skipRisksExcelFlagName          = "skip-risks-excel"
-	skipTagsExcelFlagName           = "skip-tags-excel"
-	skipReportPDFFlagName           = "skip-report-pdf"
-	skipReportADOCFlagName          = "skip-report-adoc"
+	skipDataFlowDiagramFlagName        = "skip-data-flow-diagram"
+	skipDataAssetDiagramFlagName       = "skip-data-asset-diagram"
+	skipRiskOverlayDiagramFlagName     = "skip-risk-overlay-diagram"
+	skipTechnicalAssetDiagramsFlagName = "skip-technical-asset-diagrams"
+	skipRisksJSONFlagName              = "skip-risks-json"
+	skipRisksSARIFFlagName             = "skip-risks-sarif"
+	skipTechnicalAssetsJSONFlagName    = "skip-technical-assets-json"
+	skipStatsJSONFlagName              = "skip-stats-json"
+	skipAttackPathsJSONFlagName        = "skip-attack-paths-json"
+	skipAttackTreesFlagName            = "skip-attack-trees"
+	skipLossExposureJSONFlagName       = "skip-loss-exposure-json"
+	skipRisksExcelFlagName             = "skip-risks-excel"
+	skipTagsExcelFlagName              = "skip-tags-excel"
+	skipReportPDFFlagName              = "skip-report-pdf"
+	skipReportADOCFlagName             = "skip-report-adoc"
+	skipReportHTMLFlagName             = "skip-report-html"
 
 	generateDataFlowDiagramFlagName     = "generate-data-flow-diagram"
 	generateDataAssetDiagramFlagName    = "generate-data-asset-diagram"
@@ -70,14 +96,25 @@ const (
 	generateTagsExcelFlagName           = "generate-tags-excel"
 	generateReportPDFFlagName           = "generate-report-pdf"
 	generateReportADOCFlagName          = "generate-report-adoc"
+
+	diffBaseFlagName   = "base"
+	diffFormatFlagName = "diff-format"
+	diffOutputFlagName = "diff-output"
 )
 
 type Flags struct {
 	Config
 
-	configFlag           string
-	riskRulePluginsValue string
-	skipRiskRulesValue   string
+	configFlag                          string
+	riskRulePluginsValue                string
+	scriptRiskRulesValue                string
+	skipRiskRulesValue                  string
+	dataFlowDiagramTechnicalAssetsValue string
+	failOnStatusValue                   string
+
+	diffBaseValue   string
+	diffFormatValue string
+	diffOutputValue string
 
 	generateDataFlowDiagramFlag     bool // deprecated
 	generateDataAssetDiagramFlag    bool // deprecated
@@ -2,10 +2,15 @@ package threagile
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+
 	"github.com/spf13/cobra"
+	"github.com/threagile/threagile/pkg/input"
 	"github.com/threagile/threagile/pkg/model"
 	"github.com/threagile/threagile/pkg/report"
 	"github.com/threagile/threagile/pkg/risks"
+	"gopkg.in/yaml.v3"
 )
 
 func (what *Threagile) initImport() *Threagile {
@@ -39,3 +44,81 @@ func (what *Threagile) initImport() *Threagile {
 
 	return what
 }
+
+// writeImportedModel writes a model converted from another format to the imported model file
+func (what *Threagile) writeImportedModel(cmd *cobra.Command, modelInput *input.Model) error {
+	// partial models merged into an existing model via its includes leave the version to the existing model
+	if len(modelInput.Title) > 0 {
+		modelInput.ThreagileVersion = what.config.GetThreagileVersion()
+	}
+
+	filename := what.importedModelFilename()
+	modelYaml, marshalError := yaml.Marshal(modelInput)
+	if marshalError != nil {
+		return fmt.Errorf("failed to marshal imported model: %w", marshalError)
+	}
+
+	_ = os.MkdirAll(filepath.Dir(filename), 0750)
+	writeError := os.WriteFile(filename, modelYaml, 0600)
+	if writeError != nil {
+		return fmt.Errorf("failed to write imported model to %q: %w", filename, writeError)
+	}
+
+	cmd.Printf("Imported model written to %q\n", filename)
+	return nil
+}
+
+// importedModelFilename returns the imported model file, defaulting to a file in the output directory
+func (what *Threagile) importedModelFilename() string {
+	filename := what.config.GetImportedInputFile()
+	if len(filename) == 0 {
+		filename = filepath.Join(what.config.GetOutputFolder(), ImportedModelFilename)
+	}
+
+	return filename
+}
+
+// readModelExcludingImport reads the model given via --model with all its includes except the imported model file,
+// returning nil if there is no such model; included reports if the model includes the imported model file
+func (what *Threagile) readModelExcludingImport(importedFilename string) (existing *input.Model, included bool, err error) {
+	filename := what.config.GetInputFile()
+	if _, statError := os.Stat(filename); statError != nil || sameFile(filename, importedFilename) {
+		return nil, false, nil
+	}
+
+	modelYaml, readError := os.ReadFile(filepath.Clean(filename))
+	if readError != nil {
+		return nil, false, fmt.Errorf("unable to read model file: %w", readError)
+	}
+
+	existing = new(input.Model).Defaults()
+	unmarshalError := yaml.Unmarshal(modelYaml, existing)
+	if unmarshalError != nil {
+		return nil, false, fmt.Errorf("unable to parse model yaml: %w", unmarshalError)
+	}
+
+	dir := filepath.Dir(filename)
+	for _, includeFile := range existing.Includes {
+		if sameFile(filepath.Join(dir, includeFile), importedFilename) {
+			included = true
+			continue
+		}
+
+		mergeError := existing.Merge(dir, includeFile)
+		if mergeError != nil {
+			return nil, false, fmt.Errorf("unable to merge model include %q: %w", includeFile, mergeError)
+		}
+	}
+
+	return existing, included, nil
+}
+
+func sameFile(first string, second string) bool {
+	firstInfo, firstError := os.Stat(first)
+	secondInfo, secondError := os.Stat(second)
+	if firstError != nil || secondError != nil {
+		return filepath.Clean(first) == filepath.Clean(second)
+	}
+
+	return os.SameFile(firstInfo, secondInfo)
+}
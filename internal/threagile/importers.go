@@ -0,0 +1,204 @@
+package threagile
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/threagile/threagile/pkg/importer"
+	"github.com/threagile/threagile/pkg/input"
+)
+
+func (what *Threagile) initImporters() *Threagile {
+	what.rootCmd.AddCommand(&cobra.Command{
+		Use:   ImportComposeCommand + " <compose-file>...",
+		Short: "Import docker-compose files as threagile model stub",
+		Long: "\n" + Logo + "\n\n" + fmt.Sprintf(VersionText, what.buildTimestamp) + "\n\n" +
+			"Creates a threagile model stub from one or more docker-compose files, written to --" + importedFileFlagName + "\n" +
+			"or to " + ImportedModelFilename + " in the output directory. Later files extend and override earlier ones.\n" +
+			"Services become technical assets (technologies guessed from the image names), networks become trust boundaries\n" +
+			"and depends_on and links entries become communication links (protocols inferred from the exposed ports).",
+		Args: cobra.MinimumNArgs(1),
+		RunE: what.importCompose,
+	})
+
+	what.rootCmd.AddCommand(&cobra.Command{
+		Use:     ImportKubernetesCommand + " <manifest-dir>",
+		Short:   "Import kubernetes manifests as threagile model skeleton",
+		Aliases: []string{"import-k8s"},
+		Long: "\n" + Logo + "\n\n" + fmt.Sprintf(VersionText, what.buildTimestamp) + "\n\n" +
+			"Creates a threagile model skeleton from the kubernetes manifests in a directory (parsed offline), written to\n" +
+			"--" + importedFileFlagName + " or to " + ImportedModelFilename + " in the output directory. Deployments and stateful sets become\n" +
+			"technical assets, namespaces and network policies trust boundaries, node pools shared runtimes, secrets data assets\n" +
+			"and ingresses internet facing entry points. If the model given via --" + inputFileFlagName + " exists, only what it doesn't define\n" +
+			"already is written, so that the imported model can be merged into it via its includes, keeping hand-curated details.",
+		Args: cobra.ExactArgs(1),
+		RunE: what.importKubernetes,
+	})
+
+	what.rootCmd.AddCommand(&cobra.Command{
+		Use:     ImportTerraformCommand + " <show-json-file>",
+		Short:   "Import terraform plans or state as threagile model skeleton",
+		Aliases: []string{"import-tf"},
+		Long: "\n" + Logo + "\n\n" + fmt.Sprintf(VersionText, what.buildTimestamp) + "\n\n" +
+			"Creates a threagile model skeleton from the output of \"terraform show -json\" for a plan or state (\"-\" reads stdin),\n" +
+			"written to --" + importedFileFlagName + " or to " + ImportedModelFilename + " in the output directory. Common AWS, Azure and Google\n" +
+			"resources become technical assets, accounts, subscriptions, projects, networks and subnets trust boundaries, security\n" +
+			"group rules, firewalls, load balancer listeners and integrations communication links, and encryption settings the\n" +
+			"encryption of the technical assets. If the model given via --" + inputFileFlagName + " exists, only what it doesn't define already\n" +
+			"is written, so that the imported model can be merged into it via its includes, keeping hand-curated details.",
+		Args: cobra.ExactArgs(1),
+		RunE: what.importTerraform,
+	})
+
+	what.rootCmd.AddCommand(&cobra.Command{
+		Use:   ImportOpenAPICommand + " <spec-file> [technical-asset-id]",
+		Short: "Import an OpenAPI 3 document as threagile model skeleton",
+		Long: "\n" + Logo + "\n\n" + fmt.Sprintf(VersionText, what.buildTimestamp) + "\n\n" +
+			"Creates a threagile model skeleton from an OpenAPI 3 document (yaml or json), written to --" + importedFileFlagName + " or to\n" +
+			ImportedModelFilename + " in the output directory. The API becomes a technical asset (named after the API or with the given id)\n" +
+			"accepting the data formats of the request bodies, schema components become data assets classified by their fields\n" +
+			"(personal data, credentials) and the security schemes set the authentication of the links calling the API. If the model\n" +
+			"given via --" + inputFileFlagName + " exists and contains the technical asset, it is extended: the links of its callers receive the data\n" +
+			"assets sent and received, to be merged via the includes of the model, keeping hand-curated details.",
+		Args: cobra.RangeArgs(1, 2),
+		RunE: what.importOpenAPI,
+	})
+
+	what.rootCmd.AddCommand(&cobra.Command{
+		Use:   ImportThreatDragonCommand + " <json-file>",
+		Short: "Import a Threat Dragon v2 model as threagile model",
+		Long: "\n" + Logo + "\n\n" + fmt.Sprintf(VersionText, what.buildTimestamp) + "\n\n" +
+			"Creates a threagile model from a Threat Dragon v2 model (json), written to --" + importedFileFlagName + " or to\n" +
+			ImportedModelFilename + " in the output directory. Processes, stores and actors become technical assets, data flows\n" +
+			"communication links and boundary boxes trust boundaries (by the elements they enclose). The threats recorded in\n" +
+			"Threat Dragon become custom risk categories with their identified risks, and their status risk tracking.",
+		Args: cobra.ExactArgs(1),
+		RunE: what.importThreatDragon,
+	})
+
+	what.rootCmd.AddCommand(&cobra.Command{
+		Use:   ImportTMTCommand + " <tm7-file>",
+		Short: "Import a Microsoft Threat Modeling Tool model as threagile model",
+		Long: "\n" + Logo + "\n\n" + fmt.Sprintf(VersionText, what.buildTimestamp) + "\n\n" +
+			"Creates a threagile model from a Microsoft Threat Modeling Tool model (.tm7), written to --" + importedFileFlagName + " or to\n" +
+			ImportedModelFilename + " in the output directory. Processes, data stores and external interactors become technical\n" +
+			"assets, data flows communication links and border boundaries trust boundaries (by the elements they enclose). The\n" +
+			"generated and custom threats become custom risk categories with their identified risks, and their state risk tracking.",
+		Args: cobra.ExactArgs(1),
+		RunE: what.importTMT,
+	})
+
+	return what
+}
+
+func (what *Threagile) importCompose(cmd *cobra.Command, args []string) error {
+	what.processArgs(cmd, args)
+
+	modelInput, importError := importer.ImportCompose(args...)
+	if importError != nil {
+		return fmt.Errorf("failed to import docker-compose files: %w", importError)
+	}
+
+	return what.writeImportedModel(cmd, modelInput)
+}
+
+func (what *Threagile) importKubernetes(cmd *cobra.Command, args []string) error {
+	what.processArgs(cmd, args)
+
+	return what.importReconciledModel(cmd, func(*input.Model) (*input.Model, error) {
+		modelInput, importError := importer.ImportKubernetes(args[0])
+		if importError != nil {
+			return nil, fmt.Errorf("failed to import kubernetes manifests: %w", importError)
+		}
+
+		return modelInput, nil
+	})
+}
+
+func (what *Threagile) importTerraform(cmd *cobra.Command, args []string) error {
+	what.processArgs(cmd, args)
+
+	return what.importReconciledModel(cmd, func(*input.Model) (*input.Model, error) {
+		modelInput, importError := importer.ImportTerraform(args[0])
+		if importError != nil {
+			return nil, fmt.Errorf("failed to import terraform json: %w", importError)
+		}
+
+		return modelInput, nil
+	})
+}
+
+func (what *Threagile) importOpenAPI(cmd *cobra.Command, args []string) error {
+	what.processArgs(cmd, args)
+
+	technicalAssetId := ""
+	if len(args) > 1 {
+		technicalAssetId = args[1]
+	}
+
+	return what.importReconciledModel(cmd, func(existing *input.Model) (*input.Model, error) {
+		modelInput, importError := importer.ImportOpenAPI(args[0], existing, technicalAssetId)
+		if importError != nil {
+			return nil, fmt.Errorf("failed to import openapi document: %w", importError)
+		}
+
+		return modelInput, nil
+	})
+}
+
+func (what *Threagile) importThreatDragon(cmd *cobra.Command, args []string) error {
+	what.processArgs(cmd, args)
+
+	modelInput, importError := importer.ImportThreatDragon(args[0])
+	if importError != nil {
+		return fmt.Errorf("failed to import threat dragon model: %w", importError)
+	}
+
+	return what.writeImportedModel(cmd, modelInput)
+}
+
+func (what *Threagile) importTMT(cmd *cobra.Command, args []string) error {
+	what.processArgs(cmd, args)
+
+	modelInput, importError := importer.ImportTM7(args[0])
+	if importError != nil {
+		return fmt.Errorf("failed to import threat modeling tool model: %w", importError)
+	}
+
+	return what.writeImportedModel(cmd, modelInput)
+}
+
+// importReconciledModel writes an imported model reduced to what the existing model doesn't define already, if there is one
+func (what *Threagile) importReconciledModel(cmd *cobra.Command, importModel func(existing *input.Model) (*input.Model, error)) error {
+	importedFilename := what.importedModelFilename()
+	existing, included, readError := what.readModelExcludingImport(importedFilename)
+	if readError != nil {
+		return readError
+	}
+
+	modelInput, importError := importModel(existing)
+	if importError != nil {
+		return importError
+	}
+
+	if existing != nil {
+		modelInput = importer.Reconcile(modelInput, existing)
+	}
+
+	writeError := what.writeImportedModel(cmd, modelInput)
+	if writeError != nil {
+		return writeError
+	}
+
+	if existing != nil && !included {
+		include, relError := filepath.Rel(filepath.Dir(what.config.GetInputFile()), importedFilename)
+		if relError != nil {
+			include = importedFilename
+		}
+
+		cmd.Printf("Add %q to the includes of %q to merge the imported model into it\n", include, what.config.GetInputFile())
+	}
+
+	return nil
+}
@@ -23,6 +23,7 @@ func (what *Threagile) initList() *Threagile {
 			cmd.Println("Custom risk rules:")
 			cmd.Println("----------------------")
 			customRiskRules := model.LoadCustomRiskRules(what.config.GetPluginFolder(), what.config.GetRiskRulePlugins(), DefaultProgressReporter{Verbose: what.config.GetVerbose()})
+			customRiskRules.Merge(model.LoadScriptRiskRules(what.config.GetPluginFolder(), what.config.GetScriptRiskRules(), DefaultProgressReporter{Verbose: what.config.GetVerbose()}))
 			for id, customRule := range customRiskRules {
 				cmd.Println(id, "-->", customRule.Category().Title, "--> with tags:", customRule.SupportedTags())
 			}
@@ -0,0 +1,77 @@
+package threagile
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/threagile/threagile/pkg/model"
+	"github.com/threagile/threagile/pkg/otm"
+	"github.com/threagile/threagile/pkg/risks"
+)
+
+func (what *Threagile) initOTM() *Threagile {
+	what.rootCmd.AddCommand(&cobra.Command{
+		Use:   ImportOTMCommand + " <otm-file>",
+		Short: "Import an Open Threat Model (OTM) file as threagile model",
+		Long: "\n" + Logo + "\n\n" + fmt.Sprintf(VersionText, what.buildTimestamp) + "\n\n" +
+			"Converts an Open Threat Model (JSON or YAML) into a threagile model yaml file, written to --" + importedFileFlagName + "\n" +
+			"or to " + ImportedModelFilename + " in the output directory. Trust zones, components, dataflows, assets,\n" +
+			"threats and mitigations become trust boundaries, technical assets, communication links, data assets,\n" +
+			"individual risks and risk tracking.",
+		Args: cobra.ExactArgs(1),
+		RunE: what.importOTM,
+	})
+
+	what.rootCmd.AddCommand(&cobra.Command{
+		Use:   ExportOTMCommand + " [otm-file]",
+		Short: "Export the model and its risks as Open Threat Model (OTM) file",
+		Long: "\n" + Logo + "\n\n" + fmt.Sprintf(VersionText, what.buildTimestamp) + "\n\n" +
+			"Analyzes the model given via --" + inputFileFlagName + " and writes it including all risks and their tracking status\n" +
+			"as Open Threat Model, by default to " + OTMFilename + " in the output directory.\n" +
+			"Files ending in .yaml or .yml are written as YAML, all others as JSON.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: what.exportOTM,
+	})
+
+	return what
+}
+
+func (what *Threagile) importOTM(cmd *cobra.Command, args []string) error {
+	what.processArgs(cmd, args)
+
+	document, readError := otm.Read(args[0])
+	if readError != nil {
+		return readError
+	}
+
+	modelInput, importError := otm.Import(document)
+	if importError != nil {
+		return fmt.Errorf("failed to import otm file %q: %w", args[0], importError)
+	}
+
+	return what.writeImportedModel(cmd, modelInput)
+}
+
+func (what *Threagile) exportOTM(cmd *cobra.Command, args []string) error {
+	what.processArgs(cmd, args)
+
+	progressReporter := DefaultProgressReporter{Verbose: what.config.GetVerbose()}
+	result, analyzeError := model.ReadAndAnalyzeModel(what.config, risks.GetBuiltInRiskRules(), progressReporter)
+	if analyzeError != nil {
+		return fmt.Errorf("failed to read and analyze model: %w", analyzeError)
+	}
+
+	filename := filepath.Join(what.config.GetOutputFolder(), OTMFilename)
+	if len(args) > 0 {
+		filename = args[0]
+	}
+
+	writeError := otm.Export(result.ModelInput, result.ParsedModel).Write(filename)
+	if writeError != nil {
+		return writeError
+	}
+
+	cmd.Printf("Exported model as otm to %q\n", filename)
+	return nil
+}
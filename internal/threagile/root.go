@@ -76,27 +76,45 @@ func (what *Threagile) initFlags() *Threagile {
 	what.rootCmd.PersistentFlags().StringVar(&what.flags.DataAssetDiagramFilenamePNGValue, dataAssetDiagramPNGFileFlagName, what.config.GetDataAssetDiagramFilenamePNG(), "data asset diagram PNG file")
 	what.rootCmd.PersistentFlags().StringVar(&what.flags.DataFlowDiagramFilenameDOTValue, dataFlowDiagramDOTFileFlagName, what.config.GetDataFlowDiagramFilenameDOT(), "data flow diagram DOT file")
 	what.rootCmd.PersistentFlags().StringVar(&what.flags.DataAssetDiagramFilenameDOTValue, dataAssetDiagramDOTFileFlagName, what.config.GetDataAssetDiagramFilenameDOT(), "data asset diagram DOT file")
+	what.rootCmd.PersistentFlags().StringVar(&what.flags.RiskOverlayDiagramFilenamePNGValue, riskOverlayDiagramPNGFileFlagName, what.config.GetRiskOverlayDiagramFilenamePNG(), "risk overlay diagram PNG file")
+	what.rootCmd.PersistentFlags().StringVar(&what.flags.RiskOverlayDiagramFilenameDOTValue, riskOverlayDiagramDOTFileFlagName, what.config.GetRiskOverlayDiagramFilenameDOT(), "risk overlay diagram DOT file")
 	what.rootCmd.PersistentFlags().StringVar(&what.flags.ReportFilenameValue, reportFileFlagName, what.config.GetReportFilename(), "report file")
+	what.rootCmd.PersistentFlags().StringVar(&what.flags.HtmlReportFilenameValue, reportHtmlFileFlagName, what.config.GetHtmlReportFilename(), "html report file")
 	what.rootCmd.PersistentFlags().StringVar(&what.flags.ExcelRisksFilenameValue, risksExcelFileFlagName, what.config.GetExcelRisksFilename(), "risks Excel file")
 	what.rootCmd.PersistentFlags().StringVar(&what.flags.ExcelTagsFilenameValue, tagsExcelFileFlagName, what.config.GetExcelTagsFilename(), "tags Excel file")
 	what.rootCmd.PersistentFlags().StringVar(&what.flags.JsonRisksFilenameValue, risksJsonFileFlagName, what.config.GetJsonRisksFilename(), "risks JSON file")
+	what.rootCmd.PersistentFlags().StringVar(&what.flags.SarifRisksFilenameValue, risksSarifFileFlagName, what.config.GetSarifRisksFilename(), "risks SARIF file")
 	what.rootCmd.PersistentFlags().StringVar(&what.flags.JsonTechnicalAssetsFilenameValue, technicalAssetsJsonFileFlagName, what.config.GetJsonTechnicalAssetsFilename(), "technical assets JSON file")
 	what.rootCmd.PersistentFlags().StringVar(&what.flags.JsonStatsFilenameValue, statsJsonFileFlagName, what.config.GetJsonStatsFilename(), "stats JSON file")
+	what.rootCmd.PersistentFlags().StringVar(&what.flags.JsonAttackPathsFilenameValue, attackPathsJsonFileFlagName, what.config.GetJsonAttackPathsFilename(), "attack paths JSON file")
+	what.rootCmd.PersistentFlags().StringVar(&what.flags.JsonAttackTreesFilenameValue, attackTreesJsonFileFlagName, what.config.GetJsonAttackTreesFilename(), "attack trees JSON file")
+	what.rootCmd.PersistentFlags().StringVar(&what.flags.JsonLossExposureFilenameValue, lossExposureJsonFileFlagName, what.config.GetJsonLossExposureFilename(), "loss exposure JSON file")
 	what.rootCmd.PersistentFlags().StringVar(&what.flags.TemplateFilenameValue, templateFileNameFlagName, what.config.GetTemplateFilename(), "template pdf file")
 	what.rootCmd.PersistentFlags().StringVar(&what.flags.ReportLogoImagePathValue, reportLogoImagePathFlagName, what.config.GetReportLogoImagePath(), "report logo image")
 	what.rootCmd.PersistentFlags().StringVar(&what.flags.TechnologyFilenameValue, technologyFileFlagName, what.config.GetTechnologyFilename(), "file name of additional technologies")
+	what.rootCmd.PersistentFlags().StringVar(&what.flags.ComplianceFilenameValue, complianceFileFlagName, what.config.GetComplianceFilename(), "file name of additional compliance framework mappings")
 
 	what.rootCmd.PersistentFlags().StringVar(&what.flags.riskRulePluginsValue, customRiskRulesPluginFlagName, strings.Join(what.config.GetRiskRulePlugins(), ","), "comma-separated list of plugins file names with custom risk rules to load")
+	what.rootCmd.PersistentFlags().StringVar(&what.flags.scriptRiskRulesValue, scriptRiskRulesFlagName, strings.Join(what.config.GetScriptRiskRules(), ","), "comma-separated list of YAML script risk rule files or folders to load")
 	what.rootCmd.PersistentFlags().StringVar(&what.flags.skipRiskRulesValue, skipRiskRulesFlagName, strings.Join(what.config.GetSkipRiskRules(), ","), "comma-separated list of risk rules (by their ID) to skip")
 	what.rootCmd.PersistentFlags().StringVar(&what.flags.ExecuteModelMacroValue, executeModelMacroFlagName, what.config.GetExecuteModelMacro(), "macro to execute")
 
 	// RiskExcelValue not available as flags
 
+	what.rootCmd.PersistentFlags().StringVar(&what.flags.FailOnSeverityValue, failOnFlagName, what.config.GetFailOnSeverity(), "fail (exit non-zero) on new or worsened risks of at least this severity: low, medium, elevated, high or critical")
+	what.rootCmd.PersistentFlags().StringVar(&what.flags.failOnStatusValue, failOnStatusFlagName, strings.Join(what.config.GetFailOnStatus(), ","), "comma-separated list of risk statuses considered by --"+failOnFlagName)
+	what.rootCmd.PersistentFlags().StringVar(&what.flags.BaselineFileValue, baselineFlagName, what.config.GetBaselineFile(), "risks JSON file of a previous run, only new or worsened risks are considered by --"+failOnFlagName)
+
 	what.rootCmd.PersistentFlags().IntVar(&what.flags.ServerPortValue, serverPortFlagName, what.config.GetServerPort(), "server port")
 	what.rootCmd.PersistentFlags().StringVar(&what.flags.ServerFolderValue, serverDirFlagName, what.config.GetDataFolder(), "base folder for server mode (default: "+DataDir+")")
 	what.rootCmd.PersistentFlags().IntVar(&what.flags.DiagramDPIValue, diagramDpiFlagName, what.config.GetDiagramDPI(), "DPI used to render: maximum is "+fmt.Sprintf("%d", what.config.GetMaxGraphvizDPI())+"")
 	// MaxGraphvizDPIValue not available as flags
 	what.rootCmd.PersistentFlags().IntVar(&what.flags.BackupHistoryFilesToKeepValue, backupHistoryFilesToKeepFlagName, what.config.GetBackupHistoryFilesToKeep(), "number of backup history files to keep")
+	what.rootCmd.PersistentFlags().StringVar(&what.flags.DiagramRendererValue, diagramRendererFlagName, what.config.GetDiagramRenderer(), "diagram renderer: "+report.DiagramRendererAuto+" (graphviz if dot is installed), "+report.DiagramRendererGraphviz+" or "+report.DiagramRendererBuiltIn)
+	what.rootCmd.PersistentFlags().StringVar(&what.flags.RiskOverlaySTRIDEValue, riskOverlaySTRIDEFlagName, what.config.GetRiskOverlaySTRIDE(), "limit the risk overlay diagram to one STRIDE category (spoofing, tampering, repudiation, information-disclosure, denial-of-service or elevation-of-privilege)")
+	what.rootCmd.PersistentFlags().BoolVar(&what.flags.DataFlowDiagramPerTrustBoundaryValue, dataFlowDiagramPerTrustBoundaryFlagName, what.config.GetDataFlowDiagramPerTrustBoundary(), "additionally generate a data flow diagram per trust boundary including the links crossing it")
+	what.rootCmd.PersistentFlags().StringVar(&what.flags.dataFlowDiagramTechnicalAssetsValue, dataFlowDiagramTechnicalAssetsFlagName, strings.Join(what.config.GetDataFlowDiagramTechnicalAssets(), ","), "comma-separated list of technical assets (by their ID) to additionally generate a data flow diagram of their neighbourhood for")
+	what.rootCmd.PersistentFlags().IntVar(&what.flags.DataFlowDiagramHopsValue, dataFlowDiagramHopsFlagName, what.config.GetDataFlowDiagramHops(), "number of communication links around a technical asset to include in its data flow diagram")
 
 	what.rootCmd.PersistentFlags().BoolVar(&what.flags.AddModelTitleValue, addModelTitleFlagName, what.config.GetAddModelTitle(), "add model title")
 	what.rootCmd.PersistentFlags().BoolVar(&what.flags.KeepDiagramSourceFilesValue, keepDiagramSourceFilesFlagName, what.config.GetKeepDiagramSourceFiles(), "keep diagram source files")
@@ -104,13 +122,20 @@ func (what *Threagile) initFlags() *Threagile {
 
 	what.rootCmd.PersistentFlags().BoolVar(&what.flags.SkipDataFlowDiagramValue, skipDataFlowDiagramFlagName, what.config.GetSkipDataFlowDiagram(), "skip generating data flow diagram")
 	what.rootCmd.PersistentFlags().BoolVar(&what.flags.SkipDataAssetDiagramValue, skipDataAssetDiagramFlagName, what.config.GetSkipDataAssetDiagram(), "skip generating data asset diagram")
+	what.rootCmd.PersistentFlags().BoolVar(&what.flags.SkipRiskOverlayDiagramValue, skipRiskOverlayDiagramFlagName, what.config.GetSkipRiskOverlayDiagram(), "skip generating risk overlay diagram")
+	what.rootCmd.PersistentFlags().BoolVar(&what.flags.SkipTechnicalAssetDiagramsValue, skipTechnicalAssetDiagramsFlagName, what.config.GetSkipTechnicalAssetDiagrams(), "skip embedding a data flow diagram into the report chapter of each technical asset")
 	what.rootCmd.PersistentFlags().BoolVar(&what.flags.SkipRisksJSONValue, skipRisksJSONFlagName, what.config.GetSkipRisksJSON(), "skip generating risks json")
+	what.rootCmd.PersistentFlags().BoolVar(&what.flags.SkipRisksSARIFValue, skipRisksSARIFFlagName, what.config.GetSkipRisksSARIF(), "skip generating risks sarif")
 	what.rootCmd.PersistentFlags().BoolVar(&what.flags.SkipTechnicalAssetsJSONValue, skipTechnicalAssetsJSONFlagName, what.config.GetSkipTechnicalAssetsJSON(), "skip generating technical assets json")
 	what.rootCmd.PersistentFlags().BoolVar(&what.flags.SkipStatsJSONValue, skipStatsJSONFlagName, what.config.GetSkipStatsJSON(), "skip generating stats json")
+	what.rootCmd.PersistentFlags().BoolVar(&what.flags.SkipAttackPathsJSONValue, skipAttackPathsJSONFlagName, what.config.GetSkipAttackPathsJSON(), "skip generating attack paths json")
+	what.rootCmd.PersistentFlags().BoolVar(&what.flags.SkipAttackTreesValue, skipAttackTreesFlagName, what.config.GetSkipAttackTrees(), "skip generating attack trees json and diagrams")
+	what.rootCmd.PersistentFlags().BoolVar(&what.flags.SkipLossExposureJSONValue, skipLossExposureJSONFlagName, what.config.GetSkipLossExposureJSON(), "skip generating loss exposure json")
 	what.rootCmd.PersistentFlags().BoolVar(&what.flags.SkipRisksExcelValue, skipRisksExcelFlagName, what.config.GetSkipRisksExcel(), "skip generating risks excel")
 	what.rootCmd.PersistentFlags().BoolVar(&what.flags.SkipTagsExcelValue, skipTagsExcelFlagName, what.config.GetSkipTagsExcel(), "skip generating tags excel")
 	what.rootCmd.PersistentFlags().BoolVar(&what.flags.SkipReportPDFValue, skipReportPDFFlagName, what.config.GetSkipReportPDF(), "skip generating report pdf, including diagrams")
 	what.rootCmd.PersistentFlags().BoolVar(&what.flags.SkipReportADOCValue, skipReportADOCFlagName, what.config.GetSkipReportADOC(), "skip generating report adoc, including diagrams")
+	what.rootCmd.PersistentFlags().BoolVar(&what.flags.SkipReportHTMLValue, skipReportHTMLFlagName, what.config.GetSkipReportHTML(), "skip generating report html")
 
 	what.rootCmd.PersistentFlags().BoolVar(&what.flags.generateDataFlowDiagramFlag, generateDataFlowDiagramFlagName, !what.config.GetSkipDataFlowDiagram(), "(deprecated) generate generating data flow diagram")
 	what.rootCmd.PersistentFlags().BoolVar(&what.flags.generateDataAssetDiagramFlag, generateDataAssetDiagramFlagName, !what.config.GetSkipDataAssetDiagram(), "(deprecated) generate generating data asset diagram")
@@ -122,6 +147,11 @@ func (what *Threagile) initFlags() *Threagile {
 	what.rootCmd.PersistentFlags().BoolVar(&what.flags.generateReportPDFFlag, generateReportPDFFlagName, !what.config.GetSkipReportPDF(), "(deprecated) generate generating report pdf, including diagrams")
 	what.rootCmd.PersistentFlags().BoolVar(&what.flags.generateReportADOCFlag, generateReportADOCFlagName, !what.config.GetSkipReportADOC(), "(deprecated) generate generating report adoc, including diagrams")
 
+	// diff flags keep their current value as default, they are not part of the config
+	what.rootCmd.PersistentFlags().StringVar(&what.flags.diffBaseValue, diffBaseFlagName, what.flags.diffBaseValue, "base model yaml file to compare against (diff command)")
+	what.rootCmd.PersistentFlags().StringVar(&what.flags.diffFormatValue, diffFormatFlagName, what.flags.diffFormatValue, "diff output format: "+report.DiffFormatText+", "+report.DiffFormatJSON+" or "+report.DiffFormatMarkdown)
+	what.rootCmd.PersistentFlags().StringVar(&what.flags.diffOutputValue, diffOutputFlagName, what.flags.diffOutputValue, "file to write the diff to (default: stdout)")
+
 	// AttractivenessValue not available as flags
 	// ReportConfigurationValue not available as flags
 
@@ -255,13 +285,20 @@ func (what *Threagile) readCommands() *report.GenerateCommands {
 	commands := new(report.GenerateCommands).Defaults()
 	commands.DataFlowDiagram = !what.flags.SkipDataFlowDiagramValue
 	commands.DataAssetDiagram = !what.flags.SkipDataAssetDiagramValue
+	commands.RiskOverlayDiagram = !what.flags.SkipRiskOverlayDiagramValue
+	commands.TechnicalAssetDiagrams = !what.flags.SkipTechnicalAssetDiagramsValue
 	commands.RisksJSON = !what.flags.SkipRisksJSONValue
+	commands.RisksSARIF = !what.flags.SkipRisksSARIFValue
 	commands.StatsJSON = !what.flags.SkipStatsJSONValue
+	commands.AttackPathsJSON = !what.flags.SkipAttackPathsJSONValue
+	commands.AttackTrees = !what.flags.SkipAttackTreesValue
+	commands.LossExposureJSON = !what.flags.SkipLossExposureJSONValue
 	commands.TechnicalAssetsJSON = !what.flags.SkipTechnicalAssetsJSONValue
 	commands.RisksExcel = !what.flags.SkipRisksExcelValue
 	commands.TagsExcel = !what.flags.SkipTagsExcelValue
 	commands.ReportPDF = !what.flags.SkipReportPDFValue
 	commands.ReportADOC = !what.flags.SkipReportADOCValue
+	commands.ReportHTML = !what.flags.SkipReportHTMLValue
 	return commands
 }
 
@@ -341,10 +378,22 @@ func (what *Threagile) processArgs(cmd *cobra.Command, args []string) bool {
 		what.config.DataAssetDiagramFilenameDOTValue = what.config.CleanPath(what.flags.DataAssetDiagramFilenameDOTValue)
 	}
 
+	if what.isFlagOverridden(cmd, riskOverlayDiagramPNGFileFlagName) {
+		what.config.RiskOverlayDiagramFilenamePNGValue = what.config.CleanPath(what.flags.RiskOverlayDiagramFilenamePNGValue)
+	}
+
+	if what.isFlagOverridden(cmd, riskOverlayDiagramDOTFileFlagName) {
+		what.config.RiskOverlayDiagramFilenameDOTValue = what.config.CleanPath(what.flags.RiskOverlayDiagramFilenameDOTValue)
+	}
+
 	if what.isFlagOverridden(cmd, reportFileFlagName) {
 		what.config.ReportFilenameValue = what.config.CleanPath(what.flags.ReportFilenameValue)
 	}
 
+	if what.isFlagOverridden(cmd, reportHtmlFileFlagName) {
+		what.config.HtmlReportFilenameValue = what.config.CleanPath(what.flags.HtmlReportFilenameValue)
+	}
+
 	if what.isFlagOverridden(cmd, risksExcelFileFlagName) {
 		what.config.ExcelRisksFilenameValue = what.config.CleanPath(what.flags.ExcelRisksFilenameValue)
 	}
@@ -357,6 +406,10 @@ func (what *Threagile) processArgs(cmd *cobra.Command, args []string) bool {
 		what.config.JsonRisksFilenameValue = what.config.CleanPath(what.flags.JsonRisksFilenameValue)
 	}
 
+	if what.isFlagOverridden(cmd, risksSarifFileFlagName) {
+		what.config.SarifRisksFilenameValue = what.config.CleanPath(what.flags.SarifRisksFilenameValue)
+	}
+
 	if what.isFlagOverridden(cmd, technicalAssetsJsonFileFlagName) {
 		what.config.JsonTechnicalAssetsFilenameValue = what.config.CleanPath(what.flags.JsonTechnicalAssetsFilenameValue)
 	}
@@ -365,6 +418,18 @@ func (what *Threagile) processArgs(cmd *cobra.Command, args []string) bool {
 		what.config.JsonStatsFilenameValue = what.config.CleanPath(what.flags.JsonStatsFilenameValue)
 	}
 
+	if what.isFlagOverridden(cmd, attackPathsJsonFileFlagName) {
+		what.config.JsonAttackPathsFilenameValue = what.config.CleanPath(what.flags.JsonAttackPathsFilenameValue)
+	}
+
+	if what.isFlagOverridden(cmd, attackTreesJsonFileFlagName) {
+		what.config.JsonAttackTreesFilenameValue = what.config.CleanPath(what.flags.JsonAttackTreesFilenameValue)
+	}
+
+	if what.isFlagOverridden(cmd, lossExposureJsonFileFlagName) {
+		what.config.JsonLossExposureFilenameValue = what.config.CleanPath(what.flags.JsonLossExposureFilenameValue)
+	}
+
 	if what.isFlagOverridden(cmd, templateFileNameFlagName) {
 		what.config.TemplateFilenameValue = what.flags.TemplateFilenameValue
 	}
@@ -377,14 +442,39 @@ func (what *Threagile) processArgs(cmd *cobra.Command, args []string) bool {
 		what.config.TechnologyFilenameValue = what.flags.TechnologyFilenameValue
 	}
 
+	if what.isFlagOverridden(cmd, complianceFileFlagName) {
+		what.config.ComplianceFilenameValue = what.flags.ComplianceFilenameValue
+	}
+
 	if what.isFlagOverridden(cmd, customRiskRulesPluginFlagName) {
 		what.config.RiskRulePluginsValue = strings.Split(what.flags.riskRulePluginsValue, ",")
 	}
 
+	if what.isFlagOverridden(cmd, scriptRiskRulesFlagName) {
+		what.config.ScriptRiskRulesValue = make([]string, 0)
+		for _, scriptRiskRule := range strings.Split(what.flags.scriptRiskRulesValue, ",") {
+			if len(scriptRiskRule) > 0 {
+				what.config.ScriptRiskRulesValue = append(what.config.ScriptRiskRulesValue, what.config.CleanPath(scriptRiskRule))
+			}
+		}
+	}
+
 	if what.isFlagOverridden(cmd, skipRiskRulesFlagName) {
 		what.config.SkipRiskRulesValue = strings.Split(what.flags.skipRiskRulesValue, ",")
 	}
 
+	if what.isFlagOverridden(cmd, failOnFlagName) {
+		what.config.FailOnSeverityValue = what.flags.FailOnSeverityValue
+	}
+
+	if what.isFlagOverridden(cmd, failOnStatusFlagName) {
+		what.config.FailOnStatusValue = strings.Split(what.flags.failOnStatusValue, ",")
+	}
+
+	if what.isFlagOverridden(cmd, baselineFlagName) {
+		what.config.BaselineFileValue = what.config.CleanPath(what.flags.BaselineFileValue)
+	}
+
 	if what.isFlagOverridden(cmd, executeModelMacroFlagName) {
 		what.config.ExecuteModelMacroValue = what.flags.ExecuteModelMacroValue
 	}
@@ -413,6 +503,26 @@ func (what *Threagile) processArgs(cmd *cobra.Command, args []string) bool {
 		what.config.BackupHistoryFilesToKeepValue = what.flags.BackupHistoryFilesToKeepValue
 	}
 
+	if what.isFlagOverridden(cmd, diagramRendererFlagName) {
+		what.config.DiagramRendererValue = what.flags.DiagramRendererValue
+	}
+
+	if what.isFlagOverridden(cmd, riskOverlaySTRIDEFlagName) {
+		what.config.RiskOverlaySTRIDEValue = what.flags.RiskOverlaySTRIDEValue
+	}
+
+	if what.isFlagOverridden(cmd, dataFlowDiagramPerTrustBoundaryFlagName) {
+		what.config.DataFlowDiagramPerTrustBoundaryValue = what.flags.DataFlowDiagramPerTrustBoundaryValue
+	}
+
+	if what.isFlagOverridden(cmd, dataFlowDiagramTechnicalAssetsFlagName) {
+		what.config.DataFlowDiagramTechnicalAssetsValue = strings.Split(what.flags.dataFlowDiagramTechnicalAssetsValue, ",")
+	}
+
+	if what.isFlagOverridden(cmd, dataFlowDiagramHopsFlagName) {
+		what.config.DataFlowDiagramHopsValue = what.flags.DataFlowDiagramHopsValue
+	}
+
 	if what.isFlagOverridden(cmd, addModelTitleFlagName) {
 		what.config.AddModelTitleValue = what.flags.AddModelTitleValue
 	}
@@ -433,10 +543,22 @@ func (what *Threagile) processArgs(cmd *cobra.Command, args []string) bool {
 		what.config.SkipDataAssetDiagramValue = what.flags.SkipDataAssetDiagramValue
 	}
 
+	if what.isFlagOverridden(cmd, skipRiskOverlayDiagramFlagName) {
+		what.config.SkipRiskOverlayDiagramValue = what.flags.SkipRiskOverlayDiagramValue
+	}
+
+	if what.isFlagOverridden(cmd, skipTechnicalAssetDiagramsFlagName) {
+		what.config.SkipTechnicalAssetDiagramsValue = what.flags.SkipTechnicalAssetDiagramsValue
+	}
+
 	if what.isFlagOverridden(cmd, skipRisksJSONFlagName) {
 		what.config.SkipRisksJSONValue = what.flags.SkipRisksJSONValue
 	}
 
+	if what.isFlagOverridden(cmd, skipRisksSARIFFlagName) {
+		what.config.SkipRisksSARIFValue = what.flags.SkipRisksSARIFValue
+	}
+
 	if what.isFlagOverridden(cmd, skipTechnicalAssetsJSONFlagName) {
 		what.config.SkipTechnicalAssetsJSONValue = what.flags.SkipTechnicalAssetsJSONValue
 	}
@@ -445,6 +567,18 @@ func (what *Threagile) processArgs(cmd *cobra.Command, args []string) bool {
 		what.config.SkipStatsJSONValue = what.flags.SkipStatsJSONValue
 	}
 
+	if what.isFlagOverridden(cmd, skipAttackPathsJSONFlagName) {
+		what.config.SkipAttackPathsJSONValue = what.flags.SkipAttackPathsJSONValue
+	}
+
+	if what.isFlagOverridden(cmd, skipAttackTreesFlagName) {
+		what.config.SkipAttackTreesValue = what.flags.SkipAttackTreesValue
+	}
+
+	if what.isFlagOverridden(cmd, skipLossExposureJSONFlagName) {
+		what.config.SkipLossExposureJSONValue = what.flags.SkipLossExposureJSONValue
+	}
+
 	if what.isFlagOverridden(cmd, skipRisksExcelFlagName) {
 		what.config.SkipRisksExcelValue = what.flags.SkipRisksExcelValue
 	}
@@ -461,6 +595,10 @@ func (what *Threagile) processArgs(cmd *cobra.Command, args []string) bool {
 		what.config.SkipReportADOCValue = what.flags.SkipReportADOCValue
 	}
 
+	if what.isFlagOverridden(cmd, skipReportHTMLFlagName) {
+		what.config.SkipReportHTMLValue = what.flags.SkipReportHTMLValue
+	}
+
 	if what.isFlagOverridden(cmd, generateDataFlowDiagramFlagName) {
 		what.config.SkipDataFlowDiagramValue = !what.flags.generateDataFlowDiagramFlag
 	}
@@ -0,0 +1,346 @@
+package importer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+const composeDefaultNetwork = "default"
+
+type composeFile struct {
+	Name     string                     `yaml:"name"`
+	Services map[string]*composeService `yaml:"services"`
+	Networks map[string]*composeNetwork `yaml:"networks"`
+}
+
+type composeNetwork struct {
+	Name     string `yaml:"name"`
+	Driver   string `yaml:"driver"`
+	Internal bool   `yaml:"internal"`
+}
+
+type composeService struct {
+	Image         string        `yaml:"image"`
+	Build         *composeBuild `yaml:"build"`
+	ContainerName string        `yaml:"container_name"`
+	Ports         []composePort `yaml:"ports"`
+	Expose        []string      `yaml:"expose"`
+	DependsOn     composeNames  `yaml:"depends_on"`
+	Links         []string      `yaml:"links"`
+	Networks      composeNames  `yaml:"networks"`
+}
+
+// composeBuild is either a build context path or a mapping with context and dockerfile
+type composeBuild struct {
+	Context    string `yaml:"context"`
+	Dockerfile string `yaml:"dockerfile"`
+}
+
+func (what *composeBuild) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		what.Context = node.Value
+		return nil
+	}
+
+	type plain composeBuild
+	return node.Decode((*plain)(what))
+}
+
+// composePort is either a short "[host:]published:target[/protocol]" string or a mapping with target and published port
+type composePort struct {
+	Target    int
+	Published string
+}
+
+func (what *composePort) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		value := node.Value
+		if index := strings.Index(value, "/"); index >= 0 {
+			value = value[:index]
+		}
+
+		parts := strings.Split(value, ":")
+		what.Target = parsePort(parts[len(parts)-1])
+		if len(parts) > 1 {
+			what.Published = parts[len(parts)-2]
+		}
+
+		return nil
+	}
+
+	var long struct {
+		Target    int    `yaml:"target"`
+		Published string `yaml:"published"`
+	}
+
+	decodeError := node.Decode(&long)
+	if decodeError != nil {
+		return decodeError
+	}
+
+	what.Target = long.Target
+	what.Published = long.Published
+	return nil
+}
+
+// composeNames is either a list of names or a mapping keyed by name (as used by depends_on and networks)
+type composeNames []string
+
+func (what *composeNames) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for n := 0; n+1 < len(node.Content); n += 2 {
+			*what = append(*what, node.Content[n].Value)
+		}
+
+		return nil
+
+	default:
+		var names []string
+		decodeError := node.Decode(&names)
+		if decodeError != nil {
+			return decodeError
+		}
+
+		*what = names
+		return nil
+	}
+}
+
+// ImportCompose creates a model from one or more docker-compose files; later files extend and override earlier ones
+func ImportCompose(filenames ...string) (*input.Model, error) {
+	contents := make([][]byte, 0, len(filenames))
+	for _, filename := range filenames {
+		data, readError := os.ReadFile(filepath.Clean(filename))
+		if readError != nil {
+			return nil, fmt.Errorf("unable to read docker-compose file: %w", readError)
+		}
+
+		contents = append(contents, data)
+	}
+
+	title := ""
+	if len(filenames) > 0 {
+		if absolute, absError := filepath.Abs(filenames[0]); absError == nil {
+			title = filepath.Base(filepath.Dir(absolute))
+		}
+	}
+
+	return importCompose(title, contents...)
+}
+
+func importCompose(title string, contents ...[]byte) (*input.Model, error) {
+	compose, parseError := parseCompose(contents...)
+	if parseError != nil {
+		return nil, parseError
+	}
+
+	if len(compose.Name) > 0 {
+		title = compose.Name
+	}
+
+	if len(title) == 0 {
+		title = "docker-compose"
+	}
+
+	guesser, guesserError := newTechnologyGuesser()
+	if guesserError != nil {
+		return nil, guesserError
+	}
+
+	model := newStubModel(title)
+	model.AppDescription.Description = "Imported from docker-compose"
+
+	ids := make(map[string]string)
+	for _, name := range sortedKeys(compose.Services) {
+		ids[name] = types.MakeID(name)
+	}
+
+	networkMembers := make(map[string][]string)
+	for _, name := range sortedKeys(compose.Services) {
+		service := compose.Services[name]
+		technology := guesser.guess(service.Image, name)
+		technicalAsset := newTechnicalAsset(ids[name], composeServiceDescription(service), technology, types.Container)
+		technicalAsset.CustomDevelopedParts = service.Build != nil
+		model.TechnicalAssets[name] = technicalAsset
+
+		networks := service.Networks
+		if len(networks) == 0 {
+			networks = composeNames{composeDefaultNetwork}
+		}
+
+		// a technical asset can only be inside one trust boundary, the first network wins
+		networkMembers[networks[0]] = append(networkMembers[networks[0]], ids[name])
+	}
+
+	for _, network := range sortedKeys(networkMembers) {
+		description := "Network " + network
+		if details, ok := compose.Networks[network]; ok && details != nil && details.Internal {
+			description += " (internal)"
+		}
+
+		model.TrustBoundaries[network] = input.TrustBoundary{
+			ID:                    types.MakeID(network) + "-network",
+			Description:           description,
+			Type:                  types.NetworkVirtualLAN.String(),
+			TechnicalAssetsInside: networkMembers[network],
+		}
+	}
+
+	for _, name := range sortedKeys(compose.Services) {
+		service := compose.Services[name]
+		targets := append([]string{}, service.DependsOn...)
+		for _, link := range service.Links {
+			targets = append(targets, strings.SplitN(link, ":", 2)[0])
+		}
+
+		for _, target := range targets {
+			targetService, ok := compose.Services[target]
+			if !ok {
+				return nil, fmt.Errorf("service %q references unknown service %q", name, target)
+			}
+
+			ports := targetService.ports()
+			if len(ports) == 0 {
+				ports = defaultPorts(targetService.Image, target)
+			}
+
+			protocol := protocolForPorts(ports, model.TechnicalAssets[target].Technologies[0])
+			addCommunicationLink(model, name, "Access "+target, newCommunicationLink(ids[target], name+" accesses "+target, protocol))
+		}
+	}
+
+	return model, nil
+}
+
+// parseCompose merges the given files the way docker compose does: scalars are overridden, lists are extended
+func parseCompose(contents ...[]byte) (*composeFile, error) {
+	result := &composeFile{
+		Services: make(map[string]*composeService),
+		Networks: make(map[string]*composeNetwork),
+	}
+
+	for _, data := range contents {
+		var compose composeFile
+		unmarshalError := yaml.Unmarshal(data, &compose)
+		if unmarshalError != nil {
+			return nil, fmt.Errorf("unable to parse docker-compose file: %w", unmarshalError)
+		}
+
+		if len(compose.Name) > 0 {
+			result.Name = compose.Name
+		}
+
+		for name, network := range compose.Networks {
+			result.Networks[name] = network
+		}
+
+		for name, service := range compose.Services {
+			if service == nil {
+				service = new(composeService)
+			}
+
+			existing, ok := result.Services[name]
+			if !ok {
+				result.Services[name] = service
+				continue
+			}
+
+			existing.merge(service)
+		}
+	}
+
+	if len(result.Services) == 0 {
+		return nil, fmt.Errorf("unable to parse docker-compose file: no services found")
+	}
+
+	return result, nil
+}
+
+func (what *composeService) merge(other *composeService) {
+	if len(other.Image) > 0 {
+		what.Image = other.Image
+	}
+
+	if other.Build != nil {
+		what.Build = other.Build
+	}
+
+	if len(other.ContainerName) > 0 {
+		what.ContainerName = other.ContainerName
+	}
+
+	what.Ports = append(what.Ports, other.Ports...)
+	what.Expose = appendUnique(what.Expose, other.Expose...)
+	what.DependsOn = appendUnique(what.DependsOn, other.DependsOn...)
+	what.Links = appendUnique(what.Links, other.Links...)
+	what.Networks = appendUnique(what.Networks, other.Networks...)
+}
+
+// ports returns the container ports a service listens on
+func (what *composeService) ports() []int {
+	ports := make([]int, 0)
+	for _, port := range what.Ports {
+		if port.Target > 0 {
+			ports = append(ports, port.Target)
+		}
+	}
+
+	for _, port := range what.Expose {
+		if value := parsePort(port); value > 0 {
+			ports = append(ports, value)
+		}
+	}
+
+	return ports
+}
+
+func composeServiceDescription(service *composeService) string {
+	description := make([]string, 0)
+	if len(service.Image) > 0 {
+		description = append(description, "Image "+service.Image)
+	}
+
+	if service.Build != nil {
+		description = append(description, "Built from "+filepath.Join(service.Build.Context, service.Build.Dockerfile))
+	}
+
+	published := make([]string, 0)
+	for _, port := range service.Ports {
+		if len(port.Published) > 0 {
+			published = append(published, port.Published+":"+strconv.Itoa(port.Target))
+		}
+	}
+
+	if len(published) > 0 {
+		description = append(description, "publishes ports "+strings.Join(published, ", "))
+	}
+
+	if len(service.Networks) > 1 {
+		description = append(description, "also attached to networks "+strings.Join(service.Networks[1:], ", "))
+	}
+
+	return strings.Join(description, "; ")
+}
+
+// parsePort parses a port or the first port of a range like "8000-8010"
+func parsePort(value string) int {
+	value = strings.TrimSpace(value)
+	if index := strings.Index(value, "-"); index >= 0 {
+		value = value[:index]
+	}
+
+	port, parseError := strconv.Atoi(value)
+	if parseError != nil {
+		return 0
+	}
+
+	return port
+}
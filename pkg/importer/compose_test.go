@@ -0,0 +1,89 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+const shopCompose = `
+name: shop
+services:
+  proxy:
+    image: nginx:1.25-alpine
+    ports:
+      - "443:443"
+    depends_on:
+      - web
+    networks:
+      - front
+  web:
+    build: ./web
+    expose:
+      - 8080
+    depends_on:
+      db:
+        condition: service_healthy
+    networks: [front, back]
+  db:
+    image: docker.io/bitnami/postgresql:15
+    networks:
+      back: {}
+networks:
+  front: {}
+  back:
+    internal: true
+`
+
+const shopComposeOverride = `
+services:
+  cache:
+    image: redis:7
+    networks:
+      - back
+  web:
+    links:
+      - "cache:sessions"
+`
+
+func TestImportComposeMapsServices(t *testing.T) {
+	model, err := importCompose("", []byte(shopCompose))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "shop", model.Title)
+	assert.Len(t, model.TechnicalAssets, 3)
+	assert.Equal(t, []string{types.ReverseProxy}, model.TechnicalAssets["proxy"].Technologies)
+	assert.Equal(t, []string{types.Database}, model.TechnicalAssets["db"].Technologies)
+	assert.Equal(t, types.Datastore.String(), model.TechnicalAssets["db"].Type)
+	assert.Equal(t, []string{types.UnknownTechnology}, model.TechnicalAssets["web"].Technologies)
+	assert.True(t, model.TechnicalAssets["web"].CustomDevelopedParts)
+
+	assert.Equal(t, []string{"proxy", "web"}, model.TrustBoundaries["front"].TechnicalAssetsInside)
+	assert.Equal(t, []string{"db"}, model.TrustBoundaries["back"].TechnicalAssetsInside)
+	assert.Equal(t, "back-network", model.TrustBoundaries["back"].ID)
+
+	assert.Equal(t, "web", model.TechnicalAssets["proxy"].CommunicationLinks["Access web"].Target)
+	assert.Equal(t, types.HTTP.String(), model.TechnicalAssets["proxy"].CommunicationLinks["Access web"].Protocol)
+	assert.Equal(t, types.SqlAccessProtocol.String(), model.TechnicalAssets["web"].CommunicationLinks["Access db"].Protocol)
+}
+
+func TestImportComposeMergesFiles(t *testing.T) {
+	model, err := importCompose("", []byte(shopCompose), []byte(shopComposeOverride))
+	assert.NoError(t, err)
+
+	assert.Len(t, model.TechnicalAssets, 4)
+	assert.Equal(t, []string{"cache", "db"}, model.TrustBoundaries["back"].TechnicalAssetsInside)
+	assert.Len(t, model.TechnicalAssets["web"].CommunicationLinks, 2)
+	assert.Equal(t, types.NosqlAccessProtocol.String(), model.TechnicalAssets["web"].CommunicationLinks["Access cache"].Protocol)
+}
+
+func TestImportComposeRejectsUnknownDependencies(t *testing.T) {
+	_, err := importCompose("", []byte("services:\n  web:\n    depends_on: [db]\n"))
+	assert.Error(t, err)
+}
+
+func TestNameCandidates(t *testing.T) {
+	assert.Equal(t, []string{"postgresql"}, nameCandidates("docker.io/bitnami/postgresql:15-alpine"))
+	assert.Equal(t, []string{"rabbitmq-management", "rabbitmq", "management"}, nameCandidates("RabbitMQ-Management@sha256:abc"))
+}
@@ -0,0 +1,470 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+type diagramElementKind int
+
+const (
+	diagramProcess diagramElementKind = iota
+	diagramStore
+	diagramActor
+)
+
+// diagram is a data flow diagram as drawn by threat modeling tools, with the threats recorded there
+type diagram struct {
+	elements   []*diagramElement
+	boundaries []*diagramBoundary
+	flows      []*diagramFlow
+}
+
+type diagramShape struct {
+	x, y, width, height float64
+}
+
+type diagramElement struct {
+	diagramShape
+	id            string
+	name          string
+	description   string
+	kind          diagramElementKind
+	technology    string
+	human         bool
+	encrypted     bool
+	outOfScope    bool
+	justification string
+	threats       []diagramThreat
+}
+
+type diagramBoundary struct {
+	diagramShape
+	id          string
+	name        string
+	description string
+}
+
+type diagramFlow struct {
+	id          string
+	name        string
+	description string
+	source      string
+	target      string
+	protocol    types.Protocol
+	threats     []diagramThreat
+}
+
+type diagramThreat struct {
+	category      string
+	title         string
+	description   string
+	mitigation    string
+	stride        string
+	severity      string
+	status        string
+	justification string
+}
+
+// diagramImporter converts the diagrams of a threat modeling tool into one model, the threats into custom risk categories
+// (prefixed with the tool to avoid clashes with built-in risk categories) and their status into risk tracking
+type diagramImporter struct {
+	model       *input.Model
+	prefix      string
+	ids         map[string]bool
+	categories  map[string]*input.RiskCategory
+	riskTitles  map[string]bool
+	trackedRisk map[string]bool
+}
+
+var dataFlowIdCharacters = regexp.MustCompile("[^A-Za-z0-9]+")
+
+func newDiagramImporter(title string, description string, owner string, prefix string) *diagramImporter {
+	model := newStubModel(title)
+	model.AppDescription.Description = description
+	model.Author.Name = owner
+	model.RiskTracking = make(map[string]input.RiskTracking)
+
+	return &diagramImporter{
+		model:       model,
+		prefix:      prefix,
+		ids:         make(map[string]bool),
+		categories:  make(map[string]*input.RiskCategory),
+		riskTitles:  make(map[string]bool),
+		trackedRisk: make(map[string]bool),
+	}
+}
+
+func (what *diagramImporter) add(diagram *diagram) {
+	titles := make(map[string]string)
+	ids := make(map[string]string)
+	for _, element := range diagram.elements {
+		name := strings.TrimSpace(element.name)
+		if len(name) == 0 {
+			name = [...]string{"Process", "Data store", "Actor"}[element.kind]
+		}
+
+		title := uniqueTitle(name, what.model.TechnicalAssets)
+		technicalAsset := newTechnicalAsset(what.uniqueId(title), element.description, element.technology, types.Virtual)
+		switch element.kind {
+		case diagramStore:
+			technicalAsset.Type = types.Datastore.String()
+
+		case diagramActor:
+			technicalAsset.Type = types.ExternalEntity.String()
+			technicalAsset.Machine = types.Physical.String()
+			technicalAsset.UsedAsClientByHuman = element.human
+		}
+
+		if element.encrypted {
+			technicalAsset.Encryption = types.Transparent.String()
+		}
+
+		technicalAsset.OutOfScope = element.outOfScope
+		technicalAsset.JustificationOutOfScope = element.justification
+		what.model.TechnicalAssets[title] = technicalAsset
+		titles[element.id] = title
+		ids[element.id] = technicalAsset.ID
+	}
+
+	linkIds := make(map[string]string)
+	linkTitles := make(map[string]string)
+	for _, flow := range diagram.flows {
+		sourceTitle, sourceOk := titles[flow.source]
+		targetTitle, targetOk := titles[flow.target]
+		if !sourceOk || !targetOk || sourceTitle == targetTitle {
+			continue
+		}
+
+		source := what.model.TechnicalAssets[sourceTitle]
+		if source.CommunicationLinks == nil {
+			source.CommunicationLinks = make(map[string]input.CommunicationLink)
+		}
+
+		// flows drawn several times between the same elements become one link
+		title := ""
+		for existingTitle, existing := range source.CommunicationLinks {
+			if existing.Target == ids[flow.target] {
+				title = existingTitle
+			}
+		}
+
+		if len(title) == 0 {
+			title = strings.TrimSpace(flow.name)
+			if len(title) == 0 {
+				title = "Flow to " + targetTitle
+			}
+
+			title = uniqueTitle(title, source.CommunicationLinks)
+			source.CommunicationLinks[title] = newCommunicationLink(ids[flow.target], flow.description, flow.protocol)
+			what.model.TechnicalAssets[sourceTitle] = source
+		}
+
+		linkIds[flow.id] = source.ID + ">" + strings.Trim(dataFlowIdCharacters.ReplaceAllString(strings.ToLower(title), "-"), "- ")
+		linkTitles[flow.id] = title
+	}
+
+	what.addBoundaries(diagram, ids)
+
+	for _, element := range diagram.elements {
+		for _, threat := range element.threats {
+			what.addThreat(threat, titles[element.id], input.RiskIdentified{MostRelevantTechnicalAsset: ids[element.id]})
+		}
+	}
+
+	for _, flow := range diagram.flows {
+		if _, ok := linkIds[flow.id]; !ok {
+			continue
+		}
+
+		for _, threat := range flow.threats {
+			what.addThreat(threat, linkTitles[flow.id], input.RiskIdentified{MostRelevantCommunicationLink: linkIds[flow.id]})
+		}
+	}
+}
+
+// addBoundaries places elements and boundaries into the smallest boundary box containing them
+func (what *diagramImporter) addBoundaries(diagram *diagram, ids map[string]string) {
+	boundaries := append([]*diagramBoundary{}, diagram.boundaries...)
+	sort.SliceStable(boundaries, func(i, j int) bool { return boundaries[i].area() < boundaries[j].area() })
+
+	boundaryIds := make(map[*diagramBoundary]string)
+	titles := make(map[*diagramBoundary]string)
+	for _, boundary := range diagram.boundaries {
+		title := strings.TrimSpace(boundary.name)
+		if len(title) == 0 {
+			title = "Trust boundary"
+		}
+
+		titles[boundary] = uniqueTitle(title, what.model.TrustBoundaries)
+		boundaryIds[boundary] = what.uniqueId(titles[boundary])
+		what.model.TrustBoundaries[titles[boundary]] = input.TrustBoundary{}
+	}
+
+	inside := make(map[*diagramBoundary][]string)
+	nested := make(map[*diagramBoundary][]string)
+	for _, element := range diagram.elements {
+		for _, boundary := range boundaries {
+			if boundary.contains(element.diagramShape, false) {
+				inside[boundary] = append(inside[boundary], ids[element.id])
+				break
+			}
+		}
+	}
+
+	for _, child := range diagram.boundaries {
+		for _, boundary := range boundaries {
+			if boundary != child && boundary.area() > child.area() && boundary.contains(child.diagramShape, true) {
+				nested[boundary] = append(nested[boundary], boundaryIds[child])
+				break
+			}
+		}
+	}
+
+	for _, boundary := range diagram.boundaries {
+		boundaryType := types.NetworkOnPrem
+		name := strings.ToLower(boundary.name)
+		for _, keyword := range []string{"cloud", "aws", "azure", "gcp", "google"} {
+			if strings.Contains(name, keyword) {
+				boundaryType = types.NetworkCloudProvider
+			}
+		}
+
+		// elements drawn inside an internet boundary are accessed via the internet
+		if strings.Contains(name, "internet") {
+			for _, title := range sortedKeys(what.model.TechnicalAssets) {
+				technicalAsset := what.model.TechnicalAssets[title]
+				if contains(inside[boundary], technicalAsset.ID) {
+					technicalAsset.Internet = true
+					what.model.TechnicalAssets[title] = technicalAsset
+				}
+			}
+		}
+
+		what.model.TrustBoundaries[titles[boundary]] = input.TrustBoundary{
+			ID:                    boundaryIds[boundary],
+			Description:           boundary.description,
+			Type:                  boundaryType.String(),
+			TechnicalAssetsInside: inside[boundary],
+			TrustBoundariesNested: nested[boundary],
+		}
+	}
+}
+
+func (what *diagramImporter) addThreat(threat diagramThreat, elementTitle string, risk input.RiskIdentified) {
+	category := what.riskCategory(threat)
+
+	title := fmt.Sprintf("<b>%v</b> at <b>%v</b>", threat.title, elementTitle)
+	for n := 2; what.riskTitles[title]; n++ {
+		title = fmt.Sprintf("<b>%v</b> at <b>%v</b> (%d)", threat.title, elementTitle, n)
+	}
+	what.riskTitles[title] = true
+
+	severity, impact := diagramThreatSeverity(threat.severity)
+	risk.Severity = severity.String()
+	risk.ExploitationLikelihood = types.Likely.String()
+	risk.ExploitationImpact = impact.String()
+	risk.DataBreachProbability = types.Possible.String()
+	category.RisksIdentified[title] = risk
+
+	syntheticId := category.ID + "@" + risk.MostRelevantTechnicalAsset + risk.MostRelevantCommunicationLink
+	status := diagramThreatStatus(threat.status)
+	if status == types.Unchecked || what.trackedRisk[syntheticId] {
+		return
+	}
+
+	justification := strings.TrimSpace(threat.justification)
+	if len(justification) == 0 && status == types.Mitigated {
+		justification = strings.TrimSpace(threat.mitigation)
+	}
+
+	what.trackedRisk[syntheticId] = true
+	what.model.RiskTracking[syntheticId] = input.RiskTracking{Status: status.String(), Justification: justification}
+}
+
+func (what *diagramImporter) riskCategory(threat diagramThreat) *input.RiskCategory {
+	name := strings.TrimSpace(threat.category)
+	if len(name) == 0 {
+		name = strings.TrimSpace(threat.title)
+	}
+
+	id := types.MakeID(what.prefix + " " + name)
+	if category, ok := what.categories[id]; ok {
+		return category
+	}
+
+	category := &input.RiskCategory{
+		ID:              id,
+		Title:           name,
+		Description:     strings.TrimSpace(threat.description),
+		Mitigation:      strings.TrimSpace(threat.mitigation),
+		Function:        types.Architecture.String(),
+		STRIDE:          diagramThreatStride(threat.stride).String(),
+		RisksIdentified: make(map[string]input.RiskIdentified),
+	}
+
+	if len(category.Description) == 0 {
+		category.Description = name
+	}
+
+	what.categories[id] = category
+	what.model.CustomRiskCategories = append(what.model.CustomRiskCategories, category)
+	return category
+}
+
+func (what *diagramImporter) uniqueId(title string) string {
+	id := types.MakeID(title)
+	if len(id) == 0 {
+		id = "element"
+	}
+
+	result := id
+	for n := 2; what.ids[result]; n++ {
+		result = fmt.Sprintf("%v-%d", id, n)
+	}
+
+	what.ids[result] = true
+	return result
+}
+
+func (what diagramShape) area() float64 {
+	return what.width * what.height
+}
+
+// contains checks if the center (or with whole set, the entire shape) of another shape lies within this one
+func (what diagramShape) contains(other diagramShape, whole bool) bool {
+	if !whole {
+		x, y := other.x+other.width/2, other.y+other.height/2
+		return x >= what.x && x <= what.x+what.width && y >= what.y && y <= what.y+what.height
+	}
+
+	return other.x >= what.x && other.y >= what.y && other.x+other.width <= what.x+what.width && other.y+other.height <= what.y+what.height
+}
+
+// diagramProtocol maps protocol names and descriptions like "HTTPS", "SQL" or "SE.DF.TMCore.HTTP" to protocols
+func diagramProtocol(text string, encrypted bool) types.Protocol {
+	name := strings.ToLower(strings.TrimSpace(text))
+	if index := strings.LastIndex(name, "."); index >= 0 {
+		name = name[index+1:]
+	}
+
+	for _, value := range types.ProtocolValues() {
+		if types.MakeID(name) == value.String() {
+			return value.(types.Protocol)
+		}
+	}
+
+	switch {
+	case strings.Contains(name, "https"), strings.Contains(name, "tls"), strings.Contains(name, "ssl"):
+		return types.HTTPS
+
+	case strings.Contains(name, "http"), strings.Contains(name, "rest"):
+		if encrypted {
+			return types.HTTPS
+		}
+
+		return types.HTTP
+
+	case strings.Contains(name, "sql"), strings.Contains(name, "jdbc"), strings.Contains(name, "odbc"):
+		if encrypted {
+			return types.SqlAccessProtocolEncrypted
+		}
+
+		return types.SqlAccessProtocol
+
+	case strings.Contains(name, "ssh"):
+		return types.SSH
+
+	case strings.Contains(name, "namedpipe"), strings.Contains(name, "alpc"), strings.Contains(name, "ioctl"):
+		return types.InterProcessCommunication
+
+	case strings.Contains(name, "smb"):
+		return types.SMB
+
+	case strings.Contains(name, "ldap"):
+		if encrypted {
+			return types.LDAPS
+		}
+
+		return types.LDAP
+
+	case strings.Contains(name, "ipsec"), strings.Contains(name, "vpn"):
+		return types.BinaryEncrypted
+
+	case strings.Contains(name, "binary"), strings.Contains(name, "rpc"), strings.Contains(name, "tcp"), strings.Contains(name, "udp"):
+		if encrypted {
+			return types.BinaryEncrypted
+		}
+
+		return types.BINARY
+
+	default:
+		return types.UnknownProtocol
+	}
+}
+
+func diagramThreatStride(category string) types.STRIDE {
+	name := types.MakeID(category)
+	for _, value := range types.STRIDEValues() {
+		stride := value.(types.STRIDE)
+		if name == stride.String() || strings.EqualFold(category, stride.Title()) || strings.EqualFold(category, stride.String()[:1]) {
+			return stride
+		}
+	}
+
+	switch name {
+	case "confidentiality", "linkability", "identifiability", "detectability", "disclosure-of-information", "unawareness":
+		return types.InformationDisclosure
+
+	case "availability":
+		return types.DenialOfService
+
+	case "non-repudiation":
+		return types.Repudiation
+
+	default:
+		return types.Tampering
+	}
+}
+
+// diagramThreatSeverity maps priorities like "High" to the severity and exploitation impact of risks
+func diagramThreatSeverity(priority string) (types.RiskSeverity, types.RiskExploitationImpact) {
+	switch strings.ToLower(strings.TrimSpace(priority)) {
+	case "low":
+		return types.LowSeverity, types.LowImpact
+
+	case "high":
+		return types.HighSeverity, types.HighImpact
+
+	case "critical":
+		return types.CriticalSeverity, types.VeryHighImpact
+
+	default:
+		return types.MediumSeverity, types.MediumImpact
+	}
+}
+
+// diagramThreatStatus maps threat states of Threat Dragon and the Microsoft Threat Modeling Tool to risk status
+func diagramThreatStatus(state string) types.RiskStatus {
+	switch types.MakeID(state) {
+	case "mitigated", "mitigation-implemented":
+		return types.Mitigated
+
+	case "notapplicable", "not-applicable":
+		return types.FalsePositive
+
+	case "needsinvestigation", "needs-investigation":
+		return types.InDiscussion
+
+	case "accepted":
+		return types.Accepted
+
+	default:
+		return types.Unchecked
+	}
+}
@@ -0,0 +1,369 @@
+/*
+Package importer creates stub threagile models from existing descriptions of a system (deployment files, API specs, ...).
+The imported models contain the technical assets, trust boundaries and communication links that can be derived,
+with conservative defaults for everything else, meant to be reviewed and refined afterward.
+*/
+
+package importer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+const (
+	internetClientsTitle = "Internet Clients"
+	internetClientsId    = "internet-clients"
+)
+
+// well known products not covered by technology names or aliases
+var productTechnologies = map[string]string{
+	"activemq":      types.MessageQueue,
+	"apache":        types.WebServer,
+	"artifactory":   types.ArtifactRegistry,
+	"caddy":         types.WebServer,
+	"cassandra":     types.Database,
+	"cockroachdb":   types.Database,
+	"consul":        types.ServiceRegistry,
+	"couchdb":       types.Database,
+	"dex":           types.IdentityProvider,
+	"drupal":        types.CMS,
+	"elasticsearch": types.SearchEngine,
+	"envoy":         types.ReverseProxy,
+	"etcd":          types.Database,
+	"gitea":         types.SourcecodeRepository,
+	"gitlab":        types.SourcecodeRepository,
+	"grafana":       types.Monitoring,
+	"haproxy":       types.LoadBalancer,
+	"httpd":         types.WebServer,
+	"jboss":         types.ApplicationServer,
+	"jenkins":       types.BuildPipeline,
+	"kafka":         types.MessageQueue,
+	"keycloak":      types.IdentityProvider,
+	"mariadb":       types.Database,
+	"memcached":     types.Database,
+	"minio":         types.FileServer,
+	"mongo":         types.Database,
+	"mongodb":       types.Database,
+	"mosquitto":     types.MessageQueue,
+	"mssql":         types.Database,
+	"mysql":         types.Database,
+	"nats":          types.MessageQueue,
+	"neo4j":         types.Database,
+	"nexus":         types.ArtifactRegistry,
+	"nginx":         types.ReverseProxy,
+	"openldap":      types.LDAPServer,
+	"opensearch":    types.SearchEngine,
+	"oracle":        types.Database,
+	"postfix":       types.MailServer,
+	"postgres":      types.Database,
+	"postgresql":    types.Database,
+	"prometheus":    types.Monitoring,
+	"rabbitmq":      types.MessageQueue,
+	"redis":         types.Database,
+	"registry":      types.ArtifactRegistry,
+	"solr":          types.SearchEngine,
+	"sonarqube":     types.CodeInspectionPlatform,
+	"tomcat":        types.ApplicationServer,
+	"traefik":       types.Gateway,
+	"wildfly":       types.ApplicationServer,
+	"wordpress":     types.CMS,
+	"zookeeper":     types.ServiceRegistry,
+}
+
+// default ports of well known products, used when a deployment does not declare any
+var productPorts = map[string]int{
+	"activemq":      61616,
+	"cassandra":     9042,
+	"couchdb":       5984,
+	"elasticsearch": 9200,
+	"kafka":         9092,
+	"keycloak":      8080,
+	"mariadb":       3306,
+	"memcached":     11211,
+	"mongo":         27017,
+	"mongodb":       27017,
+	"mosquitto":     1883,
+	"mssql":         1433,
+	"mysql":         3306,
+	"nats":          4222,
+	"nginx":         80,
+	"openldap":      389,
+	"opensearch":    9200,
+	"postgres":      5432,
+	"postgresql":    5432,
+	"rabbitmq":      5672,
+	"redis":         6379,
+}
+
+var datastoreTechnologies = []string{
+	types.BlockStorage,
+	types.DataLake,
+	types.Database,
+	types.FileServer,
+	types.IdentityStoreDatabase,
+	types.IdentityStoreLDAP,
+	types.LocalFileSystem,
+	types.SearchIndex,
+}
+
+// well known ports in the order they are preferred when inferring protocols
+var portProtocols = []struct {
+	port     int
+	protocol types.Protocol
+}{
+	{443, types.HTTPS},
+	{8443, types.HTTPS},
+	{80, types.HTTP},
+	{8080, types.HTTP},
+	{8000, types.HTTP},
+	{3000, types.HTTP},
+	{5000, types.HTTP},
+	{9000, types.HTTP},
+	{5432, types.SqlAccessProtocol},
+	{3306, types.SqlAccessProtocol},
+	{1433, types.SqlAccessProtocol},
+	{1521, types.SqlAccessProtocol},
+	{26257, types.SqlAccessProtocol},
+	{27017, types.NosqlAccessProtocol},
+	{6379, types.NosqlAccessProtocol},
+	{9042, types.NosqlAccessProtocol},
+	{5984, types.NosqlAccessProtocol},
+	{9200, types.NosqlAccessProtocol},
+	{11211, types.NosqlAccessProtocol},
+	{61616, types.JMS},
+	{5672, types.BINARY},
+	{9092, types.BINARY},
+	{4222, types.BINARY},
+	{1883, types.MQTT},
+	{8883, types.MQTT},
+	{636, types.LDAPS},
+	{389, types.LDAP},
+	{22, types.SSH},
+	{990, types.FTPS},
+	{21, types.FTP},
+	{465, types.SmtpEncrypted},
+	{587, types.SMTP},
+	{25, types.SMTP},
+	{995, types.Pop3Encrypted},
+	{110, types.POP3},
+	{993, types.ImapEncrypted},
+	{143, types.IMAP},
+	{2049, types.NFS},
+	{445, types.SMB},
+}
+
+type technologyGuesser struct {
+	technologies types.TechnologyMap
+}
+
+func newTechnologyGuesser() (*technologyGuesser, error) {
+	technologies := make(types.TechnologyMap)
+	loadError := technologies.LoadDefault()
+	if loadError != nil {
+		return nil, loadError
+	}
+
+	return &technologyGuesser{technologies: technologies}, nil
+}
+
+// guess matches the given names (e.g. image names) and their parts against technology names, aliases and well known products
+func (what *technologyGuesser) guess(names ...string) string {
+	for _, name := range names {
+		for _, candidate := range nameCandidates(name) {
+			if technology := what.technologies.Find(candidate); technology != nil {
+				return technology.Name
+			}
+
+			if technology, ok := productTechnologies[candidate]; ok {
+				return technology
+			}
+		}
+	}
+
+	return types.UnknownTechnology
+}
+
+// nameCandidates splits names like "docker.io/bitnami/postgresql:15-alpine" into the candidates to look up, most specific first
+func nameCandidates(name string) []string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if index := strings.LastIndex(name, "/"); index >= 0 {
+		name = name[index+1:]
+	}
+	if index := strings.IndexAny(name, ":@"); index >= 0 {
+		name = name[:index]
+	}
+
+	candidates := []string{name}
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+		if part != name {
+			candidates = append(candidates, part)
+		}
+	}
+
+	return candidates
+}
+
+// defaultPorts returns the default port of the first well known product matching the given names
+func defaultPorts(names ...string) []int {
+	for _, name := range names {
+		for _, candidate := range nameCandidates(name) {
+			if port, ok := productPorts[candidate]; ok {
+				return []int{port}
+			}
+		}
+	}
+
+	return nil
+}
+
+func isDatastore(technology string) bool {
+	for _, datastore := range datastoreTechnologies {
+		if datastore == technology {
+			return true
+		}
+	}
+
+	return false
+}
+
+// protocolForPorts infers the protocol from the best known port, falling back to the technology of the target
+func protocolForPorts(ports []int, technology string) types.Protocol {
+	for _, known := range portProtocols {
+		for _, port := range ports {
+			if port == known.port {
+				return known.protocol
+			}
+		}
+	}
+
+	switch technology {
+	case types.Database:
+		return types.SqlAccessProtocol
+
+	case types.MessageQueue:
+		return types.BINARY
+
+	case types.LDAPServer, types.IdentityStoreLDAP:
+		return types.LDAP
+
+	case types.WebServer, types.WebApplication, types.WebServiceREST, types.WebServiceSOAP, types.ReverseProxy, types.Gateway, types.LoadBalancer:
+		return types.HTTP
+
+	default:
+		return types.UnknownProtocol
+	}
+}
+
+func newStubModel(title string) *input.Model {
+	model := new(input.Model).Defaults()
+	model.Title = title
+	model.BusinessCriticality = types.Important.String()
+	return model
+}
+
+func newTechnicalAsset(id string, description string, technology string, machine types.TechnicalAssetMachine) input.TechnicalAsset {
+	assetType := types.Process
+	if isDatastore(technology) {
+		assetType = types.Datastore
+	}
+
+	return input.TechnicalAsset{
+		ID:              id,
+		Description:     description,
+		Type:            assetType.String(),
+		Usage:           types.Business.String(),
+		Size:            types.Service.String(),
+		Technologies:    []string{technology},
+		Machine:         machine.String(),
+		Encryption:      types.NoneEncryption.String(),
+		Confidentiality: types.Internal.String(),
+		Integrity:       types.Important.String(),
+		Availability:    types.Important.String(),
+	}
+}
+
+// addInternetClients adds the external entity representing clients accessing the system from the internet, returning its title
+func addInternetClients(model *input.Model, description string) string {
+	if _, ok := model.TechnicalAssets[internetClientsTitle]; ok {
+		return internetClientsTitle
+	}
+
+	clients := newTechnicalAsset(internetClientsId, description, types.Browser, types.Physical)
+	clients.Type = types.ExternalEntity.String()
+	clients.Internet = true
+	clients.UsedAsClientByHuman = true
+	model.TechnicalAssets[internetClientsTitle] = clients
+	return internetClientsTitle
+}
+
+func newCommunicationLink(target string, description string, protocol types.Protocol) input.CommunicationLink {
+	return input.CommunicationLink{
+		Target:         target,
+		Description:    description,
+		Protocol:       protocol.String(),
+		Authentication: types.NoneAuthentication.String(),
+		Authorization:  types.NoneAuthorization.String(),
+		Usage:          types.Business.String(),
+	}
+}
+
+// addCommunicationLink adds a link to the technical asset with the given title, unless it already has a link to the target
+func addCommunicationLink(model *input.Model, sourceTitle string, title string, link input.CommunicationLink) {
+	source := model.TechnicalAssets[sourceTitle]
+	if source.CommunicationLinks == nil {
+		source.CommunicationLinks = make(map[string]input.CommunicationLink)
+	}
+
+	for _, existing := range source.CommunicationLinks {
+		if existing.Target == link.Target {
+			return
+		}
+	}
+
+	source.CommunicationLinks[uniqueTitle(title, source.CommunicationLinks)] = link
+	model.TechnicalAssets[sourceTitle] = source
+}
+
+func uniqueTitle[T any](title string, existing map[string]T) string {
+	result := title
+	for n := 2; ; n++ {
+		if _, ok := existing[result]; !ok {
+			return result
+		}
+
+		result = fmt.Sprintf("%v (%d)", title, n)
+	}
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func appendUnique(values []string, additional ...string) []string {
+	for _, value := range additional {
+		found := false
+		for _, existing := range values {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,708 @@
+package importer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	kubernetesDefaultNamespace = "default"
+	kubernetesDefaultNodePool  = "default"
+)
+
+// node labels used by the managed kubernetes offerings (and karpenter) to select node pools
+var kubernetesNodePoolLabels = []string{
+	"cloud.google.com/gke-nodepool",
+	"eks.amazonaws.com/nodegroup",
+	"alpha.eksctl.io/nodegroup-name",
+	"kubernetes.azure.com/agentpool",
+	"agentpool",
+	"karpenter.sh/nodepool",
+	"doks.digitalocean.com/node-pool",
+	"node-pool",
+	"nodepool",
+}
+
+type kubernetesObject struct {
+	Kind     string             `yaml:"kind"`
+	Metadata kubernetesMetadata `yaml:"metadata"`
+	Type     string             `yaml:"type"`
+	Spec     yaml.Node          `yaml:"spec"`
+	Items    []kubernetesObject `yaml:"items"`
+}
+
+type kubernetesMetadata struct {
+	Name      string            `yaml:"name"`
+	Namespace string            `yaml:"namespace"`
+	Labels    map[string]string `yaml:"labels"`
+}
+
+type kubernetesLabelSelector struct {
+	MatchLabels map[string]string `yaml:"matchLabels"`
+}
+
+type kubernetesWorkloadSpec struct {
+	Replicas *int `yaml:"replicas"`
+	Template struct {
+		Metadata kubernetesMetadata `yaml:"metadata"`
+		Spec     kubernetesPodSpec  `yaml:"spec"`
+	} `yaml:"template"`
+}
+
+type kubernetesPodSpec struct {
+	Containers     []kubernetesContainer `yaml:"containers"`
+	InitContainers []kubernetesContainer `yaml:"initContainers"`
+	NodeSelector   map[string]string     `yaml:"nodeSelector"`
+	Affinity       struct {
+		NodeAffinity struct {
+			Required struct {
+				NodeSelectorTerms []struct {
+					MatchExpressions []struct {
+						Key      string   `yaml:"key"`
+						Operator string   `yaml:"operator"`
+						Values   []string `yaml:"values"`
+					} `yaml:"matchExpressions"`
+				} `yaml:"nodeSelectorTerms"`
+			} `yaml:"requiredDuringSchedulingIgnoredDuringExecution"`
+		} `yaml:"nodeAffinity"`
+	} `yaml:"affinity"`
+	Volumes []struct {
+		Secret struct {
+			SecretName string `yaml:"secretName"`
+		} `yaml:"secret"`
+	} `yaml:"volumes"`
+}
+
+type kubernetesContainer struct {
+	Name  string `yaml:"name"`
+	Image string `yaml:"image"`
+	Ports []struct {
+		ContainerPort int `yaml:"containerPort"`
+	} `yaml:"ports"`
+	Env []struct {
+		Name      string `yaml:"name"`
+		Value     string `yaml:"value"`
+		ValueFrom struct {
+			SecretKeyRef struct {
+				Name string `yaml:"name"`
+			} `yaml:"secretKeyRef"`
+		} `yaml:"valueFrom"`
+	} `yaml:"env"`
+	EnvFrom []struct {
+		SecretRef struct {
+			Name string `yaml:"name"`
+		} `yaml:"secretRef"`
+	} `yaml:"envFrom"`
+}
+
+type kubernetesServiceSpec struct {
+	Type     string            `yaml:"type"`
+	Selector map[string]string `yaml:"selector"`
+	Ports    []struct {
+		Port       int       `yaml:"port"`
+		TargetPort yaml.Node `yaml:"targetPort"`
+	} `yaml:"ports"`
+}
+
+type kubernetesIngressSpec struct {
+	TLS            []any                     `yaml:"tls"`
+	DefaultBackend *kubernetesIngressBackend `yaml:"defaultBackend"`
+	Rules          []struct {
+		Host string `yaml:"host"`
+		HTTP struct {
+			Paths []struct {
+				Backend kubernetesIngressBackend `yaml:"backend"`
+			} `yaml:"paths"`
+		} `yaml:"http"`
+	} `yaml:"rules"`
+}
+
+type kubernetesIngressBackend struct {
+	Service struct {
+		Name string `yaml:"name"`
+	} `yaml:"service"`
+	ServiceName string `yaml:"serviceName"` // networking.k8s.io/v1beta1
+}
+
+type kubernetesNetworkPolicySpec struct {
+	PodSelector kubernetesLabelSelector `yaml:"podSelector"`
+}
+
+type kubernetesWorkload struct {
+	kind      string
+	namespace string
+	name      string
+	replicas  int
+	labels    map[string]string
+	pod       kubernetesPodSpec
+	title     string
+	id        string
+}
+
+type kubernetesService struct {
+	namespace string
+	name      string
+	selector  map[string]string
+	ports     []int
+}
+
+type kubernetesIngress struct {
+	namespace string
+	name      string
+	tls       bool
+	backends  []string
+	title     string
+}
+
+type kubernetesNetworkPolicy struct {
+	namespace string
+	name      string
+	selector  map[string]string
+}
+
+type kubernetesSecret struct {
+	namespace  string
+	name       string
+	secretType string
+}
+
+type kubernetesImporter struct {
+	guesser *technologyGuesser
+	model   *input.Model
+
+	workloads       []*kubernetesWorkload
+	services        []*kubernetesService
+	ingresses       []*kubernetesIngress
+	networkPolicies []*kubernetesNetworkPolicy
+	secrets         []*kubernetesSecret
+
+	secretIds map[string]string
+}
+
+// ImportKubernetes creates a model from the kubernetes manifests (yaml or json) found in the given directory and its subdirectories
+func ImportKubernetes(directory string) (*input.Model, error) {
+	contents := make([][]byte, 0)
+	walkError := filepath.WalkDir(directory, func(path string, entry fs.DirEntry, walkError error) error {
+		if walkError != nil {
+			return walkError
+		}
+
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+			if entry.IsDir() {
+				return nil
+			}
+
+		default:
+			return nil
+		}
+
+		data, readError := os.ReadFile(filepath.Clean(path))
+		if readError != nil {
+			return fmt.Errorf("unable to read kubernetes manifest: %w", readError)
+		}
+
+		contents = append(contents, data)
+		return nil
+	})
+
+	if walkError != nil {
+		return nil, walkError
+	}
+
+	title := directory
+	if absolute, absError := filepath.Abs(directory); absError == nil {
+		title = filepath.Base(absolute)
+	}
+
+	return importKubernetes(title, contents...)
+}
+
+func importKubernetes(title string, contents ...[]byte) (*input.Model, error) {
+	guesser, guesserError := newTechnologyGuesser()
+	if guesserError != nil {
+		return nil, guesserError
+	}
+
+	what := &kubernetesImporter{
+		guesser:   guesser,
+		model:     newStubModel(title),
+		secretIds: make(map[string]string),
+	}
+	what.model.AppDescription.Description = "Imported from kubernetes manifests"
+
+	for _, data := range contents {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		for {
+			var object kubernetesObject
+			decodeError := decoder.Decode(&object)
+			if errors.Is(decodeError, io.EOF) {
+				break
+			}
+
+			if decodeError != nil {
+				return nil, fmt.Errorf("unable to parse kubernetes manifest: %w", decodeError)
+			}
+
+			addError := what.add(object)
+			if addError != nil {
+				return nil, addError
+			}
+		}
+	}
+
+	if len(what.workloads) == 0 {
+		return nil, fmt.Errorf("no deployments or stateful sets found in kubernetes manifests")
+	}
+
+	what.importSecrets()
+	what.importWorkloads()
+	what.importIngresses()
+	what.importCommunicationLinks()
+	what.importTrustBoundaries()
+	what.importSharedRuntimes()
+
+	return what.model, nil
+}
+
+func (what *kubernetesImporter) add(object kubernetesObject) error {
+	namespace := object.Metadata.Namespace
+	if len(namespace) == 0 {
+		namespace = kubernetesDefaultNamespace
+	}
+
+	switch object.Kind {
+	case "List":
+		for _, item := range object.Items {
+			addError := what.add(item)
+			if addError != nil {
+				return addError
+			}
+		}
+
+	case "Deployment", "StatefulSet":
+		var spec kubernetesWorkloadSpec
+		decodeError := object.Spec.Decode(&spec)
+		if decodeError != nil {
+			return fmt.Errorf("unable to parse %v %q: %w", object.Kind, object.Metadata.Name, decodeError)
+		}
+
+		replicas := 1
+		if spec.Replicas != nil {
+			replicas = *spec.Replicas
+		}
+
+		what.workloads = append(what.workloads, &kubernetesWorkload{
+			kind:      object.Kind,
+			namespace: namespace,
+			name:      object.Metadata.Name,
+			replicas:  replicas,
+			labels:    spec.Template.Metadata.Labels,
+			pod:       spec.Template.Spec,
+		})
+
+	case "Service":
+		var spec kubernetesServiceSpec
+		decodeError := object.Spec.Decode(&spec)
+		if decodeError != nil {
+			return fmt.Errorf("unable to parse service %q: %w", object.Metadata.Name, decodeError)
+		}
+
+		service := &kubernetesService{namespace: namespace, name: object.Metadata.Name, selector: spec.Selector}
+		for _, port := range spec.Ports {
+			if targetPort := parsePort(port.TargetPort.Value); targetPort > 0 {
+				service.ports = append(service.ports, targetPort)
+			}
+
+			service.ports = append(service.ports, port.Port)
+		}
+
+		what.services = append(what.services, service)
+
+	case "Ingress":
+		var spec kubernetesIngressSpec
+		decodeError := object.Spec.Decode(&spec)
+		if decodeError != nil {
+			return fmt.Errorf("unable to parse ingress %q: %w", object.Metadata.Name, decodeError)
+		}
+
+		ingress := &kubernetesIngress{namespace: namespace, name: object.Metadata.Name, tls: len(spec.TLS) > 0}
+		if spec.DefaultBackend != nil {
+			ingress.backends = appendUnique(ingress.backends, spec.DefaultBackend.name())
+		}
+
+		for _, rule := range spec.Rules {
+			for _, path := range rule.HTTP.Paths {
+				ingress.backends = appendUnique(ingress.backends, path.Backend.name())
+			}
+		}
+
+		what.ingresses = append(what.ingresses, ingress)
+
+	case "NetworkPolicy":
+		var spec kubernetesNetworkPolicySpec
+		decodeError := object.Spec.Decode(&spec)
+		if decodeError != nil {
+			return fmt.Errorf("unable to parse network policy %q: %w", object.Metadata.Name, decodeError)
+		}
+
+		what.networkPolicies = append(what.networkPolicies, &kubernetesNetworkPolicy{
+			namespace: namespace,
+			name:      object.Metadata.Name,
+			selector:  spec.PodSelector.MatchLabels,
+		})
+
+	case "Secret":
+		what.secrets = append(what.secrets, &kubernetesSecret{namespace: namespace, name: object.Metadata.Name, secretType: object.Type})
+	}
+
+	return nil
+}
+
+func (what *kubernetesIngressBackend) name() string {
+	if len(what.Service.Name) > 0 {
+		return what.Service.Name
+	}
+
+	return what.ServiceName
+}
+
+func (what *kubernetesImporter) importSecrets() {
+	names := make(map[string]int)
+	for _, secret := range what.secrets {
+		names[secret.name]++
+	}
+
+	for _, secret := range what.secrets {
+		name := secret.name
+		if names[secret.name] > 1 {
+			name = secret.namespace + "/" + secret.name
+		}
+
+		id := types.MakeID(name) + "-secret"
+		what.secretIds[secret.namespace+"/"+secret.name] = id
+
+		description := "Kubernetes secret " + secret.name + " in namespace " + secret.namespace
+		if len(secret.secretType) > 0 {
+			description += " of type " + secret.secretType
+		}
+
+		what.model.DataAssets[name+" secret"] = input.DataAsset{
+			ID:              id,
+			Description:     description,
+			Usage:           types.DevOps.String(),
+			Origin:          "Kubernetes",
+			Quantity:        types.VeryFew.String(),
+			Confidentiality: types.Confidential.String(),
+			Integrity:       types.Critical.String(),
+			Availability:    types.Important.String(),
+		}
+	}
+}
+
+func (what *kubernetesImporter) importWorkloads() {
+	names := make(map[string]int)
+	for _, workload := range what.workloads {
+		names[workload.name]++
+	}
+
+	for _, workload := range what.workloads {
+		workload.title = workload.name
+		if names[workload.name] > 1 {
+			workload.title = workload.namespace + "/" + workload.name
+		}
+		workload.id = types.MakeID(workload.title)
+
+		images := make([]string, 0)
+		for _, container := range workload.pod.Containers {
+			images = append(images, container.Image)
+		}
+
+		description := workload.kind + " in namespace " + workload.namespace
+		if len(images) > 0 {
+			description += " running " + strings.Join(images, ", ")
+		}
+
+		technology := what.guesser.guess(append(images, workload.name)...)
+		technicalAsset := newTechnicalAsset(workload.id, description, technology, types.Container)
+		technicalAsset.Redundant = workload.replicas > 1
+		technicalAsset.DataAssetsProcessed = what.secretsOf(workload)
+		what.model.TechnicalAssets[workload.title] = technicalAsset
+	}
+}
+
+func (what *kubernetesImporter) secretsOf(workload *kubernetesWorkload) []string {
+	names := make([]string, 0)
+	for _, volume := range workload.pod.Volumes {
+		names = append(names, volume.Secret.SecretName)
+	}
+
+	for _, container := range append(workload.pod.InitContainers, workload.pod.Containers...) {
+		for _, env := range container.Env {
+			names = append(names, env.ValueFrom.SecretKeyRef.Name)
+		}
+
+		for _, envFrom := range container.EnvFrom {
+			names = append(names, envFrom.SecretRef.Name)
+		}
+	}
+
+	ids := make([]string, 0)
+	for _, name := range names {
+		if id, ok := what.secretIds[workload.namespace+"/"+name]; ok {
+			ids = appendUnique(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return ids
+}
+
+// importIngresses adds an asset per ingress, accessed by the internet clients and routing to the backend services
+func (what *kubernetesImporter) importIngresses() {
+	if len(what.ingresses) == 0 {
+		return
+	}
+
+	clients := addInternetClients(what.model, "Clients accessing the ingresses from the internet")
+
+	for _, ingress := range what.ingresses {
+		title := uniqueTitle(ingress.name+" ingress", what.model.TechnicalAssets)
+		ingress.title = title
+		id := types.MakeID(ingress.namespace+"-"+ingress.name) + "-ingress"
+		description := "Ingress " + ingress.name + " in namespace " + ingress.namespace
+		what.model.TechnicalAssets[title] = newTechnicalAsset(id, description, types.ReverseProxy, types.Virtual)
+
+		protocol := types.HTTP
+		if ingress.tls {
+			protocol = types.HTTPS
+		}
+
+		addCommunicationLink(what.model, clients, "Access "+title, newCommunicationLink(id, "Internet clients access "+title, protocol))
+
+		for _, backend := range ingress.backends {
+			service := what.service(ingress.namespace, backend)
+			if service == nil {
+				continue
+			}
+
+			for _, workload := range what.selected(service) {
+				addCommunicationLink(what.model, title, "Route to "+workload.title,
+					newCommunicationLink(workload.id, title+" routes to service "+service.name, what.protocol(service, workload)))
+			}
+		}
+	}
+}
+
+// importCommunicationLinks infers links from environment variables referencing services, e.g. DB_HOST=postgres.shop.svc
+func (what *kubernetesImporter) importCommunicationLinks() {
+	for _, workload := range what.workloads {
+		for _, container := range workload.pod.Containers {
+			for _, env := range container.Env {
+				for _, service := range what.referencedServices(workload.namespace, env.Value) {
+					for _, target := range what.selected(service) {
+						if target == workload {
+							continue
+						}
+
+						addCommunicationLink(what.model, workload.title, "Access "+target.title,
+							newCommunicationLink(target.id, workload.title+" accesses service "+service.name, what.protocol(service, target)))
+					}
+				}
+			}
+		}
+	}
+}
+
+func (what *kubernetesImporter) referencedServices(namespace string, value string) []*kubernetesService {
+	services := make([]*kubernetesService, 0)
+	hosts := strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '.')
+	})
+
+	for _, host := range hosts {
+		host = strings.TrimSuffix(strings.TrimSuffix(host, ".cluster.local"), ".svc")
+		for _, service := range what.services {
+			if host == service.name && service.namespace == namespace || host == service.name+"."+service.namespace {
+				services = append(services, service)
+			}
+		}
+	}
+
+	return services
+}
+
+func (what *kubernetesImporter) service(namespace string, name string) *kubernetesService {
+	for _, service := range what.services {
+		if service.namespace == namespace && service.name == name {
+			return service
+		}
+	}
+
+	return nil
+}
+
+func (what *kubernetesImporter) selected(service *kubernetesService) []*kubernetesWorkload {
+	workloads := make([]*kubernetesWorkload, 0)
+	if len(service.selector) == 0 {
+		return workloads
+	}
+
+	for _, workload := range what.workloads {
+		if workload.namespace == service.namespace && matchesLabels(service.selector, workload.labels) {
+			workloads = append(workloads, workload)
+		}
+	}
+
+	return workloads
+}
+
+func (what *kubernetesImporter) protocol(service *kubernetesService, workload *kubernetesWorkload) types.Protocol {
+	ports := append([]int{}, service.ports...)
+	for _, container := range workload.pod.Containers {
+		for _, port := range container.Ports {
+			ports = append(ports, port.ContainerPort)
+		}
+	}
+
+	if len(ports) == 0 {
+		for _, container := range workload.pod.Containers {
+			ports = append(ports, defaultPorts(container.Image)...)
+		}
+	}
+
+	return protocolForPorts(ports, what.model.TechnicalAssets[workload.title].Technologies[0])
+}
+
+// importTrustBoundaries adds an execution environment per namespace, nesting a namespace isolation per network policy;
+// workloads selected by several network policies are placed inside the first one
+func (what *kubernetesImporter) importTrustBoundaries() {
+	namespaceMembers := make(map[string][]string)
+	policyMembers := make(map[*kubernetesNetworkPolicy][]string)
+
+	sort.SliceStable(what.networkPolicies, func(i, j int) bool {
+		return what.networkPolicies[i].name < what.networkPolicies[j].name
+	})
+
+	for _, workload := range what.workloads {
+		policy := what.networkPolicy(workload)
+		if policy != nil {
+			policyMembers[policy] = append(policyMembers[policy], workload.id)
+		} else {
+			namespaceMembers[workload.namespace] = append(namespaceMembers[workload.namespace], workload.id)
+		}
+	}
+
+	for _, ingress := range what.ingresses {
+		id := what.model.TechnicalAssets[ingress.title].ID
+		namespaceMembers[ingress.namespace] = append(namespaceMembers[ingress.namespace], id)
+	}
+
+	namespaceNested := make(map[string][]string)
+	for _, policy := range what.networkPolicies {
+		members, ok := policyMembers[policy]
+		if !ok {
+			continue
+		}
+
+		id := types.MakeID(policy.namespace+"-"+policy.name) + "-network-policy"
+		what.model.TrustBoundaries["Network policy "+policy.namespace+"/"+policy.name] = input.TrustBoundary{
+			ID:                    id,
+			Description:           "Pods selected by network policy " + policy.name + " in namespace " + policy.namespace,
+			Type:                  types.NetworkPolicyNamespaceIsolation.String(),
+			TechnicalAssetsInside: members,
+		}
+
+		namespaceNested[policy.namespace] = append(namespaceNested[policy.namespace], id)
+	}
+
+	namespaces := make(map[string]bool)
+	for namespace := range namespaceMembers {
+		namespaces[namespace] = true
+	}
+
+	for namespace := range namespaceNested {
+		namespaces[namespace] = true
+	}
+
+	for _, namespace := range sortedKeys(namespaces) {
+		what.model.TrustBoundaries["Namespace "+namespace] = input.TrustBoundary{
+			ID:                    types.MakeID(namespace) + "-namespace",
+			Description:           "Kubernetes namespace " + namespace,
+			Type:                  types.ExecutionEnvironment.String(),
+			TechnicalAssetsInside: namespaceMembers[namespace],
+			TrustBoundariesNested: namespaceNested[namespace],
+		}
+	}
+}
+
+func (what *kubernetesImporter) networkPolicy(workload *kubernetesWorkload) *kubernetesNetworkPolicy {
+	for _, policy := range what.networkPolicies {
+		if policy.namespace == workload.namespace && matchesLabels(policy.selector, workload.labels) {
+			return policy
+		}
+	}
+
+	return nil
+}
+
+// importSharedRuntimes adds a shared runtime per node pool the workloads are scheduled to
+func (what *kubernetesImporter) importSharedRuntimes() {
+	nodePools := make(map[string][]string)
+	for _, workload := range what.workloads {
+		nodePool := nodePoolOf(workload.pod)
+		nodePools[nodePool] = append(nodePools[nodePool], workload.id)
+	}
+
+	for _, nodePool := range sortedKeys(nodePools) {
+		what.model.SharedRuntimes["Node pool "+nodePool] = input.SharedRuntime{
+			ID:                     types.MakeID(nodePool) + "-node-pool",
+			Description:            "Kubernetes nodes of node pool " + nodePool,
+			TechnicalAssetsRunning: nodePools[nodePool],
+		}
+	}
+}
+
+func nodePoolOf(pod kubernetesPodSpec) string {
+	for _, label := range kubernetesNodePoolLabels {
+		if nodePool, ok := pod.NodeSelector[label]; ok && len(nodePool) > 0 {
+			return nodePool
+		}
+
+		for _, term := range pod.Affinity.NodeAffinity.Required.NodeSelectorTerms {
+			for _, expression := range term.MatchExpressions {
+				if expression.Key == label && expression.Operator == "In" && len(expression.Values) > 0 {
+					return expression.Values[0]
+				}
+			}
+		}
+	}
+
+	return kubernetesDefaultNodePool
+}
+
+// matchesLabels checks if all selector labels are present, an empty selector matches everything
+func matchesLabels(selector map[string]string, labels map[string]string) bool {
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+
+	return true
+}
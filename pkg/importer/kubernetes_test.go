@@ -0,0 +1,125 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+const shopManifests = `
+apiVersion: apps/v1
+kind: Deployment
+metadata: {name: web, namespace: shop}
+spec:
+  replicas: 3
+  template:
+    metadata: {labels: {app: web}}
+    spec:
+      nodeSelector: {cloud.google.com/gke-nodepool: frontend}
+      containers:
+        - name: web
+          image: ghcr.io/acme/shop-web:1.2
+          env:
+            - {name: API_URL, value: "http://api:8080/v1"}
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata: {name: api, namespace: shop}
+spec:
+  template:
+    metadata: {labels: {app: api}}
+    spec:
+      containers:
+        - name: api
+          image: ghcr.io/acme/shop-api:1.2
+          env:
+            - {name: DATABASE_URL, value: "postgres://shop@postgres.data.svc.cluster.local/shop"}
+            - name: DB_PASSWORD
+              valueFrom: {secretKeyRef: {name: db-credentials, key: password}}
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata: {name: postgres, namespace: data}
+spec:
+  template:
+    metadata: {labels: {app: postgres}}
+    spec:
+      containers: [{name: postgres, image: "postgres:16"}]
+---
+apiVersion: v1
+kind: List
+items:
+  - apiVersion: v1
+    kind: Service
+    metadata: {name: web, namespace: shop}
+    spec: {selector: {app: web}, ports: [{port: 80, targetPort: 8080}]}
+  - apiVersion: v1
+    kind: Service
+    metadata: {name: api, namespace: shop}
+    spec: {selector: {app: api}, ports: [{port: 8080}]}
+  - apiVersion: v1
+    kind: Service
+    metadata: {name: postgres, namespace: data}
+    spec: {selector: {app: postgres}}
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata: {name: shop, namespace: shop}
+spec:
+  tls: [{hosts: [shop.example.com]}]
+  rules:
+    - http: {paths: [{path: /, backend: {service: {name: web}}}]}
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata: {name: api-isolation, namespace: shop}
+spec:
+  podSelector: {matchLabels: {app: api}}
+---
+apiVersion: v1
+kind: Secret
+metadata: {name: db-credentials, namespace: shop}
+type: Opaque
+`
+
+func TestImportKubernetesMapsWorkloads(t *testing.T) {
+	model, err := importKubernetes("shop", []byte(shopManifests))
+	assert.NoError(t, err)
+
+	assert.Equal(t, types.Database, model.TechnicalAssets["postgres"].Technologies[0])
+	assert.Equal(t, types.Datastore.String(), model.TechnicalAssets["postgres"].Type)
+	assert.True(t, model.TechnicalAssets["web"].Redundant)
+	assert.Equal(t, []string{"db-credentials-secret"}, model.TechnicalAssets["api"].DataAssetsProcessed)
+	assert.Equal(t, types.Confidential.String(), model.DataAssets["db-credentials secret"].Confidentiality)
+
+	assert.Equal(t, types.HTTP.String(), model.TechnicalAssets["web"].CommunicationLinks["Access api"].Protocol)
+	assert.Equal(t, types.SqlAccessProtocol.String(), model.TechnicalAssets["api"].CommunicationLinks["Access postgres"].Protocol)
+}
+
+func TestImportKubernetesMapsIngressesAndBoundaries(t *testing.T) {
+	model, err := importKubernetes("shop", []byte(shopManifests))
+	assert.NoError(t, err)
+
+	clients := model.TechnicalAssets[internetClientsTitle]
+	assert.True(t, clients.Internet)
+	assert.Equal(t, types.HTTPS.String(), clients.CommunicationLinks["Access shop ingress"].Protocol)
+	assert.Equal(t, "web", model.TechnicalAssets["shop ingress"].CommunicationLinks["Route to web"].Target)
+
+	namespace := model.TrustBoundaries["Namespace shop"]
+	assert.Equal(t, types.ExecutionEnvironment.String(), namespace.Type)
+	assert.Equal(t, []string{"web", "shop-shop-ingress"}, namespace.TechnicalAssetsInside)
+	assert.Equal(t, []string{"shop-api-isolation-network-policy"}, namespace.TrustBoundariesNested)
+
+	policy := model.TrustBoundaries["Network policy shop/api-isolation"]
+	assert.Equal(t, types.NetworkPolicyNamespaceIsolation.String(), policy.Type)
+	assert.Equal(t, []string{"api"}, policy.TechnicalAssetsInside)
+
+	assert.Equal(t, []string{"web"}, model.SharedRuntimes["Node pool frontend"].TechnicalAssetsRunning)
+	assert.Equal(t, []string{"api", "postgres"}, model.SharedRuntimes["Node pool default"].TechnicalAssetsRunning)
+}
+
+func TestImportKubernetesRequiresWorkloads(t *testing.T) {
+	_, err := importKubernetes("empty", []byte("apiVersion: v1\nkind: ConfigMap\nmetadata: {name: config}\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,546 @@
+package importer
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+const openAPISchemaPrefix = "#/components/schemas/"
+
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// property names (lowercase, without separators) indicating sensitive data, matched as part of the name unless marked exact
+var (
+	openAPIStrictlyConfidentialFields = []string{
+		"password", "passwd", "secret", "privatekey", "token", "apikey", "=pin", "=ssn", "socialsecurity", "passport",
+		"creditcard", "cardnumber", "=cvv", "=cvc", "iban", "accountnumber", "taxid", "nationalid", "health", "medical",
+		"diagnosis", "biometric",
+	}
+	openAPIPersonalFields = []string{
+		"email", "phone", "mobile", "firstname", "lastname", "surname", "fullname", "givenname", "familyname", "birth",
+		"=dob", "address", "street", "postcode", "postalcode", "zipcode", "=zip", "gender", "nationality", "geolocation",
+		"latitude", "longitude", "salary", "username",
+	}
+)
+
+type openAPIDocument struct {
+	OpenAPI string `yaml:"openapi"`
+	Swagger string `yaml:"swagger"`
+	Info    struct {
+		Title       string `yaml:"title"`
+		Description string `yaml:"description"`
+		Version     string `yaml:"version"`
+	} `yaml:"info"`
+	Servers []struct {
+		URL string `yaml:"url"`
+	} `yaml:"servers"`
+	Paths      map[string]map[string]yaml.Node `yaml:"paths"`
+	Security   []map[string][]string           `yaml:"security"`
+	Components struct {
+		Schemas         map[string]*openAPISchema         `yaml:"schemas"`
+		SecuritySchemes map[string]*openAPISecurityScheme `yaml:"securitySchemes"`
+	} `yaml:"components"`
+}
+
+type openAPIOperation struct {
+	OperationID string                  `yaml:"operationId"`
+	Security    *[]map[string][]string  `yaml:"security"`
+	RequestBody *openAPIBody            `yaml:"requestBody"`
+	Responses   map[string]*openAPIBody `yaml:"responses"`
+}
+
+type openAPIBody struct {
+	Ref     string                       `yaml:"$ref"`
+	Content map[string]*openAPIMediaType `yaml:"content"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `yaml:"schema"`
+}
+
+type openAPISchema struct {
+	Ref         string                    `yaml:"$ref"`
+	Type        any                       `yaml:"type"`
+	Format      string                    `yaml:"format"`
+	Description string                    `yaml:"description"`
+	Properties  map[string]*openAPISchema `yaml:"properties"`
+	Items       *openAPISchema            `yaml:"items"`
+	AllOf       []*openAPISchema          `yaml:"allOf"`
+	OneOf       []*openAPISchema          `yaml:"oneOf"`
+	AnyOf       []*openAPISchema          `yaml:"anyOf"`
+}
+
+type openAPISecurityScheme struct {
+	Type   string `yaml:"type"`
+	Scheme string `yaml:"scheme"`
+	In     string `yaml:"in"`
+}
+
+// ImportOpenAPI creates a model for the API described by an OpenAPI 3 document (yaml or json). The API becomes the
+// technical asset with the given id (or one named after the API), which is extended if it exists in the given model:
+// the links of its existing callers receive the data assets and authentication of the API instead of an API client
+// asset being added. The result is meant to be reconciled with the existing model (see Reconcile).
+func ImportOpenAPI(filename string, existing *input.Model, technicalAssetId string) (*input.Model, error) {
+	data, readError := os.ReadFile(filepath.Clean(filename))
+	if readError != nil {
+		return nil, fmt.Errorf("unable to read openapi document: %w", readError)
+	}
+
+	return importOpenAPI(data, existing, technicalAssetId)
+}
+
+func importOpenAPI(data []byte, existing *input.Model, technicalAssetId string) (*input.Model, error) {
+	var document openAPIDocument
+	unmarshalError := yaml.Unmarshal(data, &document)
+	if unmarshalError != nil {
+		return nil, fmt.Errorf("unable to parse openapi document: %w", unmarshalError)
+	}
+
+	if !strings.HasPrefix(document.OpenAPI, "3.") {
+		if len(document.Swagger) > 0 {
+			return nil, fmt.Errorf("unable to import swagger %v document: only openapi 3 is supported", document.Swagger)
+		}
+
+		return nil, fmt.Errorf("unable to parse openapi document: no openapi 3 version found")
+	}
+
+	title := strings.TrimSpace(document.Info.Title)
+	if len(title) == 0 {
+		title = "API"
+	}
+
+	if len(technicalAssetId) == 0 {
+		technicalAssetId = types.MakeID(title)
+	}
+
+	model := newStubModel(title)
+	model.AppDescription.Description = "Imported from openapi document " + title + " " + document.Info.Version
+
+	dataAssetIds := make(map[string]string)
+	for _, name := range sortedKeys(document.Components.Schemas) {
+		schema := document.Components.Schemas[name]
+		if !document.isObject(schema, make(map[string]bool)) {
+			continue
+		}
+
+		dataAssetIds[name] = types.MakeID(name)
+		model.DataAssets[name] = document.dataAsset(name, schema, title)
+	}
+
+	apiTitle := title
+	var technicalAsset input.TechnicalAsset
+	if curated, curatedTitle := findTechnicalAsset(existing, technicalAssetId); len(curatedTitle) > 0 {
+		apiTitle = curatedTitle
+		technicalAsset = curated
+		technicalAsset.CommunicationLinks = nil
+	} else {
+		technicalAsset = newTechnicalAsset(technicalAssetId, strings.TrimSpace(document.Info.Description), types.WebServiceREST, types.Virtual)
+		technicalAsset.CustomDevelopedParts = true
+	}
+
+	link := newCommunicationLink(technicalAssetId, "Calls "+title, document.protocol())
+	link.Readonly = true
+	authentication := types.NoneAuthentication
+	operations := 0
+	formats := make([]string, 0)
+	sent := make([]string, 0)
+	received := make([]string, 0)
+	for _, path := range sortedKeys(document.Paths) {
+		for _, method := range openAPIMethods {
+			node, ok := document.Paths[path][method]
+			if !ok {
+				continue
+			}
+
+			var operation openAPIOperation
+			decodeError := node.Decode(&operation)
+			if decodeError != nil {
+				return nil, fmt.Errorf("unable to parse operation %v %v: %w", strings.ToUpper(method), path, decodeError)
+			}
+
+			if method != "get" && method != "head" && method != "options" {
+				link.Readonly = false
+			}
+
+			security := document.Security
+			if operation.Security != nil {
+				security = *operation.Security
+			}
+
+			if operationAuthentication := document.authentication(security); operations == 0 || operationAuthentication < authentication {
+				authentication = operationAuthentication
+			}
+
+			operations++
+
+			if operation.RequestBody != nil {
+				for _, contentType := range sortedKeys(operation.RequestBody.Content) {
+					if format, ok := openAPIDataFormat(contentType); ok {
+						formats = appendUnique(formats, format.String())
+					}
+
+					sent = appendUnique(sent, document.referenced(operation.RequestBody.Content[contentType].Schema, dataAssetIds)...)
+				}
+			}
+
+			for _, code := range sortedKeys(operation.Responses) {
+				response := operation.Responses[code]
+				if response == nil || !strings.HasPrefix(code, "2") {
+					continue
+				}
+
+				for _, contentType := range sortedKeys(response.Content) {
+					received = appendUnique(received, document.referenced(response.Content[contentType].Schema, dataAssetIds)...)
+				}
+			}
+		}
+	}
+
+	if operations == 0 {
+		return nil, fmt.Errorf("no operations found in openapi document")
+	}
+
+	link.Authentication = authentication.String()
+	switch authentication {
+	case types.NoneAuthentication:
+
+	case types.Externalized:
+		link.Authorization = types.EndUserIdentityPropagation.String()
+
+	default:
+		link.Authorization = types.TechnicalUser.String()
+	}
+
+	sort.Strings(sent)
+	sort.Strings(received)
+	link.DataAssetsSent = compact(sent)
+	link.DataAssetsReceived = compact(received)
+
+	technicalAsset.DataFormatsAccepted = appendUnique(technicalAsset.DataFormatsAccepted, formats...)
+	for _, name := range sortedKeys(dataAssetIds) {
+		technicalAsset.DataAssetsProcessed = appendUnique(technicalAsset.DataAssetsProcessed, dataAssetIds[name])
+	}
+
+	model.TechnicalAssets[apiTitle] = technicalAsset
+
+	// the callers of an existing API are known already, otherwise they are represented by an external entity
+	callers := 0
+	if existing != nil {
+		for _, callerTitle := range sortedKeys(existing.TechnicalAssets) {
+			caller := existing.TechnicalAssets[callerTitle]
+			for _, linkTitle := range sortedKeys(caller.CommunicationLinks) {
+				if caller.CommunicationLinks[linkTitle].Target != technicalAssetId {
+					continue
+				}
+
+				if _, ok := model.TechnicalAssets[callerTitle]; !ok {
+					caller.CommunicationLinks = make(map[string]input.CommunicationLink)
+					caller.DataAssetsProcessed = appendUnique(append([]string{}, sent...), received...)
+					caller.DataAssetsStored = nil
+					model.TechnicalAssets[callerTitle] = caller
+				}
+
+				callerLink := link
+				callerLink.Description = caller.CommunicationLinks[linkTitle].Description
+				model.TechnicalAssets[callerTitle].CommunicationLinks[linkTitle] = callerLink
+				callers++
+			}
+		}
+	}
+
+	if callers == 0 {
+		clientsTitle := title + " Clients"
+		clients := newTechnicalAsset(types.MakeID(clientsTitle), "Clients calling "+title, types.ClientSystem, types.Virtual)
+		clients.Type = types.ExternalEntity.String()
+		clients.DataAssetsProcessed = appendUnique(append([]string{}, sent...), received...)
+		model.TechnicalAssets[clientsTitle] = clients
+		addCommunicationLink(model, clientsTitle, "Access "+apiTitle, link)
+	}
+
+	return model, nil
+}
+
+func findTechnicalAsset(model *input.Model, id string) (input.TechnicalAsset, string) {
+	if model == nil {
+		return input.TechnicalAsset{}, ""
+	}
+
+	for title, technicalAsset := range model.TechnicalAssets {
+		if technicalAsset.ID == id {
+			return technicalAsset, title
+		}
+	}
+
+	return input.TechnicalAsset{}, ""
+}
+
+// protocol returns https unless all servers are plain http
+func (what *openAPIDocument) protocol() types.Protocol {
+	if len(what.Servers) == 0 {
+		return types.HTTPS
+	}
+
+	for _, server := range what.Servers {
+		parsed, parseError := url.Parse(server.URL)
+		if parseError != nil || parsed.Scheme != "http" {
+			return types.HTTPS
+		}
+	}
+
+	return types.HTTP
+}
+
+// authentication returns the weakest of the alternative security requirements, each as strong as its strongest scheme
+func (what *openAPIDocument) authentication(security []map[string][]string) types.Authentication {
+	if len(security) == 0 {
+		return types.NoneAuthentication
+	}
+
+	weakest := types.Externalized
+	for _, requirement := range security {
+		strongest := types.NoneAuthentication
+		for name := range requirement {
+			scheme, ok := what.Components.SecuritySchemes[name]
+			if !ok || scheme == nil {
+				continue
+			}
+
+			if authentication := scheme.authentication(); authentication > strongest {
+				strongest = authentication
+			}
+		}
+
+		if strongest < weakest {
+			weakest = strongest
+		}
+	}
+
+	return weakest
+}
+
+func (what *openAPISecurityScheme) authentication() types.Authentication {
+	switch strings.ToLower(what.Type) {
+	case "http":
+		if strings.EqualFold(what.Scheme, "bearer") {
+			return types.Token
+		}
+
+		return types.Credentials
+
+	case "apikey":
+		if strings.EqualFold(what.In, "cookie") {
+			return types.SessionId
+		}
+
+		return types.Token
+
+	case "oauth2":
+		return types.Token
+
+	case "openidconnect":
+		return types.Externalized
+
+	case "mutualtls":
+		return types.ClientCertificate
+
+	default:
+		return types.NoneAuthentication
+	}
+}
+
+func openAPIDataFormat(contentType string) (types.DataFormat, bool) {
+	contentType = strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+	switch {
+	case strings.HasSuffix(contentType, "json"):
+		return types.JSON, true
+
+	case strings.HasSuffix(contentType, "xml"):
+		return types.XML, true
+
+	case strings.HasSuffix(contentType, "yaml"):
+		return types.YAML, true
+
+	case contentType == "text/csv":
+		return types.CSV, true
+
+	case strings.Contains(contentType, "java-serialized-object"), strings.Contains(contentType, "protobuf"),
+		strings.Contains(contentType, "msgpack"), strings.Contains(contentType, "avro"):
+		return types.Serialization, true
+
+	case contentType == "multipart/form-data", contentType == "application/octet-stream", contentType == "application/pdf",
+		strings.HasPrefix(contentType, "image/"), strings.HasPrefix(contentType, "video/"), strings.HasPrefix(contentType, "audio/"):
+		return types.File, true
+
+	default:
+		return types.JSON, false
+	}
+}
+
+func (what *openAPIDocument) resolve(schema *openAPISchema) (*openAPISchema, string) {
+	if schema == nil || !strings.HasPrefix(schema.Ref, openAPISchemaPrefix) {
+		return schema, ""
+	}
+
+	name := strings.TrimPrefix(schema.Ref, openAPISchemaPrefix)
+	return what.Components.Schemas[name], name
+}
+
+func (what *openAPIDocument) isObject(schema *openAPISchema, seen map[string]bool) bool {
+	schema, name := what.resolve(schema)
+	if schema == nil || seen[name] {
+		return false
+	}
+
+	if len(name) > 0 {
+		seen[name] = true
+	}
+
+	if len(schema.Properties) > 0 || schema.Type == "object" {
+		return true
+	}
+
+	for _, part := range schema.AllOf {
+		if what.isObject(part, seen) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// referenced returns the data assets of the component schemas a request or response body consists of
+func (what *openAPIDocument) referenced(schema *openAPISchema, dataAssetIds map[string]string) []string {
+	result := make([]string, 0)
+	if schema == nil {
+		return result
+	}
+
+	if strings.HasPrefix(schema.Ref, openAPISchemaPrefix) {
+		if id, ok := dataAssetIds[strings.TrimPrefix(schema.Ref, openAPISchemaPrefix)]; ok {
+			result = append(result, id)
+		}
+
+		return result
+	}
+
+	result = appendUnique(result, what.referenced(schema.Items, dataAssetIds)...)
+	for _, parts := range [][]*openAPISchema{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, part := range parts {
+			result = appendUnique(result, what.referenced(part, dataAssetIds)...)
+		}
+	}
+
+	return result
+}
+
+// fields returns the paths of all properties of a schema, including nested and referenced schemas
+func (what *openAPIDocument) fields(schema *openAPISchema, prefix string, seen map[string]bool) []openAPIField {
+	schema, name := what.resolve(schema)
+	if schema == nil || seen[name] {
+		return nil
+	}
+
+	if len(name) > 0 {
+		seen[name] = true
+		defer delete(seen, name)
+	}
+
+	result := make([]openAPIField, 0)
+	for _, property := range sortedKeys(schema.Properties) {
+		result = append(result, openAPIField{path: prefix + property, format: schema.Properties[property].Format})
+		result = append(result, what.fields(schema.Properties[property], prefix+property+".", seen)...)
+	}
+
+	result = append(result, what.fields(schema.Items, prefix, seen)...)
+	for _, parts := range [][]*openAPISchema{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, part := range parts {
+			result = append(result, what.fields(part, prefix, seen)...)
+		}
+	}
+
+	return result
+}
+
+type openAPIField struct {
+	path   string
+	format string
+}
+
+// dataAsset classifies a schema by its fields: credentials and highly sensitive personal data make it strictly
+// confidential, other personal data (PII) confidential, everything else internal
+func (what *openAPIDocument) dataAsset(name string, schema *openAPISchema, title string) input.DataAsset {
+	strictly := make([]string, 0)
+	personal := make([]string, 0)
+	for _, field := range what.fields(schema, "", make(map[string]bool)) {
+		parts := strings.Split(field.path, ".")
+		switch {
+		case field.format == "password" || matchesField(parts[len(parts)-1], openAPIStrictlyConfidentialFields):
+			strictly = append(strictly, field.path)
+
+		case field.format == "email" || matchesField(parts[len(parts)-1], openAPIPersonalFields):
+			personal = append(personal, field.path)
+		}
+	}
+
+	description := strings.TrimSpace(schema.Description)
+	if len(description) == 0 {
+		description = name + " of " + title
+	}
+
+	dataAsset := input.DataAsset{
+		ID:              types.MakeID(name),
+		Description:     description,
+		Usage:           types.Business.String(),
+		Origin:          title,
+		Quantity:        types.Many.String(),
+		Confidentiality: types.Internal.String(),
+		Integrity:       types.Important.String(),
+		Availability:    types.Operational.String(),
+	}
+
+	justification := make([]string, 0)
+	if len(strictly) > 0 {
+		dataAsset.Confidentiality = types.StrictlyConfidential.String()
+		justification = append(justification, "Contains credentials or highly sensitive data: "+strings.Join(strictly, ", "))
+	} else if len(personal) > 0 {
+		dataAsset.Confidentiality = types.Confidential.String()
+	}
+
+	if len(personal) > 0 {
+		justification = append(justification, "Contains personal data: "+strings.Join(personal, ", "))
+	}
+
+	dataAsset.JustificationCiaRating = strings.Join(justification, "; ")
+	return dataAsset
+}
+
+// matchesField matches a property name against patterns, either as part of the name or exactly (with a leading "=")
+func matchesField(name string, patterns []string) bool {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' || r == '.' || r == ' ' {
+			return -1
+		}
+
+		return r
+	}, strings.ToLower(name))
+
+	for _, pattern := range patterns {
+		if exact, ok := strings.CutPrefix(pattern, "="); ok {
+			if normalized == exact {
+				return true
+			}
+		} else if strings.Contains(normalized, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,135 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+const customersSpec = `
+openapi: 3.0.3
+info: {title: Customer API, version: "1.4"}
+servers: [{url: "https://api.example.com/v1"}]
+security: [{oidc: []}]
+paths:
+  /customers:
+    parameters: [{name: page, in: query}]
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema: {type: array, items: {$ref: "#/components/schemas/Customer"}}
+        "default":
+          content:
+            application/problem+json:
+              schema: {$ref: "#/components/schemas/Problem"}
+    post:
+      security: [{apiKey: []}, {oidc: []}]
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: "#/components/schemas/Customer"}
+          application/xml:
+            schema: {$ref: "#/components/schemas/Customer"}
+      responses:
+        "201": {description: created}
+  /customers/{id}/documents:
+    put:
+      requestBody:
+        content:
+          multipart/form-data:
+            schema: {type: object, properties: {file: {type: string, format: binary}}}
+      responses:
+        "204": {description: stored}
+components:
+  securitySchemes:
+    apiKey: {type: apiKey, in: header, name: X-API-Key}
+    oidc: {type: openIdConnect, openIdConnectUrl: "https://login.example.com/.well-known/openid-configuration"}
+  schemas:
+    Customer:
+      type: object
+      properties:
+        id: {type: string}
+        first_name: {type: string}
+        contact: {type: string, format: email}
+        address: {$ref: "#/components/schemas/Address"}
+        payment: {$ref: "#/components/schemas/Payment"}
+    Address:
+      properties: {street: {type: string}, city: {type: string}}
+    Payment:
+      properties: {iban: {type: string}}
+    Problem:
+      description: RFC 7807 problem details
+      properties: {title: {type: string}, status: {type: integer}}
+    Status:
+      type: string
+      enum: [active, blocked]
+`
+
+func TestImportOpenAPIDerivesDataAssets(t *testing.T) {
+	model, err := importOpenAPI([]byte(customersSpec), nil, "")
+	assert.NoError(t, err)
+
+	customer := model.DataAssets["Customer"]
+	assert.Equal(t, types.StrictlyConfidential.String(), customer.Confidentiality)
+	assert.Equal(t, "Contains credentials or highly sensitive data: payment.iban; Contains personal data: address, address.street, contact, first_name", customer.JustificationCiaRating)
+	assert.Equal(t, types.Confidential.String(), model.DataAssets["Address"].Confidentiality)
+	assert.Equal(t, types.Internal.String(), model.DataAssets["Problem"].Confidentiality)
+	assert.Equal(t, "RFC 7807 problem details", model.DataAssets["Problem"].Description)
+	assert.NotContains(t, model.DataAssets, "Status")
+
+	api := model.TechnicalAssets["Customer API"]
+	assert.Equal(t, "customer-api", api.ID)
+	assert.Equal(t, types.WebServiceREST, api.Technologies[0])
+	assert.Equal(t, []string{"json", "xml", "file"}, api.DataFormatsAccepted)
+	assert.Equal(t, []string{"address", "customer", "payment", "problem"}, api.DataAssetsProcessed)
+}
+
+func TestImportOpenAPIDerivesLinkAuthentication(t *testing.T) {
+	model, err := importOpenAPI([]byte(customersSpec), nil, "")
+	assert.NoError(t, err)
+
+	link := model.TechnicalAssets["Customer API Clients"].CommunicationLinks["Access Customer API"]
+	assert.Equal(t, "customer-api", link.Target)
+	assert.Equal(t, types.HTTPS.String(), link.Protocol)
+	assert.Equal(t, types.Token.String(), link.Authentication)
+	assert.Equal(t, types.TechnicalUser.String(), link.Authorization)
+	assert.False(t, link.Readonly)
+	assert.Equal(t, []string{"customer"}, link.DataAssetsSent)
+	assert.Equal(t, []string{"customer"}, link.DataAssetsReceived)
+
+	var document openAPIDocument
+	assert.NoError(t, yaml.Unmarshal([]byte(customersSpec), &document))
+	assert.Equal(t, types.Externalized, document.authentication([]map[string][]string{{"oidc": nil}}))
+	assert.Equal(t, types.Token, document.authentication([]map[string][]string{{"oidc": nil, "apiKey": nil}, {"apiKey": nil}}))
+	assert.Equal(t, types.NoneAuthentication, document.authentication([]map[string][]string{{}, {"oidc": nil}}))
+}
+
+func TestImportOpenAPIExtendsExistingTechnicalAsset(t *testing.T) {
+	existing := new(input.Model).Defaults()
+	existing.TechnicalAssets["Backend"] = input.TechnicalAsset{ID: "backend", Description: "curated", DataFormatsAccepted: []string{"json"}}
+	existing.TechnicalAssets["Web Shop"] = input.TechnicalAsset{ID: "web-shop", CommunicationLinks: map[string]input.CommunicationLink{
+		"Load customers": {Target: "backend", Authentication: types.Token.String(), DataAssetsReceived: []string{"customer"}},
+	}}
+	existing.DataAssets["Customer"] = input.DataAsset{ID: "customer", Confidentiality: types.Restricted.String()}
+
+	model, err := importOpenAPI([]byte(customersSpec), existing, "backend")
+	assert.NoError(t, err)
+	assert.NotContains(t, model.TechnicalAssets, "Customer API Clients")
+
+	reconciled := Reconcile(model, existing)
+	assert.NotContains(t, reconciled.DataAssets, "Customer")
+	assert.Equal(t, []string{"json", "xml", "file"}, reconciled.TechnicalAssets["Backend"].DataFormatsAccepted)
+	assert.Empty(t, reconciled.TechnicalAssets["Backend"].Description)
+	assert.Equal(t, map[string]input.CommunicationLink{"Load customers": {DataAssetsSent: []string{"customer"}, DataAssetsReceived: []string{"customer"}}},
+		reconciled.TechnicalAssets["Web Shop"].CommunicationLinks)
+}
+
+func TestImportOpenAPIRejectsSwagger(t *testing.T) {
+	_, err := importOpenAPI([]byte("swagger: \"2.0\"\ninfo: {title: Legacy}\npaths: {}\n"), nil, "")
+	assert.ErrorContains(t, err, "only openapi 3 is supported")
+}
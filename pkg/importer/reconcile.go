@@ -0,0 +1,203 @@
+package importer
+
+import (
+	"github.com/threagile/threagile/pkg/input"
+)
+
+// Reconcile prepares an imported model to be merged into an existing (hand-curated) model via its includes.
+// Elements already present in the existing model (by title or id) are reduced to what can be merged without conflicts:
+// their settings are taken from the existing model, the import only adds communication links, data assets processed,
+// stored, sent or received, data formats accepted and members of trust boundaries and shared runtimes. Technical assets and trust boundaries the existing
+// model already places inside a trust boundary keep their place. Model level settings are left to the existing model.
+// The existing model must not include the imported model itself, otherwise everything would be reduced.
+func Reconcile(imported *input.Model, existing *input.Model) *input.Model {
+	result := new(input.Model).Defaults()
+
+	dataAssetIds := reconcileIds(imported.DataAssets, existing.DataAssets, func(value input.DataAsset) string { return value.ID })
+	technicalAssetIds := reconcileIds(imported.TechnicalAssets, existing.TechnicalAssets, func(value input.TechnicalAsset) string { return value.ID })
+	trustBoundaryIds := reconcileIds(imported.TrustBoundaries, existing.TrustBoundaries, func(value input.TrustBoundary) string { return value.ID })
+	sharedRuntimeIds := reconcileIds(imported.SharedRuntimes, existing.SharedRuntimes, func(value input.SharedRuntime) string { return value.ID })
+
+	for title, dataAsset := range imported.DataAssets {
+		if _, ok := dataAssetIds.existing[title]; !ok {
+			result.DataAssets[title] = dataAsset
+		}
+	}
+
+	for title, technicalAsset := range imported.TechnicalAssets {
+		existingTitle, ok := technicalAssetIds.existing[title]
+		if !ok {
+			technicalAsset.DataAssetsProcessed = dataAssetIds.mapAll(technicalAsset.DataAssetsProcessed)
+			technicalAsset.DataAssetsStored = dataAssetIds.mapAll(technicalAsset.DataAssetsStored)
+			technicalAsset.CommunicationLinks = reconcileCommunicationLinks(technicalAsset.CommunicationLinks, nil, technicalAssetIds, dataAssetIds)
+			result.TechnicalAssets[title] = technicalAsset
+			continue
+		}
+
+		curated := existing.TechnicalAssets[existingTitle]
+		reduced := input.TechnicalAsset{
+			DataAssetsProcessed: dataAssetIds.mapAll(technicalAsset.DataAssetsProcessed),
+			DataAssetsStored:    dataAssetIds.mapAll(technicalAsset.DataAssetsStored),
+			DataFormatsAccepted: compact(technicalAsset.DataFormatsAccepted),
+			CommunicationLinks:  reconcileCommunicationLinks(technicalAsset.CommunicationLinks, curated.CommunicationLinks, technicalAssetIds, dataAssetIds),
+		}
+
+		if len(reduced.DataAssetsProcessed) > 0 || len(reduced.DataAssetsStored) > 0 || len(reduced.DataFormatsAccepted) > 0 || len(reduced.CommunicationLinks) > 0 {
+			result.TechnicalAssets[existingTitle] = reduced
+		}
+	}
+
+	placedTechnicalAssets := make(map[string]bool)
+	placedTrustBoundaries := make(map[string]bool)
+	for _, trustBoundary := range existing.TrustBoundaries {
+		for _, id := range trustBoundary.TechnicalAssetsInside {
+			placedTechnicalAssets[id] = true
+		}
+
+		for _, id := range trustBoundary.TrustBoundariesNested {
+			placedTrustBoundaries[id] = true
+		}
+	}
+
+	for title, trustBoundary := range imported.TrustBoundaries {
+		inside := make([]string, 0)
+		for _, id := range technicalAssetIds.mapAll(trustBoundary.TechnicalAssetsInside) {
+			if !placedTechnicalAssets[id] {
+				inside = append(inside, id)
+			}
+		}
+
+		nested := make([]string, 0)
+		for _, id := range trustBoundaryIds.mapAll(trustBoundary.TrustBoundariesNested) {
+			if !placedTrustBoundaries[id] {
+				nested = append(nested, id)
+			}
+		}
+
+		existingTitle, ok := trustBoundaryIds.existing[title]
+		if !ok {
+			trustBoundary.TechnicalAssetsInside = compact(inside)
+			trustBoundary.TrustBoundariesNested = compact(nested)
+			result.TrustBoundaries[title] = trustBoundary
+			continue
+		}
+
+		if len(inside) > 0 || len(nested) > 0 {
+			result.TrustBoundaries[existingTitle] = input.TrustBoundary{TechnicalAssetsInside: compact(inside), TrustBoundariesNested: compact(nested)}
+		}
+	}
+
+	for title, sharedRuntime := range imported.SharedRuntimes {
+		running := technicalAssetIds.mapAll(sharedRuntime.TechnicalAssetsRunning)
+		existingTitle, ok := sharedRuntimeIds.existing[title]
+		if !ok {
+			sharedRuntime.TechnicalAssetsRunning = running
+			result.SharedRuntimes[title] = sharedRuntime
+			continue
+		}
+
+		if len(running) > 0 {
+			result.SharedRuntimes[existingTitle] = input.SharedRuntime{TechnicalAssetsRunning: running}
+		}
+	}
+
+	return result
+}
+
+// reconciledIds maps imported titles and ids to the ones of the existing model
+type reconciledIds struct {
+	existing map[string]string
+	ids      map[string]string
+}
+
+func reconcileIds[T any](imported map[string]T, existing map[string]T, id func(T) string) *reconciledIds {
+	result := &reconciledIds{existing: make(map[string]string), ids: make(map[string]string)}
+
+	existingById := make(map[string]string)
+	for title, value := range existing {
+		existingById[id(value)] = title
+	}
+
+	for title, value := range imported {
+		if _, ok := existing[title]; ok {
+			result.existing[title] = title
+			result.ids[id(value)] = id(existing[title])
+		} else if existingTitle, ok := existingById[id(value)]; ok {
+			result.existing[title] = existingTitle
+		}
+	}
+
+	return result
+}
+
+func (what *reconciledIds) mapAll(ids []string) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if mapped, ok := what.ids[id]; ok {
+			id = mapped
+		}
+
+		result = appendUnique(result, id)
+	}
+
+	return result
+}
+
+// reconcileCommunicationLinks reduces links to targets already linked in the existing model to the data assets they add
+// and renames links whose title is taken
+func reconcileCommunicationLinks(imported map[string]input.CommunicationLink, existing map[string]input.CommunicationLink, technicalAssetIds *reconciledIds, dataAssetIds *reconciledIds) map[string]input.CommunicationLink {
+	result := make(map[string]input.CommunicationLink)
+	for _, title := range sortedKeys(imported) {
+		link := imported[title]
+		link.Target = technicalAssetIds.mapAll([]string{link.Target})[0]
+		link.DataAssetsSent = dataAssetIds.mapAll(link.DataAssetsSent)
+		link.DataAssetsReceived = dataAssetIds.mapAll(link.DataAssetsReceived)
+
+		linked := false
+		for _, existingTitle := range sortedKeys(existing) {
+			if existing[existingTitle].Target != link.Target {
+				continue
+			}
+
+			linked = true
+			reduced := input.CommunicationLink{
+				DataAssetsSent:     compact(link.DataAssetsSent),
+				DataAssetsReceived: compact(link.DataAssetsReceived),
+			}
+
+			if len(reduced.DataAssetsSent) > 0 || len(reduced.DataAssetsReceived) > 0 {
+				result[existingTitle] = reduced
+			}
+
+			break
+		}
+
+		if linked {
+			continue
+		}
+
+		if _, taken := existing[title]; taken {
+			title = uniqueTitle(title, existing)
+		}
+
+		result[title] = link
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+func compact(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	return values
+}
@@ -0,0 +1,44 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+func TestReconcileKeepsCuratedDetails(t *testing.T) {
+	imported, err := importKubernetes("shop", []byte(shopManifests))
+	assert.NoError(t, err)
+
+	existing := new(input.Model).Defaults()
+	existing.Title = "Shop"
+	existing.TechnicalAssets["Customer Database"] = input.TechnicalAsset{
+		ID:              "postgres",
+		Confidentiality: types.StrictlyConfidential.String(),
+	}
+	existing.TechnicalAssets["api"] = input.TechnicalAsset{
+		ID: "shop-api",
+		CommunicationLinks: map[string]input.CommunicationLink{
+			"Access postgres": {Target: "cache"},
+		},
+	}
+	existing.TrustBoundaries["Data zone"] = input.TrustBoundary{ID: "data-zone", TechnicalAssetsInside: []string{"postgres"}}
+
+	reconciled := Reconcile(imported, existing)
+	assert.Empty(t, reconciled.Title)
+	assert.NotContains(t, reconciled.TechnicalAssets, "postgres")
+	assert.NotContains(t, reconciled.TechnicalAssets, "Customer Database")
+
+	api := reconciled.TechnicalAssets["api"]
+	assert.Empty(t, api.ID)
+	assert.Empty(t, api.Confidentiality)
+	assert.Equal(t, []string{"db-credentials-secret"}, api.DataAssetsProcessed)
+	assert.Equal(t, "postgres", api.CommunicationLinks["Access postgres (2)"].Target)
+
+	assert.Empty(t, reconciled.TrustBoundaries["Namespace data"].TechnicalAssetsInside)
+	assert.Equal(t, []string{"shop-api"}, reconciled.TrustBoundaries["Network policy shop/api-isolation"].TechnicalAssetsInside)
+	assert.Equal(t, []string{"shop-api", "postgres"}, reconciled.SharedRuntimes["Node pool default"].TechnicalAssetsRunning)
+	assert.Equal(t, "shop-api", reconciled.TechnicalAssets["web"].CommunicationLinks["Access api"].Target)
+}
@@ -0,0 +1,1209 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+type terraformKind struct {
+	technology string
+	machine    types.TechnicalAssetMachine
+}
+
+// resource types becoming technical assets
+var terraformTechnicalAssets = map[string]terraformKind{
+	"aws_instance":                              {types.UnknownTechnology, types.Virtual},
+	"aws_autoscaling_group":                     {types.UnknownTechnology, types.Virtual},
+	"aws_ecs_service":                           {types.UnknownTechnology, types.Container},
+	"aws_eks_cluster":                           {types.ContainerPlatform, types.Virtual},
+	"aws_lambda_function":                       {types.Function, types.Serverless},
+	"aws_db_instance":                           {types.Database, types.Virtual},
+	"aws_rds_cluster":                           {types.Database, types.Virtual},
+	"aws_docdb_cluster":                         {types.Database, types.Virtual},
+	"aws_neptune_cluster":                       {types.Database, types.Virtual},
+	"aws_redshift_cluster":                      {types.Database, types.Virtual},
+	"aws_dynamodb_table":                        {types.Database, types.Serverless},
+	"aws_elasticache_cluster":                   {types.Database, types.Virtual},
+	"aws_elasticache_replication_group":         {types.Database, types.Virtual},
+	"aws_s3_bucket":                             {types.FileServer, types.Serverless},
+	"aws_efs_file_system":                       {types.FileServer, types.Serverless},
+	"aws_ebs_volume":                            {types.BlockStorage, types.Virtual},
+	"aws_sqs_queue":                             {types.MessageQueue, types.Serverless},
+	"aws_sns_topic":                             {types.MessageQueue, types.Serverless},
+	"aws_mq_broker":                             {types.MessageQueue, types.Virtual},
+	"aws_msk_cluster":                           {types.MessageQueue, types.Virtual},
+	"aws_kinesis_stream":                        {types.MessageQueue, types.Serverless},
+	"aws_lb":                                    {types.LoadBalancer, types.Virtual},
+	"aws_alb":                                   {types.LoadBalancer, types.Virtual},
+	"aws_elb":                                   {types.LoadBalancer, types.Virtual},
+	"aws_api_gateway_rest_api":                  {types.Gateway, types.Serverless},
+	"aws_apigatewayv2_api":                      {types.Gateway, types.Serverless},
+	"aws_cloudfront_distribution":               {types.ReverseProxy, types.Serverless},
+	"aws_kms_key":                               {types.Vault, types.Serverless},
+	"aws_secretsmanager_secret":                 {types.Vault, types.Serverless},
+	"aws_cognito_user_pool":                     {types.IdentityProvider, types.Serverless},
+	"aws_opensearch_domain":                     {types.SearchEngine, types.Virtual},
+	"aws_elasticsearch_domain":                  {types.SearchEngine, types.Virtual},
+	"aws_wafv2_web_acl":                         {types.WAF, types.Serverless},
+	"aws_ecr_repository":                        {types.ArtifactRegistry, types.Serverless},
+	"aws_codebuild_project":                     {types.BuildPipeline, types.Serverless},
+	"aws_codepipeline":                          {types.BuildPipeline, types.Serverless},
+	"aws_codecommit_repository":                 {types.SourcecodeRepository, types.Serverless},
+	"aws_emr_cluster":                           {types.BigDataPlatform, types.Virtual},
+	"aws_glue_job":                              {types.BatchProcessing, types.Serverless},
+	"azurerm_linux_virtual_machine":             {types.UnknownTechnology, types.Virtual},
+	"azurerm_windows_virtual_machine":           {types.UnknownTechnology, types.Virtual},
+	"azurerm_virtual_machine":                   {types.UnknownTechnology, types.Virtual},
+	"azurerm_linux_virtual_machine_scale_set":   {types.UnknownTechnology, types.Virtual},
+	"azurerm_windows_virtual_machine_scale_set": {types.UnknownTechnology, types.Virtual},
+	"azurerm_app_service":                       {types.WebApplication, types.Virtual},
+	"azurerm_linux_web_app":                     {types.WebApplication, types.Virtual},
+	"azurerm_windows_web_app":                   {types.WebApplication, types.Virtual},
+	"azurerm_function_app":                      {types.Function, types.Serverless},
+	"azurerm_linux_function_app":                {types.Function, types.Serverless},
+	"azurerm_windows_function_app":              {types.Function, types.Serverless},
+	"azurerm_container_app":                     {types.UnknownTechnology, types.Container},
+	"azurerm_container_group":                   {types.UnknownTechnology, types.Container},
+	"azurerm_kubernetes_cluster":                {types.ContainerPlatform, types.Virtual},
+	"azurerm_mssql_server":                      {types.Database, types.Virtual},
+	"azurerm_sql_server":                        {types.Database, types.Virtual},
+	"azurerm_postgresql_server":                 {types.Database, types.Virtual},
+	"azurerm_postgresql_flexible_server":        {types.Database, types.Virtual},
+	"azurerm_mysql_server":                      {types.Database, types.Virtual},
+	"azurerm_mysql_flexible_server":             {types.Database, types.Virtual},
+	"azurerm_mariadb_server":                    {types.Database, types.Virtual},
+	"azurerm_cosmosdb_account":                  {types.Database, types.Serverless},
+	"azurerm_redis_cache":                       {types.Database, types.Virtual},
+	"azurerm_storage_account":                   {types.FileServer, types.Serverless},
+	"azurerm_key_vault":                         {types.Vault, types.Serverless},
+	"azurerm_servicebus_namespace":              {types.MessageQueue, types.Serverless},
+	"azurerm_eventhub_namespace":                {types.MessageQueue, types.Serverless},
+	"azurerm_lb":                                {types.LoadBalancer, types.Virtual},
+	"azurerm_application_gateway":               {types.LoadBalancer, types.Virtual},
+	"azurerm_api_management":                    {types.Gateway, types.Virtual},
+	"azurerm_cdn_frontdoor_profile":             {types.ReverseProxy, types.Serverless},
+	"azurerm_frontdoor":                         {types.ReverseProxy, types.Serverless},
+	"azurerm_container_registry":                {types.ArtifactRegistry, types.Serverless},
+	"azurerm_search_service":                    {types.SearchEngine, types.Serverless},
+	"azurerm_web_application_firewall_policy":   {types.WAF, types.Serverless},
+	"google_compute_instance":                   {types.UnknownTechnology, types.Virtual},
+	"google_cloud_run_service":                  {types.UnknownTechnology, types.Serverless},
+	"google_cloud_run_v2_service":               {types.UnknownTechnology, types.Serverless},
+	"google_cloudfunctions_function":            {types.Function, types.Serverless},
+	"google_cloudfunctions2_function":           {types.Function, types.Serverless},
+	"google_container_cluster":                  {types.ContainerPlatform, types.Virtual},
+	"google_sql_database_instance":              {types.Database, types.Virtual},
+	"google_alloydb_cluster":                    {types.Database, types.Virtual},
+	"google_spanner_instance":                   {types.Database, types.Serverless},
+	"google_bigtable_instance":                  {types.Database, types.Serverless},
+	"google_firestore_database":                 {types.Database, types.Serverless},
+	"google_redis_instance":                     {types.Database, types.Virtual},
+	"google_storage_bucket":                     {types.FileServer, types.Serverless},
+	"google_bigquery_dataset":                   {types.DataLake, types.Serverless},
+	"google_pubsub_topic":                       {types.MessageQueue, types.Serverless},
+	"google_kms_crypto_key":                     {types.Vault, types.Serverless},
+	"google_secret_manager_secret":              {types.Vault, types.Serverless},
+	"google_compute_forwarding_rule":            {types.LoadBalancer, types.Virtual},
+	"google_compute_global_forwarding_rule":     {types.LoadBalancer, types.Virtual},
+	"google_api_gateway_gateway":                {types.Gateway, types.Serverless},
+	"google_artifact_registry_repository":       {types.ArtifactRegistry, types.Serverless},
+	"google_compute_security_policy":            {types.WAF, types.Serverless},
+}
+
+// resource types becoming trust boundaries
+var (
+	terraformNetworks = []string{"aws_vpc", "azurerm_virtual_network", "google_compute_network"}
+	terraformSubnets  = []string{"aws_subnet", "azurerm_subnet", "google_compute_subnetwork"}
+
+	// resources grouping subnets, which are followed when placing technical assets
+	terraformSubnetGroups = map[string]string{
+		"aws_db_subnet_group":          "subnet_ids",
+		"aws_elasticache_subnet_group": "subnet_ids",
+		"aws_redshift_subnet_group":    "subnet_ids",
+		"azurerm_network_interface":    "ip_configuration.subnet_id",
+	}
+)
+
+// attributes referencing the subnet (or subnet group) a resource is placed in, in order of precedence
+var terraformSubnetAttributes = []string{
+	"subnet_id",
+	"subnet_ids",
+	"vpc_config.subnet_ids",
+	"network_configuration.subnets",
+	"vpc_zone_identifier",
+	"subnets",
+	"subnet_mapping.subnet_id",
+	"db_subnet_group_name",
+	"subnet_group_name",
+	"cluster_subnet_group_name",
+	"network_interface_ids",
+	"network_interface.subnetwork",
+	"virtual_network_subnet_id",
+	"delegated_subnet_id",
+	"subnetwork",
+	"gateway_ip_configuration.subnet_id",
+	"frontend_ip_configuration.subnet_id",
+	"ip_configuration.subnet_id",
+}
+
+// attributes referencing the network a resource is placed in, used if it isn't placed in a subnet
+var terraformNetworkAttributes = []string{
+	"vpc_id",
+	"network_interface.network",
+	"network",
+	"settings.ip_configuration.private_network",
+	"private_network",
+	"authorized_network",
+}
+
+// attributes referencing the (AWS) security groups a resource is attached to
+var terraformSecurityGroupAttributes = []string{
+	"vpc_security_group_ids",
+	"security_groups",
+	"security_group_ids",
+	"vpc_config.security_group_ids",
+	"network_configuration.security_groups",
+}
+
+// attributes indicating encryption at rest; true, non-empty strings (e.g. key ids) and non-empty blocks count as encrypted
+var terraformEncryptionAttributes = []string{
+	"storage_encrypted",
+	"encrypted",
+	"at_rest_encryption_enabled",
+	"kms_key_id",
+	"kms_key_arn",
+	"kms_master_key_id",
+	"sqs_managed_sse_enabled",
+	"server_side_encryption.enabled",
+	"server_side_encryption_configuration",
+	"encrypt_at_rest.enabled",
+	"encryption_info.encryption_at_rest_kms_key_arn",
+	"root_block_device.encrypted",
+	"encryption_type",
+	"transparent_data_encryption_enabled",
+	"infrastructure_encryption_enabled",
+	"customer_managed_key",
+	"disk_encryption_set_id",
+	"encryption.default_kms_key_name",
+	"encryption_key_name",
+	"disk_encryption_key",
+	"kms_key_name",
+	"database_encryption.state",
+}
+
+// resource types encrypting their data at rest unconditionally
+var terraformAlwaysEncrypted = []string{
+	"azurerm_storage_account",
+	"azurerm_cosmosdb_account",
+	"google_storage_bucket",
+	"google_sql_database_instance",
+	"google_spanner_instance",
+	"google_bigtable_instance",
+	"google_firestore_database",
+	"google_bigquery_dataset",
+}
+
+// attributes holding values other resources use to reference a resource
+var terraformIdentifyingAttributes = []string{
+	"id",
+	"arn",
+	"self_link",
+	"invoke_arn",
+	"qualified_arn",
+	"dns_name",
+	"bucket_regional_domain_name",
+	"bucket_domain_name",
+}
+
+var terraformIndex = regexp.MustCompile(`\[[^\]]*\]`)
+
+type terraformShow struct {
+	FormatVersion string                  `json:"format_version"`
+	PlannedValues *terraformValues        `json:"planned_values"`
+	Values        *terraformValues        `json:"values"`
+	Configuration *terraformConfiguration `json:"configuration"`
+}
+
+type terraformValues struct {
+	RootModule terraformModule `json:"root_module"`
+}
+
+type terraformModule struct {
+	Address      string               `json:"address"`
+	Resources    []*terraformResource `json:"resources"`
+	ChildModules []terraformModule    `json:"child_modules"`
+}
+
+type terraformResource struct {
+	Address      string         `json:"address"`
+	Mode         string         `json:"mode"`
+	Type         string         `json:"type"`
+	Name         string         `json:"name"`
+	ProviderName string         `json:"provider_name"`
+	Values       map[string]any `json:"values"`
+
+	module string
+}
+
+type terraformConfiguration struct {
+	RootModule terraformConfigurationModule `json:"root_module"`
+}
+
+type terraformConfigurationModule struct {
+	Resources []struct {
+		Address     string         `json:"address"`
+		Expressions map[string]any `json:"expressions"`
+	} `json:"resources"`
+	ModuleCalls map[string]struct {
+		Module terraformConfigurationModule `json:"module"`
+	} `json:"module_calls"`
+}
+
+// terraformIngress allows traffic from the given security groups (or tags) or the internet to a security group (or tag)
+type terraformIngress struct {
+	targets  []string
+	sources  []string
+	internet bool
+	port     int
+}
+
+type terraformImporter struct {
+	model *input.Model
+
+	resources   []*terraformResource
+	byValue     map[string]*terraformResource
+	byName      map[string]*terraformResource
+	expressions map[string]map[string]any
+
+	titles        map[string]string
+	accounts      map[string]map[string]bool
+	subnetMembers map[string][]string
+}
+
+// ImportTerraform creates a model from the output of "terraform show -json" for a plan or state, read from stdin for "-"
+func ImportTerraform(filename string) (*input.Model, error) {
+	var data []byte
+	var readError error
+	if filename == "-" {
+		data, readError = io.ReadAll(os.Stdin)
+	} else {
+		data, readError = os.ReadFile(filepath.Clean(filename))
+	}
+
+	if readError != nil {
+		return nil, fmt.Errorf("unable to read terraform json: %w", readError)
+	}
+
+	return importTerraform("Terraform", data)
+}
+
+func importTerraform(title string, data []byte) (*input.Model, error) {
+	var show terraformShow
+	unmarshalError := json.Unmarshal(data, &show)
+	if unmarshalError != nil {
+		return nil, fmt.Errorf("unable to parse terraform json: %w", unmarshalError)
+	}
+
+	values := show.PlannedValues
+	if values == nil {
+		values = show.Values
+	}
+
+	if values == nil {
+		return nil, fmt.Errorf("unable to parse terraform json: neither planned_values nor values found, expected output of \"terraform show -json\"")
+	}
+
+	what := &terraformImporter{
+		model:         newStubModel(title),
+		byValue:       make(map[string]*terraformResource),
+		byName:        make(map[string]*terraformResource),
+		expressions:   make(map[string]map[string]any),
+		titles:        make(map[string]string),
+		accounts:      make(map[string]map[string]bool),
+		subnetMembers: make(map[string][]string),
+	}
+	what.model.AppDescription.Description = "Imported from terraform"
+
+	what.addResources(values.RootModule)
+	if show.Configuration != nil {
+		what.addExpressions("", show.Configuration.RootModule)
+	}
+
+	what.importTechnicalAssets()
+	if len(what.model.TechnicalAssets) == 0 {
+		return nil, fmt.Errorf("no supported aws, azure or google resources found in terraform json")
+	}
+
+	what.importTrustBoundaries()
+	what.importSecurityGroups()
+	what.importFirewalls()
+	what.importNetworkSecurityGroups()
+	what.importLoadBalancers()
+	what.importIntegrations()
+
+	return what.model, nil
+}
+
+func (what *terraformImporter) addResources(module terraformModule) {
+	for _, resource := range module.Resources {
+		if resource.Mode != "managed" {
+			continue
+		}
+
+		resource.module = terraformIndex.ReplaceAllString(module.Address, "")
+		what.resources = append(what.resources, resource)
+
+		for _, attribute := range terraformIdentifyingAttributes {
+			if value, ok := resource.Values[attribute].(string); ok && len(value) > 0 {
+				what.byValue[value] = resource
+			}
+		}
+
+		// names are only used as reference if unique
+		if name, ok := resource.Values["name"].(string); ok && len(name) > 0 {
+			if _, exists := what.byName[name]; exists {
+				what.byName[name] = nil
+			} else {
+				what.byName[name] = resource
+			}
+		}
+	}
+
+	for _, child := range module.ChildModules {
+		what.addResources(child)
+	}
+}
+
+func (what *terraformImporter) addExpressions(module string, configuration terraformConfigurationModule) {
+	for _, resource := range configuration.Resources {
+		what.expressions[module+"|"+resource.Address] = resource.Expressions
+	}
+
+	for name, call := range configuration.ModuleCalls {
+		prefix := "module." + name
+		if len(module) > 0 {
+			prefix = module + "." + prefix
+		}
+
+		what.addExpressions(prefix, call.Module)
+	}
+}
+
+// references resolves the resources referenced by an attribute path, either via their values (state and known values of plans)
+// or via the configuration expressions (plans, where ids of resources yet to be created are unknown)
+func (what *terraformImporter) references(resource *terraformResource, path string) []*terraformResource {
+	result := make([]*terraformResource, 0)
+	add := func(referenced *terraformResource) {
+		if referenced == nil || referenced == resource {
+			return
+		}
+
+		for _, existing := range result {
+			if existing == referenced {
+				return
+			}
+		}
+
+		result = append(result, referenced)
+	}
+
+	for _, value := range lookupValues(resource.Values, path) {
+		if text, ok := value.(string); ok {
+			if referenced, ok := what.byValue[text]; ok {
+				add(referenced)
+			} else {
+				add(what.byName[text])
+			}
+		}
+	}
+
+	expressions := what.expressions[resource.module+"|"+resource.Type+"."+resource.Name]
+	for _, reference := range lookupReferences(expressions, path) {
+		for _, referenced := range what.referenced(resource.module, reference) {
+			add(referenced)
+		}
+	}
+
+	return result
+}
+
+// referenced resolves a configuration reference like "aws_subnet.private[0].id" within a module
+func (what *terraformImporter) referenced(module string, reference string) []*terraformResource {
+	parts := strings.Split(terraformIndex.ReplaceAllString(reference, ""), ".")
+	if len(parts) < 2 {
+		return nil
+	}
+
+	switch parts[0] {
+	case "var", "local", "each", "count", "path", "self", "module", "data", "terraform":
+		return nil
+	}
+
+	result := make([]*terraformResource, 0)
+	for _, resource := range what.resources {
+		if resource.module == module && resource.Type == parts[0] && resource.Name == parts[1] {
+			result = append(result, resource)
+		}
+	}
+
+	return result
+}
+
+func (what *terraformImporter) importTechnicalAssets() {
+	for _, resource := range what.resources {
+		kind, ok := terraformTechnicalAssets[resource.Type]
+		if !ok {
+			continue
+		}
+
+		title := resource.Address
+		technicalAsset := newTechnicalAsset(types.MakeID(title), terraformDescription(resource), kind.technology, kind.machine)
+		technicalAsset.Redundant = lookupTrue(resource.Values, "multi_az") || lookupTrue(resource.Values, "automatic_failover_enabled")
+		if terraformEncrypted(resource) {
+			technicalAsset.Encryption = types.Transparent.String()
+		}
+
+		what.titles[resource.Address] = title
+		what.model.TechnicalAssets[title] = technicalAsset
+	}
+
+	// encryption configured by separate resources
+	for _, resource := range what.resources {
+		if resource.Type == "aws_s3_bucket_server_side_encryption_configuration" {
+			for _, bucket := range what.references(resource, "bucket") {
+				what.update(bucket, func(technicalAsset *input.TechnicalAsset) {
+					technicalAsset.Encryption = types.Transparent.String()
+				})
+			}
+		}
+	}
+}
+
+// importTrustBoundaries nests subnets in networks in accounts (subscriptions, projects), placing each technical asset
+// in its first subnet, its network or its account
+func (what *terraformImporter) importTrustBoundaries() {
+	networkMembers := make(map[string][]string)
+	networkNested := make(map[string][]string)
+	accountMembers := make(map[string][]string)
+	accountNested := make(map[string][]string)
+	boundaryTitles := make(map[string]string)
+
+	for _, resource := range what.resources {
+		if kind, id := terraformAccount(resource); len(id) > 0 {
+			if what.accounts[kind] == nil {
+				what.accounts[kind] = make(map[string]bool)
+			}
+
+			what.accounts[kind][id] = true
+		}
+	}
+
+	for _, resource := range what.resources {
+		switch {
+		case contains(terraformNetworks, resource.Type):
+			boundaryTitles[resource.Address] = resource.Address
+			account := what.account(resource)
+			accountNested[account] = append(accountNested[account], types.MakeID(resource.Address))
+
+		case contains(terraformSubnets, resource.Type):
+			boundaryTitles[resource.Address] = resource.Address
+			networks := what.networks(resource, []string{"vpc_id", "virtual_network_name", "network"})
+			if len(networks) > 0 {
+				networkNested[networks[0].Address] = append(networkNested[networks[0].Address], types.MakeID(resource.Address))
+			} else {
+				account := what.account(resource)
+				accountNested[account] = append(accountNested[account], types.MakeID(resource.Address))
+			}
+		}
+	}
+
+	for _, resource := range what.resources {
+		title, ok := what.titles[resource.Address]
+		if !ok {
+			continue
+		}
+
+		id := what.model.TechnicalAssets[title].ID
+		if subnet := what.subnet(resource); subnet != nil {
+			what.subnetMembers[subnet.Address] = append(what.subnetMembers[subnet.Address], id)
+		} else if networks := what.networks(resource, terraformNetworkAttributes); len(networks) > 0 {
+			networkMembers[networks[0].Address] = append(networkMembers[networks[0].Address], id)
+		} else {
+			account := what.account(resource)
+			accountMembers[account] = append(accountMembers[account], id)
+		}
+	}
+
+	for _, resource := range what.resources {
+		title, ok := boundaryTitles[resource.Address]
+		if !ok {
+			continue
+		}
+
+		trustBoundary := input.TrustBoundary{
+			ID:          types.MakeID(resource.Address),
+			Description: terraformDescription(resource),
+			Type:        types.NetworkCloudProvider.String(),
+		}
+
+		if contains(terraformSubnets, resource.Type) {
+			trustBoundary.Type = types.NetworkCloudSecurityGroup.String()
+			trustBoundary.TechnicalAssetsInside = what.subnetMembers[resource.Address]
+		} else {
+			trustBoundary.TechnicalAssetsInside = networkMembers[resource.Address]
+			trustBoundary.TrustBoundariesNested = networkNested[resource.Address]
+		}
+
+		what.model.TrustBoundaries[title] = trustBoundary
+	}
+
+	accounts := make(map[string]bool)
+	for account := range accountMembers {
+		accounts[account] = true
+	}
+
+	for account := range accountNested {
+		accounts[account] = true
+	}
+
+	for _, account := range sortedKeys(accounts) {
+		what.model.TrustBoundaries[account] = input.TrustBoundary{
+			ID:                    types.MakeID(account),
+			Description:           account,
+			Type:                  types.NetworkCloudProvider.String(),
+			TechnicalAssetsInside: accountMembers[account],
+			TrustBoundariesNested: accountNested[account],
+		}
+	}
+}
+
+// account returns the title of the account trust boundary of a resource, resources not revealing their account (like
+// planned ones) are put into the only account of the same kind if there is exactly one
+func (what *terraformImporter) account(resource *terraformResource) string {
+	kind, id := terraformAccount(resource)
+	if len(id) == 0 && len(what.accounts[kind]) == 1 {
+		id = sortedKeys(what.accounts[kind])[0]
+	}
+
+	if len(id) == 0 {
+		return kind
+	}
+
+	return kind + " " + id
+}
+
+// subnet returns the first subnet a resource is placed in, following subnet groups and network interfaces
+func (what *terraformImporter) subnet(resource *terraformResource) *terraformResource {
+	for _, attribute := range terraformSubnetAttributes {
+		for _, referenced := range what.references(resource, attribute) {
+			if contains(terraformSubnets, referenced.Type) {
+				return referenced
+			}
+
+			if groupAttribute, ok := terraformSubnetGroups[referenced.Type]; ok {
+				for _, subnet := range what.references(referenced, groupAttribute) {
+					if contains(terraformSubnets, subnet.Type) {
+						return subnet
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (what *terraformImporter) networks(resource *terraformResource, attributes []string) []*terraformResource {
+	result := make([]*terraformResource, 0)
+	for _, attribute := range attributes {
+		for _, referenced := range what.references(resource, attribute) {
+			if contains(terraformNetworks, referenced.Type) {
+				result = append(result, referenced)
+			}
+		}
+	}
+
+	return result
+}
+
+// importSecurityGroups adds links between technical assets attached to AWS security groups allowing ingress from each other,
+// and from the internet to technical assets attached to security groups allowing ingress from anywhere
+func (what *terraformImporter) importSecurityGroups() {
+	ingresses := make([]terraformIngress, 0)
+	for _, resource := range what.resources {
+		switch resource.Type {
+		case "aws_security_group":
+			for _, rule := range lookupValues(resource.Values, "ingress") {
+				block, _ := rule.(map[string]any)
+				ingress := terraformIngress{targets: []string{resource.Address}, port: lookupPort(block, "from_port")}
+				ingress.internet = isAnywhere(lookupValues(block, "cidr_blocks")) || isAnywhere(lookupValues(block, "ipv6_cidr_blocks"))
+				for _, value := range lookupValues(block, "security_groups") {
+					if text, ok := value.(string); ok && what.byValue[text] != nil {
+						ingress.sources = append(ingress.sources, what.byValue[text].Address)
+					}
+				}
+
+				if lookupTrue(block, "self") {
+					ingress.sources = append(ingress.sources, resource.Address)
+				}
+
+				ingresses = append(ingresses, ingress)
+			}
+
+			// security groups referenced in rules of planned resources are only known from the configuration
+			expressions := what.expressions[resource.module+"|"+resource.Type+"."+resource.Name]
+			if blocks, ok := expressions["ingress"].([]any); ok {
+				for _, rule := range blocks {
+					block, _ := rule.(map[string]any)
+					ingress := terraformIngress{targets: []string{resource.Address}, port: lookupPort(lookupConstants(block), "from_port")}
+					for _, reference := range lookupReferences(block, "security_groups") {
+						for _, source := range what.referenced(resource.module, reference) {
+							ingress.sources = append(ingress.sources, source.Address)
+						}
+					}
+
+					ingresses = append(ingresses, ingress)
+				}
+			}
+
+		case "aws_security_group_rule":
+			if value, _ := resource.Values["type"].(string); value != "ingress" {
+				continue
+			}
+
+			ingress := terraformIngress{
+				targets:  addresses(what.references(resource, "security_group_id")),
+				sources:  addresses(what.references(resource, "source_security_group_id")),
+				internet: isAnywhere(lookupValues(resource.Values, "cidr_blocks")) || isAnywhere(lookupValues(resource.Values, "ipv6_cidr_blocks")),
+				port:     lookupPort(resource.Values, "from_port"),
+			}
+
+			if lookupTrue(resource.Values, "self") {
+				ingress.sources = append(ingress.sources, ingress.targets...)
+			}
+
+			ingresses = append(ingresses, ingress)
+
+		case "aws_vpc_security_group_ingress_rule":
+			ingresses = append(ingresses, terraformIngress{
+				targets:  addresses(what.references(resource, "security_group_id")),
+				sources:  addresses(what.references(resource, "referenced_security_group_id")),
+				internet: isAnywhere(lookupValues(resource.Values, "cidr_ipv4")) || isAnywhere(lookupValues(resource.Values, "cidr_ipv6")),
+				port:     lookupPort(resource.Values, "from_port"),
+			})
+		}
+	}
+
+	attached := make(map[string][]*terraformResource)
+	for _, resource := range what.resources {
+		if _, ok := what.titles[resource.Address]; !ok {
+			continue
+		}
+
+		for _, attribute := range terraformSecurityGroupAttributes {
+			for _, securityGroup := range what.references(resource, attribute) {
+				if securityGroup.Type == "aws_security_group" {
+					attached[securityGroup.Address] = append(attached[securityGroup.Address], resource)
+				}
+			}
+		}
+	}
+
+	for _, ingress := range ingresses {
+		what.addIngress(ingress, func(group string) []*terraformResource { return attached[group] })
+	}
+}
+
+// importFirewalls adds links for google firewall rules between instances with the source and target tags
+func (what *terraformImporter) importFirewalls() {
+	tagged := func(tag string) []*terraformResource {
+		result := make([]*terraformResource, 0)
+		for _, resource := range what.resources {
+			if _, ok := what.titles[resource.Address]; !ok {
+				continue
+			}
+
+			for _, value := range lookupValues(resource.Values, "tags") {
+				if value == tag || tag == "*" && resource.Type == "google_compute_instance" {
+					result = append(result, resource)
+					break
+				}
+			}
+		}
+
+		return result
+	}
+
+	for _, resource := range what.resources {
+		if resource.Type != "google_compute_firewall" {
+			continue
+		}
+
+		if direction, _ := resource.Values["direction"].(string); direction == "EGRESS" {
+			continue
+		}
+
+		ingress := terraformIngress{
+			targets:  lookupStrings(resource.Values, "target_tags"),
+			sources:  lookupStrings(resource.Values, "source_tags"),
+			internet: isAnywhere(lookupValues(resource.Values, "source_ranges")),
+		}
+
+		for _, port := range lookupValues(resource.Values, "allow.ports") {
+			if text, ok := port.(string); ok && ingress.port == 0 {
+				ingress.port = parsePort(text)
+			}
+		}
+
+		if len(ingress.targets) == 0 {
+			ingress.targets = []string{"*"}
+		}
+
+		what.addIngress(ingress, tagged)
+	}
+}
+
+// importNetworkSecurityGroups adds links from the internet to technical assets in subnets or with network interfaces
+// associated with azure network security groups allowing inbound traffic from the internet
+func (what *terraformImporter) importNetworkSecurityGroups() {
+	rules := make(map[string][]map[string]any)
+	for _, resource := range what.resources {
+		switch resource.Type {
+		case "azurerm_network_security_group":
+			for _, rule := range lookupValues(resource.Values, "security_rule") {
+				if block, ok := rule.(map[string]any); ok {
+					rules[resource.Address] = append(rules[resource.Address], block)
+				}
+			}
+
+		case "azurerm_network_security_rule":
+			for _, group := range what.references(resource, "network_security_group_name") {
+				rules[group.Address] = append(rules[group.Address], resource.Values)
+			}
+		}
+	}
+
+	associated := make(map[string][]*terraformResource)
+	for _, resource := range what.resources {
+		switch resource.Type {
+		case "azurerm_subnet_network_security_group_association":
+			for _, group := range what.references(resource, "network_security_group_id") {
+				for _, subnet := range what.references(resource, "subnet_id") {
+					for _, candidate := range what.resources {
+						if title, ok := what.titles[candidate.Address]; ok && contains(what.subnetMembers[subnet.Address], what.model.TechnicalAssets[title].ID) {
+							associated[group.Address] = append(associated[group.Address], candidate)
+						}
+					}
+				}
+			}
+
+		case "azurerm_network_interface_security_group_association":
+			for _, group := range what.references(resource, "network_security_group_id") {
+				for _, networkInterface := range what.references(resource, "network_interface_id") {
+					for _, candidate := range what.resources {
+						if _, ok := what.titles[candidate.Address]; !ok {
+							continue
+						}
+
+						for _, referenced := range what.references(candidate, "network_interface_ids") {
+							if referenced == networkInterface {
+								associated[group.Address] = append(associated[group.Address], candidate)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, group := range sortedKeys(rules) {
+		for _, rule := range rules[group] {
+			direction, _ := rule["direction"].(string)
+			access, _ := rule["access"].(string)
+			if !strings.EqualFold(direction, "Inbound") || !strings.EqualFold(access, "Allow") {
+				continue
+			}
+
+			sources := append(lookupValues(rule, "source_address_prefix"), lookupValues(rule, "source_address_prefixes")...)
+			port, _ := rule["destination_port_range"].(string)
+			what.addIngress(terraformIngress{targets: []string{group}, internet: isAnywhere(sources), port: parsePort(port)},
+				func(group string) []*terraformResource { return associated[group] })
+		}
+	}
+}
+
+func (what *terraformImporter) addIngress(ingress terraformIngress, members func(string) []*terraformResource) {
+	for _, target := range ingress.targets {
+		for _, targetResource := range members(target) {
+			if ingress.internet {
+				what.link(nil, targetResource, ingress.port, "Internet clients access "+targetResource.Address)
+			}
+
+			for _, source := range ingress.sources {
+				for _, sourceResource := range members(source) {
+					if sourceResource != targetResource {
+						what.link(sourceResource, targetResource, ingress.port, sourceResource.Address+" accesses "+targetResource.Address)
+					}
+				}
+			}
+		}
+	}
+}
+
+// importLoadBalancers adds links from AWS load balancers to their targets and from the internet to internet-facing load balancers
+func (what *terraformImporter) importLoadBalancers() {
+	targetGroups := make(map[*terraformResource][]*terraformResource)
+	for _, resource := range what.resources {
+		switch resource.Type {
+		case "aws_lb_target_group_attachment":
+			for _, targetGroup := range what.references(resource, "target_group_arn") {
+				targetGroups[targetGroup] = append(targetGroups[targetGroup], what.references(resource, "target_id")...)
+			}
+
+		case "aws_autoscaling_attachment":
+			for _, targetGroup := range what.references(resource, "lb_target_group_arn") {
+				targetGroups[targetGroup] = append(targetGroups[targetGroup], what.references(resource, "autoscaling_group_name")...)
+			}
+
+		case "aws_autoscaling_group":
+			for _, targetGroup := range what.references(resource, "target_group_arns") {
+				targetGroups[targetGroup] = append(targetGroups[targetGroup], resource)
+			}
+
+		case "aws_ecs_service":
+			for _, targetGroup := range what.references(resource, "load_balancer.target_group_arn") {
+				targetGroups[targetGroup] = append(targetGroups[targetGroup], resource)
+			}
+		}
+	}
+
+	exposed := make(map[*terraformResource]bool)
+	for _, resource := range what.resources {
+		var loadBalancers []*terraformResource
+		switch resource.Type {
+		case "aws_lb_listener":
+			loadBalancers = what.references(resource, "load_balancer_arn")
+
+		case "aws_lb_listener_rule":
+			for _, listener := range what.references(resource, "listener_arn") {
+				loadBalancers = append(loadBalancers, what.references(listener, "load_balancer_arn")...)
+			}
+
+		default:
+			continue
+		}
+
+		for _, loadBalancer := range loadBalancers {
+			if !lookupTrue(loadBalancer.Values, "internal") && resource.Type == "aws_lb_listener" {
+				exposed[loadBalancer] = true
+				what.link(nil, loadBalancer, lookupPort(resource.Values, "port"), "Internet clients access "+loadBalancer.Address)
+			}
+
+			groups := append(what.references(resource, "default_action.target_group_arn"), what.references(resource, "action.target_group_arn")...)
+			groups = append(groups, what.references(resource, "default_action.forward.target_group.arn")...)
+			for _, targetGroup := range groups {
+				for _, target := range targetGroups[targetGroup] {
+					what.link(loadBalancer, target, lookupPort(targetGroup.Values, "port"), loadBalancer.Address+" routes to "+target.Address)
+				}
+			}
+		}
+	}
+
+	for _, resource := range what.resources {
+		if (resource.Type == "aws_lb" || resource.Type == "aws_alb" || resource.Type == "aws_elb") && !exposed[resource] && !lookupTrue(resource.Values, "internal") {
+			what.link(nil, resource, 0, "Internet clients access "+resource.Address)
+		}
+
+		if strings.HasPrefix(resource.Type, "google_compute_") && strings.HasSuffix(resource.Type, "forwarding_rule") {
+			if scheme, _ := resource.Values["load_balancing_scheme"].(string); strings.HasPrefix(scheme, "EXTERNAL") {
+				port, _ := resource.Values["port_range"].(string)
+				what.link(nil, resource, parsePort(port), "Internet clients access "+resource.Address)
+			}
+		}
+	}
+}
+
+// importIntegrations adds links from API gateways and CDNs to their backends and from functions to their event sources
+func (what *terraformImporter) importIntegrations() {
+	for _, resource := range what.resources {
+		switch resource.Type {
+		case "aws_api_gateway_integration", "aws_apigatewayv2_integration":
+			apis := append(what.references(resource, "rest_api_id"), what.references(resource, "api_id")...)
+			backends := append(what.references(resource, "uri"), what.references(resource, "integration_uri")...)
+			for _, api := range apis {
+				for _, backend := range backends {
+					what.link(api, backend, 443, api.Address+" integrates "+backend.Address)
+				}
+			}
+
+		case "aws_api_gateway_rest_api", "aws_apigatewayv2_api":
+			private := false
+			for _, endpointType := range lookupValues(resource.Values, "endpoint_configuration.types") {
+				private = private || endpointType == "PRIVATE"
+			}
+
+			if !private {
+				what.link(nil, resource, 443, "Internet clients access "+resource.Address)
+			}
+
+		case "aws_cloudfront_distribution":
+			what.link(nil, resource, 443, "Internet clients access "+resource.Address)
+			for _, origin := range what.references(resource, "origin.domain_name") {
+				what.link(resource, origin, 443, resource.Address+" fetches from origin "+origin.Address)
+			}
+
+		case "aws_lambda_event_source_mapping":
+			for _, function := range what.references(resource, "function_name") {
+				for _, source := range what.references(resource, "event_source_arn") {
+					what.link(function, source, 443, function.Address+" consumes events of "+source.Address)
+				}
+			}
+		}
+	}
+}
+
+// link adds a communication link between the technical assets of two resources, from the internet clients if source is nil
+func (what *terraformImporter) link(source *terraformResource, target *terraformResource, port int, description string) {
+	targetTitle, ok := what.titles[target.Address]
+	if !ok {
+		return
+	}
+
+	var sourceTitle string
+	if source == nil {
+		sourceTitle = addInternetClients(what.model, "Clients accessing internet-facing resources")
+	} else if sourceTitle, ok = what.titles[source.Address]; !ok {
+		return
+	}
+
+	targetAsset := what.model.TechnicalAssets[targetTitle]
+	ports := make([]int, 0)
+	if port > 0 {
+		ports = append(ports, port)
+	}
+
+	protocol := protocolForPorts(ports, targetAsset.Technologies[0])
+	addCommunicationLink(what.model, sourceTitle, "Access "+targetTitle, newCommunicationLink(targetAsset.ID, description, protocol))
+}
+
+func (what *terraformImporter) update(resource *terraformResource, change func(*input.TechnicalAsset)) {
+	title, ok := what.titles[resource.Address]
+	if !ok {
+		return
+	}
+
+	technicalAsset := what.model.TechnicalAssets[title]
+	change(&technicalAsset)
+	what.model.TechnicalAssets[title] = technicalAsset
+}
+
+func terraformDescription(resource *terraformResource) string {
+	for _, attribute := range []string{"name", "identifier", "cluster_identifier", "bucket", "function_name", "replication_group_id", "domain_name"} {
+		if name, ok := resource.Values[attribute].(string); ok && len(name) > 0 {
+			return resource.Type + " " + name
+		}
+	}
+
+	return resource.Type
+}
+
+// terraformAccount returns the kind and id (if known) of the account (AWS), subscription (Azure) or project (Google) of a resource
+func terraformAccount(resource *terraformResource) (string, string) {
+	switch {
+	case strings.HasPrefix(resource.Type, "aws_"):
+		if arn, ok := resource.Values["arn"].(string); ok {
+			if parts := strings.Split(arn, ":"); len(parts) > 4 && len(parts[4]) > 0 {
+				return "AWS account", parts[4]
+			}
+		}
+
+		owner, _ := resource.Values["owner_id"].(string)
+		return "AWS account", owner
+
+	case strings.HasPrefix(resource.Type, "azurerm_"):
+		if id, ok := resource.Values["id"].(string); ok {
+			if parts := strings.Split(id, "/"); len(parts) > 2 && strings.EqualFold(parts[1], "subscriptions") {
+				return "Azure subscription", parts[2]
+			}
+		}
+
+		return "Azure subscription", ""
+
+	case strings.HasPrefix(resource.Type, "google_"):
+		project, _ := resource.Values["project"].(string)
+		return "Google project", project
+
+	default:
+		return "Cloud account", ""
+	}
+}
+
+func terraformEncrypted(resource *terraformResource) bool {
+	if contains(terraformAlwaysEncrypted, resource.Type) {
+		return true
+	}
+
+	for _, attribute := range terraformEncryptionAttributes {
+		for _, value := range lookupValues(resource.Values, attribute) {
+			switch typed := value.(type) {
+			case bool:
+				if typed {
+					return true
+				}
+
+			case string:
+				switch strings.ToLower(typed) {
+				case "", "none", "false", "disabled", "decrypted":
+
+				default:
+					return true
+				}
+
+			case map[string]any:
+				if len(typed) > 0 {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// lookupValues returns the values at a dotted path, descending into nested blocks and flattening lists
+func lookupValues(values any, path string) []any {
+	switch typed := values.(type) {
+	case []any:
+		result := make([]any, 0)
+		for _, item := range typed {
+			result = append(result, lookupValues(item, path)...)
+		}
+
+		return result
+
+	case map[string]any:
+		head, tail, nested := strings.Cut(path, ".")
+		value, ok := typed[head]
+		if !ok || value == nil {
+			return nil
+		}
+
+		if nested {
+			return lookupValues(value, tail)
+		}
+
+		if list, ok := value.([]any); ok {
+			return list
+		}
+
+		return []any{value}
+
+	default:
+		return nil
+	}
+}
+
+// lookupReferences returns the references of the configuration expression at a dotted path
+func lookupReferences(expressions any, path string) []string {
+	result := make([]string, 0)
+	for _, expression := range lookupValues(expressions, path) {
+		if block, ok := expression.(map[string]any); ok {
+			for _, reference := range lookupValues(block, "references") {
+				if text, ok := reference.(string); ok {
+					result = append(result, text)
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// lookupConstants returns the constant values of the configuration expressions of a block
+func lookupConstants(expressions map[string]any) map[string]any {
+	result := make(map[string]any)
+	for key, expression := range expressions {
+		if block, ok := expression.(map[string]any); ok {
+			result[key] = block["constant_value"]
+		}
+	}
+
+	return result
+}
+
+func lookupStrings(values map[string]any, path string) []string {
+	result := make([]string, 0)
+	for _, value := range lookupValues(values, path) {
+		if text, ok := value.(string); ok && len(text) > 0 {
+			result = append(result, text)
+		}
+	}
+
+	return result
+}
+
+func lookupTrue(values map[string]any, path string) bool {
+	for _, value := range lookupValues(values, path) {
+		if value == true {
+			return true
+		}
+	}
+
+	return false
+}
+
+func lookupPort(values map[string]any, path string) int {
+	for _, value := range lookupValues(values, path) {
+		switch typed := value.(type) {
+		case float64:
+			if typed > 0 {
+				return int(typed)
+			}
+
+		case string:
+			return parsePort(typed)
+		}
+	}
+
+	return 0
+}
+
+func isAnywhere(values []any) bool {
+	for _, value := range values {
+		switch value {
+		case "0.0.0.0/0", "::/0", "*", "Internet", "internet", "Any", "any":
+			return true
+		}
+	}
+
+	return false
+}
+
+func addresses(resources []*terraformResource) []string {
+	result := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		result = append(result, resource.Address)
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+
+	return false
+}
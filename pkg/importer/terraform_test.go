@@ -0,0 +1,125 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+// planned resources lack ids, references are resolved via the configuration
+const shopPlan = `{
+  "format_version": "1.2",
+  "planned_values": {"root_module": {
+    "resources": [
+      {"address": "aws_vpc.main", "mode": "managed", "type": "aws_vpc", "name": "main", "values": {"cidr_block": "10.0.0.0/16"}},
+      {"address": "aws_subnet.app", "mode": "managed", "type": "aws_subnet", "name": "app", "values": {"cidr_block": "10.0.1.0/24"}},
+      {"address": "aws_subnet.data", "mode": "managed", "type": "aws_subnet", "name": "data", "values": {"cidr_block": "10.0.2.0/24"}},
+      {"address": "aws_security_group.lb", "mode": "managed", "type": "aws_security_group", "name": "lb", "values": {
+        "ingress": [{"from_port": 443, "to_port": 443, "protocol": "tcp", "cidr_blocks": ["0.0.0.0/0"], "security_groups": []}]}},
+      {"address": "aws_security_group.app", "mode": "managed", "type": "aws_security_group", "name": "app", "values": {}},
+      {"address": "aws_security_group.db", "mode": "managed", "type": "aws_security_group", "name": "db", "values": {}},
+      {"address": "aws_lb.web", "mode": "managed", "type": "aws_lb", "name": "web", "values": {"name": "web", "internal": false}},
+      {"address": "aws_instance.app", "mode": "managed", "type": "aws_instance", "name": "app", "values": {"root_block_device": [{"encrypted": false}]}},
+      {"address": "aws_db_instance.shop", "mode": "managed", "type": "aws_db_instance", "name": "shop", "values": {
+        "identifier": "shop", "multi_az": true, "storage_encrypted": true}},
+      {"address": "aws_security_group_rule.db_from_app", "mode": "managed", "type": "aws_security_group_rule", "name": "db_from_app", "values": {
+        "type": "ingress", "from_port": 5432, "to_port": 5432}}
+    ],
+    "child_modules": [{"address": "module.assets", "resources": [
+      {"address": "module.assets.aws_s3_bucket.this", "mode": "managed", "type": "aws_s3_bucket", "name": "this", "values": {"bucket": "shop-assets"}}
+    ]}]
+  }},
+  "configuration": {"root_module": {
+    "resources": [
+      {"address": "aws_subnet.app", "expressions": {"vpc_id": {"references": ["aws_vpc.main.id", "aws_vpc.main"]}}},
+      {"address": "aws_subnet.data", "expressions": {"vpc_id": {"references": ["aws_vpc.main.id", "aws_vpc.main"]}}},
+      {"address": "aws_security_group.app", "expressions": {"ingress": [
+        {"from_port": {"constant_value": 8080}, "security_groups": {"references": ["aws_security_group.lb.id", "aws_security_group.lb"]}}]}},
+      {"address": "aws_lb.web", "expressions": {
+        "subnets": {"references": ["aws_subnet.app.id", "aws_subnet.app"]},
+        "security_groups": {"references": ["aws_security_group.lb.id", "aws_security_group.lb"]}}},
+      {"address": "aws_instance.app", "expressions": {
+        "subnet_id": {"references": ["aws_subnet.app.id", "aws_subnet.app"]},
+        "vpc_security_group_ids": {"references": ["aws_security_group.app.id", "aws_security_group.app"]}}},
+      {"address": "aws_db_instance.shop", "expressions": {
+        "vpc_security_group_ids": {"references": ["aws_security_group.db.id", "aws_security_group.db"]},
+        "db_subnet_group_name": {"references": ["aws_db_subnet_group.shop.name", "aws_db_subnet_group.shop"]}}},
+      {"address": "aws_security_group_rule.db_from_app", "expressions": {
+        "security_group_id": {"references": ["aws_security_group.db.id", "aws_security_group.db"]},
+        "source_security_group_id": {"references": ["aws_security_group.app.id", "aws_security_group.app"]}}}
+    ],
+    "module_calls": {"assets": {"module": {"resources": [
+      {"address": "aws_s3_bucket.this", "expressions": {"bucket": {"constant_value": "shop-assets"}}}
+    ]}}}
+  }}
+}`
+
+// resources of a state reference each other by id
+const shopState = `{
+  "format_version": "1.0",
+  "values": {"root_module": {"resources": [
+    {"address": "google_compute_network.vpc", "mode": "managed", "type": "google_compute_network", "name": "vpc", "values": {
+      "project": "shop", "self_link": "https://www.googleapis.com/compute/v1/projects/shop/global/networks/vpc"}},
+    {"address": "google_compute_subnetwork.app", "mode": "managed", "type": "google_compute_subnetwork", "name": "app", "values": {
+      "project": "shop", "network": "https://www.googleapis.com/compute/v1/projects/shop/global/networks/vpc",
+      "self_link": "https://www.googleapis.com/compute/v1/projects/shop/regions/europe-west1/subnetworks/app"}},
+    {"address": "google_compute_instance.web", "mode": "managed", "type": "google_compute_instance", "name": "web", "values": {
+      "project": "shop", "name": "web", "tags": ["web"],
+      "network_interface": [{"subnetwork": "https://www.googleapis.com/compute/v1/projects/shop/regions/europe-west1/subnetworks/app"}]}},
+    {"address": "google_compute_firewall.web", "mode": "managed", "type": "google_compute_firewall", "name": "web", "values": {
+      "project": "shop", "direction": "INGRESS", "source_ranges": ["0.0.0.0/0"], "target_tags": ["web"], "allow": [{"protocol": "tcp", "ports": ["443"]}]}},
+    {"address": "google_storage_bucket.media", "mode": "managed", "type": "google_storage_bucket", "name": "media", "values": {"project": "shop", "name": "media"}},
+    {"address": "data.google_project.current", "mode": "data", "type": "google_project", "name": "current", "values": {}}
+  ]}}
+}`
+
+func TestImportTerraformMapsPlannedResources(t *testing.T) {
+	model, err := importTerraform("shop", []byte(shopPlan))
+	assert.NoError(t, err)
+
+	database := model.TechnicalAssets["aws_db_instance.shop"]
+	assert.Equal(t, types.Database, database.Technologies[0])
+	assert.Equal(t, types.Datastore.String(), database.Type)
+	assert.Equal(t, types.Transparent.String(), database.Encryption)
+	assert.True(t, database.Redundant)
+	assert.Equal(t, types.NoneEncryption.String(), model.TechnicalAssets["aws_instance.app"].Encryption)
+	assert.Equal(t, types.FileServer, model.TechnicalAssets["module.assets.aws_s3_bucket.this"].Technologies[0])
+
+	assert.Equal(t, types.HTTPS.String(), model.TechnicalAssets[internetClientsTitle].CommunicationLinks["Access aws_lb.web"].Protocol)
+	assert.Equal(t, types.HTTP.String(), model.TechnicalAssets["aws_lb.web"].CommunicationLinks["Access aws_instance.app"].Protocol)
+	assert.Equal(t, types.SqlAccessProtocol.String(), model.TechnicalAssets["aws_instance.app"].CommunicationLinks["Access aws_db_instance.shop"].Protocol)
+}
+
+func TestImportTerraformMapsNetworksToTrustBoundaries(t *testing.T) {
+	model, err := importTerraform("shop", []byte(shopPlan))
+	assert.NoError(t, err)
+
+	account := model.TrustBoundaries["AWS account"]
+	assert.Equal(t, types.NetworkCloudProvider.String(), account.Type)
+	assert.Equal(t, []string{"aws-vpc-main"}, account.TrustBoundariesNested)
+	assert.Equal(t, []string{"aws-db-instance-shop", "module-assets-aws-s3-bucket-this"}, account.TechnicalAssetsInside)
+
+	assert.Equal(t, []string{"aws-subnet-app", "aws-subnet-data"}, model.TrustBoundaries["aws_vpc.main"].TrustBoundariesNested)
+	subnet := model.TrustBoundaries["aws_subnet.app"]
+	assert.Equal(t, types.NetworkCloudSecurityGroup.String(), subnet.Type)
+	assert.Equal(t, []string{"aws-lb-web", "aws-instance-app"}, subnet.TechnicalAssetsInside)
+}
+
+func TestImportTerraformMapsState(t *testing.T) {
+	model, err := importTerraform("shop", []byte(shopState))
+	assert.NoError(t, err)
+
+	assert.Equal(t, types.Transparent.String(), model.TechnicalAssets["google_storage_bucket.media"].Encryption)
+	assert.Equal(t, []string{"google-compute-instance-web"}, model.TrustBoundaries["google_compute_subnetwork.app"].TechnicalAssetsInside)
+	assert.Equal(t, []string{"google-compute-network-vpc"}, model.TrustBoundaries["Google project shop"].TrustBoundariesNested)
+	assert.Equal(t, types.HTTPS.String(), model.TechnicalAssets[internetClientsTitle].CommunicationLinks["Access google_compute_instance.web"].Protocol)
+}
+
+func TestImportTerraformRequiresShowJson(t *testing.T) {
+	_, err := importTerraform("empty", []byte(`{"format_version": "1.0"}`))
+	assert.Error(t, err)
+
+	_, err = importTerraform("empty", []byte(`{"format_version": "1.0", "values": {"root_module": {}}}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,228 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+type threatDragonModel struct {
+	Version string `json:"version"`
+	Summary struct {
+		Title       string `json:"title"`
+		Owner       string `json:"owner"`
+		Description string `json:"description"`
+	} `json:"summary"`
+	Detail struct {
+		Diagrams []struct {
+			Title string              `json:"title"`
+			Cells []*threatDragonCell `json:"cells"`
+
+			// Threat Dragon v1 models keep the cells in a nested diagram
+			DiagramJson json.RawMessage `json:"diagramJson"`
+		} `json:"diagrams"`
+	} `json:"detail"`
+}
+
+type threatDragonCell struct {
+	ID       string `json:"id"`
+	Shape    string `json:"shape"`
+	Position struct {
+		X float64 `json:"x"`
+		Y float64 `json:"y"`
+	} `json:"position"`
+	Size struct {
+		Width  float64 `json:"width"`
+		Height float64 `json:"height"`
+	} `json:"size"`
+	Source struct {
+		Cell string `json:"cell"`
+	} `json:"source"`
+	Target struct {
+		Cell string `json:"cell"`
+	} `json:"target"`
+	Data struct {
+		Type                   string `json:"type"`
+		Name                   string `json:"name"`
+		Description            string `json:"description"`
+		OutOfScope             bool   `json:"outOfScope"`
+		ReasonOutOfScope       string `json:"reasonOutOfScope"`
+		ProvidesAuthentication bool   `json:"providesAuthentication"`
+		IsWebApplication       bool   `json:"isWebApplication"`
+		IsALog                 bool   `json:"isALog"`
+		StoresCredentials      bool   `json:"storesCredentials"`
+		IsEncrypted            bool   `json:"isEncrypted"`
+		Protocol               string `json:"protocol"`
+		Threats                []struct {
+			Title       string `json:"title"`
+			Type        string `json:"type"`
+			Status      string `json:"status"`
+			Severity    string `json:"severity"`
+			Description string `json:"description"`
+			Mitigation  string `json:"mitigation"`
+		} `json:"threats"`
+	} `json:"data"`
+}
+
+// ImportThreatDragon creates a model from an OWASP Threat Dragon v2 model (json): actors, processes and stores become
+// technical assets, flows communication links, boundary boxes trust boundaries and the threats custom risk categories
+func ImportThreatDragon(filename string) (*input.Model, error) {
+	data, readError := os.ReadFile(filepath.Clean(filename))
+	if readError != nil {
+		return nil, fmt.Errorf("unable to read threat dragon model: %w", readError)
+	}
+
+	return importThreatDragon(data)
+}
+
+func importThreatDragon(data []byte) (*input.Model, error) {
+	var threatDragon threatDragonModel
+	unmarshalError := json.Unmarshal(data, &threatDragon)
+	if unmarshalError != nil {
+		return nil, fmt.Errorf("unable to parse threat dragon model: %w", unmarshalError)
+	}
+
+	if len(threatDragon.Version) == 0 || strings.HasPrefix(threatDragon.Version, "1.") {
+		for _, diagram := range threatDragon.Detail.Diagrams {
+			if len(diagram.DiagramJson) > 0 {
+				return nil, fmt.Errorf("unable to import threat dragon v1 model: only v2 models are supported, open and save it with threat dragon v2 to convert it")
+			}
+		}
+	}
+
+	title := strings.TrimSpace(threatDragon.Summary.Title)
+	if len(title) == 0 {
+		title = "Threat Dragon"
+	}
+
+	description := strings.TrimSpace(threatDragon.Summary.Description)
+	if len(description) == 0 {
+		description = "Imported from threat dragon"
+	}
+
+	guesser, guesserError := newTechnologyGuesser()
+	if guesserError != nil {
+		return nil, guesserError
+	}
+
+	importer := newDiagramImporter(title, description, threatDragon.Summary.Owner, "threat-dragon")
+	elements := 0
+	for _, threatDragonDiagram := range threatDragon.Detail.Diagrams {
+		converted := new(diagram)
+		for _, cell := range threatDragonDiagram.Cells {
+			shape := diagramShape{x: cell.Position.X, y: cell.Position.Y, width: cell.Size.Width, height: cell.Size.Height}
+			threats := make([]diagramThreat, 0)
+			for _, threat := range cell.Data.Threats {
+				threats = append(threats, diagramThreat{
+					title:       threat.Title,
+					description: threat.Description,
+					mitigation:  threat.Mitigation,
+					stride:      threat.Type,
+					severity:    threat.Severity,
+					status:      threat.Status,
+				})
+			}
+
+			element := &diagramElement{
+				diagramShape:  shape,
+				id:            cell.ID,
+				name:          cell.Data.Name,
+				description:   cell.Data.Description,
+				outOfScope:    cell.Data.OutOfScope,
+				justification: cell.Data.ReasonOutOfScope,
+				threats:       threats,
+			}
+
+			switch cell.Data.Type {
+			case "tm.Actor":
+				element.kind = diagramActor
+				element.technology = guessDiagramTechnology(guesser, cell.Data.Name, types.ClientSystem)
+				if cell.Data.ProvidesAuthentication {
+					element.technology = types.IdentityProvider
+				} else if isHumanActor(cell.Data.Name) {
+					element.technology = types.Browser
+				}
+
+				element.human = element.technology == types.Browser
+				converted.elements = append(converted.elements, element)
+
+			case "tm.Process":
+				element.kind = diagramProcess
+				element.technology = guessDiagramTechnology(guesser, cell.Data.Name, types.UnknownTechnology)
+				if cell.Data.IsWebApplication && element.technology == types.UnknownTechnology {
+					element.technology = types.WebApplication
+				}
+
+				converted.elements = append(converted.elements, element)
+
+			case "tm.Store":
+				element.kind = diagramStore
+				element.technology = guessDiagramTechnology(guesser, cell.Data.Name, types.Database)
+				if cell.Data.StoresCredentials {
+					element.technology = types.IdentityStoreDatabase
+				} else if cell.Data.IsALog {
+					element.technology = types.LocalFileSystem
+				}
+
+				element.encrypted = cell.Data.IsEncrypted
+				converted.elements = append(converted.elements, element)
+
+			case "tm.Flow":
+				converted.flows = append(converted.flows, &diagramFlow{
+					id:          cell.ID,
+					name:        cell.Data.Name,
+					description: cell.Data.Description,
+					source:      cell.Source.Cell,
+					target:      cell.Target.Cell,
+					protocol:    diagramProtocol(cell.Data.Protocol, cell.Data.IsEncrypted),
+					threats:     threats,
+				})
+
+			case "tm.BoundaryBox":
+				converted.boundaries = append(converted.boundaries, &diagramBoundary{
+					diagramShape: shape,
+					id:           cell.ID,
+					name:         cell.Data.Name,
+					description:  cell.Data.Description,
+				})
+			}
+		}
+
+		elements += len(converted.elements)
+		importer.add(converted)
+	}
+
+	if elements == 0 {
+		return nil, fmt.Errorf("no actors, processes or stores found in threat dragon model")
+	}
+
+	return importer.model, nil
+}
+
+// guessDiagramTechnology guesses the technology from the name of a diagram element, keeping the default for generic names
+func guessDiagramTechnology(guesser *technologyGuesser, name string, defaultTechnology string) string {
+	for _, word := range strings.Fields(name) {
+		if technology := guesser.guess(word); technology != types.UnknownTechnology {
+			return technology
+		}
+	}
+
+	return defaultTechnology
+}
+
+// isHumanActor checks if the name of an actor denotes people rather than systems
+func isHumanActor(name string) bool {
+	for _, word := range strings.Fields(strings.ToLower(name)) {
+		switch strings.TrimSuffix(word, "s") {
+		case "user", "customer", "admin", "administrator", "operator", "employee", "person", "visitor", "member", "staff":
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+const shopThreatDragon = `{
+  "version": "2.2.0",
+  "summary": {"title": "Web Shop", "owner": "Shop Team", "description": "Online shop"},
+  "detail": {"diagrams": [{
+    "title": "Main",
+    "cells": [
+      {"id": "b1", "shape": "trust-boundary-box", "position": {"x": 200, "y": 0}, "size": {"width": 600, "height": 400},
+        "data": {"type": "tm.BoundaryBox", "name": "Data Center"}},
+      {"id": "b2", "shape": "trust-boundary-box", "position": {"x": 500, "y": 50}, "size": {"width": 250, "height": 300},
+        "data": {"type": "tm.BoundaryBox", "name": "Data Zone"}},
+      {"id": "a1", "shape": "actor", "position": {"x": 10, "y": 100}, "size": {"width": 100, "height": 60},
+        "data": {"type": "tm.Actor", "name": "Customer"}},
+      {"id": "p1", "shape": "process", "position": {"x": 300, "y": 100}, "size": {"width": 80, "height": 80},
+        "data": {"type": "tm.Process", "name": "Shop", "isWebApplication": true, "threats": [
+          {"title": "Session hijacking", "type": "Spoofing", "status": "Mitigated", "severity": "High", "description": "Stolen session cookies", "mitigation": "Secure cookies"},
+          {"title": "Price manipulation", "type": "Tampering", "status": "Open", "severity": "Medium"}
+        ]}},
+      {"id": "s1", "shape": "store", "position": {"x": 600, "y": 100}, "size": {"width": 80, "height": 40},
+        "data": {"type": "tm.Store", "name": "Orders", "isEncrypted": true}},
+      {"id": "f1", "shape": "flow", "source": {"cell": "a1"}, "target": {"cell": "p1"},
+        "data": {"type": "tm.Flow", "name": "Browse", "protocol": "HTTPS", "isEncrypted": true}},
+      {"id": "f2", "shape": "flow", "source": {"cell": "p1"}, "target": {"cell": "s1"},
+        "data": {"type": "tm.Flow", "name": "Store order", "protocol": "SQL", "threats": [
+          {"title": "SQL injection", "type": "Tampering", "status": "NotApplicable", "severity": "High"}
+        ]}},
+      {"id": "f3", "shape": "flow", "source": {"cell": "p1"}, "target": {"cell": "s1"}, "data": {"type": "tm.Flow", "name": "Read order"}},
+      {"id": "c1", "shape": "trust-boundary-curve", "source": {"x": 150, "y": 0}, "target": {"x": 150, "y": 400}, "data": {"type": "tm.Boundary"}}
+    ]
+  }]}
+}`
+
+func TestImportThreatDragonMapsElements(t *testing.T) {
+	model, err := importThreatDragon([]byte(shopThreatDragon))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Web Shop", model.Title)
+	assert.Equal(t, types.ExternalEntity.String(), model.TechnicalAssets["Customer"].Type)
+	assert.True(t, model.TechnicalAssets["Customer"].UsedAsClientByHuman)
+	assert.Equal(t, types.WebApplication, model.TechnicalAssets["Shop"].Technologies[0])
+	assert.Equal(t, types.Datastore.String(), model.TechnicalAssets["Orders"].Type)
+	assert.Equal(t, types.Transparent.String(), model.TechnicalAssets["Orders"].Encryption)
+
+	assert.Equal(t, types.HTTPS.String(), model.TechnicalAssets["Customer"].CommunicationLinks["Browse"].Protocol)
+	assert.Len(t, model.TechnicalAssets["Shop"].CommunicationLinks, 1)
+	assert.Equal(t, types.SqlAccessProtocol.String(), model.TechnicalAssets["Shop"].CommunicationLinks["Store order"].Protocol)
+
+	assert.Equal(t, []string{"shop"}, model.TrustBoundaries["Data Center"].TechnicalAssetsInside)
+	assert.Equal(t, []string{"data-zone"}, model.TrustBoundaries["Data Center"].TrustBoundariesNested)
+	assert.Equal(t, []string{"orders"}, model.TrustBoundaries["Data Zone"].TechnicalAssetsInside)
+}
+
+func TestImportThreatDragonMapsThreats(t *testing.T) {
+	model, err := importThreatDragon([]byte(shopThreatDragon))
+	assert.NoError(t, err)
+	assert.Len(t, model.CustomRiskCategories, 3)
+
+	category := model.CustomRiskCategories[0]
+	assert.Equal(t, "threat-dragon-session-hijacking", category.ID)
+	assert.Equal(t, types.Spoofing.String(), category.STRIDE)
+	assert.Equal(t, "Secure cookies", category.Mitigation)
+	risk := category.RisksIdentified["<b>Session hijacking</b> at <b>Shop</b>"]
+	assert.Equal(t, types.HighSeverity.String(), risk.Severity)
+	assert.Equal(t, "shop", risk.MostRelevantTechnicalAsset)
+
+	injection := model.CustomRiskCategories[2].RisksIdentified["<b>SQL injection</b> at <b>Store order</b>"]
+	assert.Equal(t, "shop>store-order", injection.MostRelevantCommunicationLink)
+
+	assert.Equal(t, types.Mitigated.String(), model.RiskTracking["threat-dragon-session-hijacking@shop"].Status)
+	assert.Equal(t, "Secure cookies", model.RiskTracking["threat-dragon-session-hijacking@shop"].Justification)
+	assert.Equal(t, types.FalsePositive.String(), model.RiskTracking["threat-dragon-sql-injection@shop>store-order"].Status)
+	assert.NotContains(t, model.RiskTracking, "threat-dragon-price-manipulation@shop")
+}
+
+func TestImportThreatDragonRejectsV1Models(t *testing.T) {
+	_, err := importThreatDragon([]byte(`{"summary": {"title": "Old"}, "detail": {"diagrams": [{"diagramJson": {"cells": []}}]}}`))
+	assert.ErrorContains(t, err, "only v2 models are supported")
+}
@@ -0,0 +1,334 @@
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+// technologies of the stencils of the default (TMCore) template, by the last part of their type id
+var tm7Technologies = map[string]string{
+	"WebServer":       types.WebServer,
+	"WebApp":          types.WebApplication,
+	"WebSvc":          types.WebServiceREST,
+	"ThickClient":     types.Desktop,
+	"WinApp":          types.Desktop,
+	"ThinClient":      types.Browser,
+	"BrowserClient":   types.Browser,
+	"Browser":         types.Browser,
+	"HumanUser":       types.Browser,
+	"MobileClient":    types.MobileApp,
+	"AuthProvider":    types.IdentityProvider,
+	"SQL":             types.Database,
+	"SQLDatabase":     types.Database,
+	"NonRelational":   types.Database,
+	"NoSQL":           types.Database,
+	"Cache":           types.Database,
+	"FS":              types.FileServer,
+	"CloudStorage":    types.FileServer,
+	"ConfigFile":      types.LocalFileSystem,
+	"Registry":        types.LocalFileSystem,
+	"HTML5LS":         types.LocalFileSystem,
+	"CookieStore":     types.LocalFileSystem,
+	"DeviceStorage":   types.LocalFileSystem,
+	"Plugin":          types.Library,
+	"KernelThread":    types.UnknownTechnology,
+	"Win32Service":    types.UnknownTechnology,
+	"ManagedApp":      types.UnknownTechnology,
+	"VirtualMachine":  types.UnknownTechnology,
+	"AzureAD":         types.IdentityProvider,
+	"AzureSQLDB":      types.Database,
+	"AzureStorage":    types.FileServer,
+	"AzureKeyVault":   types.Vault,
+	"AzureServiceBus": types.MessageQueue,
+}
+
+var tm7Placeholder = regexp.MustCompile(`\{(source|target|flow)\.Name\}`)
+
+type tm7Model struct {
+	XMLName  xml.Name     `xml:"ThreatModel"`
+	Surfaces []tm7Surface `xml:"DrawingSurfaceList>DrawingSurfaceModel"`
+	Meta     struct {
+		ThreatModelName            string `xml:"ThreatModelName"`
+		Owner                      string `xml:"Owner"`
+		HighLevelSystemDescription string `xml:"HighLevelSystemDescription"`
+	} `xml:"MetaInformation"`
+	ThreatInstances struct {
+		Entries []struct {
+			Value tm7Threat `xml:"Value"`
+		} `xml:",any"`
+	} `xml:"ThreatInstances"`
+	KnowledgeBase struct {
+		ThreatCategories []struct {
+			Id   string `xml:"Id"`
+			Name string `xml:"Name"`
+		} `xml:"ThreatCategories>ThreatCategory"`
+		ThreatTypes []struct {
+			Id          string `xml:"Id"`
+			ShortTitle  string `xml:"ShortTitle"`
+			Category    string `xml:"Category"`
+			Description string `xml:"Description"`
+		} `xml:"ThreatTypes>ThreatType"`
+	} `xml:"KnowledgeBase"`
+}
+
+type tm7Surface struct {
+	Header  string       `xml:"Header"`
+	Borders []tm7Element `xml:"Borders>KeyValueOfguidanyType>Value"`
+	Lines   []tm7Element `xml:"Lines>KeyValueOfguidanyType>Value"`
+}
+
+type tm7Element struct {
+	GenericTypeId string        `xml:"GenericTypeId"`
+	Guid          string        `xml:"Guid"`
+	TypeId        string        `xml:"TypeId"`
+	Properties    []tm7Property `xml:"Properties>anyType"`
+	Left          float64       `xml:"Left"`
+	Top           float64       `xml:"Top"`
+	Width         float64       `xml:"Width"`
+	Height        float64       `xml:"Height"`
+	SourceGuid    string        `xml:"SourceGuid"`
+	TargetGuid    string        `xml:"TargetGuid"`
+}
+
+// tm7Property is a display attribute, either with a plain value or a list of values and the selected index
+type tm7Property struct {
+	DisplayName string `xml:"DisplayName"`
+	Value       struct {
+		Text  string   `xml:",chardata"`
+		Items []string `xml:"string"`
+	} `xml:"Value"`
+	SelectedIndex int `xml:"SelectedIndex"`
+}
+
+type tm7Threat struct {
+	FlowGuid   string `xml:"FlowGuid"`
+	SourceGuid string `xml:"SourceGuid"`
+	TargetGuid string `xml:"TargetGuid"`
+	State      string `xml:"State"`
+	Priority   string `xml:"Priority"`
+	TypeId     string `xml:"TypeId"`
+	Properties []struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	} `xml:"Properties>KeyValueOfstringstring"`
+}
+
+// ImportTM7 creates a model from a Microsoft Threat Modeling Tool model (.tm7): processes, data stores and external
+// interactors become technical assets, data flows communication links, border boundaries trust boundaries and the
+// generated or custom threats custom risk categories (one per threat type) with their state as risk tracking
+func ImportTM7(filename string) (*input.Model, error) {
+	data, readError := os.ReadFile(filepath.Clean(filename))
+	if readError != nil {
+		return nil, fmt.Errorf("unable to read threat modeling tool model: %w", readError)
+	}
+
+	return importTM7(data)
+}
+
+func importTM7(data []byte) (*input.Model, error) {
+	var tm7 tm7Model
+	unmarshalError := xml.Unmarshal(data, &tm7)
+	if unmarshalError != nil {
+		return nil, fmt.Errorf("unable to parse threat modeling tool model: %w", unmarshalError)
+	}
+
+	title := strings.TrimSpace(tm7.Meta.ThreatModelName)
+	if len(title) == 0 {
+		title = "Threat Modeling Tool"
+	}
+
+	description := strings.TrimSpace(tm7.Meta.HighLevelSystemDescription)
+	if len(description) == 0 {
+		description = "Imported from threat modeling tool"
+	}
+
+	guesser, guesserError := newTechnologyGuesser()
+	if guesserError != nil {
+		return nil, guesserError
+	}
+
+	threatsByElement := tm7.threats()
+	importer := newDiagramImporter(title, description, strings.TrimSpace(tm7.Meta.Owner), "tmt")
+	elements := 0
+	for _, surface := range tm7.Surfaces {
+		converted := new(diagram)
+		for _, border := range surface.Borders {
+			shape := diagramShape{x: border.Left, y: border.Top, width: border.Width, height: border.Height}
+			if border.GenericTypeId == "GE.TB.B" {
+				converted.boundaries = append(converted.boundaries, &diagramBoundary{diagramShape: shape, id: border.Guid, name: border.property("Name")})
+				continue
+			}
+
+			element := &diagramElement{
+				diagramShape:  shape,
+				id:            border.Guid,
+				name:          border.property("Name"),
+				outOfScope:    tm7True(border.property("Out Of Scope")),
+				justification: border.property("Reason For Out Of Scope"),
+				threats:       threatsByElement[border.Guid],
+			}
+
+			switch border.GenericTypeId {
+			case "GE.P":
+				element.kind = diagramProcess
+				element.technology = tm7Technology(guesser, border, types.UnknownTechnology)
+
+			case "GE.DS":
+				element.kind = diagramStore
+				element.technology = tm7Technology(guesser, border, types.Database)
+				element.encrypted = tm7True(border.property("Encrypted"))
+
+			case "GE.EI":
+				element.kind = diagramActor
+				element.technology = tm7Technology(guesser, border, types.ClientSystem)
+				element.human = element.technology == types.Browser || isHumanActor(element.name)
+				if element.human {
+					element.technology = types.Browser
+				}
+
+			default:
+				continue
+			}
+
+			converted.elements = append(converted.elements, element)
+		}
+
+		for _, line := range surface.Lines {
+			if line.GenericTypeId != "GE.DF" {
+				continue
+			}
+
+			converted.flows = append(converted.flows, &diagramFlow{
+				id:       line.Guid,
+				name:     line.property("Name"),
+				source:   line.SourceGuid,
+				target:   line.TargetGuid,
+				protocol: diagramProtocol(line.TypeId, false),
+				threats:  threatsByElement[line.Guid],
+			})
+		}
+
+		elements += len(converted.elements)
+		importer.add(converted)
+	}
+
+	if elements == 0 {
+		return nil, fmt.Errorf("no processes, data stores or external interactors found in threat modeling tool model")
+	}
+
+	return importer.model, nil
+}
+
+// threats returns the threat instances by the flow (or, without flow, the target) they were generated for
+func (what *tm7Model) threats() map[string][]diagramThreat {
+	categories := make(map[string]string)
+	for _, category := range what.KnowledgeBase.ThreatCategories {
+		categories[category.Id] = category.Name
+	}
+
+	threatTypes := make(map[string]int)
+	for n, threatType := range what.KnowledgeBase.ThreatTypes {
+		threatTypes[threatType.Id] = n
+	}
+
+	result := make(map[string][]diagramThreat)
+	for _, entry := range what.ThreatInstances.Entries {
+		instance := entry.Value
+		properties := make(map[string]string)
+		for _, property := range instance.Properties {
+			properties[property.Key] = strings.TrimSpace(property.Value)
+		}
+
+		threat := diagramThreat{
+			title:         properties["Title"],
+			description:   properties["UserThreatDescription"],
+			stride:        properties["UserThreatCategory"],
+			severity:      instance.Priority,
+			status:        instance.State,
+			justification: properties["StateInformation"],
+		}
+
+		if len(threat.severity) == 0 {
+			threat.severity = properties["Priority"]
+		}
+
+		if n, ok := threatTypes[instance.TypeId]; ok {
+			threatType := what.KnowledgeBase.ThreatTypes[n]
+			threat.category = strings.TrimSpace(tm7Placeholder.ReplaceAllString(threatType.ShortTitle, "$1"))
+			if len(threat.stride) == 0 {
+				threat.stride = categories[threatType.Category]
+			}
+
+			if len(threat.description) == 0 {
+				threat.description = tm7Placeholder.ReplaceAllString(threatType.Description, "$1")
+			}
+		}
+
+		if len(threat.title) == 0 {
+			threat.title = threat.category
+		}
+
+		if len(threat.title) == 0 {
+			continue
+		}
+
+		element := instance.FlowGuid
+		if len(element) == 0 {
+			element = instance.TargetGuid
+		}
+
+		result[element] = append(result[element], threat)
+	}
+
+	return result
+}
+
+func (what *tm7Element) property(displayName string) string {
+	for _, property := range what.Properties {
+		if !strings.EqualFold(property.DisplayName, displayName) {
+			continue
+		}
+
+		if len(property.Value.Items) > 0 {
+			if property.SelectedIndex >= 0 && property.SelectedIndex < len(property.Value.Items) {
+				return strings.TrimSpace(property.Value.Items[property.SelectedIndex])
+			}
+
+			return ""
+		}
+
+		return strings.TrimSpace(property.Value.Text)
+	}
+
+	return ""
+}
+
+func tm7Technology(guesser *technologyGuesser, element tm7Element, defaultTechnology string) string {
+	typeId := element.TypeId
+	if index := strings.LastIndex(typeId, "."); index >= 0 {
+		typeId = typeId[index+1:]
+	}
+
+	if technology, ok := tm7Technologies[typeId]; ok && technology != types.UnknownTechnology {
+		return technology
+	}
+
+	return guessDiagramTechnology(guesser, element.property("Name"), defaultTechnology)
+}
+
+func tm7True(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "yes":
+		return true
+
+	default:
+		return false
+	}
+}
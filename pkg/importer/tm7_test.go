@@ -0,0 +1,206 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+const shopTM7 = `<ThreatModel xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.Model" xmlns:i="http://www.w3.org/2001/XMLSchema-instance">
+  <DrawingSurfaceList>
+    <DrawingSurfaceModel xmlns:z="http://schemas.microsoft.com/2003/10/Serialization/" z:Id="i1">
+      <GenericTypeId xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">DRAWINGSURFACE</GenericTypeId>
+      <Borders xmlns:a="http://schemas.microsoft.com/2003/10/Serialization/Arrays">
+        <a:KeyValueOfguidanyType>
+          <a:Key>11111111-0000-0000-0000-000000000001</a:Key>
+          <a:Value z:Id="i2" i:type="BorderBoundary">
+            <GenericTypeId xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">GE.TB.B</GenericTypeId>
+            <Guid xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">11111111-0000-0000-0000-000000000001</Guid>
+            <Properties xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">
+              <a:anyType xmlns:b="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase.Attributes" i:type="b:StringDisplayAttribute">
+                <b:DisplayName>Name</b:DisplayName><b:Name/><b:Value xmlns:c="http://www.w3.org/2001/XMLSchema" i:type="c:string">Azure Subscription</b:Value>
+              </a:anyType>
+            </Properties>
+            <TypeId xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">SE.TB.TMCore.AzureTrustBoundary</TypeId>
+            <Height>400</Height><Left>300</Left><Top>0</Top><Width>500</Width>
+          </a:Value>
+        </a:KeyValueOfguidanyType>
+        <a:KeyValueOfguidanyType>
+          <a:Key>22222222-0000-0000-0000-000000000001</a:Key>
+          <a:Value z:Id="i3" i:type="StencilEllipse">
+            <GenericTypeId xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">GE.EI</GenericTypeId>
+            <Guid xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">22222222-0000-0000-0000-000000000001</Guid>
+            <Properties xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">
+              <a:anyType xmlns:b="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase.Attributes" i:type="b:StringDisplayAttribute">
+                <b:DisplayName>Name</b:DisplayName><b:Name/><b:Value xmlns:c="http://www.w3.org/2001/XMLSchema" i:type="c:string">Shopper</b:Value>
+              </a:anyType>
+            </Properties>
+            <TypeId xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">SE.EI.TMCore.Browser</TypeId>
+            <Height>100</Height><Left>10</Left><Top>100</Top><Width>100</Width>
+          </a:Value>
+        </a:KeyValueOfguidanyType>
+        <a:KeyValueOfguidanyType>
+          <a:Key>22222222-0000-0000-0000-000000000002</a:Key>
+          <a:Value z:Id="i4" i:type="StencilEllipse">
+            <GenericTypeId xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">GE.P</GenericTypeId>
+            <Guid xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">22222222-0000-0000-0000-000000000002</Guid>
+            <Properties xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">
+              <a:anyType xmlns:b="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase.Attributes" i:type="b:StringDisplayAttribute">
+                <b:DisplayName>Name</b:DisplayName><b:Name/><b:Value xmlns:c="http://www.w3.org/2001/XMLSchema" i:type="c:string">Shop Frontend</b:Value>
+              </a:anyType>
+              <a:anyType xmlns:b="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase.Attributes" i:type="b:BooleanDisplayAttribute">
+                <b:DisplayName>Out Of Scope</b:DisplayName><b:Name>71f3d9aa-b8ef-4e54-8126-607a1d903103</b:Name><b:Value xmlns:c="http://www.w3.org/2001/XMLSchema" i:type="c:boolean">false</b:Value>
+              </a:anyType>
+            </Properties>
+            <TypeId xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">SE.P.TMCore.WebApp</TypeId>
+            <Height>100</Height><Left>400</Left><Top>100</Top><Width>100</Width>
+          </a:Value>
+        </a:KeyValueOfguidanyType>
+        <a:KeyValueOfguidanyType>
+          <a:Key>22222222-0000-0000-0000-000000000003</a:Key>
+          <a:Value z:Id="i5" i:type="StencilParallelLines">
+            <GenericTypeId xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">GE.DS</GenericTypeId>
+            <Guid xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">22222222-0000-0000-0000-000000000003</Guid>
+            <Properties xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">
+              <a:anyType xmlns:b="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase.Attributes" i:type="b:StringDisplayAttribute">
+                <b:DisplayName>Name</b:DisplayName><b:Name/><b:Value xmlns:c="http://www.w3.org/2001/XMLSchema" i:type="c:string">Catalog</b:Value>
+              </a:anyType>
+              <a:anyType xmlns:b="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase.Attributes" i:type="b:ListDisplayAttribute">
+                <b:DisplayName>Encrypted</b:DisplayName><b:Name>encrypted</b:Name>
+                <b:Value i:type="a:ArrayOfstring"><a:string>No</a:string><a:string>Yes</a:string></b:Value>
+                <b:SelectedIndex>1</b:SelectedIndex>
+              </a:anyType>
+            </Properties>
+            <TypeId xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">SE.DS.TMCore.SQL</TypeId>
+            <Height>100</Height><Left>600</Left><Top>100</Top><Width>100</Width>
+          </a:Value>
+        </a:KeyValueOfguidanyType>
+      </Borders>
+      <Header>Diagram 1</Header>
+      <Lines xmlns:a="http://schemas.microsoft.com/2003/10/Serialization/Arrays">
+        <a:KeyValueOfguidanyType>
+          <a:Key>33333333-0000-0000-0000-000000000001</a:Key>
+          <a:Value z:Id="i6" i:type="Connector">
+            <GenericTypeId xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">GE.DF</GenericTypeId>
+            <Guid xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">33333333-0000-0000-0000-000000000001</Guid>
+            <Properties xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">
+              <a:anyType xmlns:b="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase.Attributes" i:type="b:StringDisplayAttribute">
+                <b:DisplayName>Name</b:DisplayName><b:Name/><b:Value xmlns:c="http://www.w3.org/2001/XMLSchema" i:type="c:string">Browse catalog</b:Value>
+              </a:anyType>
+            </Properties>
+            <TypeId xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">SE.DF.TMCore.HTTPS</TypeId>
+            <SourceGuid>22222222-0000-0000-0000-000000000001</SourceGuid>
+            <TargetGuid>22222222-0000-0000-0000-000000000002</TargetGuid>
+          </a:Value>
+        </a:KeyValueOfguidanyType>
+        <a:KeyValueOfguidanyType>
+          <a:Key>33333333-0000-0000-0000-000000000002</a:Key>
+          <a:Value z:Id="i7" i:type="Connector">
+            <GenericTypeId xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">GE.DF</GenericTypeId>
+            <Guid xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">33333333-0000-0000-0000-000000000002</Guid>
+            <Properties xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase"/>
+            <TypeId xmlns="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">SE.DF.TMCore.SQL</TypeId>
+            <SourceGuid>22222222-0000-0000-0000-000000000002</SourceGuid>
+            <TargetGuid>22222222-0000-0000-0000-000000000003</TargetGuid>
+          </a:Value>
+        </a:KeyValueOfguidanyType>
+      </Lines>
+    </DrawingSurfaceModel>
+  </DrawingSurfaceList>
+  <MetaInformation>
+    <Assumptions/><Contributors/><ExternalDependencies/>
+    <HighLevelSystemDescription>Catalog of the web shop</HighLevelSystemDescription>
+    <Owner>Shop Team</Owner><Reviewer/>
+    <ThreatModelName>Shop Catalog</ThreatModelName>
+  </MetaInformation>
+  <ThreatInstances xmlns:a="http://schemas.microsoft.com/2003/10/Serialization/Arrays">
+    <a:KeyValueOfstringThreatpc_P0_PhOB>
+      <a:Key>T1-key</a:Key>
+      <a:Value xmlns:b="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">
+        <b:FlowGuid>33333333-0000-0000-0000-000000000001</b:FlowGuid>
+        <b:Id>1</b:Id>
+        <b:Priority>High</b:Priority>
+        <b:Properties>
+          <a:KeyValueOfstringstring><a:Key>Title</a:Key><a:Value>Spoofing of the Shop Frontend Process</a:Value></a:KeyValueOfstringstring>
+          <a:KeyValueOfstringstring><a:Key>UserThreatCategory</a:Key><a:Value>Spoofing</a:Value></a:KeyValueOfstringstring>
+          <a:KeyValueOfstringstring><a:Key>StateInformation</a:Key><a:Value>Azure AD login enforced</a:Value></a:KeyValueOfstringstring>
+        </b:Properties>
+        <b:SourceGuid>22222222-0000-0000-0000-000000000001</b:SourceGuid>
+        <b:State>Mitigated</b:State>
+        <b:TargetGuid>22222222-0000-0000-0000-000000000002</b:TargetGuid>
+        <b:TypeId>T1</b:TypeId>
+      </a:Value>
+    </a:KeyValueOfstringThreatpc_P0_PhOB>
+    <a:KeyValueOfstringThreatpc_P0_PhOB>
+      <a:Key>T2-key</a:Key>
+      <a:Value xmlns:b="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">
+        <b:FlowGuid>33333333-0000-0000-0000-000000000002</b:FlowGuid>
+        <b:Id>2</b:Id>
+        <b:Properties>
+          <a:KeyValueOfstringstring><a:Key>Title</a:Key><a:Value>Catalog data tampering</a:Value></a:KeyValueOfstringstring>
+          <a:KeyValueOfstringstring><a:Key>Priority</a:Key><a:Value>Low</a:Value></a:KeyValueOfstringstring>
+        </b:Properties>
+        <b:State>NeedsInvestigation</b:State>
+        <b:TypeId>T2</b:TypeId>
+      </a:Value>
+    </a:KeyValueOfstringThreatpc_P0_PhOB>
+  </ThreatInstances>
+  <KnowledgeBase xmlns:a="http://schemas.datacontract.org/2004/07/ThreatModeling.KnowledgeBase">
+    <a:ThreatCategories>
+      <a:ThreatCategory><a:Id>S</a:Id><a:Name>Spoofing</a:Name></a:ThreatCategory>
+      <a:ThreatCategory><a:Id>T</a:Id><a:Name>Tampering</a:Name></a:ThreatCategory>
+    </a:ThreatCategories>
+    <a:ThreatTypes>
+      <a:ThreatType><a:Id>T1</a:Id><a:ShortTitle>Spoofing of the {target.Name} Process</a:ShortTitle><a:Category>S</a:Category><a:Description>{source.Name} may be spoofed</a:Description></a:ThreatType>
+      <a:ThreatType><a:Id>T2</a:Id><a:ShortTitle>Data store tampering</a:ShortTitle><a:Category>T</a:Category><a:Description>Data may be altered</a:Description></a:ThreatType>
+    </a:ThreatTypes>
+  </KnowledgeBase>
+</ThreatModel>`
+
+func TestImportTM7MapsElements(t *testing.T) {
+	model, err := importTM7([]byte(shopTM7))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Shop Catalog", model.Title)
+	assert.Equal(t, "Shop Team", model.Author.Name)
+	assert.Equal(t, types.Browser, model.TechnicalAssets["Shopper"].Technologies[0])
+	assert.True(t, model.TechnicalAssets["Shopper"].UsedAsClientByHuman)
+	assert.Equal(t, types.WebApplication, model.TechnicalAssets["Shop Frontend"].Technologies[0])
+	assert.False(t, model.TechnicalAssets["Shop Frontend"].OutOfScope)
+	assert.Equal(t, types.Database, model.TechnicalAssets["Catalog"].Technologies[0])
+	assert.Equal(t, types.Transparent.String(), model.TechnicalAssets["Catalog"].Encryption)
+
+	assert.Equal(t, types.HTTPS.String(), model.TechnicalAssets["Shopper"].CommunicationLinks["Browse catalog"].Protocol)
+	assert.Equal(t, types.SqlAccessProtocol.String(), model.TechnicalAssets["Shop Frontend"].CommunicationLinks["Flow to Catalog"].Protocol)
+
+	subscription := model.TrustBoundaries["Azure Subscription"]
+	assert.Equal(t, types.NetworkCloudProvider.String(), subscription.Type)
+	assert.Equal(t, []string{"shop-frontend", "catalog"}, subscription.TechnicalAssetsInside)
+}
+
+func TestImportTM7MapsThreats(t *testing.T) {
+	model, err := importTM7([]byte(shopTM7))
+	assert.NoError(t, err)
+	assert.Len(t, model.CustomRiskCategories, 2)
+
+	spoofing := model.CustomRiskCategories[0]
+	assert.Equal(t, "tmt-spoofing-of-the-target-process", spoofing.ID)
+	assert.Equal(t, types.Spoofing.String(), spoofing.STRIDE)
+	assert.Equal(t, "source may be spoofed", spoofing.Description)
+	risk := spoofing.RisksIdentified["<b>Spoofing of the Shop Frontend Process</b> at <b>Browse catalog</b>"]
+	assert.Equal(t, types.HighSeverity.String(), risk.Severity)
+	assert.Equal(t, "shopper>browse-catalog", risk.MostRelevantCommunicationLink)
+
+	tampering := model.CustomRiskCategories[1]
+	assert.Equal(t, types.Tampering.String(), tampering.STRIDE)
+	assert.Equal(t, types.LowSeverity.String(), tampering.RisksIdentified["<b>Catalog data tampering</b> at <b>Flow to Catalog</b>"].Severity)
+
+	assert.Equal(t, "Azure AD login enforced", model.RiskTracking["tmt-spoofing-of-the-target-process@shopper>browse-catalog"].Justification)
+	assert.Equal(t, types.InDiscussion.String(), model.RiskTracking["tmt-data-store-tampering@shop-frontend>flow-to-catalog"].Status)
+}
+
+func TestImportTM7RequiresElements(t *testing.T) {
+	_, err := importTM7([]byte(`<ThreatModel><DrawingSurfaceList/></ThreatModel>`))
+	assert.Error(t, err)
+}
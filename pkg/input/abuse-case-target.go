@@ -0,0 +1,34 @@
+package input
+
+import "fmt"
+
+// AbuseCaseTarget references the data assets and technical assets an abuse case is after, which turns the abuse case
+// into an attack tree
+type AbuseCaseTarget struct {
+	DataAssets      []string `yaml:"data_assets,omitempty" json:"data_assets,omitempty"`
+	TechnicalAssets []string `yaml:"technical_assets,omitempty" json:"technical_assets,omitempty"`
+}
+
+func (what *AbuseCaseTarget) Merge(other AbuseCaseTarget) error {
+	what.DataAssets = new(Strings).MergeUniqueSlice(what.DataAssets, other.DataAssets)
+	what.TechnicalAssets = new(Strings).MergeUniqueSlice(what.TechnicalAssets, other.TechnicalAssets)
+	return nil
+}
+
+func (what *AbuseCaseTarget) MergeMap(first map[string]AbuseCaseTarget, second map[string]AbuseCaseTarget) (map[string]AbuseCaseTarget, error) {
+	for mapKey, mapValue := range second {
+		mapItem, ok := first[mapKey]
+		if ok {
+			mergeError := mapItem.Merge(mapValue)
+			if mergeError != nil {
+				return first, fmt.Errorf("failed to merge abuse case target %q: %w", mapKey, mergeError)
+			}
+
+			first[mapKey] = mapItem
+		} else {
+			first[mapKey] = mapValue
+		}
+	}
+
+	return first, nil
+}
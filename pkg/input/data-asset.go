@@ -14,6 +14,8 @@ type DataAsset struct {
 	Integrity              string   `yaml:"integrity,omitempty" json:"integrity,omitempty"`
 	Availability           string   `yaml:"availability,omitempty" json:"availability,omitempty"`
 	JustificationCiaRating string   `yaml:"justification_cia_rating,omitempty" json:"justification_cia_rating,omitempty"`
+	RecordValue            float64  `yaml:"record_value,omitempty" json:"record_value,omitempty"`
+	BreachCostPerRecord    float64  `yaml:"breach_cost_per_record,omitempty" json:"breach_cost_per_record,omitempty"`
 }
 
 func (what *DataAsset) Merge(other DataAsset) error {
@@ -67,6 +69,14 @@ func (what *DataAsset) Merge(other DataAsset) error {
 
 	what.JustificationCiaRating = new(Strings).MergeMultiline(what.JustificationCiaRating, other.JustificationCiaRating)
 
+	if what.RecordValue == 0 {
+		what.RecordValue = other.RecordValue
+	}
+
+	if what.BreachCostPerRecord == 0 {
+		what.BreachCostPerRecord = other.BreachCostPerRecord
+	}
+
 	return nil
 }
 
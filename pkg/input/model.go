@@ -21,46 +21,51 @@ import (
 // === Model Type Stuff ======================================
 
 type Model struct { // TODO: Eventually remove this and directly use ParsedModelRoot? But then the error messages for model errors are not quite as good anymore...
-	ThreagileVersion                              string                    `yaml:"threagile_version,omitempty" json:"threagile_version,omitempty"`
-	Includes                                      []string                  `yaml:"includes,omitempty" json:"includes,omitempty"`
-	Title                                         string                    `yaml:"title,omitempty" json:"title,omitempty"`
-	Author                                        Author                    `yaml:"author,omitempty" json:"author,omitempty"`
-	Contributors                                  []Author                  `yaml:"contributors,omitempty" json:"contributors,omitempty"`
-	Date                                          string                    `yaml:"date,omitempty" json:"date,omitempty"`
-	AppDescription                                Overview                  `yaml:"application_description,omitempty" json:"application_description,omitempty"`
-	BusinessOverview                              Overview                  `yaml:"business_overview,omitempty" json:"business_overview,omitempty"`
-	TechnicalOverview                             Overview                  `yaml:"technical_overview,omitempty" json:"technical_overview,omitempty"`
-	BusinessCriticality                           string                    `yaml:"business_criticality,omitempty" json:"business_criticality,omitempty"`
-	ManagementSummaryComment                      string                    `yaml:"management_summary_comment,omitempty" json:"management_summary_comment,omitempty"`
-	SecurityRequirements                          map[string]string         `yaml:"security_requirements,omitempty" json:"security_requirements,omitempty"`
-	Questions                                     map[string]string         `yaml:"questions,omitempty" json:"questions,omitempty"`
-	AbuseCases                                    map[string]string         `yaml:"abuse_cases,omitempty" json:"abuse_cases,omitempty"`
-	TagsAvailable                                 []string                  `yaml:"tags_available,omitempty" json:"tags_available,omitempty"`
-	DataAssets                                    map[string]DataAsset      `yaml:"data_assets,omitempty" json:"data_assets,omitempty"`
-	TechnicalAssets                               map[string]TechnicalAsset `yaml:"technical_assets,omitempty" json:"technical_assets,omitempty"`
-	TrustBoundaries                               map[string]TrustBoundary  `yaml:"trust_boundaries,omitempty" json:"trust_boundaries,omitempty"`
-	SharedRuntimes                                map[string]SharedRuntime  `yaml:"shared_runtimes,omitempty" json:"shared_runtimes,omitempty"`
-	CustomRiskCategories                          RiskCategories            `yaml:"custom_risk_categories,omitempty" json:"custom_risk_categories,omitempty"`
-	RiskTracking                                  map[string]RiskTracking   `yaml:"risk_tracking,omitempty" json:"risk_tracking,omitempty"`
-	DiagramTweakNodesep                           int                       `yaml:"diagram_tweak_nodesep,omitempty" json:"diagram_tweak_nodesep,omitempty"`
-	DiagramTweakRanksep                           int                       `yaml:"diagram_tweak_ranksep,omitempty" json:"diagram_tweak_ranksep,omitempty"`
-	DiagramTweakEdgeLayout                        string                    `yaml:"diagram_tweak_edge_layout,omitempty" json:"diagram_tweak_edge_layout,omitempty"`
-	DiagramTweakSuppressEdgeLabels                bool                      `yaml:"diagram_tweak_suppress_edge_labels,omitempty" json:"diagram_tweak_suppress_edge_labels,omitempty"`
-	DiagramTweakLayoutLeftToRight                 bool                      `yaml:"diagram_tweak_layout_left_to_right,omitempty" json:"diagram_tweak_layout_left_to_right,omitempty"`
-	DiagramTweakInvisibleConnectionsBetweenAssets []string                  `yaml:"diagram_tweak_invisible_connections_between_assets,omitempty" json:"diagram_tweak_invisible_connections_between_assets,omitempty"`
-	DiagramTweakSameRankAssets                    []string                  `yaml:"diagram_tweak_same_rank_assets,omitempty" json:"diagram_tweak_same_rank_assets,omitempty"`
+	ThreagileVersion                              string                     `yaml:"threagile_version,omitempty" json:"threagile_version,omitempty"`
+	Includes                                      []string                   `yaml:"includes,omitempty" json:"includes,omitempty"`
+	Title                                         string                     `yaml:"title,omitempty" json:"title,omitempty"`
+	Author                                        Author                     `yaml:"author,omitempty" json:"author,omitempty"`
+	Contributors                                  []Author                   `yaml:"contributors,omitempty" json:"contributors,omitempty"`
+	Date                                          string                     `yaml:"date,omitempty" json:"date,omitempty"`
+	AppDescription                                Overview                   `yaml:"application_description,omitempty" json:"application_description,omitempty"`
+	BusinessOverview                              Overview                   `yaml:"business_overview,omitempty" json:"business_overview,omitempty"`
+	TechnicalOverview                             Overview                   `yaml:"technical_overview,omitempty" json:"technical_overview,omitempty"`
+	BusinessCriticality                           string                     `yaml:"business_criticality,omitempty" json:"business_criticality,omitempty"`
+	Currency                                      string                     `yaml:"currency,omitempty" json:"currency,omitempty"`
+	ManagementSummaryComment                      string                     `yaml:"management_summary_comment,omitempty" json:"management_summary_comment,omitempty"`
+	SecurityRequirements                          map[string]string          `yaml:"security_requirements,omitempty" json:"security_requirements,omitempty"`
+	Questions                                     map[string]string          `yaml:"questions,omitempty" json:"questions,omitempty"`
+	AbuseCases                                    map[string]string          `yaml:"abuse_cases,omitempty" json:"abuse_cases,omitempty"`
+	AbuseCaseTargets                              map[string]AbuseCaseTarget `yaml:"abuse_case_targets,omitempty" json:"abuse_case_targets,omitempty"`
+	TagsAvailable                                 []string                   `yaml:"tags_available,omitempty" json:"tags_available,omitempty"`
+	DataAssets                                    map[string]DataAsset       `yaml:"data_assets,omitempty" json:"data_assets,omitempty"`
+	TechnicalAssets                               map[string]TechnicalAsset  `yaml:"technical_assets,omitempty" json:"technical_assets,omitempty"`
+	TrustBoundaries                               map[string]TrustBoundary   `yaml:"trust_boundaries,omitempty" json:"trust_boundaries,omitempty"`
+	SharedRuntimes                                map[string]SharedRuntime   `yaml:"shared_runtimes,omitempty" json:"shared_runtimes,omitempty"`
+	CustomRiskCategories                          RiskCategories             `yaml:"custom_risk_categories,omitempty" json:"custom_risk_categories,omitempty"`
+	ScriptRiskRules                               map[string]ScriptRiskRule  `yaml:"script_risk_rules,omitempty" json:"script_risk_rules,omitempty"`
+	RiskTracking                                  map[string]RiskTracking    `yaml:"risk_tracking,omitempty" json:"risk_tracking,omitempty"`
+	DiagramTweakNodesep                           int                        `yaml:"diagram_tweak_nodesep,omitempty" json:"diagram_tweak_nodesep,omitempty"`
+	DiagramTweakRanksep                           int                        `yaml:"diagram_tweak_ranksep,omitempty" json:"diagram_tweak_ranksep,omitempty"`
+	DiagramTweakEdgeLayout                        string                     `yaml:"diagram_tweak_edge_layout,omitempty" json:"diagram_tweak_edge_layout,omitempty"`
+	DiagramTweakSuppressEdgeLabels                bool                       `yaml:"diagram_tweak_suppress_edge_labels,omitempty" json:"diagram_tweak_suppress_edge_labels,omitempty"`
+	DiagramTweakLayoutLeftToRight                 bool                       `yaml:"diagram_tweak_layout_left_to_right,omitempty" json:"diagram_tweak_layout_left_to_right,omitempty"`
+	DiagramTweakInvisibleConnectionsBetweenAssets []string                   `yaml:"diagram_tweak_invisible_connections_between_assets,omitempty" json:"diagram_tweak_invisible_connections_between_assets,omitempty"`
+	DiagramTweakSameRankAssets                    []string                   `yaml:"diagram_tweak_same_rank_assets,omitempty" json:"diagram_tweak_same_rank_assets,omitempty"`
 }
 
 func (model *Model) Defaults() *Model {
 	*model = Model{
 		Questions:            make(map[string]string),
 		AbuseCases:           make(map[string]string),
+		AbuseCaseTargets:     make(map[string]AbuseCaseTarget),
 		SecurityRequirements: make(map[string]string),
 		DataAssets:           make(map[string]DataAsset),
 		TechnicalAssets:      make(map[string]TechnicalAsset),
 		TrustBoundaries:      make(map[string]TrustBoundary),
 		SharedRuntimes:       make(map[string]SharedRuntime),
 		CustomRiskCategories: make(RiskCategories, 0),
+		ScriptRiskRules:      make(map[string]ScriptRiskRule),
 		RiskTracking:         make(map[string]RiskTracking),
 	}
 
@@ -171,6 +176,12 @@ func (model *Model) Merge(dir string, includeFilename string) error {
 				return fmt.Errorf("failed to merge business criticality: %w", mergeError)
 			}
 
+		case strings.ToLower("currency"):
+			model.Currency, mergeError = new(Strings).MergeSingleton(model.Currency, includedModel.Currency)
+			if mergeError != nil {
+				return fmt.Errorf("failed to merge currency: %w", mergeError)
+			}
+
 		case strings.ToLower("management_summary_comment"):
 			model.ManagementSummaryComment = new(Strings).MergeMultiline(model.ManagementSummaryComment, includedModel.ManagementSummaryComment)
 
@@ -192,6 +203,15 @@ func (model *Model) Merge(dir string, includeFilename string) error {
 				return fmt.Errorf("failed to merge abuse cases: %w", mergeError)
 			}
 
+		case strings.ToLower("abuse_case_targets"):
+			if model.AbuseCaseTargets == nil {
+				model.AbuseCaseTargets = make(map[string]AbuseCaseTarget)
+			}
+			model.AbuseCaseTargets, mergeError = new(AbuseCaseTarget).MergeMap(model.AbuseCaseTargets, includedModel.AbuseCaseTargets)
+			if mergeError != nil {
+				return fmt.Errorf("failed to merge abuse case targets: %w", mergeError)
+			}
+
 		case strings.ToLower("tags_available"):
 			model.TagsAvailable = new(Strings).MergeUniqueSlice(model.TagsAvailable, includedModel.TagsAvailable)
 
@@ -225,6 +245,15 @@ func (model *Model) Merge(dir string, includeFilename string) error {
 				return fmt.Errorf("failed to merge risk categories: %w", mergeError)
 			}
 
+		case strings.ToLower("script_risk_rules"):
+			if model.ScriptRiskRules == nil {
+				model.ScriptRiskRules = make(map[string]ScriptRiskRule)
+			}
+			model.ScriptRiskRules, mergeError = new(ScriptRiskRule).MergeMap(model.ScriptRiskRules, includedModel.ScriptRiskRules)
+			if mergeError != nil {
+				return fmt.Errorf("failed to merge script risk rules: %w", mergeError)
+			}
+
 		case strings.ToLower("risk_tracking"):
 			model.RiskTracking, mergeError = new(RiskTracking).MergeMap(model.RiskTracking, includedModel.RiskTracking)
 			if mergeError != nil {
@@ -0,0 +1,19 @@
+package input
+
+import "fmt"
+
+// ScriptRiskRule is a risk rule script in the format of the rule files, the id defaults to its key in the model
+type ScriptRiskRule map[string]any
+
+func (what *ScriptRiskRule) MergeMap(first map[string]ScriptRiskRule, second map[string]ScriptRiskRule) (map[string]ScriptRiskRule, error) {
+	for mapKey, mapValue := range second {
+		_, ok := first[mapKey]
+		if ok {
+			return first, fmt.Errorf("duplicate script risk rule %q", mapKey)
+		}
+
+		first[mapKey] = mapValue
+	}
+
+	return first, nil
+}
@@ -29,6 +29,8 @@ type TechnicalAsset struct {
 	DataAssetsStored        []string                     `yaml:"data_assets_stored,omitempty" json:"data_assets_stored,omitempty"`
 	DataFormatsAccepted     []string                     `yaml:"data_formats_accepted,omitempty" json:"data_formats_accepted,omitempty"`
 	DiagramTweakOrder       int                          `yaml:"diagram_tweak_order,omitempty" json:"diagram_tweak_order,omitempty"`
+	DowntimeCostPerHour     float64                      `yaml:"downtime_cost_per_hour,omitempty" json:"downtime_cost_per_hour,omitempty"`
+	LossEventFrequency      float64                      `yaml:"loss_event_frequency,omitempty" json:"loss_event_frequency,omitempty"`
 	CommunicationLinks      map[string]CommunicationLink `yaml:"communication_links,omitempty" json:"communication_links,omitempty"`
 }
 
@@ -134,6 +136,14 @@ func (what *TechnicalAsset) Merge(other TechnicalAsset) error {
 		what.DiagramTweakOrder = other.DiagramTweakOrder
 	}
 
+	if what.DowntimeCostPerHour == 0 {
+		what.DowntimeCostPerHour = other.DowntimeCostPerHour
+	}
+
+	if what.LossEventFrequency == 0 {
+		what.LossEventFrequency = other.LossEventFrequency
+	}
+
 	what.CommunicationLinks, mergeError = new(CommunicationLink).MergeMap(what.CommunicationLinks, other.CommunicationLinks)
 	if mergeError != nil {
 		return fmt.Errorf("failed to merge communication_links: %w", mergeError)
@@ -0,0 +1,219 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/threagile/threagile/pkg/types"
+)
+
+// AttackPath is the most plausible chain of communication links from an entry point (an internet-facing technical asset
+// or a client used by humans) to a technical asset storing strictly-confidential or mission-critical data
+type AttackPath struct {
+	EntryTechnicalAssetId  string            `json:"entry_technical_asset_id" yaml:"entry_technical_asset_id"`
+	TargetTechnicalAssetId string            `json:"target_technical_asset_id" yaml:"target_technical_asset_id"`
+	TargetDataAssetIds     []string          `json:"target_data_asset_ids" yaml:"target_data_asset_ids"`
+	Difficulty             float64           `json:"difficulty" yaml:"difficulty"`
+	Steps                  []*AttackPathStep `json:"steps" yaml:"steps"`
+}
+
+// AttackPathStep is one communication link of an attack path, the difficulty is the effort of an attacker controlling
+// the source of the link to get to its target
+type AttackPathStep struct {
+	CommunicationLinkId string   `json:"communication_link_id" yaml:"communication_link_id"`
+	SourceId            string   `json:"source_id" yaml:"source_id"`
+	TargetId            string   `json:"target_id" yaml:"target_id"`
+	Difficulty          float64  `json:"difficulty" yaml:"difficulty"`
+	Obstacles           []string `json:"obstacles,omitempty" yaml:"obstacles,omitempty"`
+}
+
+// AttackPaths returns the most plausible attack path for each pair of entry point and sensitive target, ranked from the
+// easiest to the hardest. Paths passing another entry point are left out, as the path starting there is easier.
+func AttackPaths(parsedModel *types.Model) []*AttackPath {
+	targets := make(map[string][]string)
+	for _, id := range parsedModel.SortedTechnicalAssetIDs() {
+		if dataAssetIds := sensitiveDataAssetsStored(parsedModel, parsedModel.TechnicalAssets[id]); len(dataAssetIds) > 0 {
+			targets[id] = dataAssetIds
+		}
+	}
+	return attackPaths(parsedModel, targets)
+}
+
+// AttackPathsTo returns the most plausible attack path from each entry point to each of the given technical assets,
+// ranked like AttackPaths
+func AttackPathsTo(parsedModel *types.Model, technicalAssetIds []string) []*AttackPath {
+	targets := make(map[string][]string)
+	for _, id := range technicalAssetIds {
+		targets[id] = make([]string, 0)
+	}
+	return attackPaths(parsedModel, targets)
+}
+
+// isAttackPathEntry tells whether an attacker can start from the technical asset
+func isAttackPathEntry(technicalAsset *types.TechnicalAsset) bool {
+	return technicalAsset.Internet || technicalAsset.UsedAsClientByHuman
+}
+
+// attackPaths computes the paths to the targets, which map the technical asset ids to the data assets making them a target
+func attackPaths(parsedModel *types.Model, targets map[string][]string) []*AttackPath {
+	ids := parsedModel.SortedTechnicalAssetIDs()
+	entries := make(map[string]bool)
+	for _, id := range ids {
+		if isAttackPathEntry(parsedModel.TechnicalAssets[id]) {
+			entries[id] = true
+		}
+	}
+
+	paths := make([]*AttackPath, 0)
+	for _, entryId := range ids {
+		if !entries[entryId] {
+			continue
+		}
+		previous := cheapestAttackSteps(parsedModel, ids, entryId)
+		for _, targetId := range ids {
+			if _, ok := targets[targetId]; !ok || targetId == entryId {
+				continue
+			}
+			path := attackPathTo(previous, entryId, targetId)
+			if path == nil || passesOtherEntry(path, entries) {
+				continue
+			}
+			path.TargetDataAssetIds = targets[targetId]
+			paths = append(paths, path)
+		}
+	}
+
+	sort.SliceStable(paths, func(i, j int) bool {
+		if paths[i].Difficulty != paths[j].Difficulty {
+			return paths[i].Difficulty < paths[j].Difficulty
+		}
+		if len(paths[i].Steps) != len(paths[j].Steps) {
+			return len(paths[i].Steps) < len(paths[j].Steps)
+		}
+		return parsedModel.TechnicalAssets[paths[i].TargetTechnicalAssetId].RAA > parsedModel.TechnicalAssets[paths[j].TargetTechnicalAssetId].RAA
+	})
+	return paths
+}
+
+func sensitiveDataAssetsStored(parsedModel *types.Model, technicalAsset *types.TechnicalAsset) []string {
+	result := make([]string, 0)
+	for _, dataAsset := range parsedModel.DataAssetsStoredSorted(technicalAsset) {
+		if dataAsset == nil {
+			continue
+		}
+		if dataAsset.Confidentiality == types.StrictlyConfidential || dataAsset.Integrity == types.MissionCritical || dataAsset.Availability == types.MissionCritical {
+			result = append(result, dataAsset.Id)
+		}
+	}
+	return result
+}
+
+// cheapestAttackSteps runs Dijkstra from the entry along the direction of the communication links and returns the
+// step leading to each reachable technical asset, ties are broken by the number of steps for the sake of shorter paths
+func cheapestAttackSteps(parsedModel *types.Model, ids []string, entryId string) map[string]*AttackPathStep {
+	difficulty := map[string]float64{entryId: 0}
+	hops := map[string]int{entryId: 0}
+	previous := make(map[string]*AttackPathStep)
+	done := make(map[string]bool)
+	for {
+		current := ""
+		for _, id := range ids {
+			if _, reached := difficulty[id]; !reached || done[id] {
+				continue
+			}
+			if current == "" || difficulty[id] < difficulty[current] || (difficulty[id] == difficulty[current] && hops[id] < hops[current]) {
+				current = id
+			}
+		}
+		if current == "" {
+			return previous
+		}
+		done[current] = true
+
+		for _, link := range parsedModel.TechnicalAssets[current].CommunicationLinksSorted() {
+			if _, ok := parsedModel.TechnicalAssets[link.TargetId]; !ok || done[link.TargetId] {
+				continue
+			}
+			step := newAttackPathStep(parsedModel, link)
+			total := difficulty[current] + step.Difficulty
+			known, reached := difficulty[link.TargetId]
+			if !reached || total < known || (total == known && hops[current]+1 < hops[link.TargetId]) {
+				difficulty[link.TargetId] = total
+				hops[link.TargetId] = hops[current] + 1
+				previous[link.TargetId] = step
+			}
+		}
+	}
+}
+
+func attackPathTo(previous map[string]*AttackPathStep, entryId string, targetId string) *AttackPath {
+	if _, ok := previous[targetId]; !ok {
+		return nil
+	}
+	path := &AttackPath{EntryTechnicalAssetId: entryId, TargetTechnicalAssetId: targetId}
+	for id := targetId; id != entryId; id = previous[id].SourceId {
+		path.Steps = append([]*AttackPathStep{previous[id]}, path.Steps...)
+		path.Difficulty += previous[id].Difficulty
+	}
+	return path
+}
+
+func passesOtherEntry(path *AttackPath, entries map[string]bool) bool {
+	for _, step := range path.Steps[:len(path.Steps)-1] {
+		if entries[step.TargetId] {
+			return true
+		}
+	}
+	return false
+}
+
+// newAttackPathStep weights a communication link: each hop costs one, and authentication, encryption, IP filtering,
+// VPN and crossing a network trust boundary make it harder to follow
+func newAttackPathStep(parsedModel *types.Model, link *types.CommunicationLink) *AttackPathStep {
+	step := &AttackPathStep{
+		CommunicationLinkId: link.Id,
+		SourceId:            link.SourceId,
+		TargetId:            link.TargetId,
+		Difficulty:          1,
+	}
+
+	switch link.Authentication {
+	case types.NoneAuthentication:
+	case types.ClientCertificate, types.TwoFactor:
+		step.addObstacle(2, fmt.Sprintf("%v authentication", link.Authentication.String()))
+	default:
+		step.addObstacle(1, fmt.Sprintf("%v authentication", link.Authentication.String()))
+	}
+	if link.Protocol.IsEncrypted() {
+		step.addObstacle(0.5, "encrypted")
+	}
+	if link.IpFiltered {
+		step.addObstacle(1, "IP filtered")
+	}
+	if link.VPN {
+		step.addObstacle(1.5, "VPN")
+	}
+	if sourceBoundary, targetBoundary := networkTrustBoundary(parsedModel, link.SourceId), networkTrustBoundary(parsedModel, link.TargetId); sourceBoundary != targetBoundary {
+		switch {
+		case targetBoundary == nil:
+			step.addObstacle(1, fmt.Sprintf("leaves trust boundary %v", sourceBoundary.Title))
+		default:
+			step.addObstacle(1, fmt.Sprintf("enters trust boundary %v", targetBoundary.Title))
+		}
+	}
+	return step
+}
+
+func (what *AttackPathStep) addObstacle(difficulty float64, obstacle string) {
+	what.Difficulty += difficulty
+	what.Obstacles = append(what.Obstacles, obstacle)
+}
+
+// networkTrustBoundary returns the innermost network trust boundary containing the technical asset, or nil
+func networkTrustBoundary(parsedModel *types.Model, technicalAssetId string) *types.TrustBoundary {
+	trustBoundary := parsedModel.DirectContainingTrustBoundaryMappedByTechnicalAssetId[technicalAssetId]
+	for trustBoundary != nil && !trustBoundary.Type.IsNetworkBoundary() {
+		trustBoundary = parsedModel.FindParentTrustBoundary(trustBoundary)
+	}
+	return trustBoundary
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+func createAttackPathModel() *types.Model {
+	parsedModel := &types.Model{
+		DataAssets: map[string]*types.DataAsset{
+			"customers": {Id: "customers", Title: "Customers", Confidentiality: types.StrictlyConfidential},
+			"logs":      {Id: "logs", Title: "Logs", Confidentiality: types.Internal},
+		},
+		TechnicalAssets: map[string]*types.TechnicalAsset{
+			"browser":  {Id: "browser", Title: "Browser", UsedAsClientByHuman: true},
+			"proxy":    {Id: "proxy", Title: "Proxy", Internet: true},
+			"app":      {Id: "app", Title: "App"},
+			"database": {Id: "database", Title: "Database", DataAssetsStored: []string{"customers"}},
+			"logstore": {Id: "logstore", Title: "Log Store", DataAssetsStored: []string{"logs"}},
+		},
+		TrustBoundaries: map[string]*types.TrustBoundary{
+			"dmz":      {Id: "dmz", Title: "DMZ", Type: types.NetworkCloudSecurityGroup, TechnicalAssetsInside: []string{"proxy"}},
+			"internal": {Id: "internal", Title: "Internal", Type: types.NetworkOnPrem, TechnicalAssetsInside: []string{"app", "database", "logstore"}},
+		},
+	}
+	parsedModel.DirectContainingTrustBoundaryMappedByTechnicalAssetId = map[string]*types.TrustBoundary{
+		"proxy":    parsedModel.TrustBoundaries["dmz"],
+		"app":      parsedModel.TrustBoundaries["internal"],
+		"database": parsedModel.TrustBoundaries["internal"],
+		"logstore": parsedModel.TrustBoundaries["internal"],
+	}
+
+	link := func(sourceId, targetId string, link *types.CommunicationLink) {
+		link.Id = sourceId + ">" + targetId
+		link.SourceId, link.TargetId = sourceId, targetId
+		parsedModel.TechnicalAssets[sourceId].CommunicationLinks = append(parsedModel.TechnicalAssets[sourceId].CommunicationLinks, link)
+	}
+	link("browser", "proxy", &types.CommunicationLink{Protocol: types.HTTPS, Authentication: types.SessionId})
+	link("proxy", "app", &types.CommunicationLink{Protocol: types.HTTP})
+	link("proxy", "database", &types.CommunicationLink{Protocol: types.JdbcEncrypted, Authentication: types.Credentials, IpFiltered: true})
+	link("app", "database", &types.CommunicationLink{Protocol: types.JDBC})
+	link("app", "logstore", &types.CommunicationLink{Protocol: types.HTTP})
+	return parsedModel
+}
+
+func TestAttackPathsFollowTheEasiestLinksToSensitiveData(t *testing.T) {
+	paths := AttackPaths(createAttackPathModel())
+
+	assert.Len(t, paths, 1)
+	path := paths[0]
+	assert.Equal(t, "proxy", path.EntryTechnicalAssetId)
+	assert.Equal(t, "database", path.TargetTechnicalAssetId)
+	assert.Equal(t, []string{"customers"}, path.TargetDataAssetIds)
+	assert.Equal(t, 3.0, path.Difficulty)
+	assert.Len(t, path.Steps, 2)
+	assert.Equal(t, "proxy>app", path.Steps[0].CommunicationLinkId)
+	assert.Equal(t, []string{"enters trust boundary Internal"}, path.Steps[0].Obstacles)
+	assert.Equal(t, "app>database", path.Steps[1].CommunicationLinkId)
+	assert.Empty(t, path.Steps[1].Obstacles)
+}
+
+func TestAttackPathsWeighAuthenticationEncryptionAndFiltering(t *testing.T) {
+	parsedModel := createAttackPathModel()
+	parsedModel.TechnicalAssets["proxy"].Internet = false
+	parsedModel.TechnicalAssets["app"].CommunicationLinks[0].VPN = true
+
+	paths := AttackPaths(parsedModel)
+
+	assert.Len(t, paths, 1)
+	path := paths[0]
+	assert.Equal(t, "browser", path.EntryTechnicalAssetId)
+	assert.Equal(t, []string{"browser>proxy", "proxy>database"}, []string{path.Steps[0].CommunicationLinkId, path.Steps[1].CommunicationLinkId})
+	assert.Equal(t, []string{"session-id authentication", "encrypted", "enters trust boundary DMZ"}, path.Steps[0].Obstacles)
+	assert.Equal(t, []string{"credentials authentication", "encrypted", "IP filtered", "enters trust boundary Internal"}, path.Steps[1].Obstacles)
+	assert.Equal(t, 8.0, path.Difficulty)
+}
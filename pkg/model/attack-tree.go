@@ -0,0 +1,181 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/threagile/threagile/pkg/types"
+)
+
+type AttackTreeOperator string
+
+const (
+	AttackTreeAnd AttackTreeOperator = "and"
+	AttackTreeOr  AttackTreeOperator = "or"
+)
+
+// maxAttackPathsPerAttackTreeTarget limits the ways to reach a technical asset in an attack tree to the most plausible ones
+const maxAttackPathsPerAttackTreeTarget = 5
+
+// AttackTree breaks an abuse case down into the steps an attacker has to take, where the children of AND nodes all have
+// to succeed and one child of an OR node is enough
+type AttackTree struct {
+	AbuseCase   string          `json:"abuse_case" yaml:"abuse_case"`
+	Description string          `json:"description,omitempty" yaml:"description,omitempty"`
+	Root        *AttackTreeNode `json:"root" yaml:"root"`
+}
+
+// AttackTreeNode is a goal (with an operator and children) or a leaf referencing a communication link or risk
+type AttackTreeNode struct {
+	Title               string             `json:"title" yaml:"title"`
+	Operator            AttackTreeOperator `json:"operator,omitempty" yaml:"operator,omitempty"`
+	DataAssetId         string             `json:"data_asset_id,omitempty" yaml:"data_asset_id,omitempty"`
+	TechnicalAssetId    string             `json:"technical_asset_id,omitempty" yaml:"technical_asset_id,omitempty"`
+	CommunicationLinkId string             `json:"communication_link_id,omitempty" yaml:"communication_link_id,omitempty"`
+	RiskId              string             `json:"risk_id,omitempty" yaml:"risk_id,omitempty"`
+	Severity            string             `json:"severity,omitempty" yaml:"severity,omitempty"`
+	Difficulty          float64            `json:"difficulty,omitempty" yaml:"difficulty,omitempty"`
+	Details             []string           `json:"details,omitempty" yaml:"details,omitempty"`
+	Children            []*AttackTreeNode  `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// AttackTrees generates an attack tree for each abuse case referencing target data assets or technical assets, sorted
+// by abuse case. Each targeted technical asset has to be reached from an entry point (along the attack paths) and
+// exploited (by one of its unmitigated risks), targeted data assets are attacked on any technical asset storing or
+// processing them.
+func AttackTrees(parsedModel *types.Model) []*AttackTree {
+	abuseCases := make([]string, 0, len(parsedModel.AbuseCaseTargets))
+	for abuseCase := range parsedModel.AbuseCaseTargets {
+		abuseCases = append(abuseCases, abuseCase)
+	}
+	sort.Strings(abuseCases)
+
+	trees := make([]*AttackTree, 0)
+	for _, abuseCase := range abuseCases {
+		target := parsedModel.AbuseCaseTargets[abuseCase]
+		targetIds := make([]string, 0)
+		for _, id := range parsedModel.SortedTechnicalAssetIDs() {
+			technicalAsset := parsedModel.TechnicalAssets[id]
+			if slices.Contains(target.TechnicalAssets, id) || containsAny(technicalAsset.DataAssetsStored, target.DataAssets) ||
+				containsAny(technicalAsset.DataAssetsProcessed, target.DataAssets) {
+				targetIds = append(targetIds, id)
+			}
+		}
+		builder := &attackTreeBuilder{parsedModel: parsedModel, paths: make(map[string][]*AttackPath)}
+		for _, path := range AttackPathsTo(parsedModel, targetIds) {
+			builder.paths[path.TargetTechnicalAssetId] = append(builder.paths[path.TargetTechnicalAssetId], path)
+		}
+
+		root := &AttackTreeNode{Title: abuseCase, Operator: AttackTreeOr}
+		dataAssetIds := append([]string{}, target.DataAssets...)
+		sort.Strings(dataAssetIds)
+		for _, dataAssetId := range dataAssetIds {
+			dataAsset := parsedModel.DataAssets[dataAssetId]
+			node := &AttackTreeNode{Title: "Get " + dataAsset.Title, Operator: AttackTreeOr, DataAssetId: dataAssetId}
+			for _, id := range targetIds {
+				technicalAsset := parsedModel.TechnicalAssets[id]
+				if slices.Contains(technicalAsset.DataAssetsStored, dataAssetId) || slices.Contains(technicalAsset.DataAssetsProcessed, dataAssetId) {
+					node.Children = append(node.Children, builder.technicalAssetNode(technicalAsset))
+				}
+			}
+			if len(node.Children) == 0 {
+				node.Operator = ""
+				node.Details = []string{"neither stored nor processed by any technical asset"}
+			}
+			root.Children = append(root.Children, node)
+		}
+		for _, id := range targetIds {
+			if slices.Contains(target.TechnicalAssets, id) {
+				root.Children = append(root.Children, builder.technicalAssetNode(parsedModel.TechnicalAssets[id]))
+			}
+		}
+
+		trees = append(trees, &AttackTree{AbuseCase: abuseCase, Description: parsedModel.AbuseCases[abuseCase], Root: root})
+	}
+	return trees
+}
+
+type attackTreeBuilder struct {
+	parsedModel *types.Model
+	paths       map[string][]*AttackPath
+}
+
+func (what *attackTreeBuilder) technicalAssetNode(technicalAsset *types.TechnicalAsset) *AttackTreeNode {
+	reach := &AttackTreeNode{Title: "Reach " + technicalAsset.Title, Operator: AttackTreeOr}
+	if isAttackPathEntry(technicalAsset) {
+		reach.Children = append(reach.Children, &AttackTreeNode{Title: "Directly exposed", TechnicalAssetId: technicalAsset.Id,
+			Details: []string{"internet-facing or used as client by humans"}})
+	}
+	paths := what.paths[technicalAsset.Id]
+	if len(paths) > maxAttackPathsPerAttackTreeTarget {
+		paths = paths[:maxAttackPathsPerAttackTreeTarget]
+	}
+	for _, path := range paths {
+		pathNode := &AttackTreeNode{
+			Title:            "From " + what.parsedModel.TechnicalAssets[path.EntryTechnicalAssetId].Title,
+			Operator:         AttackTreeAnd,
+			TechnicalAssetId: path.EntryTechnicalAssetId,
+			Difficulty:       path.Difficulty,
+		}
+		for _, step := range path.Steps {
+			title := "To " + what.parsedModel.TechnicalAssets[step.TargetId].Title
+			if link, ok := what.parsedModel.CommunicationLinks[step.CommunicationLinkId]; ok {
+				title += " via " + link.Title
+			}
+			pathNode.Children = append(pathNode.Children, &AttackTreeNode{
+				Title:               title,
+				CommunicationLinkId: step.CommunicationLinkId,
+				Difficulty:          step.Difficulty,
+				Details:             step.Obstacles,
+			})
+		}
+		reach.Children = append(reach.Children, pathNode)
+	}
+	if len(reach.Children) == 0 {
+		reach.Operator = ""
+		reach.Details = []string{"no attack path from an entry point, requires insider access"}
+	}
+
+	exploit := &AttackTreeNode{Title: "Exploit " + technicalAsset.Title, Operator: AttackTreeOr}
+	risks := make([]*types.Risk, 0)
+	for _, risk := range what.parsedModel.AllRisks() {
+		if risk.MostRelevantTechnicalAssetId == technicalAsset.Id && what.parsedModel.GetRiskTrackingWithDefault(risk).Status.IsStillAtRisk() {
+			risks = append(risks, risk)
+		}
+	}
+	sort.SliceStable(risks, func(i, j int) bool {
+		if risks[i].Severity != risks[j].Severity {
+			return risks[i].Severity > risks[j].Severity
+		}
+		return risks[i].SyntheticId < risks[j].SyntheticId
+	})
+	for _, risk := range risks {
+		exploit.Children = append(exploit.Children, &AttackTreeNode{
+			Title:    removeFormattingTags(risk.Title),
+			RiskId:   risk.SyntheticId,
+			Severity: risk.Severity.String(),
+			Details:  []string{fmt.Sprintf("%v likelihood, %v impact", risk.ExploitationLikelihood.String(), risk.ExploitationImpact.String())},
+		})
+	}
+	if len(exploit.Children) == 0 {
+		exploit.Operator = ""
+		exploit.Details = []string{"no unmitigated risks identified, requires an unknown weakness"}
+	}
+
+	return &AttackTreeNode{
+		Title:            "Compromise " + technicalAsset.Title,
+		Operator:         AttackTreeAnd,
+		TechnicalAssetId: technicalAsset.Id,
+		Children:         []*AttackTreeNode{reach, exploit},
+	}
+}
+
+func containsAny(a []string, x []string) bool {
+	for _, item := range x {
+		if slices.Contains(a, item) {
+			return true
+		}
+	}
+	return false
+}
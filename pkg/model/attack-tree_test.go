@@ -0,0 +1,70 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+func createAttackTreeModel() *types.Model {
+	parsedModel := createAttackPathModel()
+	parsedModel.TechnicalAssets["app"].DataAssetsProcessed = []string{"customers"}
+	parsedModel.AbuseCases = map[string]string{"Data Theft": "Stealing customer data", "Defacement": "Changing the proxy"}
+	parsedModel.AbuseCaseTargets = map[string]*types.AbuseCaseTarget{
+		"Data Theft": {DataAssets: []string{"customers"}},
+		"Defacement": {TechnicalAssets: []string{"proxy"}},
+	}
+	parsedModel.GeneratedRisksByCategory = map[string][]*types.Risk{
+		"sql-injection": {
+			{CategoryId: "sql-injection", SyntheticId: "sql-injection@database", Title: "<b>SQL Injection</b>", Severity: types.HighSeverity, MostRelevantTechnicalAssetId: "database"},
+			{CategoryId: "sql-injection", SyntheticId: "sql-injection@database@fixed", Severity: types.CriticalSeverity, MostRelevantTechnicalAssetId: "database"},
+		},
+	}
+	parsedModel.RiskTracking = map[string]*types.RiskTracking{
+		"sql-injection@database@fixed": {SyntheticRiskId: "sql-injection@database@fixed", Status: types.Mitigated},
+	}
+	return parsedModel
+}
+
+func TestAttackTreesCombineReachablePathsAndRisks(t *testing.T) {
+	trees := AttackTrees(createAttackTreeModel())
+
+	assert.Len(t, trees, 2)
+	tree := trees[0]
+	assert.Equal(t, "Data Theft", tree.AbuseCase)
+	assert.Equal(t, "Stealing customer data", tree.Description)
+	assert.Equal(t, AttackTreeOr, tree.Root.Operator)
+	assert.Len(t, tree.Root.Children, 1)
+
+	getCustomers := tree.Root.Children[0]
+	assert.Equal(t, "customers", getCustomers.DataAssetId)
+	assert.Equal(t, []string{"Compromise App", "Compromise Database"}, []string{getCustomers.Children[0].Title, getCustomers.Children[1].Title})
+
+	compromiseDatabase := getCustomers.Children[1]
+	assert.Equal(t, AttackTreeAnd, compromiseDatabase.Operator)
+	reach, exploit := compromiseDatabase.Children[0], compromiseDatabase.Children[1]
+	assert.Equal(t, AttackTreeOr, reach.Operator)
+	assert.Len(t, reach.Children, 1)
+	assert.Equal(t, "From Proxy", reach.Children[0].Title)
+	assert.Equal(t, AttackTreeAnd, reach.Children[0].Operator)
+	assert.Equal(t, "proxy>app", reach.Children[0].Children[0].CommunicationLinkId)
+	assert.Len(t, exploit.Children, 1)
+	assert.Equal(t, "SQL Injection", exploit.Children[0].Title)
+	assert.Equal(t, "sql-injection@database", exploit.Children[0].RiskId)
+
+	exploitApp := getCustomers.Children[0].Children[1]
+	assert.Empty(t, exploitApp.Operator)
+	assert.Empty(t, exploitApp.Children)
+}
+
+func TestAttackTreesTreatEntryPointsAsDirectlyExposed(t *testing.T) {
+	trees := AttackTrees(createAttackTreeModel())
+
+	tree := trees[1]
+	assert.Equal(t, "Defacement", tree.AbuseCase)
+	assert.Len(t, tree.Root.Children, 1)
+	reach := tree.Root.Children[0].Children[0]
+	assert.Equal(t, "Directly exposed", reach.Children[0].Title)
+	assert.Equal(t, "From Browser", reach.Children[1].Title)
+}
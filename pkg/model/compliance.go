@@ -0,0 +1,150 @@
+package model
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/threagile/threagile/pkg/types"
+)
+
+// ComplianceStatus rates a control by the risks found in the categories mapped to it
+type ComplianceStatus string
+
+const (
+	// ComplianceNotAssessed means none of the mapped risk categories has been checked
+	ComplianceNotAssessed ComplianceStatus = "not-assessed"
+	// ComplianceCovered means the mapped risk categories have been checked and have no unmitigated risks
+	ComplianceCovered ComplianceStatus = "covered"
+	// ComplianceOpenRisks means at least one of the mapped risk categories has unmitigated risks
+	ComplianceOpenRisks ComplianceStatus = "open-risks"
+)
+
+func (what ComplianceStatus) Title() string {
+	return map[ComplianceStatus]string{
+		ComplianceNotAssessed: "Not Assessed",
+		ComplianceCovered:     "Covered",
+		ComplianceOpenRisks:   "Open Risks",
+	}[what]
+}
+
+// ComplianceControlCoverage is the state of one control: the assessed risk categories are the mapped ones checked by
+// the risk rules, the open risks are the unmitigated risks of those categories
+type ComplianceControlCoverage struct {
+	Id                 string           `json:"id" yaml:"id"`
+	Title              string           `json:"title,omitempty" yaml:"title,omitempty"`
+	Status             ComplianceStatus `json:"status" yaml:"status"`
+	RiskCategories     []string         `json:"risk_categories" yaml:"risk_categories"`
+	AssessedCategories []string         `json:"assessed_categories" yaml:"assessed_categories"`
+	Risks              int              `json:"risks" yaml:"risks"`
+	OpenRisks          []string         `json:"open_risks" yaml:"open_risks"`
+}
+
+// ComplianceFrameworkCoverage is the state of the controls of one framework, sorted by control id
+type ComplianceFrameworkCoverage struct {
+	Id          string                       `json:"id" yaml:"id"`
+	Title       string                       `json:"title,omitempty" yaml:"title,omitempty"`
+	Controls    []*ComplianceControlCoverage `json:"controls" yaml:"controls"`
+	Covered     int                          `json:"covered" yaml:"covered"`
+	OpenRisks   int                          `json:"open_risks" yaml:"open_risks"`
+	NotAssessed int                          `json:"not_assessed" yaml:"not_assessed"`
+}
+
+// ComplianceCoverage rates the controls of the mapped frameworks by the risks of the model, frameworks sorted by id.
+// Categories of skipped risk rules count as not checked.
+func ComplianceCoverage(parsedModel *types.Model, complianceMap types.ComplianceMap, skipRiskRules []string) []*ComplianceFrameworkCoverage {
+	result := make([]*ComplianceFrameworkCoverage, 0)
+	for id, framework := range complianceMap {
+		frameworkCoverage := &ComplianceFrameworkCoverage{Id: id, Title: framework.Title, Controls: make([]*ComplianceControlCoverage, 0)}
+		for controlId, control := range framework.Controls {
+			controlCoverage := complianceControlCoverage(parsedModel, controlId, control, skipRiskRules)
+			switch controlCoverage.Status {
+			case ComplianceCovered:
+				frameworkCoverage.Covered++
+			case ComplianceOpenRisks:
+				frameworkCoverage.OpenRisks++
+			default:
+				frameworkCoverage.NotAssessed++
+			}
+			frameworkCoverage.Controls = append(frameworkCoverage.Controls, controlCoverage)
+		}
+		sort.Slice(frameworkCoverage.Controls, func(i, j int) bool {
+			return compareControlIds(frameworkCoverage.Controls[i].Id, frameworkCoverage.Controls[j].Id) < 0
+		})
+		result = append(result, frameworkCoverage)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+	return result
+}
+
+func complianceControlCoverage(parsedModel *types.Model, controlId string, control types.ComplianceControl, skipRiskRules []string) *ComplianceControlCoverage {
+	result := &ComplianceControlCoverage{
+		Id:                 controlId,
+		Title:              control.Title,
+		Status:             ComplianceNotAssessed,
+		RiskCategories:     append([]string{}, control.RiskCategories...),
+		AssessedCategories: make([]string, 0),
+		OpenRisks:          make([]string, 0),
+	}
+	for _, categoryId := range control.RiskCategories {
+		if parsedModel.GetRiskCategory(categoryId) == nil || slices.Contains(skipRiskRules, categoryId) {
+			continue
+		}
+		result.AssessedCategories = append(result.AssessedCategories, categoryId)
+		for _, risk := range parsedModel.GeneratedRisksByCategory[categoryId] {
+			result.Risks++
+			if parsedModel.GetRiskTrackingWithDefault(risk).Status.IsStillAtRisk() && !slices.Contains(result.OpenRisks, risk.SyntheticId) {
+				result.OpenRisks = append(result.OpenRisks, risk.SyntheticId)
+			}
+		}
+	}
+	sort.Strings(result.OpenRisks)
+
+	if len(result.OpenRisks) > 0 {
+		result.Status = ComplianceOpenRisks
+	} else if len(result.AssessedCategories) > 0 {
+		result.Status = ComplianceCovered
+	}
+	return result
+}
+
+// compareControlIds orders control ids naturally, so "A.8.3" comes before "A.8.24"
+func compareControlIds(a, b string) int {
+	chunksA, chunksB := controlIdChunks(a), controlIdChunks(b)
+	for i := 0; i < len(chunksA) && i < len(chunksB); i++ {
+		numberA, errorA := strconv.Atoi(chunksA[i])
+		numberB, errorB := strconv.Atoi(chunksB[i])
+		if errorA == nil && errorB == nil {
+			if numberA != numberB {
+				return numberA - numberB
+			}
+			continue
+		}
+		if compared := strings.Compare(chunksA[i], chunksB[i]); compared != 0 {
+			return compared
+		}
+	}
+	return len(chunksA) - len(chunksB)
+}
+
+// controlIdChunks splits a control id into runs of digits and runs of other characters
+func controlIdChunks(id string) []string {
+	chunks := make([]string, 0)
+	for _, r := range id {
+		if len(chunks) > 0 && unicode.IsDigit(r) == unicode.IsDigit(lastRune(chunks[len(chunks)-1])) {
+			chunks[len(chunks)-1] += string(r)
+			continue
+		}
+		chunks = append(chunks, string(r))
+	}
+	return chunks
+}
+
+func lastRune(text string) rune {
+	runes := []rune(text)
+	return runes[len(runes)-1]
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+func TestComplianceCoverageRatesControlsByRisks(t *testing.T) {
+	parsedModel := &types.Model{
+		BuiltInRiskCategories: types.RiskCategories{{ID: "sql-nosql-injection"}, {ID: "cross-site-scripting"}, {ID: "missing-waf"}},
+		GeneratedRisksByCategory: map[string][]*types.Risk{
+			"sql-nosql-injection":  {{CategoryId: "sql-nosql-injection", SyntheticId: "sql-nosql-injection@b"}, {CategoryId: "sql-nosql-injection", SyntheticId: "sql-nosql-injection@a"}},
+			"cross-site-scripting": {{CategoryId: "cross-site-scripting", SyntheticId: "cross-site-scripting@a"}},
+		},
+		RiskTracking: map[string]*types.RiskTracking{
+			"cross-site-scripting@a": {SyntheticRiskId: "cross-site-scripting@a", Status: types.Mitigated},
+		},
+	}
+	complianceMap := types.ComplianceMap{
+		"owasp": {Title: "OWASP", Controls: map[string]types.ComplianceControl{
+			"A3":  {Title: "Injection", RiskCategories: []string{"sql-nosql-injection", "cross-site-scripting"}},
+			"A10": {Title: "SSRF", RiskCategories: []string{"server-side-request-forgery"}},
+			"A5":  {Title: "Misconfiguration", RiskCategories: []string{"missing-waf", "missing-hardening"}},
+			"A2":  {Title: "Cryptography", RiskCategories: []string{"cross-site-scripting"}},
+		}},
+		"empty": {},
+	}
+
+	coverage := ComplianceCoverage(parsedModel, complianceMap, nil)
+
+	assert.Equal(t, []string{"empty", "owasp"}, []string{coverage[0].Id, coverage[1].Id})
+	assert.Empty(t, coverage[0].Controls)
+	owasp := coverage[1]
+	assert.Equal(t, []string{"A2", "A3", "A5", "A10"}, []string{owasp.Controls[0].Id, owasp.Controls[1].Id, owasp.Controls[2].Id, owasp.Controls[3].Id})
+	assert.Equal(t, ComplianceCovered, owasp.Controls[0].Status)
+	assert.Equal(t, ComplianceOpenRisks, owasp.Controls[1].Status)
+	assert.Equal(t, 3, owasp.Controls[1].Risks)
+	assert.Equal(t, []string{"sql-nosql-injection@a", "sql-nosql-injection@b"}, owasp.Controls[1].OpenRisks)
+	assert.Equal(t, ComplianceCovered, owasp.Controls[2].Status)
+	assert.Equal(t, []string{"missing-waf"}, owasp.Controls[2].AssessedCategories)
+	assert.Equal(t, ComplianceNotAssessed, owasp.Controls[3].Status)
+	assert.Equal(t, []int{2, 1, 1}, []int{owasp.Covered, owasp.OpenRisks, owasp.NotAssessed})
+
+	skipped := ComplianceCoverage(parsedModel, complianceMap, []string{"sql-nosql-injection"})[1]
+	assert.Equal(t, ComplianceCovered, skipped.Controls[1].Status)
+	assert.Equal(t, []string{"cross-site-scripting"}, skipped.Controls[1].AssessedCategories)
+}
+
+func TestCompareControlIds(t *testing.T) {
+	assert.Negative(t, compareControlIds("A.8.3", "A.8.24"))
+	assert.Positive(t, compareControlIds("CC6.10", "CC6.2"))
+	assert.Negative(t, compareControlIds("AC-3", "SC-7"))
+	assert.Negative(t, compareControlIds("1.3", "1.3.1"))
+	assert.Zero(t, compareControlIds("A01:2021", "A01:2021"))
+}
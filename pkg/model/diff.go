@@ -0,0 +1,267 @@
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/types"
+)
+
+// ModelDiff is the security relevant delta between two analyzed versions of a model
+type ModelDiff struct {
+	BaseTitle          string      `json:"base_title,omitempty" yaml:"base_title,omitempty"`
+	Title              string      `json:"title,omitempty" yaml:"title,omitempty"`
+	TechnicalAssets    ElementDiff `json:"technical_assets" yaml:"technical_assets"`
+	CommunicationLinks ElementDiff `json:"communication_links" yaml:"communication_links"`
+	DataAssets         ElementDiff `json:"data_assets" yaml:"data_assets"`
+	TrustBoundaries    ElementDiff `json:"trust_boundaries" yaml:"trust_boundaries"`
+	Risks              RiskDiff    `json:"risks" yaml:"risks"`
+}
+
+type ElementDiff struct {
+	Added   []ElementRef    `json:"added,omitempty" yaml:"added,omitempty"`
+	Removed []ElementRef    `json:"removed,omitempty" yaml:"removed,omitempty"`
+	Changed []ElementChange `json:"changed,omitempty" yaml:"changed,omitempty"`
+}
+
+type ElementRef struct {
+	Id    string `json:"id" yaml:"id"`
+	Title string `json:"title,omitempty" yaml:"title,omitempty"`
+}
+
+type ElementChange struct {
+	ElementRef `json:",inline" yaml:",inline"`
+	Fields     []string `json:"fields" yaml:"fields"`
+}
+
+// RiskDiff lists new and resolved risks, risks whose rating changed (re-rated, the status may have changed as well) and
+// risks of which only the status changed (re-triaged)
+type RiskDiff struct {
+	New       []*types.Risk `json:"new,omitempty" yaml:"new,omitempty"`
+	Resolved  []*types.Risk `json:"resolved,omitempty" yaml:"resolved,omitempty"`
+	Rerated   []RiskChange  `json:"rerated,omitempty" yaml:"rerated,omitempty"`
+	Retriaged []RiskChange  `json:"retriaged,omitempty" yaml:"retriaged,omitempty"`
+}
+
+type RiskChange struct {
+	SyntheticId string     `json:"synthetic_id" yaml:"synthetic_id"`
+	Title       string     `json:"title,omitempty" yaml:"title,omitempty"`
+	Before      RiskRating `json:"before" yaml:"before"`
+	After       RiskRating `json:"after" yaml:"after"`
+}
+
+type RiskRating struct {
+	Severity               types.RiskSeverity               `json:"severity" yaml:"severity"`
+	ExploitationLikelihood types.RiskExploitationLikelihood `json:"exploitation_likelihood" yaml:"exploitation_likelihood"`
+	ExploitationImpact     types.RiskExploitationImpact     `json:"exploitation_impact" yaml:"exploitation_impact"`
+	RiskStatus             types.RiskStatus                 `json:"risk_status" yaml:"risk_status"`
+}
+
+// technical asset fields not compared, either because they are diffed separately or because they are derived
+var ignoredTechnicalAssetFields = []string{"communication_links", "raa"}
+
+// DiffModels compares two analyzed models; risk status is taken from the risk tracking of each model
+func DiffModels(base *types.Model, current *types.Model) *ModelDiff {
+	diff := &ModelDiff{
+		BaseTitle: base.Title,
+		Title:     current.Title,
+	}
+
+	diff.TechnicalAssets = diffElements(toElementMap(base.TechnicalAssets), toElementMap(current.TechnicalAssets), ignoredTechnicalAssetFields...)
+	diff.CommunicationLinks = diffElements(toElementMap(base.CommunicationLinks), toElementMap(current.CommunicationLinks))
+	diff.DataAssets = diffElements(toElementMap(base.DataAssets), toElementMap(current.DataAssets))
+	diff.TrustBoundaries = diffElements(toElementMap(base.TrustBoundaries), toElementMap(current.TrustBoundaries))
+	diff.Risks = diffRisks(base, current)
+
+	return diff
+}
+
+// IsEmpty returns true if neither the architecture nor the generated risks changed
+func (what *ModelDiff) IsEmpty() bool {
+	for _, elements := range []ElementDiff{what.TechnicalAssets, what.CommunicationLinks, what.DataAssets, what.TrustBoundaries} {
+		if len(elements.Added) > 0 || len(elements.Removed) > 0 || len(elements.Changed) > 0 {
+			return false
+		}
+	}
+
+	return len(what.Risks.New) == 0 && len(what.Risks.Resolved) == 0 && len(what.Risks.Rerated) == 0 && len(what.Risks.Retriaged) == 0
+}
+
+type diffElement struct {
+	title  string
+	values map[string]any
+}
+
+func toElementMap[T any](elements map[string]*T) map[string]diffElement {
+	result := make(map[string]diffElement)
+	for id, element := range elements {
+		if element == nil {
+			continue
+		}
+
+		data, marshalError := json.Marshal(element)
+		if marshalError != nil {
+			continue
+		}
+
+		values := make(map[string]any)
+		if json.Unmarshal(data, &values) != nil {
+			continue
+		}
+
+		title, _ := values["title"].(string)
+		result[id] = diffElement{title: title, values: values}
+	}
+
+	return result
+}
+
+func diffElements(base map[string]diffElement, current map[string]diffElement, ignoredFields ...string) ElementDiff {
+	result := ElementDiff{}
+	for _, id := range sortedKeys(current) {
+		baseElement, ok := base[id]
+		if !ok {
+			result.Added = append(result.Added, ElementRef{Id: id, Title: current[id].title})
+			continue
+		}
+
+		fields := changedFields(baseElement.values, current[id].values, ignoredFields)
+		if len(fields) > 0 {
+			result.Changed = append(result.Changed, ElementChange{ElementRef: ElementRef{Id: id, Title: current[id].title}, Fields: fields})
+		}
+	}
+
+	for _, id := range sortedKeys(base) {
+		if _, ok := current[id]; !ok {
+			result.Removed = append(result.Removed, ElementRef{Id: id, Title: base[id].title})
+		}
+	}
+
+	return result
+}
+
+func changedFields(base map[string]any, current map[string]any, ignoredFields []string) []string {
+	names := make(map[string]bool)
+	for name := range base {
+		names[name] = true
+	}
+
+	for name := range current {
+		names[name] = true
+	}
+
+	result := make([]string, 0)
+	for name := range names {
+		if contains(ignoredFields, name) {
+			continue
+		}
+
+		if !reflect.DeepEqual(normalizeDiffValue(base[name]), normalizeDiffValue(current[name])) {
+			result = append(result, name)
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// normalizeDiffValue sorts lists of strings, as the order of derived id lists (e.g. processed data assets) is not stable
+func normalizeDiffValue(value any) any {
+	list, ok := value.([]any)
+	if !ok {
+		return value
+	}
+
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		text, isString := item.(string)
+		if !isString {
+			return value
+		}
+
+		values = append(values, text)
+	}
+
+	sort.Strings(values)
+	return values
+}
+
+func diffRisks(base *types.Model, current *types.Model) RiskDiff {
+	baseRisks := risksBySyntheticId(base)
+	currentRisks := risksBySyntheticId(current)
+
+	result := RiskDiff{}
+	for _, id := range sortedKeys(currentRisks) {
+		currentRisk := currentRisks[id]
+		baseRisk, ok := baseRisks[id]
+		if !ok {
+			result.New = append(result.New, currentRisk)
+			continue
+		}
+
+		before := RiskRating{
+			Severity:               baseRisk.Severity,
+			ExploitationLikelihood: baseRisk.ExploitationLikelihood,
+			ExploitationImpact:     baseRisk.ExploitationImpact,
+			RiskStatus:             baseRisk.RiskStatus,
+		}
+
+		after := RiskRating{
+			Severity:               currentRisk.Severity,
+			ExploitationLikelihood: currentRisk.ExploitationLikelihood,
+			ExploitationImpact:     currentRisk.ExploitationImpact,
+			RiskStatus:             currentRisk.RiskStatus,
+		}
+
+		if before == after {
+			continue
+		}
+
+		change := RiskChange{
+			SyntheticId: currentRisk.SyntheticId,
+			Title:       currentRisk.Title,
+			Before:      before,
+			After:       after,
+		}
+
+		if before.Severity == after.Severity && before.ExploitationLikelihood == after.ExploitationLikelihood && before.ExploitationImpact == after.ExploitationImpact {
+			result.Retriaged = append(result.Retriaged, change)
+		} else {
+			result.Rerated = append(result.Rerated, change)
+		}
+	}
+
+	for _, id := range sortedKeys(baseRisks) {
+		if _, ok := currentRisks[id]; !ok {
+			result.Resolved = append(result.Resolved, baseRisks[id])
+		}
+	}
+
+	types.SortByRiskSeverity(result.New)
+	types.SortByRiskSeverity(result.Resolved)
+
+	return result
+}
+
+func risksBySyntheticId(parsedModel *types.Model) map[string]*types.Risk {
+	result := make(map[string]*types.Risk)
+	for _, risks := range parsedModel.GeneratedRisksByCategoryWithCurrentStatus() {
+		for _, risk := range risks {
+			result[strings.ToLower(risk.SyntheticId)] = risk
+		}
+	}
+
+	return result
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
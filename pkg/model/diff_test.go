@@ -0,0 +1,93 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+func TestDiffModelsUnchangedIsEmpty(t *testing.T) {
+	diff := DiffModels(createDiffModel(), createDiffModel())
+
+	assert.True(t, diff.IsEmpty())
+}
+
+func TestDiffModelsDetectsElementChanges(t *testing.T) {
+	base := createDiffModel()
+	current := createDiffModel()
+	current.TechnicalAssets["ta1"].Encryption = types.Transparent
+	current.TechnicalAssets["ta1"].DataAssetsProcessed = []string{"da2", "da1"}
+	current.TechnicalAssets["ta2"] = &types.TechnicalAsset{Id: "ta2", Title: "Technical Asset 2"}
+	delete(current.DataAssets, "da2")
+
+	diff := DiffModels(base, current)
+
+	assert.False(t, diff.IsEmpty())
+	assert.Equal(t, []ElementRef{{Id: "ta2", Title: "Technical Asset 2"}}, diff.TechnicalAssets.Added)
+	assert.Equal(t, []ElementChange{{ElementRef: ElementRef{Id: "ta1", Title: "Technical Asset 1"}, Fields: []string{"encryption"}}}, diff.TechnicalAssets.Changed)
+	assert.Equal(t, []ElementRef{{Id: "da2", Title: "Data Asset 2"}}, diff.DataAssets.Removed)
+	assert.Empty(t, diff.CommunicationLinks.Changed)
+}
+
+func TestDiffModelsDetectsRiskChanges(t *testing.T) {
+	base := createDiffModel()
+	base.GeneratedRisksByCategory["test-category"] = append(base.GeneratedRisksByCategory["test-category"],
+		&types.Risk{CategoryId: "test-category", SyntheticId: "test-category@ta9", Severity: types.LowSeverity})
+
+	current := createDiffModel()
+	current.GeneratedRisksByCategory["test-category"][0].Severity = types.CriticalSeverity
+	current.GeneratedRisksByCategory["other-category"] = []*types.Risk{
+		{CategoryId: "other-category", SyntheticId: "other-category@ta1", Severity: types.MediumSeverity},
+	}
+	current.RiskTracking = map[string]*types.RiskTracking{
+		"test-category@ta1": {SyntheticRiskId: "test-category@ta1", Status: types.InProgress},
+	}
+
+	diff := DiffModels(base, current)
+
+	assert.Len(t, diff.Risks.New, 1)
+	assert.Equal(t, "other-category@ta1", diff.Risks.New[0].SyntheticId)
+	assert.Len(t, diff.Risks.Resolved, 1)
+	assert.Equal(t, "test-category@ta9", diff.Risks.Resolved[0].SyntheticId)
+	assert.Len(t, diff.Risks.Rerated, 1)
+	assert.Equal(t, types.HighSeverity, diff.Risks.Rerated[0].Before.Severity)
+	assert.Equal(t, types.CriticalSeverity, diff.Risks.Rerated[0].After.Severity)
+	assert.Equal(t, types.Unchecked, diff.Risks.Rerated[0].Before.RiskStatus)
+	assert.Equal(t, types.InProgress, diff.Risks.Rerated[0].After.RiskStatus)
+}
+
+func TestDiffModelsSeparatesStatusOnlyChanges(t *testing.T) {
+	current := createDiffModel()
+	current.RiskTracking = map[string]*types.RiskTracking{
+		"test-category@ta1": {SyntheticRiskId: "test-category@ta1", Status: types.Accepted},
+	}
+
+	diff := DiffModels(createDiffModel(), current)
+
+	assert.False(t, diff.IsEmpty())
+	assert.Empty(t, diff.Risks.Rerated)
+	assert.Len(t, diff.Risks.Retriaged, 1)
+	assert.Equal(t, types.Unchecked, diff.Risks.Retriaged[0].Before.RiskStatus)
+	assert.Equal(t, types.Accepted, diff.Risks.Retriaged[0].After.RiskStatus)
+	assert.Equal(t, types.HighSeverity, diff.Risks.Retriaged[0].After.Severity)
+}
+
+func createDiffModel() *types.Model {
+	return &types.Model{
+		Title: "Diff Model",
+		DataAssets: map[string]*types.DataAsset{
+			"da1": {Id: "da1", Title: "Data Asset 1"},
+			"da2": {Id: "da2", Title: "Data Asset 2"},
+		},
+		TechnicalAssets: map[string]*types.TechnicalAsset{
+			"ta1": {Id: "ta1", Title: "Technical Asset 1", DataAssetsProcessed: []string{"da1", "da2"}},
+		},
+		CommunicationLinks: map[string]*types.CommunicationLink{},
+		TrustBoundaries:    map[string]*types.TrustBoundary{},
+		RiskTracking:       map[string]*types.RiskTracking{},
+		GeneratedRisksByCategory: map[string][]*types.Risk{
+			"test-category": {{CategoryId: "test-category", SyntheticId: "test-category@ta1", Severity: types.HighSeverity}},
+		},
+	}
+}
@@ -0,0 +1,278 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/types"
+)
+
+type explainRiskConfig interface {
+}
+
+type explainRiskReporter interface {
+	Println(a ...any)
+	Printf(format string, a ...any)
+}
+
+// ExplainRisk prints why the risk with the given synthetic id was generated: the category, the model elements
+// the risk was found on, the explanation trail recorded by the rule, the data assets driving the impact rating
+// and the risk tracking applying to it.
+func (what ReadResult) ExplainRisk(cfg explainRiskConfig, risk string, reporter explainRiskReporter) error {
+	parsedModel := what.ParsedModel
+	if parsedModel == nil {
+		return fmt.Errorf("no model loaded")
+	}
+
+	generatedRisk, ok := parsedModel.GeneratedRisksBySyntheticId[strings.ToLower(strings.TrimSpace(risk))]
+	if !ok || generatedRisk == nil {
+		return fmt.Errorf("risk %q not found in generated risks", risk)
+	}
+
+	category := parsedModel.GetRiskCategory(generatedRisk.CategoryId)
+	if category == nil {
+		return fmt.Errorf("unknown risk category %q of risk %q", generatedRisk.CategoryId, generatedRisk.SyntheticId)
+	}
+
+	reporter.Printf("Risk: %v\n", removeFormattingTags(generatedRisk.Title))
+	reporter.Printf("  ID:                       %v\n", generatedRisk.SyntheticId)
+	reporter.Printf("  Severity:                 %v\n", generatedRisk.Severity.Title())
+	reporter.Printf("  Exploitation likelihood:  %v\n", generatedRisk.ExploitationLikelihood.Title())
+	reporter.Printf("  Exploitation impact:      %v\n", generatedRisk.ExploitationImpact.Title())
+	reporter.Printf("  Data breach probability:  %v\n", generatedRisk.DataBreachProbability.Title())
+	reporter.Println()
+
+	what.explainRiskCategory(category, reporter)
+	what.explainRiskElements(generatedRisk, reporter)
+	what.explainRiskTrail(generatedRisk, reporter)
+	what.explainRiskDataAssets(generatedRisk, reporter)
+	what.explainRiskTracking(generatedRisk, reporter)
+
+	return nil
+}
+
+func (what ReadResult) explainRiskCategory(category *types.RiskCategory, reporter explainRiskReporter) {
+	origin := "built-in"
+	if _, ok := what.CustomRiskRules[category.ID]; ok {
+		origin = "custom"
+	}
+
+	reporter.Printf("Category: %v (%v, %v)\n", category.Title, category.ID, origin)
+	reporter.Printf("  STRIDE:    %v\n", category.STRIDE.Title())
+	reporter.Printf("  Function:  %v\n", category.Function.Title())
+	if category.CWE > 0 {
+		reporter.Printf("  CWE:       %v\n", category.CWE)
+	}
+	if len(category.DetectionLogic) > 0 {
+		reporter.Printf("  Detection logic: %v\n", removeFormattingTags(category.DetectionLogic))
+	}
+	if len(category.RiskAssessment) > 0 {
+		reporter.Printf("  Risk rating:     %v\n", removeFormattingTags(category.RiskAssessment))
+	}
+	reporter.Println()
+}
+
+func (what ReadResult) explainRiskElements(risk *types.Risk, reporter explainRiskReporter) {
+	parsedModel := what.ParsedModel
+
+	reporter.Println("Matched model elements:")
+	if len(risk.MostRelevantTechnicalAssetId) > 0 {
+		techAsset := parsedModel.TechnicalAssets[risk.MostRelevantTechnicalAssetId]
+		if techAsset != nil {
+			reporter.Printf("  Technical asset:     %v (%v)\n", techAsset.Title, techAsset.Id)
+			reporter.Printf("    type: %v, technologies: %v, machine: %v, internet: %v, out of scope: %v\n",
+				techAsset.Type, techAsset.Technologies.String(), techAsset.Machine, techAsset.Internet, techAsset.OutOfScope)
+			trustBoundaryId := parsedModel.GetTechnicalAssetTrustBoundaryId(techAsset)
+			if len(trustBoundaryId) > 0 {
+				reporter.Printf("    inside trust boundary: %v\n", trustBoundaryId)
+			}
+		} else {
+			reporter.Printf("  Technical asset:     %v (not found in model)\n", risk.MostRelevantTechnicalAssetId)
+		}
+	}
+
+	if len(risk.MostRelevantCommunicationLinkId) > 0 {
+		commLink := parsedModel.CommunicationLinks[risk.MostRelevantCommunicationLinkId]
+		if commLink != nil {
+			reporter.Printf("  Communication link:  %v (%v)\n", commLink.Title, commLink.Id)
+			reporter.Printf("    from %v to %v, protocol: %v, authentication: %v, authorization: %v, vpn: %v, ip filtered: %v\n",
+				commLink.SourceId, commLink.TargetId, commLink.Protocol, commLink.Authentication, commLink.Authorization, commLink.VPN, commLink.IpFiltered)
+		} else {
+			reporter.Printf("  Communication link:  %v (not found in model)\n", risk.MostRelevantCommunicationLinkId)
+		}
+	}
+
+	if len(risk.MostRelevantTrustBoundaryId) > 0 {
+		trustBoundary := parsedModel.TrustBoundaries[risk.MostRelevantTrustBoundaryId]
+		if trustBoundary != nil {
+			reporter.Printf("  Trust boundary:      %v (%v, %v)\n", trustBoundary.Title, trustBoundary.Id, trustBoundary.Type)
+		} else {
+			reporter.Printf("  Trust boundary:      %v (not found in model)\n", risk.MostRelevantTrustBoundaryId)
+		}
+	}
+
+	if len(risk.MostRelevantSharedRuntimeId) > 0 {
+		sharedRuntime := parsedModel.SharedRuntimes[risk.MostRelevantSharedRuntimeId]
+		if sharedRuntime != nil {
+			reporter.Printf("  Shared runtime:      %v (%v)\n", sharedRuntime.Title, sharedRuntime.Id)
+		} else {
+			reporter.Printf("  Shared runtime:      %v (not found in model)\n", risk.MostRelevantSharedRuntimeId)
+		}
+	}
+
+	if len(risk.MostRelevantDataAssetId) > 0 {
+		dataAsset := parsedModel.DataAssets[risk.MostRelevantDataAssetId]
+		if dataAsset != nil {
+			reporter.Printf("  Data asset:          %v (%v)\n", dataAsset.Title, dataAsset.Id)
+		} else {
+			reporter.Printf("  Data asset:          %v (not found in model)\n", risk.MostRelevantDataAssetId)
+		}
+	}
+
+	if len(risk.DataBreachTechnicalAssetIDs) > 0 {
+		reporter.Printf("  Data breach assets:  %v\n", strings.Join(risk.DataBreachTechnicalAssetIDs, ", "))
+	}
+	reporter.Println()
+}
+
+func (what ReadResult) explainRiskTrail(risk *types.Risk, reporter explainRiskReporter) {
+	if len(risk.RiskExplanation) == 0 && len(risk.RatingExplanation) == 0 {
+		reporter.Println("No explanation trail was recorded by the rule generating this risk.")
+		reporter.Println()
+		return
+	}
+
+	if len(risk.RiskExplanation) > 0 {
+		reporter.Println("Why the risk was identified:")
+		for _, line := range risk.RiskExplanation {
+			reporter.Printf("  %v\n", line)
+		}
+		reporter.Println()
+	}
+
+	if len(risk.RatingExplanation) > 0 {
+		reporter.Println("How the risk was rated:")
+		for _, line := range risk.RatingExplanation {
+			reporter.Printf("  %v\n", line)
+		}
+		reporter.Println()
+	}
+}
+
+func (what ReadResult) explainRiskDataAssets(risk *types.Risk, reporter explainRiskReporter) {
+	dataAssets := what.impactDrivingDataAssets(risk)
+	if len(dataAssets) == 0 {
+		return
+	}
+
+	reporter.Println("Data assets driving the impact rating:")
+	for _, dataAsset := range dataAssets {
+		reporter.Printf("  %v (%v): confidentiality %v, integrity %v, availability %v, quantity %v\n",
+			dataAsset.Title, dataAsset.Id, dataAsset.Confidentiality, dataAsset.Integrity, dataAsset.Availability, dataAsset.Quantity)
+	}
+	reporter.Println()
+}
+
+// impactDrivingDataAssets collects the data assets of the elements a risk was found on,
+// sorted with the most sensitive data first
+func (what ReadResult) impactDrivingDataAssets(risk *types.Risk) []*types.DataAsset {
+	parsedModel := what.ParsedModel
+
+	ids := make(map[string]bool)
+	if len(risk.MostRelevantDataAssetId) > 0 {
+		ids[risk.MostRelevantDataAssetId] = true
+	}
+
+	techAssetIds := append([]string{risk.MostRelevantTechnicalAssetId}, risk.DataBreachTechnicalAssetIDs...)
+	for _, techAssetId := range techAssetIds {
+		techAsset := parsedModel.TechnicalAssets[techAssetId]
+		if techAsset == nil {
+			continue
+		}
+
+		for _, id := range techAsset.DataAssetsProcessed {
+			ids[id] = true
+		}
+
+		for _, id := range techAsset.DataAssetsStored {
+			ids[id] = true
+		}
+	}
+
+	commLink := parsedModel.CommunicationLinks[risk.MostRelevantCommunicationLinkId]
+	if commLink != nil {
+		for _, id := range commLink.DataAssetsSent {
+			ids[id] = true
+		}
+
+		for _, id := range commLink.DataAssetsReceived {
+			ids[id] = true
+		}
+	}
+
+	result := make([]*types.DataAsset, 0)
+	for id := range ids {
+		dataAsset := parsedModel.DataAssets[id]
+		if dataAsset != nil {
+			result = append(result, dataAsset)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		left := result[i].Confidentiality.AttackerAttractivenessForAsset() + result[i].Integrity.AttackerAttractivenessForAsset() + result[i].Availability.AttackerAttractivenessForAsset()
+		right := result[j].Confidentiality.AttackerAttractivenessForAsset() + result[j].Integrity.AttackerAttractivenessForAsset() + result[j].Availability.AttackerAttractivenessForAsset()
+		if left == right {
+			return result[i].Title < result[j].Title
+		}
+		return left > right
+	})
+
+	return result
+}
+
+func (what ReadResult) explainRiskTracking(risk *types.Risk, reporter explainRiskReporter) {
+	parsedModel := what.ParsedModel
+
+	tracking := parsedModel.GetRiskTracking(risk)
+	if tracking == nil {
+		tracking = parsedModel.RiskTracking[strings.ToLower(risk.SyntheticId)]
+	}
+
+	if tracking == nil {
+		reporter.Printf("Risk tracking: none (status %v)\n", types.Unchecked.Title())
+		return
+	}
+
+	reporter.Printf("Risk tracking: %v\n", tracking.Status.Title())
+	for pattern := range parsedModel.RiskTracking {
+		if !strings.Contains(pattern, "*") {
+			continue
+		}
+
+		expression := regexp.MustCompile(strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, `[^@]+`))
+		if expression.MatchString(strings.ToLower(risk.SyntheticId)) {
+			reporter.Printf("  applied via wildcard: %v\n", pattern)
+		}
+	}
+	if len(tracking.Justification) > 0 {
+		reporter.Printf("  justification: %v\n", tracking.Justification)
+	}
+	if len(tracking.Ticket) > 0 {
+		reporter.Printf("  ticket:        %v\n", tracking.Ticket)
+	}
+	if len(tracking.CheckedBy) > 0 {
+		reporter.Printf("  checked by:    %v\n", tracking.CheckedBy)
+	}
+	if !tracking.Date.IsZero() {
+		reporter.Printf("  date:          %v\n", tracking.Date.Format("2006-01-02"))
+	}
+}
+
+func removeFormattingTags(content string) string {
+	result := strings.ReplaceAll(strings.ReplaceAll(content, "<b>", ""), "</b>", "")
+	result = strings.ReplaceAll(strings.ReplaceAll(result, "<i>", ""), "</i>", "")
+	result = strings.ReplaceAll(strings.ReplaceAll(result, "<u>", ""), "</u>", "")
+	return result
+}
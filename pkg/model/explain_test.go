@@ -0,0 +1,88 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+func TestExplainRiskUnknownRiskFails(t *testing.T) {
+	result := ReadResult{ParsedModel: createExplainModel()}
+
+	err := result.ExplainRisk(nil, "unknown@risk", new(mockExplainReporter))
+
+	assert.Error(t, err)
+}
+
+func TestExplainRiskPrintsTrailDataAssetsAndTracking(t *testing.T) {
+	result := ReadResult{ParsedModel: createExplainModel()}
+	reporter := new(mockExplainReporter)
+
+	err := result.ExplainRisk(nil, "Test-Category@TA1", reporter)
+
+	assert.NoError(t, err)
+	output := reporter.String()
+	assert.Contains(t, output, "Test Category (test-category, built-in)")
+	assert.Contains(t, output, "Technical asset:     Technical Asset 1 (ta1)")
+	assert.Contains(t, output, "flagged because of reasons")
+	assert.Contains(t, output, "'Severity' is 'high'")
+	assert.Contains(t, output, "Secret Data (da1)")
+	assert.Contains(t, output, "Risk tracking: Mitigated")
+	assert.Contains(t, output, "applied via wildcard: test-category@*")
+	assert.Contains(t, output, "justification: fixed")
+}
+
+func createExplainModel() *types.Model {
+	risk := &types.Risk{
+		CategoryId:                   "test-category",
+		Severity:                     types.HighSeverity,
+		Title:                        "<b>Test Category</b> risk at <b>Technical Asset 1</b>",
+		SyntheticId:                  "test-category@ta1",
+		MostRelevantTechnicalAssetId: "ta1",
+		RiskExplanation:              []string{"flagged because of reasons"},
+		RatingExplanation:            []string{"'Severity' is 'high'"},
+	}
+
+	tracking := &types.RiskTracking{
+		SyntheticRiskId: "test-category@*",
+		Justification:   "fixed",
+		Status:          types.Mitigated,
+	}
+
+	return &types.Model{
+		DataAssets: map[string]*types.DataAsset{
+			"da1": {Id: "da1", Title: "Secret Data", Confidentiality: types.StrictlyConfidential},
+		},
+		TechnicalAssets: map[string]*types.TechnicalAsset{
+			"ta1": {Id: "ta1", Title: "Technical Asset 1", DataAssetsStored: []string{"da1"}},
+		},
+		BuiltInRiskCategories: types.RiskCategories{
+			{ID: "test-category", Title: "Test Category"},
+		},
+		RiskTracking: map[string]*types.RiskTracking{
+			"test-category@*":   tracking,
+			"test-category@ta1": tracking,
+		},
+		GeneratedRisksByCategory:    map[string][]*types.Risk{"test-category": {risk}},
+		GeneratedRisksBySyntheticId: map[string]*types.Risk{"test-category@ta1": risk},
+	}
+}
+
+type mockExplainReporter struct {
+	output strings.Builder
+}
+
+func (m *mockExplainReporter) Println(a ...any) {
+	m.output.WriteString(fmt.Sprintln(a...))
+}
+
+func (m *mockExplainReporter) Printf(format string, a ...any) {
+	m.output.WriteString(fmt.Sprintf(format, a...))
+}
+
+func (m *mockExplainReporter) String() string {
+	return m.output.String()
+}
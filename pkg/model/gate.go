@@ -0,0 +1,98 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/types"
+)
+
+// RiskGate selects the risks that should make a (CI) run fail: risks of at least a given severity
+// that are still in one of the given statuses and are either new or worsened compared to a baseline
+type RiskGate struct {
+	Severity types.RiskSeverity
+	Status   []types.RiskStatus
+	Baseline map[string]*types.Risk
+}
+
+func NewRiskGate(severity string, status []string, baseline []*types.Risk) (*RiskGate, error) {
+	gate := &RiskGate{
+		Status: make([]types.RiskStatus, 0),
+	}
+
+	parsedSeverity, severityError := types.ParseRiskSeverity(strings.TrimSpace(severity))
+	if severityError != nil {
+		return nil, fmt.Errorf("invalid fail-on severity: %w", severityError)
+	}
+	gate.Severity = parsedSeverity
+
+	for _, value := range status {
+		if len(strings.TrimSpace(value)) == 0 {
+			continue
+		}
+
+		parsedStatus, statusError := types.ParseRiskStatus(value)
+		if statusError != nil {
+			return nil, fmt.Errorf("invalid fail-on status: %w", statusError)
+		}
+		gate.Status = append(gate.Status, parsedStatus)
+	}
+
+	if len(gate.Status) == 0 {
+		gate.Status = []types.RiskStatus{types.Unchecked, types.InDiscussion}
+	}
+
+	if baseline != nil {
+		gate.Baseline = make(map[string]*types.Risk)
+		for _, risk := range baseline {
+			gate.Baseline[strings.ToLower(risk.SyntheticId)] = risk
+		}
+	}
+
+	return gate, nil
+}
+
+// FailingRisks returns the risks of the model violating the gate, sorted by severity
+func (what *RiskGate) FailingRisks(parsedModel *types.Model) []*types.Risk {
+	result := make([]*types.Risk, 0)
+	for _, risks := range parsedModel.GeneratedRisksByCategoryWithCurrentStatus() {
+		for _, risk := range risks {
+			if risk.Severity < what.Severity || !what.matchesStatus(risk.RiskStatus) {
+				continue
+			}
+
+			if what.isKnown(risk) {
+				continue
+			}
+
+			result = append(result, risk)
+		}
+	}
+
+	types.SortByRiskSeverity(result)
+	return result
+}
+
+// isKnown returns true if the baseline already contained the risk at the same or higher severity and in a gated status
+func (what *RiskGate) isKnown(risk *types.Risk) bool {
+	if what.Baseline == nil {
+		return false
+	}
+
+	baselineRisk, ok := what.Baseline[strings.ToLower(risk.SyntheticId)]
+	if !ok {
+		return false
+	}
+
+	return baselineRisk.Severity >= risk.Severity && what.matchesStatus(baselineRisk.RiskStatus)
+}
+
+func (what *RiskGate) matchesStatus(status types.RiskStatus) bool {
+	for _, candidate := range what.Status {
+		if candidate == status {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+func TestNewRiskGateInvalidSeverityFails(t *testing.T) {
+	_, err := NewRiskGate("very-bad", nil, nil)
+
+	assert.Error(t, err)
+}
+
+func TestNewRiskGateInvalidStatusFails(t *testing.T) {
+	_, err := NewRiskGate("high", []string{"unknown"}, nil)
+
+	assert.Error(t, err)
+}
+
+func TestRiskGateWithoutBaselineFailsOnUntrackedRisksAboveThreshold(t *testing.T) {
+	gate, err := NewRiskGate("elevated", nil, nil)
+	assert.NoError(t, err)
+
+	failingRisks := gate.FailingRisks(createGateModel())
+
+	assert.Len(t, failingRisks, 1)
+	assert.Equal(t, "high-risk@ta1", failingRisks[0].SyntheticId)
+}
+
+func TestRiskGateHonorsStatusFilter(t *testing.T) {
+	gate, err := NewRiskGate("low", []string{"accepted"}, nil)
+	assert.NoError(t, err)
+
+	failingRisks := gate.FailingRisks(createGateModel())
+
+	assert.Len(t, failingRisks, 1)
+	assert.Equal(t, "accepted-risk@ta1", failingRisks[0].SyntheticId)
+}
+
+func TestRiskGateIgnoresKnownRisksFromBaseline(t *testing.T) {
+	baseline := []*types.Risk{
+		{SyntheticId: "high-risk@ta1", Severity: types.HighSeverity},
+	}
+
+	gate, err := NewRiskGate("elevated", nil, baseline)
+	assert.NoError(t, err)
+
+	assert.Empty(t, gate.FailingRisks(createGateModel()))
+}
+
+func TestRiskGateFailsOnWorsenedRisks(t *testing.T) {
+	baseline := []*types.Risk{
+		{SyntheticId: "high-risk@ta1", Severity: types.MediumSeverity},
+	}
+
+	gate, err := NewRiskGate("elevated", nil, baseline)
+	assert.NoError(t, err)
+
+	assert.Len(t, gate.FailingRisks(createGateModel()), 1)
+}
+
+func TestRiskGateFailsOnRisksNoLongerTracked(t *testing.T) {
+	baseline := []*types.Risk{
+		{SyntheticId: "high-risk@ta1", Severity: types.HighSeverity, RiskStatus: types.Mitigated},
+	}
+
+	gate, err := NewRiskGate("elevated", nil, baseline)
+	assert.NoError(t, err)
+
+	assert.Len(t, gate.FailingRisks(createGateModel()), 1)
+}
+
+func createGateModel() *types.Model {
+	return &types.Model{
+		RiskTracking: map[string]*types.RiskTracking{
+			"accepted-risk@ta1": {SyntheticRiskId: "accepted-risk@ta1", Status: types.Accepted},
+		},
+		GeneratedRisksByCategory: map[string][]*types.Risk{
+			"high-risk":     {{CategoryId: "high-risk", SyntheticId: "high-risk@ta1", Severity: types.HighSeverity}},
+			"low-risk":      {{CategoryId: "low-risk", SyntheticId: "low-risk@ta1", Severity: types.LowSeverity}},
+			"accepted-risk": {{CategoryId: "accepted-risk", SyntheticId: "accepted-risk@ta1", Severity: types.CriticalSeverity}},
+		},
+	}
+}
@@ -0,0 +1,267 @@
+package model
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sort"
+
+	"github.com/threagile/threagile/pkg/types"
+)
+
+// lossExposureIterations is the number of years simulated, the fixed seed keeps reports of the same model reproducible
+const (
+	lossExposureIterations = 10000
+	lossExposureSeed       = 1
+)
+
+// LossExposureRange is the distribution of the annualised loss over the simulated years
+type LossExposureRange struct {
+	Mean float64 `json:"mean" yaml:"mean"`
+	P10  float64 `json:"p10" yaml:"p10"`
+	P50  float64 `json:"p50" yaml:"p50"`
+	P90  float64 `json:"p90" yaml:"p90"`
+}
+
+// RiskLossExposure is the annualised loss caused by one unmitigated risk
+type RiskLossExposure struct {
+	SyntheticId        string            `json:"synthetic_id" yaml:"synthetic_id"`
+	TechnicalAssetId   string            `json:"technical_asset_id" yaml:"technical_asset_id"`
+	LossEventFrequency float64           `json:"loss_event_frequency" yaml:"loss_event_frequency"`
+	AnnualizedLoss     LossExposureRange `json:"annualized_loss" yaml:"annualized_loss"`
+}
+
+// TechnicalAssetLossExposure is the annualised loss caused by all unmitigated risks of a technical asset
+type TechnicalAssetLossExposure struct {
+	TechnicalAssetId string            `json:"technical_asset_id" yaml:"technical_asset_id"`
+	AnnualizedLoss   LossExposureRange `json:"annualized_loss" yaml:"annualized_loss"`
+}
+
+// LossExposure is the FAIR-style estimation of the annualised loss of the model, with the risks and technical assets
+// ranked from the highest to the lowest mean loss
+type LossExposure struct {
+	Currency        string                        `json:"currency,omitempty" yaml:"currency,omitempty"`
+	Iterations      int                           `json:"iterations" yaml:"iterations"`
+	Total           LossExposureRange             `json:"total" yaml:"total"`
+	Risks           []*RiskLossExposure           `json:"risks" yaml:"risks"`
+	TechnicalAssets []*TechnicalAssetLossExposure `json:"technical_assets" yaml:"technical_assets"`
+}
+
+// lossExposureEstimate is the range of a value as minimum, most likely and maximum
+type lossExposureEstimate struct {
+	min, mode, max float64
+}
+
+// EstimateLossExposure simulates the years of the unmitigated risks: the number of loss events per year follows the
+// exploitation likelihood (or the loss_event_frequency of the technical asset), and each event leaks records of the
+// data assets on the breached technical assets with the data breach probability and, for denial-of-service risks,
+// causes downtime of the technical asset. The leaked share of the records and the length of the downtime follow the
+// exploitation impact, the number of records follows the quantity of the data asset. Only risks with financial
+// attributes on the affected assets contribute.
+func EstimateLossExposure(parsedModel *types.Model) *LossExposure {
+	result := &LossExposure{
+		Currency:        parsedModel.Currency,
+		Iterations:      lossExposureIterations,
+		Risks:           make([]*RiskLossExposure, 0),
+		TechnicalAssets: make([]*TechnicalAssetLossExposure, 0),
+	}
+	random := rand.New(rand.NewPCG(lossExposureSeed, lossExposureSeed))
+
+	total := make([]float64, lossExposureIterations)
+	byTechnicalAsset := make(map[string][]float64)
+	for _, risk := range sortedRisksForLossExposure(parsedModel) {
+		technicalAsset := parsedModel.TechnicalAssets[risk.MostRelevantTechnicalAssetId]
+		dataAssets := breachedDataAssets(parsedModel, risk)
+		downtimeCostPerHour := 0.0
+		if technicalAsset != nil && isDenialOfService(parsedModel, risk) {
+			downtimeCostPerHour = technicalAsset.DowntimeCostPerHour
+		}
+		if len(dataAssets) == 0 && downtimeCostPerHour == 0 {
+			continue
+		}
+
+		frequency := lossEventFrequency(risk, technicalAsset)
+		losses := make([]float64, lossExposureIterations)
+		for i := range losses {
+			events := poisson(random, frequency.sample(random))
+			for event := 0; event < events; event++ {
+				for _, dataAsset := range dataAssets {
+					if random.Float64() < breachProbability(risk.DataBreachProbability) {
+						records := recordCount(random, dataAsset.Quantity) * leakedShare(risk.ExploitationImpact).sample(random)
+						losses[i] += records * (dataAsset.RecordValue + dataAsset.BreachCostPerRecord)
+					}
+				}
+				losses[i] += downtimeHours(risk.ExploitationImpact).sample(random) * downtimeCostPerHour
+			}
+			total[i] += losses[i]
+		}
+
+		result.Risks = append(result.Risks, &RiskLossExposure{
+			SyntheticId:        risk.SyntheticId,
+			TechnicalAssetId:   risk.MostRelevantTechnicalAssetId,
+			LossEventFrequency: frequency.mean(),
+			AnnualizedLoss:     newLossExposureRange(losses),
+		})
+		if len(risk.MostRelevantTechnicalAssetId) > 0 {
+			if _, ok := byTechnicalAsset[risk.MostRelevantTechnicalAssetId]; !ok {
+				byTechnicalAsset[risk.MostRelevantTechnicalAssetId] = make([]float64, lossExposureIterations)
+			}
+			for i, loss := range losses {
+				byTechnicalAsset[risk.MostRelevantTechnicalAssetId][i] += loss
+			}
+		}
+	}
+
+	for id, losses := range byTechnicalAsset {
+		result.TechnicalAssets = append(result.TechnicalAssets, &TechnicalAssetLossExposure{TechnicalAssetId: id, AnnualizedLoss: newLossExposureRange(losses)})
+	}
+	result.Total = newLossExposureRange(total)
+
+	sort.SliceStable(result.Risks, func(i, j int) bool {
+		return result.Risks[i].AnnualizedLoss.Mean > result.Risks[j].AnnualizedLoss.Mean
+	})
+	sort.Slice(result.TechnicalAssets, func(i, j int) bool {
+		if result.TechnicalAssets[i].AnnualizedLoss.Mean != result.TechnicalAssets[j].AnnualizedLoss.Mean {
+			return result.TechnicalAssets[i].AnnualizedLoss.Mean > result.TechnicalAssets[j].AnnualizedLoss.Mean
+		}
+		return result.TechnicalAssets[i].TechnicalAssetId < result.TechnicalAssets[j].TechnicalAssetId
+	})
+	return result
+}
+
+// sortedRisksForLossExposure returns the risks still at risk according to the risk tracking in a stable order, so the
+// seeded simulation is reproducible
+func sortedRisksForLossExposure(parsedModel *types.Model) []*types.Risk {
+	risks := make([]*types.Risk, 0)
+	for _, risk := range parsedModel.AllRisks() {
+		if parsedModel.GetRiskTrackingWithDefault(risk).Status.IsStillAtRisk() {
+			risks = append(risks, risk)
+		}
+	}
+	sort.Slice(risks, func(i, j int) bool {
+		return risks[i].SyntheticId < risks[j].SyntheticId
+	})
+	return risks
+}
+
+// breachedDataAssets returns the data assets with financial attributes stored or processed on the technical assets
+// a risk may leak data from
+func breachedDataAssets(parsedModel *types.Model, risk *types.Risk) []*types.DataAsset {
+	ids := make([]string, 0)
+	for _, technicalAssetId := range risk.DataBreachTechnicalAssetIDs {
+		technicalAsset, ok := parsedModel.TechnicalAssets[technicalAssetId]
+		if !ok {
+			continue
+		}
+		for _, id := range append(append([]string{}, technicalAsset.DataAssetsStored...), technicalAsset.DataAssetsProcessed...) {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Strings(ids)
+
+	result := make([]*types.DataAsset, 0)
+	for _, id := range ids {
+		if dataAsset, ok := parsedModel.DataAssets[id]; ok && dataAsset.RecordValue+dataAsset.BreachCostPerRecord > 0 {
+			result = append(result, dataAsset)
+		}
+	}
+	return result
+}
+
+func isDenialOfService(parsedModel *types.Model, risk *types.Risk) bool {
+	category := parsedModel.GetRiskCategory(risk.CategoryId)
+	return category != nil && category.STRIDE == types.DenialOfService
+}
+
+// lossEventFrequency is the number of loss events per year, given by the technical asset or derived from the likelihood
+func lossEventFrequency(risk *types.Risk, technicalAsset *types.TechnicalAsset) lossExposureEstimate {
+	if technicalAsset != nil && technicalAsset.LossEventFrequency > 0 {
+		return lossExposureEstimate{technicalAsset.LossEventFrequency / 2, technicalAsset.LossEventFrequency, technicalAsset.LossEventFrequency * 2}
+	}
+	return [...]lossExposureEstimate{
+		{0.01, 0.05, 0.2},
+		{0.1, 0.3, 1},
+		{0.3, 1, 3},
+		{1, 4, 12},
+	}[risk.ExploitationLikelihood]
+}
+
+func breachProbability(probability types.DataBreachProbability) float64 {
+	return [...]float64{0.05, 0.3, 0.7}[probability]
+}
+
+// recordCount draws the number of records of a data asset log-uniformly from the range of its quantity
+func recordCount(random *rand.Rand, quantity types.Quantity) float64 {
+	limits := [...][2]float64{{1, 100}, {100, 10000}, {10000, 1000000}, {1000000, 100000000}}[quantity]
+	return math.Exp(math.Log(limits[0]) + random.Float64()*(math.Log(limits[1])-math.Log(limits[0])))
+}
+
+// leakedShare is the share of the records leaked by one loss event
+func leakedShare(impact types.RiskExploitationImpact) lossExposureEstimate {
+	return [...]lossExposureEstimate{
+		{0.001, 0.01, 0.1},
+		{0.01, 0.05, 0.3},
+		{0.1, 0.3, 0.7},
+		{0.3, 0.8, 1},
+	}[impact]
+}
+
+// downtimeHours is the duration of the outage caused by one denial-of-service loss event
+func downtimeHours(impact types.RiskExploitationImpact) lossExposureEstimate {
+	return [...]lossExposureEstimate{
+		{0.5, 1, 4},
+		{1, 4, 12},
+		{4, 12, 48},
+		{12, 48, 168},
+	}[impact]
+}
+
+// sample draws from the triangular distribution of the estimate
+func (what lossExposureEstimate) sample(random *rand.Rand) float64 {
+	if what.max <= what.min {
+		return what.mode
+	}
+	u := random.Float64()
+	split := (what.mode - what.min) / (what.max - what.min)
+	if u < split {
+		return what.min + math.Sqrt(u*(what.max-what.min)*(what.mode-what.min))
+	}
+	return what.max - math.Sqrt((1-u)*(what.max-what.min)*(what.max-what.mode))
+}
+
+func (what lossExposureEstimate) mean() float64 {
+	return (what.min + what.mode + what.max) / 3
+}
+
+// poisson draws the number of events in a year, approximated by the normal distribution for high frequencies
+func poisson(random *rand.Rand, lambda float64) int {
+	if lambda > 30 {
+		return max(0, int(math.Round(lambda+math.Sqrt(lambda)*random.NormFloat64())))
+	}
+	limit := math.Exp(-lambda)
+	events := 0
+	for p := random.Float64(); p > limit; p *= random.Float64() {
+		events++
+	}
+	return events
+}
+
+func newLossExposureRange(losses []float64) LossExposureRange {
+	sorted := append([]float64{}, losses...)
+	sort.Float64s(sorted)
+	sum := 0.0
+	for _, loss := range sorted {
+		sum += loss
+	}
+	percentile := func(p float64) float64 {
+		return sorted[int(p*float64(len(sorted)-1))]
+	}
+	return LossExposureRange{
+		Mean: sum / float64(len(sorted)),
+		P10:  percentile(0.1),
+		P50:  percentile(0.5),
+		P90:  percentile(0.9),
+	}
+}
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+func createLossExposureModel(t *testing.T) *types.Model {
+	parsedModel := createAttackPathModel()
+	parsedModel.Currency = "EUR"
+	parsedModel.DataAssets["customers"].Quantity = types.Many
+	parsedModel.DataAssets["customers"].BreachCostPerRecord = 150
+	parsedModel.DataAssets["customers"].RecordValue = 10
+	parsedModel.TechnicalAssets["proxy"].DowntimeCostPerHour = 5000
+	parsedModel.BuiltInRiskCategories = types.RiskCategories{
+		{ID: "sql-injection", STRIDE: types.InformationDisclosure},
+		{ID: "dos", STRIDE: types.DenialOfService},
+	}
+	parsedModel.GeneratedRisksByCategory = map[string][]*types.Risk{
+		"sql-injection": {
+			{CategoryId: "sql-injection", SyntheticId: "sql-injection@database", ExploitationLikelihood: types.VeryLikely, ExploitationImpact: types.HighImpact,
+				MostRelevantTechnicalAssetId: "database", DataBreachProbability: types.Probable, DataBreachTechnicalAssetIDs: []string{"database"}},
+			{CategoryId: "sql-injection", SyntheticId: "sql-injection@logstore", ExploitationLikelihood: types.VeryLikely, ExploitationImpact: types.HighImpact,
+				MostRelevantTechnicalAssetId: "logstore", DataBreachProbability: types.Probable, DataBreachTechnicalAssetIDs: []string{"logstore"}},
+			{CategoryId: "sql-injection", SyntheticId: "sql-injection@app", ExploitationLikelihood: types.Frequent,
+				MostRelevantTechnicalAssetId: "app", DataBreachProbability: types.Probable, DataBreachTechnicalAssetIDs: []string{"database"}},
+		},
+		"dos": {
+			{CategoryId: "dos", SyntheticId: "dos@proxy", ExploitationLikelihood: types.Likely, ExploitationImpact: types.MediumImpact, MostRelevantTechnicalAssetId: "proxy"},
+			{CategoryId: "dos", SyntheticId: "dos@app", ExploitationLikelihood: types.Frequent, ExploitationImpact: types.HighImpact, MostRelevantTechnicalAssetId: "app"},
+		},
+	}
+	parsedModel.GeneratedRisksBySyntheticId = make(map[string]*types.Risk)
+	for _, risk := range parsedModel.AllRisks() {
+		parsedModel.GeneratedRisksBySyntheticId[risk.SyntheticId] = risk
+	}
+
+	// risks are mitigated through the risk tracking only, one of them by a wildcard entry
+	parsedModel.RiskTracking = map[string]*types.RiskTracking{
+		"sql-injection@app":      {SyntheticRiskId: "sql-injection@app", Status: types.Mitigated},
+		"sql-injection@logstore": {SyntheticRiskId: "sql-injection@logstore", Status: types.InDiscussion},
+		"dos@*":                  {SyntheticRiskId: "dos@*", Status: types.Accepted},
+		"dos@proxy":              {SyntheticRiskId: "dos@proxy", Status: types.Unchecked},
+	}
+	assert.NoError(t, parsedModel.ApplyWildcardRiskTrackingEvaluation(false, new(warningCollector)))
+	return parsedModel
+}
+
+func TestEstimateLossExposureOfUnmitigatedRisksWithFinancialAttributes(t *testing.T) {
+	exposure := EstimateLossExposure(createLossExposureModel(t))
+
+	assert.Equal(t, "EUR", exposure.Currency)
+	assert.Equal(t, lossExposureIterations, exposure.Iterations)
+	assert.Len(t, exposure.Risks, 2)
+	assert.Equal(t, "sql-injection@database", exposure.Risks[0].SyntheticId)
+	assert.Equal(t, "dos@proxy", exposure.Risks[1].SyntheticId)
+	assert.Equal(t, []string{"database", "proxy"}, []string{exposure.TechnicalAssets[0].TechnicalAssetId, exposure.TechnicalAssets[1].TechnicalAssetId})
+
+	for _, risk := range exposure.Risks {
+		loss := risk.AnnualizedLoss
+		assert.True(t, loss.P10 <= loss.P50 && loss.P50 <= loss.P90, risk.SyntheticId)
+		assert.Greater(t, loss.Mean, 0.0, risk.SyntheticId)
+	}
+	dos := exposure.Risks[1]
+	expected := (0.1 + 0.3 + 1) / 3 * (1 + 4 + 12) / 3 * 5000
+	assert.InDelta(t, expected, dos.AnnualizedLoss.Mean, 0.05*expected)
+	assert.InDelta(t, exposure.Risks[0].AnnualizedLoss.Mean+dos.AnnualizedLoss.Mean, exposure.Total.Mean, 0.01)
+}
+
+func TestEstimateLossExposureIsReproducibleAndHonoursLossEventFrequency(t *testing.T) {
+	parsedModel := createLossExposureModel(t)
+	assert.Equal(t, EstimateLossExposure(parsedModel), EstimateLossExposure(parsedModel))
+
+	parsedModel.TechnicalAssets["proxy"].LossEventFrequency = 10
+	exposure := EstimateLossExposure(parsedModel)
+	for _, risk := range exposure.Risks {
+		if risk.SyntheticId == "dos@proxy" {
+			assert.InDelta(t, 11.67, risk.LossEventFrequency, 0.01)
+		}
+	}
+
+	parsedModel.DataAssets["customers"].BreachCostPerRecord = 0
+	parsedModel.DataAssets["customers"].RecordValue = 0
+	parsedModel.TechnicalAssets["proxy"].DowntimeCostPerHour = 0
+	exposure = EstimateLossExposure(parsedModel)
+	assert.Empty(t, exposure.Risks)
+	assert.Empty(t, exposure.TechnicalAssets)
+	assert.Equal(t, 0.0, exposure.Total.P90)
+}
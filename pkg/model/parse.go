@@ -49,6 +49,7 @@ func ParseModel(config technologyMapConfigReader, modelInput *input.Model, built
 		BusinessOverview:               removePathElementsFromImageFiles(modelInput.BusinessOverview),
 		TechnicalOverview:              removePathElementsFromImageFiles(modelInput.TechnicalOverview),
 		BusinessCriticality:            businessCriticality,
+		Currency:                       modelInput.Currency,
 		ManagementSummaryComment:       modelInput.ManagementSummaryComment,
 		SecurityRequirements:           modelInput.SecurityRequirements,
 		Questions:                      modelInput.Questions,
@@ -102,6 +103,9 @@ func ParseModel(config technologyMapConfigReader, modelInput *input.Model, built
 		if err != nil {
 			return nil, fmt.Errorf("unknown 'availability' value of data asset %q: %v", title, asset.Availability)
 		}
+		if asset.RecordValue < 0 || asset.BreachCostPerRecord < 0 {
+			return nil, fmt.Errorf("negative 'record_value' or 'breach_cost_per_record' value of data asset %q", title)
+		}
 
 		err = checkIdSyntax(id)
 		if err != nil {
@@ -127,6 +131,8 @@ func ParseModel(config technologyMapConfigReader, modelInput *input.Model, built
 			Integrity:              integrity,
 			Availability:           availability,
 			JustificationCiaRating: fmt.Sprintf("%v", asset.JustificationCiaRating),
+			RecordValue:            asset.RecordValue,
+			BreachCostPerRecord:    asset.BreachCostPerRecord,
 		}
 	}
 
@@ -180,6 +186,9 @@ func ParseModel(config technologyMapConfigReader, modelInput *input.Model, built
 		if err != nil {
 			return nil, fmt.Errorf("unknown 'size' value of technical asset %q: %v", title, asset.Size)
 		}
+		if asset.DowntimeCostPerHour < 0 || asset.LossEventFrequency < 0 {
+			return nil, fmt.Errorf("negative 'downtime_cost_per_hour' or 'loss_event_frequency' value of technical asset %q", title)
+		}
 
 		technicalAssetTechnologies := make([]*types.Technology, 0)
 
@@ -368,6 +377,8 @@ func ParseModel(config technologyMapConfigReader, modelInput *input.Model, built
 			DataFormatsAccepted:     dataFormatsAccepted,
 			CommunicationLinks:      communicationLinks,
 			DiagramTweakOrder:       asset.DiagramTweakOrder,
+			DowntimeCostPerHour:     asset.DowntimeCostPerHour,
+			LossEventFrequency:      asset.LossEventFrequency,
 		}
 	}
 
@@ -704,6 +715,29 @@ func ParseModel(config technologyMapConfigReader, modelInput *input.Model, built
 		parsedModel.RiskTracking[syntheticRiskId] = tracking
 	}
 
+	// Abuse Case Targets ===============================================================================
+	parsedModel.AbuseCaseTargets = make(map[string]*types.AbuseCaseTarget)
+	for abuseCase, abuseCaseTarget := range modelInput.AbuseCaseTargets {
+		if _, ok := parsedModel.AbuseCases[abuseCase]; !ok {
+			return nil, fmt.Errorf("missing referenced abuse case at abuse case targets: %v", abuseCase)
+		}
+		where := fmt.Sprintf("abuse case target %q", abuseCase)
+		for _, dataAssetId := range abuseCaseTarget.DataAssets {
+			if err := parsedModel.CheckDataAssetTargetExists(dataAssetId, where); err != nil {
+				return nil, err
+			}
+		}
+		for _, technicalAssetId := range abuseCaseTarget.TechnicalAssets {
+			if err := parsedModel.CheckTechnicalAssetExists(technicalAssetId, where, false); err != nil {
+				return nil, err
+			}
+		}
+		parsedModel.AbuseCaseTargets[abuseCase] = &types.AbuseCaseTarget{
+			DataAssets:      abuseCaseTarget.DataAssets,
+			TechnicalAssets: abuseCaseTarget.TechnicalAssets,
+		}
+	}
+
 	// ====================== model consistency check (linking)
 	for _, technicalAsset := range parsedModel.TechnicalAssets {
 		for _, commLink := range technicalAsset.CommunicationLinks {
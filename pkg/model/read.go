@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/risks/script"
 	"github.com/threagile/threagile/pkg/types"
 )
 
@@ -19,16 +20,6 @@ type ReadResult struct {
 	CustomRiskRules  types.RiskRules
 }
 
-type explainRiskConfig interface {
-}
-
-type explainRiskReporter interface {
-}
-
-func (what ReadResult) ExplainRisk(cfg explainRiskConfig, risk string, reporter explainRiskReporter) error {
-	return fmt.Errorf("not implemented")
-}
-
 // TODO: consider about splitting this function into smaller ones for better reusability
 
 type configReader interface {
@@ -57,6 +48,7 @@ type configReader interface {
 	GetTemplateFilename() string
 	GetTechnologyFilename() string
 	GetRiskRulePlugins() []string
+	GetScriptRiskRules() []string
 	GetSkipRiskRules() []string
 	GetExecuteModelMacro() string
 	GetRiskExcelConfigHideColumns() []string
@@ -72,6 +64,7 @@ type configReader interface {
 	GetAddLegend() bool
 	GetKeepDiagramSourceFiles() bool
 	GetIgnoreOrphanedRiskTracking() bool
+	GetSeverityMatrix() *types.SeverityMatrix
 	GetThreagileVersion() string
 	GetProgressReporter() types.ProgressReporter
 }
@@ -97,6 +90,11 @@ func ReadAndAnalyzeModel(config configReader, builtinRiskRules types.RiskRules,
 }
 
 func AnalyzeModel(modelInput *input.Model, config configReader, builtinRiskRules types.RiskRules, customRiskRules types.RiskRules, progressReporter types.ProgressReporter) (*ReadResult, error) {
+	scriptRiskRules, scriptError := loadAllScriptRiskRules(modelInput, config.GetPluginFolder(), config.GetScriptRiskRules(), progressReporter)
+	if scriptError != nil {
+		return nil, fmt.Errorf("unable to load script risk rules: %w", scriptError)
+	}
+	builtinRiskRules, customRiskRules = addScriptRiskRules(builtinRiskRules, customRiskRules, scriptRiskRules, progressReporter)
 
 	parsedModel, parseError := ParseModel(config, modelInput, builtinRiskRules, customRiskRules)
 	if parseError != nil {
@@ -105,7 +103,7 @@ func AnalyzeModel(modelInput *input.Model, config configReader, builtinRiskRules
 
 	introTextRAA := applyRAA(parsedModel, progressReporter)
 
-	applyRiskGeneration(parsedModel, builtinRiskRules.Merge(customRiskRules), config.GetSkipRiskRules(), progressReporter)
+	applyRiskGeneration(parsedModel, builtinRiskRules.Merge(customRiskRules), config.GetSkipRiskRules(), config.GetSeverityMatrix(), progressReporter)
 	err := parsedModel.ApplyWildcardRiskTrackingEvaluation(config.GetIgnoreOrphanedRiskTracking(), progressReporter)
 	if err != nil {
 		return nil, fmt.Errorf("unable to apply wildcard risk tracking evaluation: %w", err)
@@ -126,7 +124,7 @@ func AnalyzeModel(modelInput *input.Model, config configReader, builtinRiskRules
 }
 
 func applyRiskGeneration(parsedModel *types.Model, rules types.RiskRules,
-	skipRiskRules []string,
+	skipRiskRules []string, severityMatrix *types.SeverityMatrix,
 	progressReporter types.ProgressReporter) {
 	progressReporter.Info("Applying risk generation")
 
@@ -137,6 +135,7 @@ func applyRiskGeneration(parsedModel *types.Model, rules types.RiskRules,
 		}
 	}
 
+	activeRules := make(types.RiskRules)
 	for id, rule := range rules {
 		_, ok := skippedRules[id]
 		if ok {
@@ -146,12 +145,37 @@ func applyRiskGeneration(parsedModel *types.Model, rules types.RiskRules,
 		}
 
 		parsedModel.AddToListOfSupportedTags(rule.SupportedTags())
-		newRisks, riskError := rule.GenerateRisks(parsedModel)
+		activeRules[id] = rule
+	}
+
+	// script risk rules share one view of the model, created before any risks are generated
+	scriptModelView, viewError := script.NewModelView(parsedModel)
+	if viewError != nil {
+		progressReporter.Warnf("Unable to create model view for script risk rules: %v", viewError)
+	}
+
+	for id, rule := range activeRules {
+		var newRisks []*types.Risk
+		var riskError error
+		scriptRule, isScriptRule := rule.(*script.RiskRule)
+		if isScriptRule && viewError == nil {
+			newRisks, riskError = scriptRule.GenerateRisksForModelView(scriptModelView, severityMatrix)
+		} else {
+			newRisks, riskError = rule.GenerateRisks(parsedModel)
+		}
 		if riskError != nil {
 			progressReporter.Warnf("Error generating risks for %q: %v", id, riskError)
 			continue
 		}
 
+		if (severityMatrix != nil && !isScriptRule) || severityMatrix.OverridesCategory(id) {
+			// script rules calculate severities with the matrix of the config, other rules rate with the default
+			// matrix and their risks are rated here; the overrides of a category apply to the risks of all rules
+			for _, risk := range newRisks {
+				risk.Severity = severityMatrix.Severity(id, risk.ExploitationLikelihood, risk.ExploitationImpact)
+			}
+		}
+
 		if len(newRisks) > 0 {
 			parsedModel.GeneratedRisksByCategory[id] = newRisks
 		}
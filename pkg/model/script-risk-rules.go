@@ -0,0 +1,195 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/risks/script"
+	"github.com/threagile/threagile/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadScriptRiskRules loads the YAML risk rule scripts in the plugin folder and in the given files or folders.
+// YAML files in the plugin folder without an id and a risk script are no risk rules and skipped.
+func LoadScriptRiskRules(pluginDir string, scriptRuleFiles []string, reporter types.ProgressReporter) types.RiskRules {
+	scriptRiskRules := make(types.RiskRules)
+	if len(pluginDir) > 0 {
+		loadScriptRiskRulesFromFolder(scriptRiskRules, pluginDir, true, reporter)
+	}
+
+	for _, filename := range scriptRuleFiles {
+		if len(filename) == 0 {
+			continue
+		}
+
+		fileInfo, statError := os.Stat(filename)
+		if statError != nil {
+			reporter.Warnf("Script risk rule %q not loaded: %v", filename, statError)
+			continue
+		}
+
+		if fileInfo.IsDir() {
+			loadScriptRiskRulesFromFolder(scriptRiskRules, filename, false, reporter)
+		} else {
+			loadScriptRiskRuleFromFile(scriptRiskRules, filename, false, reporter)
+		}
+	}
+
+	return scriptRiskRules
+}
+
+func loadScriptRiskRulesFromFolder(scriptRiskRules types.RiskRules, folder string, skipOtherFiles bool, reporter types.ProgressReporter) {
+	entries, readError := os.ReadDir(folder)
+	if readError != nil {
+		if !skipOtherFiles {
+			reporter.Warnf("Script risk rules in %q not loaded: %v", folder, readError)
+		}
+		return
+	}
+
+	for _, entry := range entries {
+		extension := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (extension != ".yaml" && extension != ".yml") {
+			continue
+		}
+
+		loadScriptRiskRuleFromFile(scriptRiskRules, filepath.Join(folder, entry.Name()), skipOtherFiles, reporter)
+	}
+}
+
+func loadScriptRiskRuleFromFile(scriptRiskRules types.RiskRules, filename string, skipOtherFiles bool, reporter types.ProgressReporter) {
+	// #nosec G304 // fine for potential file for now because used mostly internally or as part of CI/CD
+	data, readError := os.ReadFile(filename)
+	if readError != nil {
+		reporter.Warnf("Script risk rule %q not loaded: %v", filename, readError)
+		return
+	}
+
+	if !isScriptRiskRule(data) {
+		if !skipOtherFiles {
+			reporter.Warnf("Script risk rule %q not loaded: no id or risk script found", filename)
+		}
+		return
+	}
+
+	rule, parseError := new(script.RiskRule).Init().ParseFromData(data)
+	if parseError != nil {
+		reporter.Warnf("Script risk rule %q not loaded: %v", filename, parseError)
+		return
+	}
+
+	if _, ok := scriptRiskRules[rule.Category().ID]; ok {
+		reporter.Warnf("script risk rule %q from %q shadows script risk rule loaded before", rule.Category().ID, filename)
+	}
+
+	scriptRiskRules[rule.Category().ID] = rule
+	reporter.Info("Script risk rule loaded:", rule.Category().ID)
+}
+
+// isScriptRiskRule tells YAML risk rule scripts apart from the other YAML files, like models, in the plugin folder
+func isScriptRiskRule(data []byte) bool {
+	var rule struct {
+		ID   string `yaml:"id"`
+		Risk any    `yaml:"risk"`
+	}
+
+	if yaml.Unmarshal(data, &rule) != nil {
+		return false
+	}
+
+	return len(rule.ID) > 0 && rule.Risk != nil
+}
+
+// loadAllScriptRiskRules loads the script risk rules of the plugin folder, the given files or folders and the model,
+// rules of the model shadow the others
+func loadAllScriptRiskRules(modelInput *input.Model, pluginDir string, scriptRuleFiles []string, progressReporter types.ProgressReporter) (types.RiskRules, error) {
+	scriptRiskRules := LoadScriptRiskRules(pluginDir, scriptRuleFiles, progressReporter)
+
+	modelRiskRules, parseError := ParseScriptRiskRules(modelInput.ScriptRiskRules)
+	if parseError != nil {
+		return nil, parseError
+	}
+
+	for id, rule := range modelRiskRules {
+		if _, ok := scriptRiskRules[id]; ok {
+			progressReporter.Warnf("script risk rule %q of the model shadows script risk rule loaded from file", id)
+		}
+
+		scriptRiskRules[id] = rule
+	}
+
+	return scriptRiskRules, nil
+}
+
+// ParseScriptRiskRules parses the script risk rules of the model section script_risk_rules, keyed by rule id
+func ParseScriptRiskRules(rules map[string]input.ScriptRiskRule) (types.RiskRules, error) {
+	scriptRiskRules := make(types.RiskRules)
+	for id, rule := range rules {
+		if ruleId, ok := rule["id"]; ok && fmt.Sprint(ruleId) != id {
+			return nil, fmt.Errorf("script risk rule %q has different id %q", id, ruleId)
+		}
+
+		ruleWithId := make(map[string]any, len(rule)+1)
+		for key, value := range rule {
+			ruleWithId[key] = value
+		}
+		ruleWithId["id"] = id
+
+		data, marshalError := yaml.Marshal(ruleWithId)
+		if marshalError != nil {
+			return nil, fmt.Errorf("unable to read script risk rule %q: %w", id, marshalError)
+		}
+
+		parsedRule, parseError := new(script.RiskRule).Init().ParseFromData(data)
+		if parseError != nil {
+			return nil, fmt.Errorf("unable to parse script risk rule %q: %w", id, parseError)
+		}
+
+		scriptRiskRules[id] = parsedRule
+	}
+
+	return scriptRiskRules, nil
+}
+
+// addScriptRiskRules adds the script risk rules to the custom risk rules. A script risk rule shadowing a built-in or
+// custom risk rule replaces it, the given rules are left untouched.
+func addScriptRiskRules(builtinRiskRules types.RiskRules, customRiskRules types.RiskRules, scriptRiskRules types.RiskRules, progressReporter types.ProgressReporter) (types.RiskRules, types.RiskRules) {
+	if len(scriptRiskRules) == 0 {
+		return builtinRiskRules, customRiskRules
+	}
+
+	builtinResult := make(types.RiskRules)
+	for id, rule := range builtinRiskRules {
+		builtinResult[id] = rule
+	}
+
+	customResult := make(types.RiskRules)
+	for id, rule := range customRiskRules {
+		customResult[id] = rule
+	}
+
+	ids := make([]string, 0)
+	for id := range scriptRiskRules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		if rule, ok := builtinResult[id]; ok && rule != nil {
+			progressReporter.Warnf("script risk rule %q shadows built-in risk rule", id)
+			delete(builtinResult, id)
+		}
+
+		if rule, ok := customResult[id]; ok && rule != nil {
+			progressReporter.Warnf("script risk rule %q shadows custom risk rule", id)
+		}
+
+		customResult[id] = scriptRiskRules[id]
+	}
+
+	return builtinResult, customResult
+}
@@ -0,0 +1,226 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/risks"
+	"github.com/threagile/threagile/pkg/risks/script"
+	"github.com/threagile/threagile/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+const legacyComponentRule = `
+id: legacy-component
+title: Legacy Component
+function: operations
+stride: tampering
+cwe: 1104
+risk:
+  id:
+    parameter: tech_asset
+    id: "{$risk.id}@{tech_asset.id}"
+  data:
+    parameter: tech_asset
+    title: "<b>Legacy Component</b> risk at <b>{tech_asset.title}</b>"
+    severity: medium
+    exploitation_likelihood: likely
+    exploitation_impact: medium
+    data_breach_probability: improbable
+    most_relevant_technical_asset: "{tech_asset.id}"
+  match:
+    parameter: tech_asset
+    do:
+      - if:
+          contains:
+            item: legacy
+            in: "{tech_asset.tags}"
+          then:
+            return: true
+`
+
+type warningCollector struct {
+	warnings []string
+}
+
+func (what *warningCollector) Info(...any)                   {}
+func (what *warningCollector) Warn(a ...any)                 { what.warnings = append(what.warnings, fmt.Sprint(a...)) }
+func (what *warningCollector) Error(...any)                  {}
+func (what *warningCollector) Infof(string, ...any)          {}
+func (what *warningCollector) Warnf(format string, a ...any) { what.Warn(fmt.Sprintf(format, a...)) }
+func (what *warningCollector) Errorf(string, ...any)         {}
+
+func writeScriptRiskRule(t *testing.T, folder string, filename string, content string) string {
+	path := filepath.Join(folder, filename)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadScriptRiskRulesFromPluginFolderAndFiles(t *testing.T) {
+	pluginDir := t.TempDir()
+	writeScriptRiskRule(t, pluginDir, "legacy-component.yaml", legacyComponentRule)
+	writeScriptRiskRule(t, pluginDir, "threagile.yaml", "title: Some Model\ntechnical_assets: {}\n")
+	writeScriptRiskRule(t, pluginDir, "notes.txt", legacyComponentRule)
+
+	otherDir := t.TempDir()
+	otherRule := writeScriptRiskRule(t, otherDir, "other.yml", strings.Replace(legacyComponentRule, "id: legacy-component\ntitle: Legacy Component", "id: other-rule\ntitle: Other", 1))
+	noRule := writeScriptRiskRule(t, otherDir, "no-rule.yaml", "title: Some Model\n")
+
+	reporter := new(warningCollector)
+	rules := LoadScriptRiskRules(pluginDir, []string{otherRule, noRule, filepath.Join(otherDir, "missing.yaml")}, reporter)
+
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "Legacy Component", rules["legacy-component"].Category().Title)
+	assert.Equal(t, "Other", rules["other-rule"].Category().Title)
+	assert.Len(t, reporter.warnings, 2)
+
+	risks, err := rules["legacy-component"].GenerateRisks(&types.Model{
+		TechnicalAssets: map[string]*types.TechnicalAsset{
+			"app": {Id: "app", Title: "App", Tags: []string{"legacy"}},
+			"db":  {Id: "db", Title: "DB"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, risks, 1)
+	assert.Equal(t, "legacy-component@app", risks[0].SyntheticId)
+}
+
+func TestParseScriptRiskRulesOfModel(t *testing.T) {
+	var rule input.ScriptRiskRule
+	assert.NoError(t, yaml.Unmarshal([]byte(legacyComponentRule), &rule))
+	delete(rule, "id")
+
+	rules, err := ParseScriptRiskRules(map[string]input.ScriptRiskRule{"model-rule": rule})
+	assert.NoError(t, err)
+	assert.Equal(t, "model-rule", rules["model-rule"].Category().ID)
+
+	rule["id"] = "something-else"
+	_, err = ParseScriptRiskRules(map[string]input.ScriptRiskRule{"model-rule": rule})
+	assert.Error(t, err)
+}
+
+func TestAddScriptRiskRulesShadowsBuiltInAndCustomRules(t *testing.T) {
+	pluginDir := t.TempDir()
+	writeScriptRiskRule(t, pluginDir, "legacy-component.yaml", legacyComponentRule)
+	scriptRules := LoadScriptRiskRules(pluginDir, nil, new(warningCollector))
+	scriptRules["missing-waf"] = scriptRules["legacy-component"]
+
+	builtinRules := types.RiskRules{"missing-waf": &CustomRiskCategory{}, "path-traversal": &CustomRiskCategory{}}
+	customRules := types.RiskRules{"legacy-component": &CustomRiskCategory{}}
+	reporter := new(warningCollector)
+	builtinResult, customResult := addScriptRiskRules(builtinRules, customRules, scriptRules, reporter)
+
+	assert.Equal(t, []string{
+		`script risk rule "legacy-component" shadows custom risk rule`,
+		`script risk rule "missing-waf" shadows built-in risk rule`,
+	}, reporter.warnings)
+	assert.Len(t, builtinResult, 1)
+	assert.Contains(t, builtinResult, "path-traversal")
+	assert.Len(t, customResult, 2)
+	assert.Equal(t, scriptRules["legacy-component"], customResult["legacy-component"])
+	assert.Len(t, builtinRules, 2)
+	assert.IsType(t, &CustomRiskCategory{}, customRules["legacy-component"])
+}
+
+func loadTestModel(t testing.TB, filename string, rules types.RiskRules) *types.Model {
+	modelInput := new(input.Model).Defaults()
+	assert.NoError(t, modelInput.Load(filepath.Join("..", "..", "test", filename)))
+
+	parsedModel, parseError := ParseModel(&mockConfig{}, modelInput, rules, make(types.RiskRules))
+	assert.NoError(t, parseError)
+	return parsedModel
+}
+
+func yamlModelView(t testing.TB, parsedModel *types.Model) map[string]any {
+	data, marshalError := yaml.Marshal(parsedModel)
+	assert.NoError(t, marshalError)
+
+	var view map[string]any
+	assert.NoError(t, yaml.Unmarshal(data, &view))
+	return view
+}
+
+func TestScriptModelViewMatchesYamlRoundTrip(t *testing.T) {
+	rules := risks.GetBuiltInRiskRules()
+	parsedModel := loadTestModel(t, "main.yaml", rules)
+	applyRiskGeneration(parsedModel, rules, nil, nil, new(warningCollector))
+	assert.NotEmpty(t, parsedModel.GeneratedRisksByCategory)
+
+	view, viewError := script.NewModelView(parsedModel)
+	assert.NoError(t, viewError)
+	assert.Equal(t, yamlModelView(t, parsedModel), view)
+}
+
+// BenchmarkScriptRiskRules compares converting the model through YAML for every script risk rule with converting it
+// once per rule and once per analysis, for each of the test models
+func BenchmarkScriptRiskRules(b *testing.B) {
+	scriptRules, loadError := risks.GetScriptRiskRules()
+	assert.NoError(b, loadError)
+
+	for _, filename := range []string{"all.yaml", "main.yaml"} {
+		parsedModel := loadTestModel(b, filename, types.RiskRules(scriptRules))
+
+		b.Run(filename+"/yaml-per-rule", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, rule := range scriptRules {
+					_, riskError := rule.(*script.RiskRule).GenerateRisksForModelView(yamlModelView(b, parsedModel), nil)
+					assert.NoError(b, riskError)
+				}
+			}
+		})
+
+		b.Run(filename+"/view-per-rule", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, rule := range scriptRules {
+					_, riskError := rule.GenerateRisks(parsedModel)
+					assert.NoError(b, riskError)
+				}
+			}
+		})
+
+		b.Run(filename+"/shared-view", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				modelView, viewError := script.NewModelView(parsedModel)
+				assert.NoError(b, viewError)
+				for _, rule := range scriptRules {
+					_, riskError := rule.(*script.RiskRule).GenerateRisksForModelView(modelView, nil)
+					assert.NoError(b, riskError)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyRiskGenerationRatesWithSeverityMatrixOfConfig(t *testing.T) {
+	scriptRule, err := new(script.RiskRule).Init().ParseFromData([]byte(strings.Replace(legacyComponentRule, "severity: medium", `severity: "calculate_severity(likely, medium)"`, 1)))
+	assert.NoError(t, err)
+
+	rules := risks.GetBuiltInRiskRules().Merge(types.RiskRules{"legacy-component": scriptRule})
+	everythingCritical := &types.SeverityMatrix{Thresholds: map[string]float64{"low": 0, "medium": 0, "elevated": 0, "high": 0}}
+
+	ratedModel := loadTestModel(t, "main.yaml", rules)
+	ratedModel.TechnicalAssets["apache-webserver"].Tags = append(ratedModel.TechnicalAssets["apache-webserver"].Tags, "legacy")
+	applyRiskGeneration(ratedModel, rules, nil, everythingCritical, new(warningCollector))
+	assert.NotEmpty(t, ratedModel.GeneratedRisksByCategory["legacy-component"])
+	for id := range rules {
+		for _, risk := range ratedModel.GeneratedRisksByCategory[id] {
+			assert.Equal(t, types.CriticalSeverity, risk.Severity, risk.SyntheticId)
+		}
+	}
+
+	// the matrix of one analysis does not leak into the next one
+	defaultModel := loadTestModel(t, "main.yaml", rules)
+	defaultModel.TechnicalAssets["apache-webserver"].Tags = append(defaultModel.TechnicalAssets["apache-webserver"].Tags, "legacy")
+	applyRiskGeneration(defaultModel, rules, nil, nil, new(warningCollector))
+	assert.Equal(t, types.ElevatedSeverity, defaultModel.GeneratedRisksByCategory["legacy-component"][0].Severity)
+	for id := range rules {
+		for _, risk := range defaultModel.GeneratedRisksByCategory[id] {
+			assert.Equal(t, types.CalculateSeverity(risk.ExploitationLikelihood, risk.ExploitationImpact), risk.Severity, risk.SyntheticId)
+		}
+	}
+}
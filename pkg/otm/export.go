@@ -0,0 +1,321 @@
+package otm
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+const (
+	internetTrustZoneId = "internet"
+	defaultTrustZoneId  = "default"
+)
+
+type exporter struct {
+	modelInput  *input.Model
+	parsedModel *types.Model
+	document    *Document
+
+	componentIndex map[string]int
+	dataflowIndex  map[string]int
+}
+
+// Export converts a threagile model into an OTM document; the risks (generated and individual ones) are taken
+// from the analyzed model and exported as threats with their risk tracking status, skipped if parsedModel is nil
+func Export(modelInput *input.Model, parsedModel *types.Model) *Document {
+	what := &exporter{
+		modelInput:     modelInput,
+		parsedModel:    parsedModel,
+		document:       &Document{OtmVersion: Version},
+		componentIndex: make(map[string]int),
+		dataflowIndex:  make(map[string]int),
+	}
+
+	what.exportProject()
+	what.exportAssets()
+	what.exportTrustZones()
+	what.exportComponents()
+	what.exportDataflows()
+	if parsedModel != nil {
+		what.exportThreats()
+	}
+
+	return what.document
+}
+
+func (what *exporter) exportProject() {
+	// shared runtimes have no OTM equivalent and are kept with the other model level settings
+	project := *what.modelInput
+	project.DataAssets = nil
+	project.TechnicalAssets = nil
+	project.TrustBoundaries = nil
+	project.Includes = nil
+
+	description := what.modelInput.AppDescription.Description
+	if len(description) == 0 {
+		description = what.modelInput.BusinessOverview.Description
+	}
+
+	what.document.Project = Project{
+		Name:         what.modelInput.Title,
+		Id:           types.MakeID(what.modelInput.Title),
+		Description:  description,
+		Owner:        what.modelInput.Author.Name,
+		OwnerContact: what.modelInput.Author.Contact,
+		Attributes:   toAttributes(project),
+	}
+
+	what.document.Representations = []Representation{{
+		Name: what.modelInput.Title + " Data-Flow Diagram",
+		Id:   types.MakeID(what.modelInput.Title) + "-diagram",
+		Type: "threat-model",
+	}}
+}
+
+func (what *exporter) exportAssets() {
+	for _, title := range sortedKeys(what.modelInput.DataAssets) {
+		dataAsset := what.modelInput.DataAssets[title]
+		confidentiality, _ := types.ParseConfidentiality(dataAsset.Confidentiality)
+		integrity, _ := types.ParseCriticality(dataAsset.Integrity)
+		availability, _ := types.ParseCriticality(dataAsset.Availability)
+
+		what.document.Assets = append(what.document.Assets, Asset{
+			Name:        title,
+			Id:          dataAsset.ID,
+			Description: dataAsset.Description,
+			Risk: AssetRisk{
+				Confidentiality: scaleFromIndex(int(confidentiality), len(types.ConfidentialityValues())),
+				Integrity:       scaleFromIndex(int(integrity), len(types.CriticalityValues())),
+				Availability:    scaleFromIndex(int(availability), len(types.CriticalityValues())),
+				Comment:         dataAsset.JustificationCiaRating,
+			},
+			Attributes: toAttributes(dataAsset),
+		})
+	}
+}
+
+func (what *exporter) exportTrustZones() {
+	parents := make(map[string]string)
+	for _, trustBoundary := range what.modelInput.TrustBoundaries {
+		for _, nested := range trustBoundary.TrustBoundariesNested {
+			parents[nested] = trustBoundary.ID
+		}
+	}
+
+	for _, title := range sortedKeys(what.modelInput.TrustBoundaries) {
+		trustBoundary := what.modelInput.TrustBoundaries[title]
+		zone := TrustZone{
+			Id:          trustBoundary.ID,
+			Name:        title,
+			Type:        trustBoundary.Type,
+			Description: trustBoundary.Description,
+			Risk:        TrustZoneRisk{TrustRating: trustRating(trustBoundary.Type)},
+			Attributes:  toAttributes(trustBoundary),
+		}
+
+		if parent, ok := parents[trustBoundary.ID]; ok {
+			zone.Parent = &Parent{TrustZone: parent}
+		}
+
+		what.document.TrustZones = append(what.document.TrustZones, zone)
+	}
+}
+
+func (what *exporter) exportComponents() {
+	trustBoundaries := make(map[string]string)
+	for _, trustBoundary := range what.modelInput.TrustBoundaries {
+		for _, technicalAssetId := range trustBoundary.TechnicalAssetsInside {
+			trustBoundaries[technicalAssetId] = trustBoundary.ID
+		}
+	}
+
+	implicitZones := make(map[string]bool)
+	for _, title := range sortedKeys(what.modelInput.TechnicalAssets) {
+		technicalAsset := what.modelInput.TechnicalAssets[title]
+		zone, ok := trustBoundaries[technicalAsset.ID]
+		if !ok {
+			zone = defaultTrustZoneId
+			if technicalAsset.Internet {
+				zone = internetTrustZoneId
+			}
+			implicitZones[zone] = true
+		}
+
+		componentType := technicalAsset.Technology
+		if len(technicalAsset.Technologies) > 0 {
+			componentType = technicalAsset.Technologies[0]
+		}
+
+		stored := technicalAsset
+		stored.CommunicationLinks = nil
+		component := Component{
+			Id:          technicalAsset.ID,
+			Name:        title,
+			Type:        componentType,
+			Description: technicalAsset.Description,
+			Parent:      &Parent{TrustZone: zone},
+			Tags:        technicalAsset.Tags,
+			Attributes:  toAttributes(stored),
+		}
+
+		if len(technicalAsset.DataAssetsProcessed) > 0 || len(technicalAsset.DataAssetsStored) > 0 {
+			component.Assets = &ComponentAssets{
+				Processed: technicalAsset.DataAssetsProcessed,
+				Stored:    technicalAsset.DataAssetsStored,
+			}
+		}
+
+		what.componentIndex[technicalAsset.ID] = len(what.document.Components)
+		what.document.Components = append(what.document.Components, component)
+	}
+
+	if implicitZones[internetTrustZoneId] {
+		what.document.TrustZones = append(what.document.TrustZones, TrustZone{
+			Id:         internetTrustZoneId,
+			Name:       "Internet",
+			Type:       internetTrustZoneId,
+			Risk:       TrustZoneRisk{TrustRating: 0},
+			Attributes: toAttributes(trustZoneAttributes{Implicit: true}),
+		})
+	}
+
+	if implicitZones[defaultTrustZoneId] {
+		what.document.TrustZones = append(what.document.TrustZones, TrustZone{
+			Id:          defaultTrustZoneId,
+			Name:        "Default",
+			Type:        defaultTrustZoneId,
+			Description: "Technical assets outside of any trust boundary",
+			Risk:        TrustZoneRisk{TrustRating: 50},
+			Attributes:  toAttributes(trustZoneAttributes{Implicit: true}),
+		})
+	}
+}
+
+func (what *exporter) exportDataflows() {
+	for _, sourceTitle := range sortedKeys(what.modelInput.TechnicalAssets) {
+		source := what.modelInput.TechnicalAssets[sourceTitle]
+		for _, title := range sortedKeys(source.CommunicationLinks) {
+			communicationLink := source.CommunicationLinks[title]
+			id := source.ID + ">" + types.MakeID(title)
+			assets := compact(append(append([]string{}, communicationLink.DataAssetsSent...), communicationLink.DataAssetsReceived...))
+			what.dataflowIndex[id] = len(what.document.Dataflows)
+			what.document.Dataflows = append(what.document.Dataflows, Dataflow{
+				Id:            id,
+				Name:          title,
+				Description:   communicationLink.Description,
+				Bidirectional: len(communicationLink.DataAssetsSent) > 0 && len(communicationLink.DataAssetsReceived) > 0,
+				Source:        source.ID,
+				Destination:   communicationLink.Target,
+				Assets:        assets,
+				Tags:          communicationLink.Tags,
+				Attributes:    toAttributes(communicationLink),
+			})
+		}
+	}
+}
+
+func (what *exporter) exportThreats() {
+	mitigations := make(map[string]bool)
+	for _, risk := range what.sortedRisks() {
+		category := what.parsedModel.GetRiskCategory(risk.CategoryId)
+		if category == nil {
+			continue
+		}
+
+		status := risk.RiskStatus.String()
+		threat := Threat{
+			Id:          risk.SyntheticId,
+			Name:        removeFormattingTags(risk.Title),
+			Description: category.Description,
+			Categories:  []string{category.STRIDE.Title()},
+			Risk: ThreatRisk{
+				Likelihood:    scaleFromIndex(int(risk.ExploitationLikelihood), len(types.RiskExploitationLikelihoodValues())),
+				Impact:        scaleFromIndex(int(risk.ExploitationImpact), len(types.RiskExploitationImpactValues())),
+				ImpactComment: category.Impact,
+			},
+		}
+
+		if category.CWE > 0 {
+			threat.Cwes = []string{fmt.Sprintf("CWE-%d", category.CWE)}
+		}
+
+		attributes := threatAttributes{Category: category.ID, SyntheticId: risk.SyntheticId, Status: status}
+		if tracking := what.parsedModel.GetRiskTracking(risk); tracking != nil {
+			attributes.Justification = tracking.Justification
+			attributes.Ticket = tracking.Ticket
+			attributes.CheckedBy = tracking.CheckedBy
+			if !tracking.Date.IsZero() {
+				attributes.Date = tracking.Date.Format("2006-01-02")
+			}
+		}
+		threat.Attributes = toAttributes(attributes)
+		what.document.Threats = append(what.document.Threats, threat)
+
+		instance := ThreatInstance{Threat: risk.SyntheticId, State: status}
+		if len(category.Mitigation) > 0 {
+			mitigationId := category.ID + "-mitigation"
+			mitigationState := "recommended"
+			if risk.RiskStatus == types.Mitigated {
+				mitigationState = "implemented"
+			}
+
+			instance.Mitigations = []MitigationInstance{{Mitigation: mitigationId, State: mitigationState}}
+			if !mitigations[mitigationId] {
+				mitigations[mitigationId] = true
+				name := category.Action
+				if len(name) == 0 {
+					name = category.Title
+				}
+				what.document.Mitigations = append(what.document.Mitigations, Mitigation{
+					Id:            mitigationId,
+					Name:          name,
+					Description:   category.Mitigation,
+					RiskReduction: 50,
+				})
+			}
+		}
+
+		if index, ok := what.dataflowIndex[risk.MostRelevantCommunicationLinkId]; ok {
+			what.document.Dataflows[index].Threats = append(what.document.Dataflows[index].Threats, instance)
+		} else if index, ok := what.componentIndex[risk.MostRelevantTechnicalAssetId]; ok {
+			what.document.Components[index].Threats = append(what.document.Components[index].Threats, instance)
+		}
+	}
+
+	sort.Slice(what.document.Mitigations, func(i, j int) bool {
+		return what.document.Mitigations[i].Id < what.document.Mitigations[j].Id
+	})
+}
+
+func (what *exporter) sortedRisks() []*types.Risk {
+	risks := make([]*types.Risk, 0)
+	for _, categoryRisks := range what.parsedModel.GeneratedRisksByCategoryWithCurrentStatus() {
+		risks = append(risks, categoryRisks...)
+	}
+
+	sort.Slice(risks, func(i, j int) bool {
+		return risks[i].SyntheticId < risks[j].SyntheticId
+	})
+
+	return risks
+}
+
+// trustRating rates network boundaries as less trusted than logical groupings
+func trustRating(trustBoundaryType string) float64 {
+	switch trustBoundaryType {
+	case types.ExecutionEnvironment.String(), types.NetworkPolicyNamespaceIsolation.String():
+		return 75
+
+	default:
+		return 50
+	}
+}
+
+var formattingTags = regexp.MustCompile(`</?(b|i|u)>`)
+
+func removeFormattingTags(text string) string {
+	return formattingTags.ReplaceAllString(text, "")
+}
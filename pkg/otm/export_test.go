@@ -0,0 +1,90 @@
+package otm
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+func createExportTestModel() *input.Model {
+	model := new(input.Model).Defaults()
+	model.Title = "Export Test"
+	model.BusinessCriticality = types.Critical.String()
+	model.DataAssets["Orders"] = input.DataAsset{ID: "orders", Usage: "business", Quantity: "many", Confidentiality: "confidential", Integrity: "critical", Availability: "important"}
+	model.TechnicalAssets["Client"] = input.TechnicalAsset{ID: "client", Type: "external-entity", Internet: true, Technologies: []string{types.Browser},
+		CommunicationLinks: map[string]input.CommunicationLink{
+			"Place Order": {Target: "api", Protocol: "https", DataAssetsSent: []string{"orders"}, DataAssetsReceived: []string{"orders"}},
+		}}
+	model.TechnicalAssets["API"] = input.TechnicalAsset{ID: "api", Type: "process", Technologies: []string{types.WebServiceREST}, DataAssetsProcessed: []string{"orders"}}
+	model.TrustBoundaries["Backend"] = input.TrustBoundary{ID: "backend", Type: "network-cloud-provider", TechnicalAssetsInside: []string{"api"}}
+	model.RiskTracking["some-risk@api"] = input.RiskTracking{Status: "accepted", Justification: "known"}
+	return model
+}
+
+func TestExportMapsModelElements(t *testing.T) {
+	document := Export(createExportTestModel(), nil)
+
+	assert.Equal(t, Version, document.OtmVersion)
+	assert.Equal(t, "export-test", document.Project.Id)
+	assert.Len(t, document.Assets, 1)
+	assert.Equal(t, 75.0, document.Assets[0].Risk.Confidentiality)
+
+	assert.Len(t, document.TrustZones, 2, "backend and the implicit internet zone")
+	assert.Equal(t, internetTrustZoneId, document.TrustZones[1].Id)
+
+	assert.Len(t, document.Components, 2)
+	assert.Equal(t, "api", document.Components[0].Id)
+	assert.Equal(t, &Parent{TrustZone: "backend"}, document.Components[0].Parent)
+	assert.Equal(t, &Parent{TrustZone: internetTrustZoneId}, document.Components[1].Parent)
+
+	assert.Len(t, document.Dataflows, 1)
+	assert.Equal(t, "client>place-order", document.Dataflows[0].Id)
+	assert.True(t, document.Dataflows[0].Bidirectional)
+	assert.Empty(t, document.Threats)
+}
+
+func TestExportThreatsWithRiskTracking(t *testing.T) {
+	parsedModel := &types.Model{
+		BuiltInRiskCategories: types.RiskCategories{
+			{ID: "test-category", Title: "Test Category", Mitigation: "Fix it", STRIDE: types.Spoofing, CWE: 287},
+		},
+		RiskTracking: map[string]*types.RiskTracking{
+			"test-category@api": {SyntheticRiskId: "test-category@api", Status: types.Mitigated, Justification: "fixed"},
+		},
+		GeneratedRisksByCategory: map[string][]*types.Risk{"test-category": {
+			{CategoryId: "test-category", SyntheticId: "test-category@api", Title: "<b>Test</b> risk", MostRelevantTechnicalAssetId: "api"},
+		}},
+	}
+
+	document := Export(createExportTestModel(), parsedModel)
+
+	assert.Len(t, document.Threats, 1)
+	assert.Equal(t, "Test risk", document.Threats[0].Name)
+	assert.Equal(t, []string{"CWE-287"}, document.Threats[0].Cwes)
+	assert.Equal(t, []ThreatInstance{{Threat: "test-category@api", State: "mitigated",
+		Mitigations: []MitigationInstance{{Mitigation: "test-category-mitigation", State: "implemented"}}}}, document.Components[0].Threats)
+	assert.Len(t, document.Mitigations, 1)
+
+	imported, err := Import(document)
+	assert.NoError(t, err)
+	assert.Equal(t, input.RiskTracking{Status: "mitigated", Justification: "fixed"}, imported.RiskTracking["test-category@api"])
+	assert.Empty(t, imported.CustomRiskCategories, "generated risks are not imported as individual risks")
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	model := createExportTestModel()
+	for _, filename := range []string{"model.otm.json", "model.otm.yaml"} {
+		filename = filepath.Join(t.TempDir(), filename)
+		assert.NoError(t, Export(model, nil).Write(filename))
+
+		document, err := Read(filename)
+		assert.NoError(t, err)
+
+		imported, err := Import(document)
+		assert.NoError(t, err)
+		assert.Equal(t, model, imported, filename)
+	}
+}
@@ -0,0 +1,745 @@
+package otm
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+// threat attributes set by the export of generated risks
+type threatAttributes struct {
+	Category      string `yaml:"category,omitempty"`
+	SyntheticId   string `yaml:"synthetic_id,omitempty"`
+	Status        string `yaml:"status,omitempty"`
+	Justification string `yaml:"justification,omitempty"`
+	Ticket        string `yaml:"ticket,omitempty"`
+	Date          string `yaml:"date,omitempty"`
+	CheckedBy     string `yaml:"checked_by,omitempty"`
+}
+
+// zone attributes set by the export for trust zones not backed by a trust boundary
+type trustZoneAttributes struct {
+	Implicit bool `yaml:"implicit,omitempty"`
+}
+
+type importer struct {
+	document     *Document
+	model        *input.Model
+	technologies types.TechnologyMap
+
+	trustZones   map[string]*TrustZone
+	internet     map[string]bool
+	components   map[string]*Component
+	assetTitles  map[string]string
+	linkIds      map[string]string
+	mitigations  map[string]*Mitigation
+	riskTitles   map[string]bool
+	categoryById map[string]*input.RiskCategory
+}
+
+// Import converts an OTM document into a threagile model:
+// trust zones become trust boundaries, components technical assets, dataflows communication links,
+// assets data assets, and threats with their mitigations individual risks and risk tracking
+func Import(document *Document) (*input.Model, error) {
+	technologies := make(types.TechnologyMap)
+	loadError := technologies.LoadDefault()
+	if loadError != nil {
+		return nil, loadError
+	}
+
+	what := &importer{
+		document:     document,
+		model:        new(input.Model).Defaults(),
+		technologies: technologies,
+		trustZones:   make(map[string]*TrustZone),
+		internet:     make(map[string]bool),
+		components:   make(map[string]*Component),
+		assetTitles:  make(map[string]string),
+		linkIds:      make(map[string]string),
+		mitigations:  make(map[string]*Mitigation),
+		riskTitles:   make(map[string]bool),
+		categoryById: make(map[string]*input.RiskCategory),
+	}
+
+	what.importProject()
+
+	for i := range document.TrustZones {
+		what.trustZones[document.TrustZones[i].Id] = &document.TrustZones[i]
+	}
+	for i := range document.Components {
+		what.components[document.Components[i].Id] = &document.Components[i]
+	}
+	for i := range document.Mitigations {
+		what.mitigations[document.Mitigations[i].Id] = &document.Mitigations[i]
+	}
+
+	for _, importStep := range []func() error{what.importAssets, what.importTrustZones, what.importComponents, what.importDataflows, what.importThreats} {
+		importError := importStep()
+		if importError != nil {
+			return nil, importError
+		}
+	}
+
+	return what.model, nil
+}
+
+func (what *importer) importProject() {
+	project := what.document.Project
+	if fromAttributes(project.Attributes, what.model) {
+		// the collections are restored from the OTM elements
+		what.model.DataAssets = make(map[string]input.DataAsset)
+		what.model.TechnicalAssets = make(map[string]input.TechnicalAsset)
+		what.model.TrustBoundaries = make(map[string]input.TrustBoundary)
+		if what.model.SharedRuntimes == nil {
+			what.model.SharedRuntimes = make(map[string]input.SharedRuntime)
+		}
+		if what.model.RiskTracking == nil {
+			what.model.RiskTracking = make(map[string]input.RiskTracking)
+		}
+		if what.model.CustomRiskCategories == nil {
+			what.model.CustomRiskCategories = make(input.RiskCategories, 0)
+		}
+	} else {
+		what.model.BusinessCriticality = types.Important.String()
+		what.model.AppDescription.Description = project.Description
+		what.model.Author = input.Author{Name: project.Owner, Contact: project.OwnerContact}
+	}
+
+	what.model.Title = project.Name
+	for _, category := range what.model.CustomRiskCategories {
+		what.categoryById[category.ID] = category
+	}
+
+	for _, tag := range project.Tags {
+		what.addTag(tag)
+	}
+}
+
+func (what *importer) importAssets() error {
+	for _, asset := range what.document.Assets {
+		dataAsset := input.DataAsset{}
+		if !fromAttributes(asset.Attributes, &dataAsset) {
+			dataAsset = input.DataAsset{
+				Usage:                  types.Business.String(),
+				Quantity:               types.Many.String(),
+				Confidentiality:        types.Confidentiality(scaleToIndex(asset.Risk.Confidentiality, len(types.ConfidentialityValues()))).String(),
+				Integrity:              types.Criticality(scaleToIndex(asset.Risk.Integrity, len(types.CriticalityValues()))).String(),
+				Availability:           types.Criticality(scaleToIndex(asset.Risk.Availability, len(types.CriticalityValues()))).String(),
+				JustificationCiaRating: asset.Risk.Comment,
+			}
+		}
+
+		dataAsset.ID = makeId(asset.Id)
+		dataAsset.Description = asset.Description
+		title := uniqueTitle(asset.Name, what.model.DataAssets)
+		what.assetTitles[asset.Id] = dataAsset.ID
+		what.model.DataAssets[title] = dataAsset
+
+		for _, tag := range dataAsset.Tags {
+			what.addTag(tag)
+		}
+	}
+
+	return nil
+}
+
+func (what *importer) importTrustZones() error {
+	for _, zone := range what.document.TrustZones {
+		var zoneAttributes trustZoneAttributes
+		_ = fromAttributes(zone.Attributes, &zoneAttributes)
+		if isInternetZone(zone) {
+			what.internet[zone.Id] = true
+			continue
+		}
+
+		if zoneAttributes.Implicit {
+			continue
+		}
+
+		trustBoundary := input.TrustBoundary{}
+		if !fromAttributes(zone.Attributes, &trustBoundary) || len(trustBoundary.Type) == 0 {
+			trustBoundary.Type = trustBoundaryType(zone.Type).String()
+		}
+
+		trustBoundary.ID = makeId(zone.Id)
+		trustBoundary.Description = zone.Description
+		trustBoundary.TechnicalAssetsInside = nil
+		trustBoundary.TrustBoundariesNested = nil
+
+		for _, nested := range what.document.TrustZones {
+			if nested.Parent != nil && nested.Parent.TrustZone == zone.Id && !isInternetZone(nested) {
+				trustBoundary.TrustBoundariesNested = append(trustBoundary.TrustBoundariesNested, makeId(nested.Id))
+			}
+		}
+
+		for _, component := range what.document.Components {
+			if what.trustZoneOf(&component) == zone.Id {
+				trustBoundary.TechnicalAssetsInside = append(trustBoundary.TechnicalAssetsInside, makeId(component.Id))
+			}
+		}
+
+		what.model.TrustBoundaries[uniqueTitle(zone.Name, what.model.TrustBoundaries)] = trustBoundary
+		for _, tag := range trustBoundary.Tags {
+			what.addTag(tag)
+		}
+	}
+
+	return nil
+}
+
+func (what *importer) importComponents() error {
+	runtimes := make(map[string][]string)
+	for _, component := range what.document.Components {
+		if component.Parent == nil || (len(component.Parent.TrustZone) == 0 && len(component.Parent.Component) == 0) {
+			return fmt.Errorf("component %q has no parent", component.Id)
+		}
+
+		if len(component.Parent.TrustZone) > 0 && what.trustZones[component.Parent.TrustZone] == nil {
+			return fmt.Errorf("component %q references unknown trust zone %q", component.Id, component.Parent.TrustZone)
+		}
+
+		if len(component.Parent.Component) > 0 {
+			if what.components[component.Parent.Component] == nil {
+				return fmt.Errorf("component %q references unknown parent component %q", component.Id, component.Parent.Component)
+			}
+
+			runtimes[component.Parent.Component] = append(runtimes[component.Parent.Component], makeId(component.Id))
+		}
+
+		technicalAsset := input.TechnicalAsset{}
+		if !fromAttributes(component.Attributes, &technicalAsset) {
+			technicalAsset = what.defaultTechnicalAsset(&component)
+		}
+
+		technicalAsset.ID = makeId(component.Id)
+		technicalAsset.Description = component.Description
+		technicalAsset.Tags = append(technicalAsset.Tags, component.Tags...)
+		sort.Strings(technicalAsset.Tags)
+		technicalAsset.Tags = compact(technicalAsset.Tags)
+		technicalAsset.CommunicationLinks = nil
+		if component.Assets != nil {
+			processed, processedError := what.dataAssetIds(component.Assets.Processed, "component "+component.Id)
+			if processedError != nil {
+				return processedError
+			}
+
+			stored, storedError := what.dataAssetIds(component.Assets.Stored, "component "+component.Id)
+			if storedError != nil {
+				return storedError
+			}
+
+			technicalAsset.DataAssetsProcessed = processed
+			technicalAsset.DataAssetsStored = stored
+		}
+
+		what.model.TechnicalAssets[uniqueTitle(component.Name, what.model.TechnicalAssets)] = technicalAsset
+		for _, tag := range technicalAsset.Tags {
+			what.addTag(tag)
+		}
+	}
+
+	for _, parentId := range sortedKeys(runtimes) {
+		parent := what.components[parentId]
+		what.model.SharedRuntimes[uniqueTitle(parent.Name, what.model.SharedRuntimes)] = input.SharedRuntime{
+			ID:                     makeId(parent.Id) + "-runtime",
+			Description:            parent.Description,
+			TechnicalAssetsRunning: append([]string{makeId(parent.Id)}, runtimes[parentId]...),
+		}
+	}
+
+	return nil
+}
+
+func (what *importer) defaultTechnicalAsset(component *Component) input.TechnicalAsset {
+	technology := types.UnknownTechnology
+	for _, candidate := range []string{component.Type, component.Name} {
+		if found := what.technologies.Find(makeId(candidate)); found != nil {
+			technology = found.Name
+			break
+		}
+	}
+
+	assetType := types.Process
+	if what.internet[what.trustZoneOf(component)] {
+		assetType = types.ExternalEntity
+	} else if component.Assets != nil && len(component.Assets.Stored) > 0 {
+		assetType = types.Datastore
+	}
+
+	return input.TechnicalAsset{
+		Type:            assetType.String(),
+		Usage:           types.Business.String(),
+		Size:            types.Service.String(),
+		Technologies:    []string{technology},
+		Internet:        what.internet[what.trustZoneOf(component)],
+		Machine:         types.Virtual.String(),
+		Encryption:      types.NoneEncryption.String(),
+		Confidentiality: types.Internal.String(),
+		Integrity:       types.Important.String(),
+		Availability:    types.Important.String(),
+	}
+}
+
+func (what *importer) importDataflows() error {
+	for _, dataflow := range what.document.Dataflows {
+		source := what.components[dataflow.Source]
+		if source == nil {
+			return fmt.Errorf("dataflow %q references unknown source component %q", dataflow.Id, dataflow.Source)
+		}
+
+		if what.components[dataflow.Destination] == nil {
+			return fmt.Errorf("dataflow %q references unknown destination component %q", dataflow.Id, dataflow.Destination)
+		}
+
+		communicationLink := input.CommunicationLink{}
+		if !fromAttributes(dataflow.Attributes, &communicationLink) {
+			assets, assetsError := what.dataAssetIds(dataflow.Assets, "dataflow "+dataflow.Id)
+			if assetsError != nil {
+				return assetsError
+			}
+
+			communicationLink = input.CommunicationLink{
+				Protocol:       types.UnknownProtocol.String(),
+				Authentication: types.NoneAuthentication.String(),
+				Authorization:  types.NoneAuthorization.String(),
+				Usage:          types.Business.String(),
+				DataAssetsSent: assets,
+			}
+			if dataflow.Bidirectional {
+				communicationLink.DataAssetsReceived = assets
+			}
+		}
+
+		communicationLink.Target = makeId(dataflow.Destination)
+		communicationLink.Description = dataflow.Description
+		communicationLink.Tags = compact(append(communicationLink.Tags, dataflow.Tags...))
+
+		sourceTitle, sourceAsset := what.technicalAsset(makeId(source.Id))
+		if sourceAsset.CommunicationLinks == nil {
+			sourceAsset.CommunicationLinks = make(map[string]input.CommunicationLink)
+		}
+		title := uniqueTitle(dataflow.Name, sourceAsset.CommunicationLinks)
+		sourceAsset.CommunicationLinks[title] = communicationLink
+		what.model.TechnicalAssets[sourceTitle] = sourceAsset
+		what.linkIds[dataflow.Id] = sourceAsset.ID + ">" + types.MakeID(title)
+
+		for _, tag := range communicationLink.Tags {
+			what.addTag(tag)
+		}
+	}
+
+	return nil
+}
+
+func (what *importer) importThreats() error {
+	threats := make(map[string]*Threat)
+	for i := range what.document.Threats {
+		threats[what.document.Threats[i].Id] = &what.document.Threats[i]
+	}
+
+	type threatTarget struct {
+		instance        ThreatInstance
+		technicalAsset  string
+		communication   string
+		elementName     string
+		elementTypeName string
+	}
+
+	targets := make(map[string][]threatTarget)
+	for _, component := range what.document.Components {
+		for _, instance := range component.Threats {
+			targets[instance.Threat] = append(targets[instance.Threat], threatTarget{instance: instance, technicalAsset: makeId(component.Id), elementName: component.Name, elementTypeName: "component"})
+		}
+	}
+	for _, dataflow := range what.document.Dataflows {
+		for _, instance := range dataflow.Threats {
+			targets[instance.Threat] = append(targets[instance.Threat], threatTarget{instance: instance, communication: what.linkIds[dataflow.Id], elementName: dataflow.Name, elementTypeName: "dataflow"})
+		}
+	}
+
+	for _, threatId := range sortedKeys(targets) {
+		if threats[threatId] == nil {
+			return fmt.Errorf("%v %q references unknown threat %q", targets[threatId][0].elementTypeName, targets[threatId][0].elementName, threatId)
+		}
+	}
+
+	for _, threat := range what.document.Threats {
+		var generated threatAttributes
+		if fromAttributes(threat.Attributes, &generated) && len(generated.SyntheticId) > 0 {
+			// the risk is generated by threagile itself, so only its tracking is imported
+			state := generated.Status
+			mitigations := make([]MitigationInstance, 0)
+			if len(targets[threat.Id]) > 0 {
+				state = targets[threat.Id][0].instance.State
+				mitigations = targets[threat.Id][0].instance.Mitigations
+			}
+
+			what.trackRisk(generated.SyntheticId, state, mitigations, input.RiskTracking{
+				Justification: generated.Justification,
+				Ticket:        generated.Ticket,
+				Date:          generated.Date,
+				CheckedBy:     generated.CheckedBy,
+			})
+			continue
+		}
+
+		if len(targets[threat.Id]) == 0 {
+			continue
+		}
+
+		category := what.riskCategory(&threat)
+		likelihood := types.RiskExploitationLikelihood(scaleToIndex(threat.Risk.Likelihood, len(types.RiskExploitationLikelihoodValues())))
+		impact := types.RiskExploitationImpact(scaleToIndex(threat.Risk.Impact, len(types.RiskExploitationImpactValues())))
+		for _, target := range targets[threat.Id] {
+			title := fmt.Sprintf("<b>%v</b> at <b>%v</b>", threat.Name, target.elementName)
+			for n := 2; what.riskTitles[title]; n++ {
+				title = fmt.Sprintf("<b>%v</b> at <b>%v</b> (%d)", threat.Name, target.elementName, n)
+			}
+			what.riskTitles[title] = true
+
+			category.RisksIdentified[title] = input.RiskIdentified{
+				Severity:                      types.CalculateSeverity(likelihood, impact).String(),
+				ExploitationLikelihood:        likelihood.String(),
+				ExploitationImpact:            impact.String(),
+				DataBreachProbability:         types.Possible.String(),
+				MostRelevantTechnicalAsset:    target.technicalAsset,
+				MostRelevantCommunicationLink: target.communication,
+			}
+
+			syntheticId := category.ID
+			if len(target.technicalAsset) > 0 {
+				syntheticId += "@" + target.technicalAsset
+			}
+			if len(target.communication) > 0 {
+				syntheticId += "@" + target.communication
+			}
+
+			what.trackRisk(syntheticId, target.instance.State, target.instance.Mitigations, input.RiskTracking{})
+		}
+	}
+
+	return nil
+}
+
+func (what *importer) riskCategory(threat *Threat) *input.RiskCategory {
+	id := makeId(threat.Id)
+	if category, ok := what.categoryById[id]; ok {
+		if category.RisksIdentified == nil {
+			category.RisksIdentified = make(map[string]input.RiskIdentified)
+		}
+		return category
+	}
+
+	category := &input.RiskCategory{
+		ID:              id,
+		Title:           threat.Name,
+		Description:     threat.Description,
+		Impact:          threat.Risk.ImpactComment,
+		Function:        types.Architecture.String(),
+		STRIDE:          strideOf(threat.Categories).String(),
+		RiskAssessment:  threat.Risk.LikelihoodComment,
+		RisksIdentified: make(map[string]input.RiskIdentified),
+	}
+
+	if len(category.Description) == 0 {
+		category.Description = threat.Name
+	}
+
+	for _, cwe := range threat.Cwes {
+		number, parseError := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(cwe)), "CWE-"))
+		if parseError == nil {
+			category.CWE = number
+			break
+		}
+	}
+
+	mitigations := make([]string, 0)
+	for _, instances := range what.threatInstances(threat.Id) {
+		for _, instance := range instances.Mitigations {
+			if mitigation, ok := what.mitigations[instance.Mitigation]; ok {
+				text := mitigation.Name
+				if len(mitigation.Description) > 0 {
+					text += ": " + mitigation.Description
+				}
+				if !contains(mitigations, text) {
+					mitigations = append(mitigations, text)
+				}
+			}
+		}
+	}
+	category.Mitigation = strings.Join(mitigations, "\n")
+
+	what.categoryById[id] = category
+	what.model.CustomRiskCategories = append(what.model.CustomRiskCategories, category)
+
+	return category
+}
+
+func (what *importer) threatInstances(threatId string) []ThreatInstance {
+	instances := make([]ThreatInstance, 0)
+	for _, component := range what.document.Components {
+		for _, instance := range component.Threats {
+			if instance.Threat == threatId {
+				instances = append(instances, instance)
+			}
+		}
+	}
+	for _, dataflow := range what.document.Dataflows {
+		for _, instance := range dataflow.Threats {
+			if instance.Threat == threatId {
+				instances = append(instances, instance)
+			}
+		}
+	}
+
+	return instances
+}
+
+// trackRisk adds risk tracking for a reviewed threat; tracking already present with the same status is kept
+func (what *importer) trackRisk(syntheticId string, state string, mitigations []MitigationInstance, tracking input.RiskTracking) {
+	status := riskStatus(state)
+	implemented := make([]string, 0)
+	for _, instance := range mitigations {
+		if riskStatus(instance.State) != types.Mitigated {
+			continue
+		}
+
+		if mitigation, ok := what.mitigations[instance.Mitigation]; ok {
+			implemented = append(implemented, mitigation.Name)
+		} else {
+			implemented = append(implemented, instance.Mitigation)
+		}
+	}
+
+	if status == types.Unchecked && len(implemented) > 0 && len(implemented) == len(mitigations) {
+		status = types.Mitigated
+	}
+
+	if status == types.Unchecked {
+		return
+	}
+
+	if what.isTracked(syntheticId, status) {
+		return
+	}
+
+	tracking.Status = status.String()
+	if len(tracking.Justification) == 0 && len(implemented) > 0 {
+		tracking.Justification = "Mitigations implemented: " + strings.Join(implemented, ", ")
+	}
+
+	what.model.RiskTracking[syntheticId] = tracking
+}
+
+// isTracked checks for risk tracking with the given status, either for the risk itself or via a wildcard pattern
+func (what *importer) isTracked(syntheticId string, status types.RiskStatus) bool {
+	for pattern, tracking := range what.model.RiskTracking {
+		if tracking.Status != status.String() {
+			continue
+		}
+
+		expression := regexp.MustCompile("^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, `[^@]+`) + "$")
+		if expression.MatchString(syntheticId) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (what *importer) trustZoneOf(component *Component) string {
+	for depth := 0; component != nil && component.Parent != nil && depth < len(what.components); depth++ {
+		if len(component.Parent.TrustZone) > 0 {
+			return component.Parent.TrustZone
+		}
+
+		component = what.components[component.Parent.Component]
+	}
+
+	return ""
+}
+
+func (what *importer) technicalAsset(id string) (string, input.TechnicalAsset) {
+	for title, technicalAsset := range what.model.TechnicalAssets {
+		if technicalAsset.ID == id {
+			return title, technicalAsset
+		}
+	}
+
+	return "", input.TechnicalAsset{}
+}
+
+func (what *importer) dataAssetIds(assetIds []string, context string) ([]string, error) {
+	var result []string
+	for _, assetId := range assetIds {
+		id, ok := what.assetTitles[assetId]
+		if !ok {
+			return nil, fmt.Errorf("%v references unknown asset %q", context, assetId)
+		}
+
+		result = append(result, id)
+	}
+
+	return result, nil
+}
+
+func (what *importer) addTag(tag string) {
+	changes := make([]string, 0)
+	what.model.AddTagToModelInput(tag, false, &changes)
+}
+
+func isInternetZone(zone TrustZone) bool {
+	return strings.Contains(strings.ToLower(zone.Name+" "+zone.Type), "internet")
+}
+
+func trustBoundaryType(zoneType string) types.TrustBoundaryType {
+	zoneType = strings.ToLower(zoneType)
+	switch {
+	case strings.Contains(zoneType, "security-group") || strings.Contains(zoneType, "security group"):
+		return types.NetworkCloudSecurityGroup
+
+	case strings.Contains(zoneType, "namespace"):
+		return types.NetworkPolicyNamespaceIsolation
+
+	case strings.Contains(zoneType, "cloud"):
+		return types.NetworkCloudProvider
+
+	case strings.Contains(zoneType, "vlan") || strings.Contains(zoneType, "vpc") || strings.Contains(zoneType, "virtual"):
+		return types.NetworkVirtualLAN
+
+	case strings.Contains(zoneType, "container") || strings.Contains(zoneType, "runtime") || strings.Contains(zoneType, "execution"):
+		return types.ExecutionEnvironment
+
+	case strings.Contains(zoneType, "hoster") || strings.Contains(zoneType, "dmz"):
+		return types.NetworkDedicatedHoster
+
+	default:
+		if trustBoundary, parseError := types.ParseTrustBoundary(zoneType); parseError == nil {
+			return trustBoundary
+		}
+
+		return types.NetworkOnPrem
+	}
+}
+
+func strideOf(categories []string) types.STRIDE {
+	for _, category := range categories {
+		name := types.MakeID(category)
+		for _, value := range types.STRIDEValues() {
+			stride := value.(types.STRIDE)
+			if name == stride.String() || strings.EqualFold(category, stride.Title()) {
+				return stride
+			}
+		}
+	}
+
+	return types.Tampering
+}
+
+// riskStatus maps OTM threat and mitigation states, which differ between tools, to risk status
+func riskStatus(state string) types.RiskStatus {
+	switch types.MakeID(state) {
+	case "accepted", "risk-accepted":
+		return types.Accepted
+
+	case "in-progress", "implementing", "required":
+		return types.InProgress
+
+	case "in-discussion", "under-review", "review":
+		return types.InDiscussion
+
+	case "mitigated", "implemented", "fixed", "closed", "resolved":
+		return types.Mitigated
+
+	case "false-positive", "not-applicable", "na", "n-a":
+		return types.FalsePositive
+
+	default:
+		return types.Unchecked
+	}
+}
+
+// scaleToIndex maps a 0 to 100 rating onto the index of an ordered enum with count values
+func scaleToIndex(value float64, count int) int {
+	index := int(value * float64(count) / 100)
+	if index < 0 {
+		return 0
+	}
+
+	if index >= count {
+		return count - 1
+	}
+
+	return index
+}
+
+// scaleFromIndex maps the index of an ordered enum with count values onto a 0 to 100 rating
+func scaleFromIndex(index int, count int) float64 {
+	if count <= 1 {
+		return 0
+	}
+
+	return float64(index*100) / float64(count-1)
+}
+
+var invalidIdCharacters = regexp.MustCompile(`[^A-Za-z0-9\-]+`)
+
+// makeId keeps ids that are valid threagile ids and normalizes all others
+func makeId(id string) string {
+	if len(id) > 0 && !invalidIdCharacters.MatchString(id) {
+		return id
+	}
+
+	return types.MakeID(id)
+}
+
+func uniqueTitle[T any](title string, existing map[string]T) string {
+	if len(strings.TrimSpace(title)) == 0 {
+		title = "unnamed"
+	}
+
+	result := title
+	for n := 2; ; n++ {
+		if _, ok := existing[result]; !ok {
+			return result
+		}
+
+		result = fmt.Sprintf("%v (%d)", title, n)
+	}
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func compact(values []string) []string {
+	var result []string
+	for _, value := range values {
+		if !contains(result, value) {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+
+	return false
+}
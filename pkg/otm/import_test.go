@@ -0,0 +1,111 @@
+package otm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+const webShopOTM = `{
+  "otmVersion": "0.2.0",
+  "project": {
+    "name": "Web Shop",
+    "id": "web-shop",
+    "description": "Online shop",
+    "owner": "Shop Team",
+    "ownerContact": "shop@example.com"
+  },
+  "assets": [
+    {
+      "name": "Customer Data",
+      "id": "customer-data",
+      "risk": {"confidentiality": 80, "integrity": 60, "availability": 30}
+    }
+  ],
+  "trustZones": [
+    {"id": "internet", "name": "Internet", "type": "internet", "risk": {"trustRating": 0}},
+    {"id": "cloud", "name": "Cloud VPC", "type": "cloud", "risk": {"trustRating": 60}},
+    {"id": "private", "name": "Private Subnet", "type": "vpc", "risk": {"trustRating": 80}, "parent": {"trustZone": "cloud"}}
+  ],
+  "components": [
+    {"id": "browser", "name": "Browser", "type": "browser", "parent": {"trustZone": "internet"}},
+    {"id": "web-app", "name": "Web App", "type": "web-application", "parent": {"trustZone": "cloud"}, "tags": ["frontend"],
+      "assets": {"processed": ["customer-data"]},
+      "threats": [{"threat": "xss", "state": "mitigated", "mitigations": [{"mitigation": "output-encoding", "state": "implemented"}]}]},
+    {"id": "shop-db", "name": "Shop DB", "type": "postgresql", "parent": {"trustZone": "private"},
+      "assets": {"stored": ["customer-data"]},
+      "threats": [{"threat": "sqli", "state": "exposed"}]}
+  ],
+  "dataflows": [
+    {"id": "f1", "name": "Browse", "source": "browser", "destination": "web-app", "bidirectional": true, "assets": ["customer-data"]},
+    {"id": "f2", "name": "Query", "source": "web-app", "destination": "shop-db", "assets": ["customer-data"],
+      "threats": [{"threat": "sqli", "state": "not-applicable"}]}
+  ],
+  "threats": [
+    {"id": "xss", "name": "Cross-Site Scripting", "categories": ["Tampering"], "cwes": ["CWE-79"], "risk": {"likelihood": 60, "impact": 80}},
+    {"id": "sqli", "name": "SQL Injection", "categories": ["Information Disclosure"], "cwes": ["CWE-89"], "risk": {"likelihood": 40, "impact": 100}}
+  ],
+  "mitigations": [
+    {"id": "output-encoding", "name": "Output Encoding", "description": "Encode all output", "riskReduction": 80}
+  ]
+}
+`
+
+func TestImportMapsOTMElements(t *testing.T) {
+	document, err := Parse([]byte(webShopOTM))
+	assert.NoError(t, err)
+
+	model, err := Import(document)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Web Shop", model.Title)
+	assert.Equal(t, "Shop Team", model.Author.Name)
+	assert.Equal(t, types.StrictlyConfidential.String(), model.DataAssets["Customer Data"].Confidentiality)
+
+	assert.Len(t, model.TrustBoundaries, 2, "the internet zone is no trust boundary")
+	assert.Equal(t, types.NetworkCloudProvider.String(), model.TrustBoundaries["Cloud VPC"].Type)
+	assert.Equal(t, []string{"private"}, model.TrustBoundaries["Cloud VPC"].TrustBoundariesNested)
+	assert.Equal(t, []string{"shop-db"}, model.TrustBoundaries["Private Subnet"].TechnicalAssetsInside)
+
+	browser := model.TechnicalAssets["Browser"]
+	assert.True(t, browser.Internet)
+	assert.Equal(t, types.ExternalEntity.String(), browser.Type)
+	assert.Equal(t, []string{types.Browser}, browser.Technologies)
+	assert.Equal(t, []string{"customer-data"}, browser.CommunicationLinks["Browse"].DataAssetsReceived)
+	assert.Equal(t, types.Datastore.String(), model.TechnicalAssets["Shop DB"].Type)
+	assert.Equal(t, "shop-db", model.TechnicalAssets["Web App"].CommunicationLinks["Query"].Target)
+	assert.Contains(t, model.TagsAvailable, "frontend")
+
+	assert.Len(t, model.CustomRiskCategories, 2)
+	xss := model.CustomRiskCategories[0]
+	assert.Equal(t, "xss", xss.ID)
+	assert.Equal(t, 79, xss.CWE)
+	assert.Equal(t, types.Tampering.String(), xss.STRIDE)
+	assert.Equal(t, "Output Encoding: Encode all output", xss.Mitigation)
+	assert.Equal(t, "web-app", xss.RisksIdentified["<b>Cross-Site Scripting</b> at <b>Web App</b>"].MostRelevantTechnicalAsset)
+	assert.Len(t, model.CustomRiskCategories[1].RisksIdentified, 2)
+
+	assert.Equal(t, input.RiskTracking{Status: types.Mitigated.String(), Justification: "Mitigations implemented: Output Encoding"}, model.RiskTracking["xss@web-app"])
+	assert.Equal(t, types.FalsePositive.String(), model.RiskTracking["sqli@web-app>query"].Status)
+	assert.NotContains(t, model.RiskTracking, "sqli@shop-db", "exposed threats are unchecked")
+}
+
+func TestImportRejectsDanglingReferences(t *testing.T) {
+	document := &Document{
+		OtmVersion: Version,
+		Project:    Project{Name: "Broken", Id: "broken"},
+		Components: []Component{{Id: "a", Name: "A", Parent: &Parent{TrustZone: "missing"}}},
+	}
+
+	_, err := Import(document)
+	assert.Error(t, err)
+}
+
+func TestRiskStatus(t *testing.T) {
+	assert.Equal(t, types.Unchecked, riskStatus("EXPOSED"))
+	assert.Equal(t, types.Mitigated, riskStatus("Implemented"))
+	assert.Equal(t, types.FalsePositive, riskStatus("NOT_APPLICABLE"))
+	assert.Equal(t, types.InProgress, riskStatus("in-progress"))
+}
@@ -0,0 +1,239 @@
+/*
+Package otm converts between threagile models and the Open Threat Model (OTM) format,
+see https://github.com/iriusrisk/OpenThreatModel
+*/
+
+package otm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	Version = "0.2.0"
+
+	// attributeThreagile holds the threagile representation of an element, so that an export can be re-imported without loss
+	attributeThreagile = "threagile"
+)
+
+type Document struct {
+	OtmVersion      string           `json:"otmVersion" yaml:"otmVersion"`
+	Project         Project          `json:"project" yaml:"project"`
+	Representations []Representation `json:"representations,omitempty" yaml:"representations,omitempty"`
+	Assets          []Asset          `json:"assets,omitempty" yaml:"assets,omitempty"`
+	TrustZones      []TrustZone      `json:"trustZones,omitempty" yaml:"trustZones,omitempty"`
+	Components      []Component      `json:"components,omitempty" yaml:"components,omitempty"`
+	Dataflows       []Dataflow       `json:"dataflows,omitempty" yaml:"dataflows,omitempty"`
+	Threats         []Threat         `json:"threats,omitempty" yaml:"threats,omitempty"`
+	Mitigations     []Mitigation     `json:"mitigations,omitempty" yaml:"mitigations,omitempty"`
+}
+
+type Attributes map[string]any
+
+type Project struct {
+	Name         string     `json:"name" yaml:"name"`
+	Id           string     `json:"id" yaml:"id"`
+	Description  string     `json:"description,omitempty" yaml:"description,omitempty"`
+	Owner        string     `json:"owner,omitempty" yaml:"owner,omitempty"`
+	OwnerContact string     `json:"ownerContact,omitempty" yaml:"ownerContact,omitempty"`
+	Tags         []string   `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Attributes   Attributes `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+type Representation struct {
+	Name        string     `json:"name" yaml:"name"`
+	Id          string     `json:"id" yaml:"id"`
+	Type        string     `json:"type" yaml:"type"`
+	Description string     `json:"description,omitempty" yaml:"description,omitempty"`
+	Attributes  Attributes `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+type Asset struct {
+	Name        string     `json:"name" yaml:"name"`
+	Id          string     `json:"id" yaml:"id"`
+	Description string     `json:"description,omitempty" yaml:"description,omitempty"`
+	Risk        AssetRisk  `json:"risk" yaml:"risk"`
+	Attributes  Attributes `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+// AssetRisk rates confidentiality, integrity and availability from 0 to 100
+type AssetRisk struct {
+	Confidentiality float64 `json:"confidentiality" yaml:"confidentiality"`
+	Integrity       float64 `json:"integrity" yaml:"integrity"`
+	Availability    float64 `json:"availability" yaml:"availability"`
+	Comment         string  `json:"comment,omitempty" yaml:"comment,omitempty"`
+}
+
+type TrustZone struct {
+	Id          string        `json:"id" yaml:"id"`
+	Name        string        `json:"name" yaml:"name"`
+	Type        string        `json:"type,omitempty" yaml:"type,omitempty"`
+	Description string        `json:"description,omitempty" yaml:"description,omitempty"`
+	Risk        TrustZoneRisk `json:"risk" yaml:"risk"`
+	Parent      *Parent       `json:"parent,omitempty" yaml:"parent,omitempty"`
+	Attributes  Attributes    `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+// TrustZoneRisk rates the trust in a zone from 0 (untrusted) to 100 (fully trusted)
+type TrustZoneRisk struct {
+	TrustRating float64 `json:"trustRating" yaml:"trustRating"`
+}
+
+// Parent references either a trust zone or a component
+type Parent struct {
+	TrustZone string `json:"trustZone,omitempty" yaml:"trustZone,omitempty"`
+	Component string `json:"component,omitempty" yaml:"component,omitempty"`
+}
+
+type Component struct {
+	Id          string           `json:"id" yaml:"id"`
+	Name        string           `json:"name" yaml:"name"`
+	Type        string           `json:"type,omitempty" yaml:"type,omitempty"`
+	Description string           `json:"description,omitempty" yaml:"description,omitempty"`
+	Parent      *Parent          `json:"parent,omitempty" yaml:"parent,omitempty"`
+	Tags        []string         `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Assets      *ComponentAssets `json:"assets,omitempty" yaml:"assets,omitempty"`
+	Threats     []ThreatInstance `json:"threats,omitempty" yaml:"threats,omitempty"`
+	Attributes  Attributes       `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+type ComponentAssets struct {
+	Processed []string `json:"processed,omitempty" yaml:"processed,omitempty"`
+	Stored    []string `json:"stored,omitempty" yaml:"stored,omitempty"`
+}
+
+type Dataflow struct {
+	Id            string           `json:"id" yaml:"id"`
+	Name          string           `json:"name" yaml:"name"`
+	Description   string           `json:"description,omitempty" yaml:"description,omitempty"`
+	Bidirectional bool             `json:"bidirectional,omitempty" yaml:"bidirectional,omitempty"`
+	Source        string           `json:"source" yaml:"source"`
+	Destination   string           `json:"destination" yaml:"destination"`
+	Assets        []string         `json:"assets,omitempty" yaml:"assets,omitempty"`
+	Tags          []string         `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Threats       []ThreatInstance `json:"threats,omitempty" yaml:"threats,omitempty"`
+	Attributes    Attributes       `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+type ThreatInstance struct {
+	Threat      string               `json:"threat" yaml:"threat"`
+	State       string               `json:"state" yaml:"state"`
+	Mitigations []MitigationInstance `json:"mitigations,omitempty" yaml:"mitigations,omitempty"`
+}
+
+type MitigationInstance struct {
+	Mitigation string `json:"mitigation" yaml:"mitigation"`
+	State      string `json:"state" yaml:"state"`
+}
+
+type Threat struct {
+	Id          string     `json:"id" yaml:"id"`
+	Name        string     `json:"name" yaml:"name"`
+	Description string     `json:"description,omitempty" yaml:"description,omitempty"`
+	Categories  []string   `json:"categories,omitempty" yaml:"categories,omitempty"`
+	Cwes        []string   `json:"cwes,omitempty" yaml:"cwes,omitempty"`
+	Risk        ThreatRisk `json:"risk" yaml:"risk"`
+	Tags        []string   `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Attributes  Attributes `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+// ThreatRisk rates likelihood and impact from 0 to 100
+type ThreatRisk struct {
+	Likelihood        float64 `json:"likelihood" yaml:"likelihood"`
+	LikelihoodComment string  `json:"likelihoodComment,omitempty" yaml:"likelihoodComment,omitempty"`
+	Impact            float64 `json:"impact" yaml:"impact"`
+	ImpactComment     string  `json:"impactComment,omitempty" yaml:"impactComment,omitempty"`
+}
+
+type Mitigation struct {
+	Id            string     `json:"id" yaml:"id"`
+	Name          string     `json:"name" yaml:"name"`
+	Description   string     `json:"description,omitempty" yaml:"description,omitempty"`
+	RiskReduction float64    `json:"riskReduction" yaml:"riskReduction"`
+	Attributes    Attributes `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+// Read parses an OTM document in JSON or YAML format
+func Read(filename string) (*Document, error) {
+	data, readError := os.ReadFile(filepath.Clean(filename))
+	if readError != nil {
+		return nil, fmt.Errorf("unable to read otm file: %w", readError)
+	}
+
+	return Parse(data)
+}
+
+// Parse parses an OTM document in JSON or YAML format (JSON being a subset of YAML)
+func Parse(data []byte) (*Document, error) {
+	document := new(Document)
+	unmarshalError := yaml.Unmarshal(data, document)
+	if unmarshalError != nil {
+		return nil, fmt.Errorf("unable to parse otm: %w", unmarshalError)
+	}
+
+	if len(document.OtmVersion) == 0 {
+		return nil, fmt.Errorf("unable to parse otm: missing otmVersion")
+	}
+
+	return document, nil
+}
+
+// Write writes the document as YAML if the filename has a .yaml or .yml extension, otherwise as JSON
+func (what *Document) Write(filename string) error {
+	var data []byte
+	var marshalError error
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		data, marshalError = yaml.Marshal(what)
+
+	default:
+		data, marshalError = json.MarshalIndent(what, "", "  ")
+	}
+
+	if marshalError != nil {
+		return fmt.Errorf("unable to marshal otm: %w", marshalError)
+	}
+
+	writeError := os.WriteFile(filename, data, 0600)
+	if writeError != nil {
+		return fmt.Errorf("unable to write otm file %q: %w", filename, writeError)
+	}
+
+	return nil
+}
+
+// toAttributes stores the threagile representation of an element as attributes
+func toAttributes(value any) Attributes {
+	data, marshalError := yaml.Marshal(value)
+	if marshalError != nil {
+		return nil
+	}
+
+	var values map[string]any
+	if yaml.Unmarshal(data, &values) != nil || len(values) == 0 {
+		return nil
+	}
+
+	return Attributes{attributeThreagile: values}
+}
+
+// fromAttributes restores the threagile representation of an element from attributes, if present
+func fromAttributes(attributes Attributes, value any) bool {
+	values, ok := attributes[attributeThreagile]
+	if !ok {
+		return false
+	}
+
+	data, marshalError := yaml.Marshal(values)
+	if marshalError != nil {
+		return false
+	}
+
+	return yaml.Unmarshal(data, value) == nil
+}
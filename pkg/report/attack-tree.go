@@ -0,0 +1,179 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/model"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+// AttackTreeFilename returns the file name of the attack tree diagram of an abuse case, like "attack-tree-data-theft.svg"
+func AttackTreeFilename(abuseCase string, extension string) string {
+	return "attack-tree-" + strings.Trim(diagramFilenamePart(strings.ToLower(abuseCase)), "-") + extension
+}
+
+// attackTreeNodeColors returns the fill, border and text color of a node: risks in the color of their severity,
+// AND gates dark and OR gates light
+func attackTreeNodeColors(node *model.AttackTreeNode) (string, string, string) {
+	if len(node.RiskId) > 0 {
+		if severity, err := types.ParseRiskSeverity(node.Severity); err == nil {
+			color := rgbHexColorOfSeverity(severity)
+			return color, darkenHexColor(color), "#FFFFFF"
+		}
+	}
+	switch node.Operator {
+	case model.AttackTreeAnd:
+		return rgbHexColorTwilight(), darkenHexColor(rgbHexColorTwilight()), "#FFFFFF"
+	case model.AttackTreeOr:
+		return "#DFF4FF", rgbHexColorTwilight(), Black
+	default:
+		return VeryLightGray, LightGray, Black
+	}
+}
+
+// attackTreeNodeLines returns the operator (if any), the title and the details of a node
+func attackTreeNodeLines(node *model.AttackTreeNode) (string, string, []string) {
+	operator := strings.ToUpper(string(node.Operator))
+	details := append([]string{}, node.Details...)
+	if node.Difficulty > 0 {
+		details = append(details, fmt.Sprintf("difficulty %.1f", node.Difficulty))
+	}
+	if len(node.Severity) > 0 {
+		details = append([]string{node.Severity + " risk"}, details...)
+	}
+	return operator, node.Title, details
+}
+
+func walkAttackTree(node *model.AttackTreeNode, parentId string, id *int, visit func(node *model.AttackTreeNode, nodeId string, parentId string)) {
+	*id++
+	nodeId := "n" + strconv.Itoa(*id)
+	visit(node, nodeId, parentId)
+	for _, child := range node.Children {
+		walkAttackTree(child, nodeId, id, visit)
+	}
+}
+
+// RenderAttackTreeDiagram renders the attack tree without graphviz as "svg" or "png"
+func RenderAttackTreeDiagram(tree *model.AttackTree, format string, dpi int) ([]byte, error) {
+	fonts, err := loadDiagramFonts()
+	if err != nil {
+		return nil, err
+	}
+
+	diagram := &builtInDiagram{fonts: fonts, graph: newLayoutGraph(false), title: tree.AbuseCase}
+	nodes := make(map[string]*diagramNode)
+	id := 0
+	walkAttackTree(tree.Root, "", &id, func(node *model.AttackTreeNode, nodeId string, parentId string) {
+		fill, stroke, textColor := attackTreeNodeColors(node)
+		operator, title, details := attackTreeNodeLines(node)
+		lines := make([]diagramLine, 0)
+		if len(operator) > 0 {
+			lines = append(lines, diagramLine{text: operator, style: diagramTextStyle{size: diagramSmallFontSize, bold: true, color: textColor}})
+		}
+		lines = append(lines, diagramLine{text: title, style: diagramTextStyle{size: diagramFontSize, bold: true, color: textColor}})
+		for _, detail := range details {
+			lines = append(lines, diagramLine{text: detail, style: diagramTextStyle{size: diagramSmallFontSize, color: textColor}})
+		}
+		shape := boxShape
+		if len(node.Operator) == 0 {
+			shape = ellipseShape
+		}
+		nodes[nodeId] = diagram.addNode(&diagramNode{id: nodeId, shape: shape, style: diagramStyle{fill: fill, stroke: stroke, width: 2}, lines: lines}, nil)
+		if parent, ok := nodes[parentId]; ok {
+			diagram.edges = append(diagram.edges, &diagramEdge{
+				style: diagramStyle{stroke: MiddleLightGray, width: 1.5},
+				edge:  diagram.graph.addEdge(parent.node, nodes[nodeId].node),
+			})
+		}
+	})
+	return diagram.render(format, dpi)
+}
+
+// WriteAttackTreeGraphvizDOT writes the attack tree of an abuse case with the goals on top and the leaves at the bottom
+func WriteAttackTreeGraphvizDOT(tree *model.AttackTree, diagramFilenameDOT string, dpi int, progressReporter progressReporter) (*os.File, error) {
+	progressReporter.Info("Writing attack tree input: " + tree.AbuseCase)
+
+	var dotContent strings.Builder
+	dotContent.WriteString("digraph generatedModel { concentrate=false \n")
+	dotContent.WriteString(`	graph [ label="` + encode(tree.AbuseCase) + `"
+		labelloc=t
+		fontname="Verdana"
+		fontsize=40
+		outputorder="nodesfirst"
+		dpi=` + strconv.Itoa(dpi) + `
+		splines=polyline
+		rankdir="TB"
+	];
+	node [
+		fontname="Verdana"
+		fontsize="20"
+	];
+	edge [
+		shape="none"
+		fontname="Verdana"
+		fontsize="18"
+	];
+`)
+	id := 0
+	walkAttackTree(tree.Root, "", &id, func(node *model.AttackTreeNode, nodeId string, parentId string) {
+		fill, stroke, textColor := attackTreeNodeColors(node)
+		operator, title, details := attackTreeNodeLines(node)
+		shape := "box"
+		if len(node.Operator) == 0 {
+			shape = "ellipse"
+		}
+		label := "<b>" + encode(title) + "</b>"
+		if len(operator) > 0 {
+			label = `<font point-size="15"><b>` + operator + "</b></font><br/>" + label
+		}
+		for _, detail := range details {
+			label += `<br/><font point-size="15">` + encode(detail) + "</font>"
+		}
+		dotContent.WriteString("  " + nodeId + ` [ shape="` + shape + `" style="filled" penwidth="3.0"` +
+			` fillcolor="` + fill + `" color="` + stroke + `" fontcolor="` + textColor + `" label=<` + label + `> ];` + "\n")
+		if len(parentId) > 0 {
+			dotContent.WriteString("  " + parentId + " -> " + nodeId + ` [ color="` + MiddleLightGray + `" penwidth="2.0" arrowhead="none" ];` + "\n")
+		}
+	})
+	dotContent.WriteString("}")
+
+	// Write the DOT file
+	file, err := os.Create(filepath.Clean(diagramFilenameDOT))
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s: %w", diagramFilenameDOT, err)
+	}
+	defer func() { _ = file.Close() }()
+	_, err = fmt.Fprintln(file, dotContent.String())
+	if err != nil {
+		return nil, fmt.Errorf("error writing %s: %w", diagramFilenameDOT, err)
+	}
+	return file, nil
+}
+
+func GenerateAttackTreeGraphvizImage(dotFile *os.File, targetDir string, attackTreeFilenameSVG string, progressReporter progressReporter) error {
+	progressReporter.Info("Rendering attack tree input")
+	filename := filepath.Join(targetDir, attackTreeFilenameSVG)
+	cmd := exec.Command("dot", "-Tsvg", dotFile.Name(), "-o", filename) // #nosec G204
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	if err != nil {
+		return fmt.Errorf("graph rendering call failed with error: %w", err)
+	}
+	return nil
+}
+
+// GenerateAttackTreeImage is the built-in counterpart of GenerateAttackTreeGraphvizImage
+func GenerateAttackTreeImage(tree *model.AttackTree, targetDir string, attackTreeFilenameSVG string, dpi int, progressReporter progressReporter) error {
+	progressReporter.Info("Rendering attack tree: " + tree.AbuseCase)
+	content, err := RenderAttackTreeDiagram(tree, "svg", dpi)
+	if err != nil {
+		return fmt.Errorf("unable to render attack tree: %w", err)
+	}
+	return writeDiagramImage(filepath.Join(targetDir, attackTreeFilenameSVG), content)
+}
@@ -0,0 +1,50 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/model"
+)
+
+func attackTreeTestTree() *model.AttackTree {
+	return &model.AttackTree{AbuseCase: "Data Theft", Root: &model.AttackTreeNode{
+		Title:    "Data Theft",
+		Operator: model.AttackTreeOr,
+		Children: []*model.AttackTreeNode{{
+			Title:    "Compromise Database",
+			Operator: model.AttackTreeAnd,
+			Children: []*model.AttackTreeNode{
+				{Title: "To Database via JDBC", CommunicationLinkId: "app>database", Difficulty: 2, Details: []string{"encrypted"}},
+				{Title: "SQL Injection", RiskId: "sql-injection@database", Severity: "high"},
+			},
+		}},
+	}}
+}
+
+func TestAttackTreeFilename(t *testing.T) {
+	assert.Equal(t, "attack-tree-data-theft.svg", AttackTreeFilename("Data Theft", ".svg"))
+	assert.Equal(t, "attack-tree-denial-of-service.gv", AttackTreeFilename("Denial-of-Service!", ".gv"))
+}
+
+func TestWriteAttackTreeGraphvizDOTAndSVG(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "attack-tree.gv")
+	_, err := WriteAttackTreeGraphvizDOT(attackTreeTestTree(), filename, 100, &mockProgressReporter{})
+	assert.NoError(t, err)
+	content, err := os.ReadFile(filename)
+	assert.NoError(t, err)
+
+	dot := string(content)
+	assert.Contains(t, dot, `label="Data Theft"`)
+	assert.Contains(t, dot, "n1 -> n2")
+	assert.Contains(t, dot, "n2 -> n4")
+	assert.Contains(t, dot, "<b>AND</b>")
+	assert.Contains(t, dot, `fillcolor="`+rgbHexColorHighRisk()+`"`)
+
+	svg, err := RenderAttackTreeDiagram(attackTreeTestTree(), "svg", 100)
+	assert.NoError(t, err)
+	assert.Contains(t, string(svg), ">Compromise Database</text>")
+	assert.Contains(t, string(svg), ">difficulty 2.0</text>")
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 
 	"github.com/jung-kurt/gofpdf"
+	"github.com/threagile/threagile/pkg/types"
 )
 
 const (
@@ -173,3 +174,35 @@ func rgbHexColorOperation() string {
 func colorModelFailure(pdf *gofpdf.Fpdf) {
 	pdf.SetTextColor(148, 82, 0)
 }
+
+func rgbHexColorOfSeverity(severity types.RiskSeverity) string {
+	switch severity {
+	case types.CriticalSeverity:
+		return rgbHexColorCriticalRisk()
+	case types.HighSeverity:
+		return rgbHexColorHighRisk()
+	case types.ElevatedSeverity:
+		return rgbHexColorElevatedRisk()
+	case types.MediumSeverity:
+		return rgbHexColorMediumRisk()
+	default:
+		return rgbHexColorLowRisk()
+	}
+}
+
+func rgbHexColorOfRiskStatus(status types.RiskStatus) string {
+	switch status {
+	case types.InDiscussion:
+		return rgbHexColorRiskStatusInDiscussion()
+	case types.Accepted:
+		return rgbHexColorRiskStatusAccepted()
+	case types.InProgress:
+		return rgbHexColorRiskStatusInProgress()
+	case types.Mitigated:
+		return rgbHexColorRiskStatusMitigated()
+	case types.FalsePositive:
+		return rgbHexColorRiskStatusFalsePositive()
+	default:
+		return RgbHexColorRiskStatusUnchecked()
+	}
+}
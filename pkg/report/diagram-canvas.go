@@ -0,0 +1,284 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"sync"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/wcharczuk/go-chart/drawing"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/math/fixed"
+)
+
+// diagramCanvas is drawn on by the built-in diagram renderer, coordinates and font sizes are in pixels at 72 DPI
+type diagramCanvas interface {
+	polygon(points []layoutPoint, style diagramStyle)
+	polyline(points []layoutPoint, style diagramStyle)
+	text(x, y float64, value string, style diagramTextStyle)
+	beginNode(id string)
+	endNode()
+	bytes() ([]byte, error)
+}
+
+type diagramStyle struct {
+	fill   string
+	stroke string
+	width  float64
+	dash   []float64
+}
+
+type diagramTextStyle struct {
+	size  float64
+	bold  bool
+	color string
+	// the text is centered on x unless it is left aligned
+	leftAligned bool
+}
+
+type diagramFonts struct {
+	regular *truetype.Font
+	bold    *truetype.Font
+	faces   map[diagramTextStyle]font.Face
+	lock    sync.Mutex
+}
+
+var (
+	loadDiagramFonts = sync.OnceValues(func() (*diagramFonts, error) {
+		regular, err := truetype.Parse(goregular.TTF)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse regular font: %w", err)
+		}
+		bold, err := truetype.Parse(gobold.TTF)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse bold font: %w", err)
+		}
+		return &diagramFonts{regular: regular, bold: bold, faces: make(map[diagramTextStyle]font.Face)}, nil
+	})
+)
+
+func (what *diagramFonts) font(style diagramTextStyle) *truetype.Font {
+	if style.bold {
+		return what.bold
+	}
+	return what.regular
+}
+
+// measure returns the width of the text in pixels at 72 DPI
+func (what *diagramFonts) measure(value string, style diagramTextStyle) float64 {
+	what.lock.Lock()
+	defer what.lock.Unlock()
+
+	key := diagramTextStyle{size: style.size, bold: style.bold}
+	face, ok := what.faces[key]
+	if !ok {
+		face = truetype.NewFace(what.font(style), &truetype.Options{Size: style.size, DPI: 72})
+		what.faces[key] = face
+	}
+	return fixedToFloat(font.MeasureString(face, value))
+}
+
+func fixedToFloat(value fixed.Int26_6) float64 {
+	return float64(value) / 64
+}
+
+type svgCanvas struct {
+	content strings.Builder
+}
+
+func newSVGCanvas(width, height float64) *svgCanvas {
+	canvas := new(svgCanvas)
+	canvas.content.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="no"?>` + "\n")
+	canvas.content.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%.0fpt" height="%.0fpt" viewBox="0 0 %.2f %.2f">`+"\n",
+		width, height, width, height))
+	canvas.content.WriteString(fmt.Sprintf(`<rect x="0" y="0" width="%.2f" height="%.2f" fill="#FFFFFF"/>`+"\n", width, height))
+	return canvas
+}
+
+func (what *svgCanvas) polygon(points []layoutPoint, style diagramStyle) {
+	what.content.WriteString(`<polygon points="` + svgPoints(points) + `"` + svgStyle(style) + "/>\n")
+}
+
+func (what *svgCanvas) polyline(points []layoutPoint, style diagramStyle) {
+	style.fill = ""
+	what.content.WriteString(`<polyline points="` + svgPoints(points) + `"` + svgStyle(style) + "/>\n")
+}
+
+func (what *svgCanvas) text(x, y float64, value string, style diagramTextStyle) {
+	anchor := "middle"
+	if style.leftAligned {
+		anchor = "start"
+	}
+	weight := ""
+	if style.bold {
+		weight = ` font-weight="bold"`
+	}
+	what.content.WriteString(fmt.Sprintf(`<text x="%.2f" y="%.2f" text-anchor="%s" font-family="Go, Arial, sans-serif" font-size="%.1f"%s fill="%s">%s</text>`+"\n",
+		x, y, anchor, style.size, weight, style.color, xmlEscape(value)))
+}
+
+// beginNode groups the node like graphviz does, so the html report can link the nodes by their title
+func (what *svgCanvas) beginNode(id string) {
+	what.content.WriteString(`<g class="node"><title>` + xmlEscape(id) + "</title>\n")
+}
+
+func (what *svgCanvas) endNode() {
+	what.content.WriteString("</g>\n")
+}
+
+func (what *svgCanvas) bytes() ([]byte, error) {
+	return []byte(what.content.String() + "</svg>\n"), nil
+}
+
+func svgPoints(points []layoutPoint) string {
+	values := make([]string, 0, len(points))
+	for _, point := range points {
+		values = append(values, fmt.Sprintf("%.2f,%.2f", point.x, point.y))
+	}
+	return strings.Join(values, " ")
+}
+
+func svgStyle(style diagramStyle) string {
+	fill := style.fill
+	if len(fill) == 0 {
+		fill = "none"
+	}
+	result := ` fill="` + fill + `"`
+	if len(style.stroke) > 0 {
+		result += fmt.Sprintf(` stroke="%s" stroke-width="%.1f"`, style.stroke, style.width)
+	}
+	if len(style.dash) > 0 {
+		values := make([]string, 0, len(style.dash))
+		for _, value := range style.dash {
+			values = append(values, fmt.Sprintf("%.1f", value))
+		}
+		result += ` stroke-dasharray="` + strings.Join(values, ",") + `"`
+	}
+	return result
+}
+
+func xmlEscape(value string) string {
+	var result bytes.Buffer
+	for _, r := range value {
+		switch r {
+		case '&':
+			result.WriteString("&amp;")
+		case '<':
+			result.WriteString("&lt;")
+		case '>':
+			result.WriteString("&gt;")
+		case '"':
+			result.WriteString("&quot;")
+		case '\'':
+			result.WriteString("&apos;")
+		default:
+			result.WriteRune(r)
+		}
+	}
+	return result.String()
+}
+
+type pngCanvas struct {
+	image   *image.RGBA
+	context *drawing.RasterGraphicContext
+	fonts   *diagramFonts
+	err     error
+}
+
+func newPNGCanvas(width, height float64, dpi int, fonts *diagramFonts) (*pngCanvas, error) {
+	scale := float64(dpi) / 72
+	img := image.NewRGBA(image.Rect(0, 0, int(width*scale+0.5), int(height*scale+0.5)))
+	for n := range img.Pix {
+		img.Pix[n] = 0xFF
+	}
+
+	context, err := drawing.NewRasterGraphicContext(img)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create graphic context: %w", err)
+	}
+	// the raster context scales glyphs by font size times DPI in 26.6 fixed point units, so 64 DPI keeps sizes in pixels
+	context.SetDPI(64)
+	context.Scale(scale, scale)
+	return &pngCanvas{image: img, context: context, fonts: fonts}, nil
+}
+
+func (what *pngCanvas) polygon(points []layoutPoint, style diagramStyle) {
+	what.path(points, true, style)
+}
+
+func (what *pngCanvas) polyline(points []layoutPoint, style diagramStyle) {
+	style.fill = ""
+	what.path(points, false, style)
+}
+
+func (what *pngCanvas) path(points []layoutPoint, closed bool, style diagramStyle) {
+	if len(points) == 0 {
+		return
+	}
+
+	what.context.BeginPath()
+	what.context.MoveTo(points[0].x, points[0].y)
+	for _, point := range points[1:] {
+		what.context.LineTo(point.x, point.y)
+	}
+	if closed {
+		what.context.Close()
+	}
+
+	what.context.SetLineDash(style.dash, 0)
+	what.context.SetLineWidth(style.width)
+	what.context.SetFillColor(pngColor(style.fill))
+	what.context.SetStrokeColor(pngColor(style.stroke))
+	switch {
+	case len(style.fill) > 0 && len(style.stroke) > 0:
+		what.context.FillStroke()
+	case len(style.fill) > 0:
+		what.context.Fill()
+	case len(style.stroke) > 0:
+		what.context.Stroke()
+	}
+}
+
+func (what *pngCanvas) text(x, y float64, value string, style diagramTextStyle) {
+	if !style.leftAligned {
+		x -= what.fonts.measure(value, style) / 2
+	}
+
+	what.context.SetFont(what.fonts.font(style))
+	what.context.SetFontSize(style.size)
+	what.context.SetFillColor(pngColor(style.color))
+	if _, err := what.context.FillStringAt(value, x, y); err != nil && what.err == nil {
+		what.err = fmt.Errorf("unable to draw text %q: %w", value, err)
+	}
+}
+
+func (what *pngCanvas) beginNode(string) {
+}
+
+func (what *pngCanvas) endNode() {
+}
+
+func (what *pngCanvas) bytes() ([]byte, error) {
+	if what.err != nil {
+		return nil, what.err
+	}
+
+	var result bytes.Buffer
+	if err := png.Encode(&result, what.image); err != nil {
+		return nil, fmt.Errorf("unable to encode png: %w", err)
+	}
+	return result.Bytes(), nil
+}
+
+func pngColor(value string) color.Color {
+	if len(value) == 0 {
+		return color.Transparent
+	}
+	return drawing.ColorFromHex(strings.TrimPrefix(value, "#"))
+}
@@ -0,0 +1,325 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/types"
+)
+
+// DataFlowDiagramFormats are the text formats the data flow diagram can be exported as, with their file extension
+var DataFlowDiagramFormats = map[string]string{
+	"mermaid":     ".mmd",
+	"plantuml":    ".puml",
+	"structurizr": ".dsl",
+}
+
+// WriteDataFlowDiagram writes the data flow diagram as Mermaid flowchart, PlantUML or Structurizr DSL, keeping the
+// nesting of the trust boundaries, the shapes of the technical asset types and the colors of the links by encryption
+func WriteDataFlowDiagram(parsedModel *types.Model, format string, filename string, addModelTitle bool) error {
+	var content string
+	switch format {
+	case "mermaid":
+		content = makeDataFlowDiagramMermaid(parsedModel, addModelTitle)
+	case "plantuml":
+		content = makeDataFlowDiagramPlantUML(parsedModel, addModelTitle)
+	case "structurizr":
+		content = makeDataFlowDiagramStructurizr(parsedModel)
+	default:
+		return fmt.Errorf("unknown data flow diagram format %q (mermaid, plantuml, structurizr)", format)
+	}
+
+	err := os.WriteFile(filepath.Clean(filename), []byte(content), 0600)
+	if err != nil {
+		return fmt.Errorf("error writing %s: %w", filename, err)
+	}
+	return nil
+}
+
+func makeDataFlowDiagramMermaid(parsedModel *types.Model, addModelTitle bool) string {
+	var content strings.Builder
+	if addModelTitle {
+		content.WriteString("---\ntitle: " + strconv.Quote(parsedModel.Title) + "\n---\n")
+	}
+	direction := "TB"
+	if parsedModel.DiagramTweakLayoutLeftToRight {
+		direction = "LR"
+	}
+	content.WriteString("flowchart " + direction + "\n")
+
+	var styles strings.Builder
+	walkDataFlowDiagram(parsedModel,
+		func(trustBoundary *types.TrustBoundary, depth int) {
+			content.WriteString(indent(depth) + "subgraph " + diagramId("boundary", trustBoundary.Id) + `["` + mermaidEncode(trustBoundary.Title+" ("+trustBoundary.Type.String()+")") + "\"]\n")
+		},
+		func(trustBoundary *types.TrustBoundary, depth int) {
+			content.WriteString(indent(depth) + "end\n")
+		},
+		func(technicalAsset *types.TechnicalAsset, depth int) {
+			id := diagramId("asset", technicalAsset.Id)
+			open, close := "[", "]"
+			switch {
+			case technicalAsset.UsedAsClientByHuman:
+				open, close = "{{", "}}"
+			case technicalAsset.Type == types.Process:
+				open, close = "([", "])"
+			case technicalAsset.Type == types.Datastore:
+				open, close = "[(", ")]"
+			}
+			content.WriteString(indent(depth) + id + open + `"` + mermaidEncode(technicalAsset.Title) + `"` + close + "\n")
+
+			styles.WriteString("  style " + id + " fill:" + determineShapeFillColor(technicalAsset, parsedModel) + ",stroke:" + determineShapeBorderColor(technicalAsset, parsedModel))
+			if determineShapeBorderLineStyle(technicalAsset) != "solid" {
+				styles.WriteString(",stroke-dasharray:3 3")
+			}
+			styles.WriteString("\n")
+		})
+	content.WriteString(styles.String())
+
+	for n, dataFlow := range sortedDataFlows(parsedModel) {
+		arrow := "-->"
+		if determineArrowLineStyle(dataFlow) != "solid" {
+			arrow = "-.->"
+		}
+		label := ""
+		if !parsedModel.DiagramTweakSuppressEdgeLabels {
+			label = `|"` + mermaidEncode(dataFlow.Protocol.String()) + `"|`
+		}
+		content.WriteString("  " + diagramId("asset", dataFlow.SourceId) + " " + arrow + label + " " + diagramId("asset", dataFlow.TargetId) + "\n")
+		content.WriteString("  linkStyle " + strconv.Itoa(n) + " stroke:" + determineProtocolColor(dataFlow) + "\n")
+	}
+	return content.String()
+}
+
+func makeDataFlowDiagramPlantUML(parsedModel *types.Model, addModelTitle bool) string {
+	var content strings.Builder
+	content.WriteString("@startuml\n")
+	if addModelTitle {
+		content.WriteString("title " + plantUMLEncode(parsedModel.Title) + "\n")
+	}
+	if parsedModel.DiagramTweakLayoutLeftToRight {
+		content.WriteString("left to right direction\n")
+	}
+
+	walkDataFlowDiagram(parsedModel,
+		func(trustBoundary *types.TrustBoundary, depth int) {
+			content.WriteString(indent(depth) + `rectangle "` + plantUMLEncode(trustBoundary.Title) + `\n(` + trustBoundary.Type.String() + `)" as ` + diagramId("boundary", trustBoundary.Id) + " #line.dashed {\n")
+		},
+		func(trustBoundary *types.TrustBoundary, depth int) {
+			content.WriteString(indent(depth) + "}\n")
+		},
+		func(technicalAsset *types.TechnicalAsset, depth int) {
+			element := "rectangle"
+			switch {
+			case technicalAsset.UsedAsClientByHuman:
+				element = "hexagon"
+			case technicalAsset.Type == types.Process:
+				element = "usecase"
+			case technicalAsset.Type == types.Datastore:
+				element = "database"
+			}
+			color := determineShapeFillColor(technicalAsset, parsedModel)
+			if determineShapeBorderLineStyle(technicalAsset) != "solid" {
+				color += ";line.dotted"
+			}
+			content.WriteString(indent(depth) + element + ` "` + plantUMLEncode(technicalAsset.Title) + `" as ` + diagramId("asset", technicalAsset.Id) + " " + color + "\n")
+		})
+
+	for _, dataFlow := range sortedDataFlows(parsedModel) {
+		style := determineProtocolColor(dataFlow)
+		if determineArrowLineStyle(dataFlow) != "solid" {
+			style += ",dashed"
+		}
+		content.WriteString(diagramId("asset", dataFlow.SourceId) + " -[" + style + "]-> " + diagramId("asset", dataFlow.TargetId))
+		if !parsedModel.DiagramTweakSuppressEdgeLabels {
+			content.WriteString(" : " + plantUMLEncode(dataFlow.Protocol.String()))
+		}
+		content.WriteString("\n")
+	}
+	content.WriteString("@enduml\n")
+	return content.String()
+}
+
+func makeDataFlowDiagramStructurizr(parsedModel *types.Model) string {
+	var content strings.Builder
+	content.WriteString(`workspace ` + structurizrEncode(parsedModel.Title) + ` {
+  model {
+    properties {
+      "structurizr.groupSeparator" "/"
+    }
+`)
+
+	walkDataFlowDiagram(parsedModel,
+		func(trustBoundary *types.TrustBoundary, depth int) {
+			// the group separator must not be part of the group names
+			title := strings.ReplaceAll(trustBoundary.Title+" ("+trustBoundary.Type.String()+")", "/", "-")
+			content.WriteString(indent(depth+1) + "group " + structurizrEncode(title) + " {\n")
+		},
+		func(trustBoundary *types.TrustBoundary, depth int) {
+			content.WriteString(indent(depth+1) + "}\n")
+		},
+		func(technicalAsset *types.TechnicalAsset, depth int) {
+			tags := []string{technicalAsset.Type.String()}
+			if technicalAsset.UsedAsClientByHuman {
+				tags = append(tags, "used-as-client-by-human")
+			}
+			if technicalAsset.OutOfScope {
+				tags = append(tags, "out-of-scope")
+			}
+			content.WriteString(indent(depth+1) + diagramId("asset", technicalAsset.Id) + " = softwareSystem " + structurizrEncode(technicalAsset.Title) + " " +
+				structurizrEncode(technicalAsset.Technologies.String()) + " " + structurizrEncode(strings.Join(tags, ",")) + "\n")
+		})
+
+	for _, dataFlow := range sortedDataFlows(parsedModel) {
+		tag := "unencrypted"
+		if dataFlow.Protocol == types.UnknownProtocol {
+			tag = "unknown-protocol"
+		} else if dataFlow.Protocol.IsEncrypted() {
+			tag = "encrypted"
+		} else if dataFlow.Protocol.IsProcessLocal() {
+			tag = "process-local"
+		}
+		content.WriteString("    " + diagramId("asset", dataFlow.SourceId) + " -> " + diagramId("asset", dataFlow.TargetId) + " " +
+			structurizrEncode(dataFlow.Title) + " " + structurizrEncode(dataFlow.Protocol.String()) + " " + structurizrEncode(tag) + "\n")
+	}
+
+	direction := "tb"
+	if parsedModel.DiagramTweakLayoutLeftToRight {
+		direction = "lr"
+	}
+	content.WriteString(`  }
+
+  views {
+    systemLandscape "DataFlowDiagram" {
+      include *
+      autoLayout ` + direction + `
+    }
+
+    styles {
+      element "` + types.ExternalEntity.String() + `" {
+        shape Box
+      }
+      element "` + types.Process.String() + `" {
+        shape Ellipse
+      }
+      element "` + types.Datastore.String() + `" {
+        shape Cylinder
+      }
+      element "used-as-client-by-human" {
+        shape Hexagon
+      }
+      element "out-of-scope" {
+        background ` + OutOfScopeFancy + `
+        border dotted
+      }
+      relationship "encrypted" {
+        color ` + Green + `
+      }
+      relationship "unencrypted" {
+        color ` + Red + `
+      }
+      relationship "process-local" {
+        color ` + Gray + `
+      }
+      relationship "unknown-protocol" {
+        color ` + Pink + `
+      }
+    }
+  }
+}
+`)
+	return content.String()
+}
+
+// walkDataFlowDiagram visits the trust boundaries containing anything (nested ones inside their parents) and the
+// technical assets inside them in a reproducible order, followed by the technical assets outside any trust boundary
+func walkDataFlowDiagram(parsedModel *types.Model, enterBoundary, leaveBoundary func(*types.TrustBoundary, int), visitAsset func(*types.TechnicalAsset, int)) {
+	var walk func(trustBoundary *types.TrustBoundary, depth int)
+	walk = func(trustBoundary *types.TrustBoundary, depth int) {
+		if len(trustBoundary.TechnicalAssetsInside) == 0 && len(trustBoundary.TrustBoundariesNested) == 0 {
+			return
+		}
+		enterBoundary(trustBoundary, depth)
+		nested := append([]string{}, trustBoundary.TrustBoundariesNested...)
+		sort.Strings(nested)
+		for _, id := range nested {
+			walk(parsedModel.TrustBoundaries[id], depth+1)
+		}
+		inside := append([]string{}, trustBoundary.TechnicalAssetsInside...)
+		sort.Strings(inside)
+		for _, id := range inside {
+			visitAsset(parsedModel.TechnicalAssets[id], depth+1)
+		}
+		leaveBoundary(trustBoundary, depth)
+	}
+
+	inBoundary := make(map[string]bool)
+	trustBoundaryIds := make([]string, 0)
+	for id, trustBoundary := range parsedModel.TrustBoundaries {
+		trustBoundaryIds = append(trustBoundaryIds, id)
+		for _, technicalAssetId := range trustBoundary.TechnicalAssetsInside {
+			inBoundary[technicalAssetId] = true
+		}
+	}
+	sort.Strings(trustBoundaryIds)
+	for _, id := range trustBoundaryIds {
+		if parsedModel.FindParentTrustBoundary(parsedModel.TrustBoundaries[id]) == nil {
+			walk(parsedModel.TrustBoundaries[id], 1)
+		}
+	}
+
+	for _, id := range parsedModel.SortedTechnicalAssetIDs() {
+		if !inBoundary[id] {
+			visitAsset(parsedModel.TechnicalAssets[id], 1)
+		}
+	}
+}
+
+func sortedDataFlows(parsedModel *types.Model) []*types.CommunicationLink {
+	dataFlows := make([]*types.CommunicationLink, 0)
+	for _, id := range parsedModel.SortedTechnicalAssetIDs() {
+		outgoing := append([]*types.CommunicationLink{}, parsedModel.TechnicalAssets[id].CommunicationLinks...)
+		sort.Slice(outgoing, func(i, j int) bool { return outgoing[i].Id < outgoing[j].Id })
+		dataFlows = append(dataFlows, outgoing...)
+	}
+	return dataFlows
+}
+
+// green when encrypted, gray when process-local, red when unencrypted and pink when the protocol is unknown
+func determineProtocolColor(cl *types.CommunicationLink) string {
+	if cl.Protocol == types.UnknownProtocol {
+		return Pink
+	}
+	if cl.Protocol.IsEncrypted() {
+		return Green
+	}
+	if cl.Protocol.IsProcessLocal() {
+		return Gray
+	}
+	return Red
+}
+
+// diagramId turns an id (letters, digits and dashes) into an identifier valid in all text diagram formats
+func diagramId(kind string, id string) string {
+	return kind + "_" + strings.ReplaceAll(id, "-", "_")
+}
+
+func indent(depth int) string {
+	return strings.Repeat("  ", depth)
+}
+
+func mermaidEncode(value string) string {
+	return strings.ReplaceAll(value, `"`, "#quot;")
+}
+
+func plantUMLEncode(value string) string {
+	return strings.ReplaceAll(value, `"`, "'")
+}
+
+func structurizrEncode(value string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(value, `\`, `\\`), `"`, `\"`) + `"`
+}
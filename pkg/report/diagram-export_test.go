@@ -0,0 +1,100 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+func diagramExportTestModel() *types.Model {
+	webServer := &types.TechnicalAsset{Id: "web-server", Title: "Web \"Shop\" Server", Type: types.Process, Machine: types.Virtual, DataAssetsProcessed: []string{"orders"}}
+	database := &types.TechnicalAsset{Id: "database", Title: "Database", Type: types.Datastore, Machine: types.Virtual, DataAssetsProcessed: []string{"orders"}}
+	browser := &types.TechnicalAsset{Id: "browser", Title: "Browser", Type: types.ExternalEntity, Machine: types.Virtual, UsedAsClientByHuman: true, OutOfScope: true}
+	browser.CommunicationLinks = []*types.CommunicationLink{
+		{Id: "browser>shop", Title: "Shop", SourceId: "browser", TargetId: "web-server", Protocol: types.HTTPS, DataAssetsSent: []string{"orders"}},
+	}
+	webServer.CommunicationLinks = []*types.CommunicationLink{
+		{Id: "web-server>store", Title: "Store", SourceId: "web-server", TargetId: "database", Protocol: types.JDBC},
+	}
+
+	return &types.Model{
+		Title: "Shop",
+		TechnicalAssets: map[string]*types.TechnicalAsset{
+			"web-server": webServer,
+			"database":   database,
+			"browser":    browser,
+		},
+		TrustBoundaries: map[string]*types.TrustBoundary{
+			"data-center": {Id: "data-center", Title: "Data Center", Type: types.NetworkOnPrem, TechnicalAssetsInside: []string{"web-server"}, TrustBoundariesNested: []string{"data-zone"}},
+			"data-zone":   {Id: "data-zone", Title: "Data/Zone", Type: types.NetworkDedicatedHoster, TechnicalAssetsInside: []string{"database"}},
+			"empty":       {Id: "empty", Title: "Empty", Type: types.NetworkOnPrem},
+		},
+		DataAssets: map[string]*types.DataAsset{"orders": {Id: "orders", Confidentiality: types.Confidential}},
+	}
+}
+
+func TestDataFlowDiagramMermaidKeepsNestingShapesAndColors(t *testing.T) {
+	mermaid := makeDataFlowDiagramMermaid(diagramExportTestModel(), true)
+
+	assert.Equal(t, `---
+title: "Shop"
+---
+flowchart TB
+  subgraph boundary_data_center["Data Center (network-on-prem)"]
+    subgraph boundary_data_zone["Data/Zone (network-dedicated-hoster)"]
+      asset_database[("Database")]
+    end
+    asset_web_server(["Web #quot;Shop#quot; Server"])
+  end
+  asset_browser{{"Browser"}}
+  style asset_database fill:#FFE7EF,stroke:#AF780E
+  style asset_web_server fill:#FFE7EF,stroke:#AF780E
+  style asset_browser fill:#FFE7EF,stroke:#000000,stroke-dasharray:3 3
+  asset_browser -->|"https"| asset_web_server
+  linkStyle 0 stroke:#008000
+  asset_web_server -.->|"jdbc"| asset_database
+  linkStyle 1 stroke:#CC0000
+`, mermaid)
+}
+
+func TestDataFlowDiagramPlantUMLKeepsNestingShapesAndColors(t *testing.T) {
+	plantUML := makeDataFlowDiagramPlantUML(diagramExportTestModel(), false)
+
+	assert.Contains(t, plantUML, "  rectangle \"Data Center\\n(network-on-prem)\" as boundary_data_center #line.dashed {\n"+
+		"    rectangle \"Data/Zone\\n(network-dedicated-hoster)\" as boundary_data_zone #line.dashed {\n"+
+		"      database \"Database\" as asset_database #FFE7EF\n"+
+		"    }\n"+
+		"    usecase \"Web 'Shop' Server\" as asset_web_server #FFE7EF\n"+
+		"  }\n")
+	assert.Contains(t, plantUML, "hexagon \"Browser\" as asset_browser #FFE7EF;line.dotted\n")
+	assert.Contains(t, plantUML, "asset_browser -[#008000]-> asset_web_server : https\n")
+	assert.Contains(t, plantUML, "asset_web_server -[#CC0000,dashed]-> asset_database : jdbc\n")
+	assert.NotContains(t, plantUML, "Empty")
+}
+
+func TestDataFlowDiagramStructurizrKeepsNestingShapesAndColors(t *testing.T) {
+	structurizr := makeDataFlowDiagramStructurizr(diagramExportTestModel())
+
+	assert.Contains(t, structurizr, "    group \"Data Center (network-on-prem)\" {\n"+
+		"      group \"Data-Zone (network-dedicated-hoster)\" {\n"+
+		"        asset_database = softwareSystem \"Database\" \"\" \"datastore\"\n"+
+		"      }\n"+
+		"      asset_web_server = softwareSystem \"Web \\\"Shop\\\" Server\" \"\" \"process\"\n"+
+		"    }\n")
+	assert.Contains(t, structurizr, "asset_browser = softwareSystem \"Browser\" \"\" \"external-entity,used-as-client-by-human,out-of-scope\"\n")
+	assert.Contains(t, structurizr, "asset_browser -> asset_web_server \"Shop\" \"https\" \"encrypted\"\n")
+	assert.Contains(t, structurizr, "asset_web_server -> asset_database \"Store\" \"jdbc\" \"unencrypted\"\n")
+}
+
+func TestWriteDataFlowDiagramRejectsUnknownFormats(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "diagram.mmd")
+	assert.NoError(t, WriteDataFlowDiagram(diagramExportTestModel(), "mermaid", filename, false))
+	content, err := os.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "flowchart TB\n")
+
+	assert.Error(t, WriteDataFlowDiagram(diagramExportTestModel(), "visio", filename, false))
+}
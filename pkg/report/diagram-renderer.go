@@ -0,0 +1,647 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/threagile/threagile/pkg/types"
+)
+
+const (
+	DiagramRendererAuto     = "auto"
+	DiagramRendererGraphviz = "graphviz"
+	DiagramRendererBuiltIn  = "builtin"
+)
+
+// UseGraphviz tells whether the diagrams are rendered by the external graphviz dot binary or by the built-in renderer,
+// auto picks graphviz whenever dot is found on the path
+func UseGraphviz(diagramRenderer string) (bool, error) {
+	switch diagramRenderer {
+	case DiagramRendererAuto, "":
+		_, err := exec.LookPath("dot")
+		return err == nil, nil
+	case DiagramRendererGraphviz:
+		return true, nil
+	case DiagramRendererBuiltIn:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unknown diagram renderer %q (%v, %v or %v)", diagramRenderer, DiagramRendererAuto, DiagramRendererGraphviz, DiagramRendererBuiltIn)
+	}
+}
+
+// RenderDataFlowDiagram renders the data flow diagram of the whole model, or of the part selected by the scope,
+// without graphviz as "svg" or "png"
+func RenderDataFlowDiagram(parsedModel *types.Model, format string, dpi int, addModelTitle bool, scope DiagramScope) ([]byte, error) {
+	fonts, err := loadDiagramFonts()
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := scope.filter(parsedModel)
+	if err != nil {
+		return nil, err
+	}
+	diagram := newDataFlowDiagram(parsedModel, filter, fonts)
+	diagram.title = scope.diagramTitle(parsedModel, addModelTitle)
+	return diagram.render(format, dpi)
+}
+
+// RenderDataAssetDiagram renders the data asset diagram without graphviz as "svg" or "png"
+func RenderDataAssetDiagram(parsedModel *types.Model, format string, dpi int) ([]byte, error) {
+	fonts, err := loadDiagramFonts()
+	if err != nil {
+		return nil, err
+	}
+
+	return newDataAssetDiagram(parsedModel, fonts).render(format, dpi)
+}
+
+// GenerateDataFlowDiagramImage is the built-in counterpart of GenerateDataFlowDiagramGraphvizImage
+func GenerateDataFlowDiagramImage(parsedModel *types.Model, targetDir string, dataFlowDiagramFilenamePNG string, dpi int, addModelTitle bool,
+	scope DiagramScope, progressReporter progressReporter) error {
+	progressReporter.Info("Rendering data flow diagram")
+	content, err := RenderDataFlowDiagram(parsedModel, "png", dpi, addModelTitle, scope)
+	if err != nil {
+		return fmt.Errorf("unable to render data flow diagram: %w", err)
+	}
+	return writeDiagramImage(filepath.Join(targetDir, dataFlowDiagramFilenamePNG), content)
+}
+
+// GenerateDataAssetDiagramImage is the built-in counterpart of GenerateDataAssetDiagramGraphvizImage
+func GenerateDataAssetDiagramImage(parsedModel *types.Model, targetDir string, dataAssetDiagramFilenamePNG string, dpi int,
+	progressReporter progressReporter) error {
+	progressReporter.Info("Rendering data asset diagram")
+	content, err := RenderDataAssetDiagram(parsedModel, "png", dpi)
+	if err != nil {
+		return fmt.Errorf("unable to render data asset diagram: %w", err)
+	}
+	return writeDiagramImage(filepath.Join(targetDir, dataAssetDiagramFilenamePNG), content)
+}
+
+func writeDiagramImage(filename string, content []byte) error {
+	err := os.WriteFile(filepath.Clean(filename), content, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", filename, err)
+	}
+	return nil
+}
+
+type diagramShape int
+
+const (
+	boxShape diagramShape = iota
+	ellipseShape
+	cylinderShape
+	octagonShape
+)
+
+func (what diagramShape) graphviz() string {
+	switch what {
+	case ellipseShape:
+		return "ellipse"
+	case cylinderShape:
+		return "cylinder"
+	case octagonShape:
+		return "octagon"
+	default:
+		return "box"
+	}
+}
+
+type diagramLine struct {
+	text  string
+	style diagramTextStyle
+}
+
+type diagramNode struct {
+	id          string
+	shape       diagramShape
+	style       diagramStyle
+	peripheries int
+	lines       []diagramLine
+	node        *layoutNode
+}
+
+type diagramEdge struct {
+	style  diagramStyle
+	hollow bool
+	label  string
+	color  string
+	edge   *layoutEdge
+}
+
+type diagramCluster struct {
+	label   diagramLine
+	style   diagramStyle
+	depth   int
+	cluster *layoutCluster
+}
+
+type builtInDiagram struct {
+	fonts    *diagramFonts
+	graph    *layoutGraph
+	nodes    []*diagramNode
+	edges    []*diagramEdge
+	clusters []*diagramCluster
+	title    string
+}
+
+const (
+	diagramSmallFontSize    = 11
+	diagramFontSize         = 14
+	diagramTitleFontSize    = 28
+	diagramLineSpacing      = 1.3
+	diagramNodePadding      = 12
+	diagramCylinderCapDepth = 6
+	diagramPeripheryGap     = 4
+	diagramArrowLength      = 10
+)
+
+func newDataFlowDiagram(parsedModel *types.Model, filter *diagramScopeFilter, fonts *diagramFonts) *builtInDiagram {
+	return newTechAssetDiagram(parsedModel, filter, fonts,
+		func(technicalAsset *types.TechnicalAsset) *diagramNode {
+			return makeTechAssetDiagramNode(parsedModel, technicalAsset)
+		},
+		func(dataFlow *types.CommunicationLink) *diagramEdge {
+			penWidth, _ := strconv.ParseFloat(determineArrowPenWidth(dataFlow, parsedModel), 64)
+			edge := &diagramEdge{
+				style:  diagramStyle{stroke: determineArrowColor(dataFlow, parsedModel), width: penWidth, dash: diagramDash(determineArrowLineStyle(dataFlow))},
+				hollow: dataFlow.Readonly,
+			}
+			if !parsedModel.DiagramTweakSuppressEdgeLabels {
+				edge.label, edge.color = dataFlow.Protocol.String(), determineLabelColor(dataFlow, parsedModel)
+			}
+			return edge
+		})
+}
+
+// newTechAssetDiagram lays out the technical assets in their trust boundaries with the communication links between
+// them, the callbacks decide on how the assets and links look like and the filter limits them to a part of the model
+func newTechAssetDiagram(parsedModel *types.Model, filter *diagramScopeFilter, fonts *diagramFonts,
+	makeNode func(technicalAsset *types.TechnicalAsset) *diagramNode, makeEdge func(dataFlow *types.CommunicationLink) *diagramEdge) *builtInDiagram {
+	diagram := &builtInDiagram{fonts: fonts, graph: newLayoutGraph(parsedModel.DiagramTweakLayoutLeftToRight)}
+	if parsedModel.DiagramTweakNodesep > 0 {
+		diagram.graph.nodeSeparation = float64(parsedModel.DiagramTweakNodesep) * 20
+	}
+	if parsedModel.DiagramTweakRanksep > 0 {
+		diagram.graph.rankSeparation = float64(parsedModel.DiagramTweakRanksep) * 20
+	}
+
+	// Trust Boundaries
+	clusters := make(map[string]*layoutCluster)
+	var addBoundary func(trustBoundary *types.TrustBoundary) *layoutCluster
+	addBoundary = func(trustBoundary *types.TrustBoundary) *layoutCluster {
+		if cluster, ok := clusters[trustBoundary.Id]; ok {
+			return cluster
+		}
+
+		var parent *layoutCluster
+		parentBoundary := parsedModel.FindParentTrustBoundary(trustBoundary)
+		if parentBoundary != nil {
+			parent = addBoundary(parentBoundary)
+		}
+
+		fillColor, fontColor, dash := "#FAFAFA", rgbHexColorTwilight(), []float64{8, 4}
+		if parentBoundary != nil {
+			fillColor = "#F1F1F1"
+		}
+		if trustBoundary.Type == types.NetworkPolicyNamespaceIsolation {
+			fontColor, fillColor = "#222222", "#DFF4FF"
+		}
+		if trustBoundary.Type == types.ExecutionEnvironment {
+			fontColor, fillColor, dash = "#555555", "#FFFFF0", []float64{2, 3}
+		}
+		label := diagramLine{
+			text:  trustBoundary.Title + " (" + trustBoundary.Type.String() + ")",
+			style: diagramTextStyle{size: diagramFontSize, bold: true, color: fontColor, leftAligned: true},
+		}
+
+		cluster := diagram.graph.addCluster(trustBoundary.Id, parent, fonts.measure(label.text, label.style))
+		clusters[trustBoundary.Id] = cluster
+		diagram.clusters = append(diagram.clusters, &diagramCluster{
+			label:   label,
+			style:   diagramStyle{fill: fillColor, stroke: rgbHexColorTwilight(), width: 2, dash: dash},
+			cluster: cluster,
+		})
+		return cluster
+	}
+	for _, id := range sortedKeysOfTrustBoundaries(parsedModel) {
+		if trustBoundary := parsedModel.TrustBoundaries[id]; filter.includesTrustBoundary(parsedModel, trustBoundary) {
+			addBoundary(trustBoundary)
+		}
+	}
+	for _, cluster := range diagram.clusters {
+		for parent := cluster.cluster.parent; parent != nil; parent = parent.parent {
+			cluster.depth++
+		}
+	}
+
+	// Technical Assets
+	techAssets := make([]*types.TechnicalAsset, 0, len(parsedModel.TechnicalAssets))
+	for _, techAsset := range parsedModel.TechnicalAssets {
+		techAssets = append(techAssets, techAsset)
+	}
+	sort.Sort(types.ByOrderAndIdSort(techAssets))
+	nodes := make(map[string]*layoutNode)
+	for _, technicalAsset := range techAssets {
+		if !filter.includesTechnicalAsset(technicalAsset.Id) {
+			continue
+		}
+		var cluster *layoutCluster
+		if trustBoundary := parsedModel.GetTechnicalAssetTrustBoundaryId(technicalAsset); len(trustBoundary) > 0 {
+			cluster = clusters[trustBoundary]
+		}
+		node := diagram.addNode(makeNode(technicalAsset), cluster)
+		nodes[technicalAsset.Id] = node.node
+	}
+
+	// Data Flows (Technical Communication Links)
+	for _, technicalAsset := range techAssets {
+		for _, dataFlow := range technicalAsset.CommunicationLinks {
+			from, to := nodes[technicalAsset.Id], nodes[dataFlow.TargetId]
+			if from == nil || to == nil || !filter.includesCommunicationLink(dataFlow) {
+				continue
+			}
+
+			edge := makeEdge(dataFlow)
+			edge.edge = diagram.graph.addEdge(from, to)
+			diagram.edges = append(diagram.edges, edge)
+		}
+	}
+
+	return diagram
+}
+
+func newDataAssetDiagram(parsedModel *types.Model, fonts *diagramFonts) *builtInDiagram {
+	diagram := &builtInDiagram{fonts: fonts, graph: newLayoutGraph(true)}
+	diagram.graph.rankSeparation = 160
+
+	// Data Assets
+	dataAssets := make([]*types.DataAsset, 0, len(parsedModel.DataAssets))
+	for _, dataAsset := range parsedModel.DataAssets {
+		dataAssets = append(dataAssets, dataAsset)
+	}
+	sortByDataAssetDataBreachProbabilityAndTitle(parsedModel, dataAssets)
+	nodes := make(map[string]*layoutNode)
+	for _, dataAsset := range dataAssets {
+		color := determineDataAssetColor(parsedModel, dataAsset)
+		node := diagram.addNode(&diagramNode{
+			id:    dataAsset.Id,
+			shape: ellipseShape,
+			style: diagramStyle{fill: color, stroke: color, width: 3},
+			lines: []diagramLine{{text: dataAsset.Title, style: diagramTextStyle{size: diagramFontSize, bold: true, color: "#FFFFFF"}}},
+		}, nil)
+		nodes[dataAsset.Id] = node.node
+	}
+
+	// Technical Assets
+	techAssets := make([]*types.TechnicalAsset, 0, len(parsedModel.TechnicalAssets))
+	for _, techAsset := range parsedModel.TechnicalAssets {
+		techAssets = append(techAssets, techAsset)
+	}
+	sort.Sort(types.ByOrderAndIdSort(techAssets))
+	for _, technicalAsset := range techAssets {
+		if len(technicalAsset.DataAssetsStored) == 0 && len(technicalAsset.DataAssetsProcessed) == 0 {
+			continue
+		}
+
+		color := determineTechAssetRiskColor(parsedModel, technicalAsset)
+		node := diagram.addNode(&diagramNode{
+			id:    technicalAsset.Id,
+			shape: boxShape,
+			style: diagramStyle{fill: color, stroke: color, width: 3},
+			lines: []diagramLine{{text: technicalAsset.Title, style: diagramTextStyle{size: diagramFontSize, bold: true, color: "#FFFFFF"}}},
+		}, nil)
+
+		// Data Asset to Tech Asset links
+		for _, dataAssetId := range technicalAsset.DataAssetsStored {
+			if source := nodes[dataAssetId]; source != nil {
+				diagram.edges = append(diagram.edges, &diagramEdge{
+					style: diagramStyle{stroke: "#0000FF", width: 1},
+					edge:  diagram.graph.addEdge(source, node.node),
+				})
+			}
+		}
+		for _, dataAssetId := range technicalAsset.DataAssetsProcessed {
+			if source := nodes[dataAssetId]; source != nil && !contains(technicalAsset.DataAssetsStored, dataAssetId) {
+				diagram.edges = append(diagram.edges, &diagramEdge{
+					style: diagramStyle{stroke: "#666666", width: 1, dash: diagramDash("dashed")},
+					edge:  diagram.graph.addEdge(source, node.node),
+				})
+			}
+		}
+	}
+
+	return diagram
+}
+
+func makeTechAssetDiagramNode(parsedModel *types.Model, technicalAsset *types.TechnicalAsset) *diagramNode {
+	// RAA = Relative Attacker Attractiveness
+	attackerAttractiveness := "RAA: out of scope"
+	if !technicalAsset.OutOfScope {
+		attackerAttractiveness = "RAA: " + fmt.Sprintf("%.0f", technicalAsset.RAA) + " %"
+	}
+
+	penWidth, _ := strconv.ParseFloat(determineShapeBorderPenWidth(technicalAsset, parsedModel), 64)
+	return &diagramNode{
+		id:    technicalAsset.Id,
+		shape: techAssetDiagramShape(technicalAsset),
+		style: diagramStyle{
+			fill:   determineShapeFillColor(technicalAsset, parsedModel),
+			stroke: determineShapeBorderColor(technicalAsset, parsedModel),
+			width:  penWidth,
+			dash:   diagramDash(determineShapeBorderLineStyle(technicalAsset)),
+		},
+		peripheries: determineShapePeripheries(technicalAsset),
+		lines: []diagramLine{
+			{text: technicalAsset.Technologies.String(), style: diagramTextStyle{size: diagramSmallFontSize, color: DarkBlue}},
+			{text: technicalAsset.Size.String(), style: diagramTextStyle{size: diagramSmallFontSize, color: LightGray}},
+			{text: technicalAsset.Title, style: diagramTextStyle{size: diagramFontSize, bold: true, color: determineTechnicalAssetLabelColor(technicalAsset, parsedModel)}},
+			{text: attackerAttractiveness, style: diagramTextStyle{size: diagramSmallFontSize, color: "#603112"}},
+		},
+	}
+}
+
+func techAssetDiagramShape(technicalAsset *types.TechnicalAsset) diagramShape {
+	if technicalAsset.UsedAsClientByHuman {
+		return octagonShape
+	}
+	switch technicalAsset.Type {
+	case types.Process:
+		return ellipseShape
+	case types.Datastore:
+		return cylinderShape
+	default:
+		return boxShape
+	}
+}
+
+func determineDataAssetColor(parsedModel *types.Model, dataAsset *types.DataAsset) string {
+	if !isDataBreachPotentialStillAtRisk(parsedModel, dataAsset) {
+		return "#444444" // since black is too dark here as fill color
+	}
+	switch identifiedDataBreachProbabilityStillAtRisk(parsedModel, dataAsset) {
+	case types.Probable:
+		return rgbHexColorHighRisk()
+	case types.Possible:
+		return rgbHexColorMediumRisk()
+	case types.Improbable:
+		return rgbHexColorLowRisk()
+	default:
+		return "#444444" // since black is too dark here as fill color
+	}
+}
+
+func determineTechAssetRiskColor(parsedModel *types.Model, technicalAsset *types.TechnicalAsset) string {
+	if technicalAsset.OutOfScope {
+		return rgbHexColorOutOfScope()
+	}
+	generatedRisks := parsedModel.GeneratedRisks(technicalAsset)
+	if len(types.ReduceToOnlyStillAtRisk(generatedRisks)) == 0 {
+		return "#444444" // since black is too dark here as fill color
+	}
+	switch types.HighestSeverityStillAtRisk(generatedRisks) {
+	case types.CriticalSeverity:
+		return rgbHexColorCriticalRisk()
+	case types.HighSeverity:
+		return rgbHexColorHighRisk()
+	case types.ElevatedSeverity:
+		return rgbHexColorElevatedRisk()
+	case types.MediumSeverity:
+		return rgbHexColorMediumRisk()
+	case types.LowSeverity:
+		return rgbHexColorLowRisk()
+	default:
+		return "#444444" // since black is too dark here as fill color
+	}
+}
+
+func diagramDash(lineStyle string) []float64 {
+	switch lineStyle {
+	case "dotted":
+		return []float64{2, 3}
+	case "dashed":
+		return []float64{8, 4}
+	default:
+		return nil
+	}
+}
+
+// addNode sizes the node by its text lines and shape before adding it to the layout
+func (what *builtInDiagram) addNode(node *diagramNode, cluster *layoutCluster) *diagramNode {
+	width, height := 0.0, 0.0
+	for _, line := range node.lines {
+		width = max(width, what.fonts.measure(line.text, line.style))
+		height += line.style.size * diagramLineSpacing
+	}
+	width += 2 * diagramNodePadding
+	height += 2 * diagramNodePadding
+
+	switch node.shape {
+	case ellipseShape:
+		width, height = width*1.3, height*1.3
+	case cylinderShape:
+		height += 2 * diagramCylinderCapDepth
+	case octagonShape:
+		width += height / 2
+	}
+	width += 2 * diagramPeripheryGap * float64(max(node.peripheries-1, 0))
+	height += 2 * diagramPeripheryGap * float64(max(node.peripheries-1, 0))
+
+	node.node = what.graph.addNode(node.id, width, height, cluster)
+	what.nodes = append(what.nodes, node)
+	return node
+}
+
+func (what *builtInDiagram) render(format string, dpi int) ([]byte, error) {
+	what.graph.layout()
+
+	titleHeight := 0.0
+	if len(what.title) > 0 {
+		titleHeight = diagramTitleFontSize * 2
+	}
+	width, height := what.graph.width, what.graph.height+titleHeight
+	if len(what.title) > 0 {
+		width = max(width, what.fonts.measure(what.title, diagramTextStyle{size: diagramTitleFontSize, bold: true})+2*what.graph.margin)
+	}
+
+	var canvas diagramCanvas
+	switch format {
+	case "svg":
+		canvas = newSVGCanvas(width, height)
+	case "png":
+		pngCanvas, err := newPNGCanvas(width, height, dpi, what.fonts)
+		if err != nil {
+			return nil, err
+		}
+		canvas = pngCanvas
+	default:
+		return nil, fmt.Errorf("unknown diagram format %q (svg or png)", format)
+	}
+
+	if len(what.title) > 0 {
+		canvas.text(width/2, diagramTitleFontSize*1.5, what.title, diagramTextStyle{size: diagramTitleFontSize, bold: true, color: Black})
+	}
+	what.draw(canvas, layoutPoint{x: (width - what.graph.width) / 2, y: titleHeight})
+	return canvas.bytes()
+}
+
+// draw paints the clusters from the outermost to the innermost, then the edges and finally the nodes on top
+func (what *builtInDiagram) draw(canvas diagramCanvas, offset layoutPoint) {
+	clusters := append([]*diagramCluster{}, what.clusters...)
+	sort.SliceStable(clusters, func(i, j int) bool { return clusters[i].depth < clusters[j].depth })
+	for _, cluster := range clusters {
+		box := cluster.cluster
+		if box.maxLayer < 0 {
+			continue
+		}
+		canvas.polygon(translatePoints(rectanglePoints(box.x, box.y, box.width, box.height), offset), cluster.style)
+		canvas.text(offset.x+box.x+what.graph.clusterPadding/2, offset.y+box.y+what.graph.clusterLabelHeight*0.8, cluster.label.text, cluster.label.style)
+	}
+
+	for _, edge := range what.edges {
+		what.drawEdge(canvas, edge, offset)
+	}
+
+	for _, node := range what.nodes {
+		canvas.beginNode(hash(node.id))
+		what.drawNode(canvas, node, offset)
+		canvas.endNode()
+	}
+}
+
+func (what *builtInDiagram) drawNode(canvas diagramCanvas, node *diagramNode, offset layoutPoint) {
+	x, y := offset.x+node.node.x, offset.y+node.node.y
+	width, height := node.node.width, node.node.height
+	for periphery := max(node.peripheries, 1) - 1; periphery >= 0; periphery-- {
+		inset := 2 * diagramPeripheryGap * float64(max(node.peripheries-1, 0)-periphery)
+		style := node.style
+		if periphery > 0 {
+			style.fill = ""
+		}
+		what.drawShape(canvas, node.shape, x, y, width-inset, height-inset, style)
+	}
+
+	lineHeights := 0.0
+	for _, line := range node.lines {
+		lineHeights += line.style.size * diagramLineSpacing
+	}
+	baseline := y - lineHeights/2
+	for _, line := range node.lines {
+		baseline += line.style.size * diagramLineSpacing
+		if len(line.text) == 0 {
+			continue
+		}
+		canvas.text(x, baseline-line.style.size*(diagramLineSpacing-1)-2, line.text, line.style)
+	}
+}
+
+func (what *builtInDiagram) drawShape(canvas diagramCanvas, shape diagramShape, x, y, width, height float64, style diagramStyle) {
+	left, top := x-width/2, y-height/2
+	switch shape {
+	case ellipseShape:
+		canvas.polygon(ellipsePoints(x, y, width/2, height/2, 0, 2*math.Pi), style)
+	case octagonShape:
+		cut := min(width, height) / 4
+		canvas.polygon([]layoutPoint{
+			{left + cut, top}, {left + width - cut, top}, {left + width, top + cut}, {left + width, top + height - cut},
+			{left + width - cut, top + height}, {left + cut, top + height}, {left, top + height - cut}, {left, top + cut},
+		}, style)
+	case cylinderShape:
+		capTop, capBottom := top+diagramCylinderCapDepth, top+height-diagramCylinderCapDepth
+		outline := []layoutPoint{{left, capTop}, {left, capBottom}}
+		outline = append(outline, ellipsePoints(x, capBottom, width/2, diagramCylinderCapDepth, math.Pi, -math.Pi)...)
+		outline = append(outline, layoutPoint{left + width, capTop})
+		outline = append(outline, ellipsePoints(x, capTop, width/2, diagramCylinderCapDepth, 0, -math.Pi)...)
+		canvas.polygon(outline, style)
+		canvas.polyline(ellipsePoints(x, capTop, width/2, diagramCylinderCapDepth, math.Pi, -math.Pi), style)
+	default:
+		canvas.polygon(rectanglePoints(left, top, width, height), style)
+	}
+}
+
+func (what *builtInDiagram) drawEdge(canvas diagramCanvas, edge *diagramEdge, offset layoutPoint) {
+	points := translatePoints(edge.edge.points, offset)
+	if len(points) < 2 {
+		return
+	}
+
+	// shorten the line so that it ends at the back of the arrow head
+	end, previous := points[len(points)-1], points[len(points)-2]
+	length := math.Hypot(end.x-previous.x, end.y-previous.y)
+	if length == 0 {
+		return
+	}
+	dx, dy := (end.x-previous.x)/length, (end.y-previous.y)/length
+	arrowLength := diagramArrowLength + edge.style.width
+	back := layoutPoint{end.x - dx*arrowLength, end.y - dy*arrowLength}
+	line := append(append([]layoutPoint{}, points[:len(points)-1]...), back)
+	canvas.polyline(line, edge.style)
+
+	headStyle := diagramStyle{fill: edge.style.stroke, stroke: edge.style.stroke, width: 1}
+	if edge.hollow {
+		headStyle.fill = "#FFFFFF"
+	}
+	halfWidth := arrowLength / 2.5
+	canvas.polygon([]layoutPoint{
+		end,
+		{back.x - dy*halfWidth, back.y + dx*halfWidth},
+		{back.x + dy*halfWidth, back.y - dx*halfWidth},
+	}, headStyle)
+
+	if len(edge.label) > 0 {
+		middle := pointAlong(points, 0.5)
+		canvas.text(middle.x+4, middle.y-4, edge.label, diagramTextStyle{size: diagramSmallFontSize, color: edge.color, leftAligned: true})
+	}
+}
+
+func rectanglePoints(left, top, width, height float64) []layoutPoint {
+	return []layoutPoint{{left, top}, {left + width, top}, {left + width, top + height}, {left, top + height}}
+}
+
+// ellipsePoints approximates an arc of the ellipse, angles are in radians and clockwise as the y axis points down
+func ellipsePoints(x, y, rx, ry, start, delta float64) []layoutPoint {
+	const segments = 48
+	count := int(math.Ceil(math.Abs(delta) / (2 * math.Pi) * segments))
+	points := make([]layoutPoint, 0, count+1)
+	for n := 0; n <= count; n++ {
+		angle := start + delta*float64(n)/float64(count)
+		points = append(points, layoutPoint{x + rx*math.Cos(angle), y + ry*math.Sin(angle)})
+	}
+	if delta == 2*math.Pi {
+		points = points[:len(points)-1]
+	}
+	return points
+}
+
+func translatePoints(points []layoutPoint, offset layoutPoint) []layoutPoint {
+	result := make([]layoutPoint, 0, len(points))
+	for _, point := range points {
+		result = append(result, layoutPoint{point.x + offset.x, point.y + offset.y})
+	}
+	return result
+}
+
+// pointAlong returns the point at the given fraction of the length of the polyline
+func pointAlong(points []layoutPoint, fraction float64) layoutPoint {
+	total := 0.0
+	for n := 1; n < len(points); n++ {
+		total += math.Hypot(points[n].x-points[n-1].x, points[n].y-points[n-1].y)
+	}
+
+	remaining := total * fraction
+	for n := 1; n < len(points); n++ {
+		segment := math.Hypot(points[n].x-points[n-1].x, points[n].y-points[n-1].y)
+		if segment >= remaining && segment > 0 {
+			ratio := remaining / segment
+			return layoutPoint{points[n-1].x + (points[n].x-points[n-1].x)*ratio, points[n-1].y + (points[n].y-points[n-1].y)*ratio}
+		}
+		remaining -= segment
+	}
+	return points[len(points)-1]
+}
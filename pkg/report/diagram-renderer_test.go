@@ -0,0 +1,68 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderDataFlowDiagramSVGIsWellFormedAndLinkable(t *testing.T) {
+	svg, err := RenderDataFlowDiagram(diagramExportTestModel(), "svg", 100, true, DiagramScope{})
+	assert.NoError(t, err)
+
+	decoder := xml.NewDecoder(bytes.NewReader(svg))
+	for {
+		_, err := decoder.Token()
+		if err != nil {
+			assert.Equal(t, "EOF", err.Error())
+			break
+		}
+	}
+
+	content := string(svg)
+	assert.Contains(t, content, `<g class="node"><title>`+hash("web-server")+"</title>")
+	assert.Contains(t, content, `<g class="node"><title>`+hash("browser")+"</title>")
+	assert.Contains(t, content, ">Web &quot;Shop&quot; Server</text>")
+	assert.Contains(t, content, ">Data/Zone (network-dedicated-hoster)</text>")
+	assert.Contains(t, content, ">Shop</text>")
+	assert.Contains(t, content, ">https</text>")
+	assert.NotContains(t, content, "Empty")
+	assert.Contains(t, content, `stroke="`+Amber+`"`)
+}
+
+func TestRenderDiagramsAsPNGScalesByDPI(t *testing.T) {
+	content, err := RenderDataFlowDiagram(diagramExportTestModel(), "png", 72, false, DiagramScope{})
+	assert.NoError(t, err)
+	small, err := png.Decode(bytes.NewReader(content))
+	assert.NoError(t, err)
+
+	content, err = RenderDataFlowDiagram(diagramExportTestModel(), "png", 144, false, DiagramScope{})
+	assert.NoError(t, err)
+	large, err := png.Decode(bytes.NewReader(content))
+	assert.NoError(t, err)
+	assert.InDelta(t, 2*small.Bounds().Dx(), large.Bounds().Dx(), 1)
+
+	content, err = RenderDataAssetDiagram(diagramExportTestModel(), "png", 72)
+	assert.NoError(t, err)
+	_, err = png.Decode(bytes.NewReader(content))
+	assert.NoError(t, err)
+
+	_, err = RenderDataAssetDiagram(diagramExportTestModel(), "gif", 72)
+	assert.Error(t, err)
+}
+
+func TestUseGraphviz(t *testing.T) {
+	useGraphviz, err := UseGraphviz(DiagramRendererBuiltIn)
+	assert.NoError(t, err)
+	assert.False(t, useGraphviz)
+
+	useGraphviz, err = UseGraphviz(DiagramRendererGraphviz)
+	assert.NoError(t, err)
+	assert.True(t, useGraphviz)
+
+	_, err = UseGraphviz("visio")
+	assert.Error(t, err)
+}
@@ -0,0 +1,179 @@
+package report
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/types"
+)
+
+// DiagramScope limits the data flow diagram to a part of the model, the zero value draws the whole model
+type DiagramScope struct {
+	// TrustBoundaryId draws the technical assets inside the trust boundary (including nested ones) together with the
+	// technical assets on the other side of the communication links crossing it
+	TrustBoundaryId string
+	// TechnicalAssetId draws the technical assets reachable within Hops communication links (in either direction)
+	TechnicalAssetId string
+	Hops             int
+}
+
+func TrustBoundaryDiagramScope(trustBoundaryId string) DiagramScope {
+	return DiagramScope{TrustBoundaryId: trustBoundaryId}
+}
+
+func NeighbourhoodDiagramScope(technicalAssetId string, hops int) DiagramScope {
+	return DiagramScope{TechnicalAssetId: technicalAssetId, Hops: hops}
+}
+
+func (what DiagramScope) IsEmpty() bool {
+	return len(what.TrustBoundaryId) == 0 && len(what.TechnicalAssetId) == 0
+}
+
+// Filename derives the file name of the scoped diagram from the one of the full diagram, like "data-flow-diagram.png"
+// becoming "data-flow-diagram-boundary-dmz.png"
+func (what DiagramScope) Filename(filename string) string {
+	var suffix string
+	switch {
+	case len(what.TrustBoundaryId) > 0:
+		suffix = "-boundary-" + diagramFilenamePart(what.TrustBoundaryId)
+	case len(what.TechnicalAssetId) > 0:
+		suffix = "-asset-" + diagramFilenamePart(what.TechnicalAssetId)
+	default:
+		return filename
+	}
+
+	extension := ""
+	if index := strings.LastIndex(filename, "."); index > strings.LastIndexAny(filename, `/\`) {
+		filename, extension = filename[:index], filename[index:]
+	}
+	return filename + suffix + extension
+}
+
+var diagramFilenameUnsafeCharacters = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+func diagramFilenamePart(id string) string {
+	return diagramFilenameUnsafeCharacters.ReplaceAllString(id, "-")
+}
+
+func (what DiagramScope) title(parsedModel *types.Model) string {
+	switch {
+	case len(what.TrustBoundaryId) > 0:
+		if trustBoundary, ok := parsedModel.TrustBoundaries[what.TrustBoundaryId]; ok {
+			return trustBoundary.Title
+		}
+	case len(what.TechnicalAssetId) > 0:
+		if technicalAsset, ok := parsedModel.TechnicalAssets[what.TechnicalAssetId]; ok {
+			hops := strconv.Itoa(what.Hops) + " hops"
+			if what.Hops == 1 {
+				hops = "1 hop"
+			}
+			return technicalAsset.Title + " (" + hops + ")"
+		}
+	}
+	return ""
+}
+
+// diagramTitle returns the title of a data flow diagram in this scope, which is empty when no title is wanted
+func (what DiagramScope) diagramTitle(parsedModel *types.Model, addModelTitle bool) string {
+	scopeTitle := what.title(parsedModel)
+	switch {
+	case !addModelTitle:
+		return scopeTitle
+	case len(scopeTitle) > 0:
+		return parsedModel.Title + ": " + scopeTitle
+	default:
+		return parsedModel.Title
+	}
+}
+
+// diagramScopeFilter tells which parts of the model are drawn, a nil filter draws everything
+type diagramScopeFilter struct {
+	// drawn technical assets
+	drawn map[string]bool
+	// focus are the drawn technical assets whose communication links are drawn
+	focus map[string]bool
+}
+
+func (what DiagramScope) filter(parsedModel *types.Model) (*diagramScopeFilter, error) {
+	if what.IsEmpty() {
+		return nil, nil
+	}
+
+	filter := &diagramScopeFilter{drawn: make(map[string]bool), focus: make(map[string]bool)}
+	switch {
+	case len(what.TrustBoundaryId) > 0:
+		trustBoundary, ok := parsedModel.TrustBoundaries[what.TrustBoundaryId]
+		if !ok {
+			return nil, fmt.Errorf("unknown trust boundary for diagram: %v", what.TrustBoundaryId)
+		}
+		for _, id := range parsedModel.RecursivelyAllTechnicalAssetIDsInside(trustBoundary) {
+			filter.drawn[id] = true
+			filter.focus[id] = true
+		}
+		for _, technicalAsset := range parsedModel.TechnicalAssets {
+			for _, link := range technicalAsset.CommunicationLinks {
+				if filter.focus[link.SourceId] || filter.focus[link.TargetId] {
+					filter.drawn[link.SourceId] = true
+					filter.drawn[link.TargetId] = true
+				}
+			}
+		}
+
+	default:
+		if _, ok := parsedModel.TechnicalAssets[what.TechnicalAssetId]; !ok {
+			return nil, fmt.Errorf("unknown technical asset for diagram: %v", what.TechnicalAssetId)
+		}
+		if what.Hops < 0 {
+			return nil, fmt.Errorf("negative number of hops for diagram: %v", what.Hops)
+		}
+		neighbours := make(map[string][]string)
+		for _, technicalAsset := range parsedModel.TechnicalAssets {
+			for _, link := range technicalAsset.CommunicationLinks {
+				neighbours[link.SourceId] = append(neighbours[link.SourceId], link.TargetId)
+				neighbours[link.TargetId] = append(neighbours[link.TargetId], link.SourceId)
+			}
+		}
+		filter.drawn[what.TechnicalAssetId] = true
+		current := []string{what.TechnicalAssetId}
+		for hop := 0; hop < what.Hops && len(current) > 0; hop++ {
+			next := make([]string, 0)
+			for _, id := range current {
+				for _, neighbour := range neighbours[id] {
+					if !filter.drawn[neighbour] {
+						filter.drawn[neighbour] = true
+						next = append(next, neighbour)
+					}
+				}
+			}
+			current = next
+		}
+		filter.focus = filter.drawn
+	}
+	return filter, nil
+}
+
+func (what *diagramScopeFilter) includesTechnicalAsset(id string) bool {
+	return what == nil || what.drawn[id]
+}
+
+func (what *diagramScopeFilter) includesCommunicationLink(link *types.CommunicationLink) bool {
+	if what == nil {
+		return true
+	}
+	return what.drawn[link.SourceId] && what.drawn[link.TargetId] && (what.focus[link.SourceId] || what.focus[link.TargetId])
+}
+
+// includesTrustBoundary tells whether any drawn technical asset is inside the trust boundary or its nested ones
+func (what *diagramScopeFilter) includesTrustBoundary(parsedModel *types.Model, trustBoundary *types.TrustBoundary) bool {
+	if what == nil {
+		return true
+	}
+	for _, id := range parsedModel.RecursivelyAllTechnicalAssetIDsInside(trustBoundary) {
+		if what.drawn[id] {
+			return true
+		}
+	}
+	return false
+}
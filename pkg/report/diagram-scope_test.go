@@ -0,0 +1,69 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrustBoundaryDiagramScopeIncludesLinksCrossingIt(t *testing.T) {
+	parsedModel := diagramExportTestModel()
+	filter, err := TrustBoundaryDiagramScope("data-zone").filter(parsedModel)
+	assert.NoError(t, err)
+
+	assert.True(t, filter.includesTechnicalAsset("database"))
+	assert.True(t, filter.includesTechnicalAsset("web-server"))
+	assert.False(t, filter.includesTechnicalAsset("browser"))
+	assert.True(t, filter.includesCommunicationLink(parsedModel.TechnicalAssets["web-server"].CommunicationLinks[0]))
+	assert.False(t, filter.includesCommunicationLink(parsedModel.TechnicalAssets["browser"].CommunicationLinks[0]))
+	assert.True(t, filter.includesTrustBoundary(parsedModel, parsedModel.TrustBoundaries["data-center"]))
+	assert.False(t, filter.includesTrustBoundary(parsedModel, parsedModel.TrustBoundaries["empty"]))
+
+	_, err = TrustBoundaryDiagramScope("unknown").filter(parsedModel)
+	assert.Error(t, err)
+}
+
+func TestNeighbourhoodDiagramScopeFollowsLinksInBothDirections(t *testing.T) {
+	parsedModel := diagramExportTestModel()
+	filter, err := NeighbourhoodDiagramScope("database", 1).filter(parsedModel)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"database": true, "web-server": true}, filter.drawn)
+
+	filter, err = NeighbourhoodDiagramScope("database", 2).filter(parsedModel)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"database": true, "web-server": true, "browser": true}, filter.drawn)
+
+	filter, err = NeighbourhoodDiagramScope("database", 0).filter(parsedModel)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"database": true}, filter.drawn)
+
+	_, err = NeighbourhoodDiagramScope("database", -1).filter(parsedModel)
+	assert.Error(t, err)
+}
+
+func TestDiagramScopeFilename(t *testing.T) {
+	assert.Equal(t, "data-flow-diagram.png", DiagramScope{}.Filename("data-flow-diagram.png"))
+	assert.Equal(t, "data-flow-diagram-boundary-data-zone.png", TrustBoundaryDiagramScope("data-zone").Filename("data-flow-diagram.png"))
+	assert.Equal(t, "out.d/diagram-asset-web-server-1", NeighbourhoodDiagramScope("web/server 1", 1).Filename("out.d/diagram"))
+}
+
+func TestWriteScopedDataFlowDiagramGraphvizDOT(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "data-flow-diagram.gv")
+	_, err := WriteDataFlowDiagramGraphvizDOT(diagramExportTestModel(), filename, 100, true, false, NeighbourhoodDiagramScope("database", 1), &mockProgressReporter{})
+	assert.NoError(t, err)
+	content, err := os.ReadFile(filename)
+	assert.NoError(t, err)
+
+	dot := string(content)
+	assert.Contains(t, dot, `label="Shop: Database (1 hop)"`)
+	assert.Contains(t, dot, hash("web-server")+" -> "+hash("database"))
+	assert.NotContains(t, dot, hash("browser"))
+	assert.NotContains(t, dot, "cluster_"+hash("empty"))
+
+	svg, err := RenderDataFlowDiagram(diagramExportTestModel(), "svg", 100, false, TrustBoundaryDiagramScope("data-zone"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(svg), ">Data Center (network-on-prem)</text>")
+	assert.NotContains(t, string(svg), ">Browser</text>")
+}
@@ -0,0 +1,207 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/model"
+)
+
+const (
+	DiffFormatText     = "text"
+	DiffFormatJSON     = "json"
+	DiffFormatMarkdown = "markdown"
+)
+
+func WriteModelDiff(diff *model.ModelDiff, format string, writer io.Writer) error {
+	switch strings.ToLower(format) {
+	case DiffFormatText, "":
+		return WriteModelDiffText(diff, writer)
+
+	case DiffFormatJSON:
+		return WriteModelDiffJSON(diff, writer)
+
+	case DiffFormatMarkdown, "md":
+		return WriteModelDiffMarkdown(diff, writer)
+
+	default:
+		return fmt.Errorf("unknown diff format %q (supported: %v, %v, %v)", format, DiffFormatText, DiffFormatJSON, DiffFormatMarkdown)
+	}
+}
+
+func WriteModelDiffJSON(diff *model.ModelDiff, writer io.Writer) error {
+	jsonBytes, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal model diff to JSON: %w", err)
+	}
+
+	_, err = fmt.Fprintln(writer, string(jsonBytes))
+	if err != nil {
+		return fmt.Errorf("failed to write model diff: %w", err)
+	}
+
+	return nil
+}
+
+func WriteModelDiffText(diff *model.ModelDiff, writer io.Writer) error {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("Model diff: %v -> %v\n", diff.BaseTitle, diff.Title))
+	if diff.IsEmpty() {
+		text.WriteString("\nNo changes.\n")
+	}
+
+	for _, section := range diffSections(diff) {
+		if len(section.elements.Added) == 0 && len(section.elements.Removed) == 0 && len(section.elements.Changed) == 0 {
+			continue
+		}
+
+		text.WriteString("\n" + section.title + ":\n")
+		for _, element := range section.elements.Added {
+			text.WriteString(fmt.Sprintf("  + %v (%v)\n", element.Id, element.Title))
+		}
+		for _, element := range section.elements.Removed {
+			text.WriteString(fmt.Sprintf("  - %v (%v)\n", element.Id, element.Title))
+		}
+		for _, element := range section.elements.Changed {
+			text.WriteString(fmt.Sprintf("  ~ %v (%v): %v\n", element.Id, element.Title, strings.Join(element.Fields, ", ")))
+		}
+	}
+
+	if len(diff.Risks.New) > 0 {
+		text.WriteString("\nNew risks:\n")
+		for _, risk := range diff.Risks.New {
+			text.WriteString(fmt.Sprintf("  + [%v] %v: %v\n", risk.Severity.Title(), risk.SyntheticId, removeFormattingTags(risk.Title)))
+		}
+	}
+
+	if len(diff.Risks.Resolved) > 0 {
+		text.WriteString("\nResolved risks:\n")
+		for _, risk := range diff.Risks.Resolved {
+			text.WriteString(fmt.Sprintf("  - [%v] %v: %v\n", risk.Severity.Title(), risk.SyntheticId, removeFormattingTags(risk.Title)))
+		}
+	}
+
+	if len(diff.Risks.Rerated) > 0 {
+		text.WriteString("\nRe-rated risks:\n")
+		for _, change := range diff.Risks.Rerated {
+			text.WriteString(fmt.Sprintf("  ~ %v: %v\n", change.SyntheticId, describeRiskRatingChange(change)))
+		}
+	}
+
+	if len(diff.Risks.Retriaged) > 0 {
+		text.WriteString("\nRe-triaged risks:\n")
+		for _, change := range diff.Risks.Retriaged {
+			text.WriteString(fmt.Sprintf("  ~ %v: %v\n", change.SyntheticId, describeRiskRatingChange(change)))
+		}
+	}
+
+	_, err := io.WriteString(writer, text.String())
+	if err != nil {
+		return fmt.Errorf("failed to write model diff: %w", err)
+	}
+
+	return nil
+}
+
+func WriteModelDiffMarkdown(diff *model.ModelDiff, writer io.Writer) error {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("## Threat model diff: %v\n\n", escapeMarkdown(diff.Title)))
+	if diff.IsEmpty() {
+		text.WriteString("No changes to the architecture or the generated risks.\n")
+	} else {
+		text.WriteString("| | Added | Removed | Changed |\n")
+		text.WriteString("|---|---:|---:|---:|\n")
+		for _, section := range diffSections(diff) {
+			text.WriteString(fmt.Sprintf("| %v | %d | %d | %d |\n", section.title,
+				len(section.elements.Added), len(section.elements.Removed), len(section.elements.Changed)))
+		}
+		text.WriteString(fmt.Sprintf("| Risks | %d new | %d resolved | %d re-rated, %d re-triaged |\n", len(diff.Risks.New), len(diff.Risks.Resolved), len(diff.Risks.Rerated), len(diff.Risks.Retriaged)))
+	}
+
+	for _, section := range diffSections(diff) {
+		if len(section.elements.Added) == 0 && len(section.elements.Removed) == 0 && len(section.elements.Changed) == 0 {
+			continue
+		}
+
+		text.WriteString("\n### " + section.title + "\n\n")
+		for _, element := range section.elements.Added {
+			text.WriteString(fmt.Sprintf("- :heavy_plus_sign: `%v` %v\n", element.Id, escapeMarkdown(element.Title)))
+		}
+		for _, element := range section.elements.Removed {
+			text.WriteString(fmt.Sprintf("- :heavy_minus_sign: `%v` %v\n", element.Id, escapeMarkdown(element.Title)))
+		}
+		for _, element := range section.elements.Changed {
+			text.WriteString(fmt.Sprintf("- :pencil2: `%v` %v (%v)\n", element.Id, escapeMarkdown(element.Title), strings.Join(element.Fields, ", ")))
+		}
+	}
+
+	if len(diff.Risks.New) > 0 || len(diff.Risks.Resolved) > 0 {
+		text.WriteString("\n### Risks\n\n")
+		text.WriteString("| Change | Severity | Status | Risk | ID |\n")
+		text.WriteString("|---|---|---|---|---|\n")
+		for _, risk := range diff.Risks.New {
+			text.WriteString(fmt.Sprintf("| new | %v | %v | %v | `%v` |\n", risk.Severity.Title(), risk.RiskStatus.Title(), escapeMarkdown(removeFormattingTags(risk.Title)), risk.SyntheticId))
+		}
+		for _, risk := range diff.Risks.Resolved {
+			text.WriteString(fmt.Sprintf("| resolved | %v | %v | %v | `%v` |\n", risk.Severity.Title(), risk.RiskStatus.Title(), escapeMarkdown(removeFormattingTags(risk.Title)), risk.SyntheticId))
+		}
+	}
+
+	if len(diff.Risks.Rerated) > 0 {
+		text.WriteString("\n### Re-rated risks\n\n")
+		for _, change := range diff.Risks.Rerated {
+			text.WriteString(fmt.Sprintf("- `%v`: %v\n", change.SyntheticId, describeRiskRatingChange(change)))
+		}
+	}
+
+	if len(diff.Risks.Retriaged) > 0 {
+		text.WriteString("\n### Re-triaged risks\n\n")
+		for _, change := range diff.Risks.Retriaged {
+			text.WriteString(fmt.Sprintf("- `%v`: %v\n", change.SyntheticId, describeRiskRatingChange(change)))
+		}
+	}
+
+	_, err := io.WriteString(writer, text.String())
+	if err != nil {
+		return fmt.Errorf("failed to write model diff: %w", err)
+	}
+
+	return nil
+}
+
+type diffSection struct {
+	title    string
+	elements model.ElementDiff
+}
+
+func diffSections(diff *model.ModelDiff) []diffSection {
+	return []diffSection{
+		{title: "Technical assets", elements: diff.TechnicalAssets},
+		{title: "Communication links", elements: diff.CommunicationLinks},
+		{title: "Data assets", elements: diff.DataAssets},
+		{title: "Trust boundaries", elements: diff.TrustBoundaries},
+	}
+}
+
+func describeRiskRatingChange(change model.RiskChange) string {
+	parts := make([]string, 0)
+	if change.Before.Severity != change.After.Severity {
+		parts = append(parts, fmt.Sprintf("severity %v -> %v", change.Before.Severity.Title(), change.After.Severity.Title()))
+	}
+	if change.Before.ExploitationLikelihood != change.After.ExploitationLikelihood {
+		parts = append(parts, fmt.Sprintf("likelihood %v -> %v", change.Before.ExploitationLikelihood.Title(), change.After.ExploitationLikelihood.Title()))
+	}
+	if change.Before.ExploitationImpact != change.After.ExploitationImpact {
+		parts = append(parts, fmt.Sprintf("impact %v -> %v", change.Before.ExploitationImpact.Title(), change.After.ExploitationImpact.Title()))
+	}
+	if change.Before.RiskStatus != change.After.RiskStatus {
+		parts = append(parts, fmt.Sprintf("status %v -> %v", change.Before.RiskStatus.Title(), change.After.RiskStatus.Title()))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func escapeMarkdown(value string) string {
+	return strings.NewReplacer("|", "\\|", "*", "\\*", "_", "\\_", "<", "&lt;", ">", "&gt;").Replace(value)
+}
@@ -8,11 +8,12 @@ import (
 	"unicode/utf8"
 
 	"github.com/shopspring/decimal"
+	"github.com/threagile/threagile/pkg/model"
 	"github.com/threagile/threagile/pkg/types"
 	"github.com/xuri/excelize/v2"
 )
 
-func WriteRisksExcelToFile(parsedModel *types.Model, filename string, config reportConfigReader) error {
+func WriteRisksExcelToFile(parsedModel *types.Model, compliance []*model.ComplianceFrameworkCoverage, filename string, config reportConfigReader) error {
 	columns := new(ExcelColumns).GetColumns()
 	excel := excelize.NewFile()
 	sheetName := parsedModel.Title
@@ -248,6 +249,13 @@ func WriteRisksExcelToFile(parsedModel *types.Model, filename string, config rep
 		return fmt.Errorf("failed to add autofilter: %w", err)
 	}
 
+	if len(compliance) > 0 {
+		complianceError := writeComplianceSheet(excel, compliance, cellStyles)
+		if complianceError != nil {
+			return fmt.Errorf("failed to write compliance sheet: %w", complianceError)
+		}
+	}
+
 	// save file
 	saveAsError := excel.SaveAs(filename)
 	if saveAsError != nil {
@@ -257,6 +265,101 @@ func WriteRisksExcelToFile(parsedModel *types.Model, filename string, config rep
 	return nil
 }
 
+// writeComplianceSheet adds a sheet listing the controls of the compliance frameworks with their coverage and open risks
+func writeComplianceSheet(excel *excelize.File, compliance []*model.ComplianceFrameworkCoverage, cellStyles *ExcelStyles) error {
+	sheetName := "Compliance"
+	_, newSheetError := excel.NewSheet(sheetName)
+	if newSheetError != nil {
+		return fmt.Errorf("failed to add sheet: %w", newSheetError)
+	}
+
+	columns := []string{"Framework", "Control", "Control Title", "Status", "Assessed Risk Categories", "Risks", "Open Risks", "Open Risk IDs"}
+	widths := []float64{30, 12, 50, 14, 50, 8, 12, 80}
+	for i, title := range columns {
+		columnName, columnNameError := excelize.ColumnNumberToName(i + 1)
+		if columnNameError != nil {
+			return columnNameError
+		}
+		setCellValueError := excel.SetCellValue(sheetName, columnName+"1", title)
+		if setCellValueError != nil {
+			return fmt.Errorf("unable to set cell value: %w", setCellValueError)
+		}
+		setColWidthError := excel.SetColWidth(sheetName, columnName, columnName, widths[i])
+		if setColWidthError != nil {
+			return setColWidthError
+		}
+	}
+	setCellStyleError := excel.SetCellStyle(sheetName, "A1", "H1", cellStyles.headCenterBoldItalic)
+	if setCellStyleError != nil {
+		return fmt.Errorf("unable to set cell style: %w", setCellStyleError)
+	}
+
+	row := 1
+	for _, framework := range compliance {
+		frameworkTitle := framework.Title
+		if len(frameworkTitle) == 0 {
+			frameworkTitle = framework.Id
+		}
+		for _, control := range framework.Controls {
+			row++
+			values := []any{frameworkTitle, control.Id, control.Title, control.Status.Title(), strings.Join(control.AssessedCategories, ", "),
+				control.Risks, len(control.OpenRisks), strings.Join(control.OpenRisks, ", ")}
+			for i, value := range values {
+				cellName, coordinateError := excelize.CoordinatesToCellName(i+1, row)
+				if coordinateError != nil {
+					return coordinateError
+				}
+				setCellValueError := excel.SetCellValue(sheetName, cellName, value)
+				if setCellValueError != nil {
+					return fmt.Errorf("unable to set cell value: %w", setCellValueError)
+				}
+			}
+
+			statusStyle := cellStyles.grayCenter
+			switch control.Status {
+			case model.ComplianceOpenRisks:
+				statusStyle = cellStyles.redCenter
+			case model.ComplianceCovered:
+				statusStyle = cellStyles.greenCenter
+			}
+			for _, style := range []struct {
+				from, to string
+				style    int
+			}{
+				{"A", "C", cellStyles.blackLeft},
+				{"D", "D", statusStyle},
+				{"E", "E", cellStyles.blackLeft},
+				{"F", "G", cellStyles.blackCenter},
+				{"H", "H", cellStyles.blackLeft},
+			} {
+				setCellStyleError := excel.SetCellStyle(sheetName, style.from+strconv.Itoa(row), style.to+strconv.Itoa(row), style.style)
+				if setCellStyleError != nil {
+					return fmt.Errorf("unable to set cell style: %w", setCellStyleError)
+				}
+			}
+		}
+	}
+
+	freezeError := excel.SetPanes(sheetName, &excelize.Panes{
+		Freeze:      true,
+		Split:       false,
+		XSplit:      0,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	})
+	if freezeError != nil {
+		return fmt.Errorf("unable to freeze header: %w", freezeError)
+	}
+
+	autoFilterError := excel.AutoFilter(sheetName, fmt.Sprintf("A1:H%d", row), []excelize.AutoFilterOptions{})
+	if autoFilterError != nil {
+		return fmt.Errorf("failed to add autofilter: %w", autoFilterError)
+	}
+
+	return nil
+}
+
 // TODO: eventually when len(sortedTagsAvailable) == 0 is: write a hint in the Excel that no tags are used
 func WriteTagsExcelToFile(parsedModel *types.Model, filename string, config reportConfigReader) error {
 	excelRow := 0
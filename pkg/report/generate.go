@@ -8,34 +8,50 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/threagile/threagile/pkg/model"
 	"github.com/threagile/threagile/pkg/types"
 )
 
 type GenerateCommands struct {
-	DataFlowDiagram     bool
-	DataAssetDiagram    bool
-	RisksJSON           bool
-	TechnicalAssetsJSON bool
-	StatsJSON           bool
-	RisksExcel          bool
-	TagsExcel           bool
-	ReportPDF           bool
-	ReportADOC          bool
+	DataFlowDiagram    bool
+	DataAssetDiagram   bool
+	RiskOverlayDiagram bool
+	// TechnicalAssetDiagrams embeds the data flow diagram around each technical asset into its report chapter
+	TechnicalAssetDiagrams bool
+	RisksJSON              bool
+	RisksSARIF             bool
+	TechnicalAssetsJSON    bool
+	StatsJSON              bool
+	AttackPathsJSON        bool
+	AttackTrees            bool
+	LossExposureJSON       bool
+	RisksExcel             bool
+	TagsExcel              bool
+	ReportPDF              bool
+	ReportADOC             bool
+	ReportHTML             bool
 }
 
 func (c *GenerateCommands) Defaults() *GenerateCommands {
 	*c = GenerateCommands{
-		DataFlowDiagram:     true,
-		DataAssetDiagram:    true,
-		RisksJSON:           true,
-		TechnicalAssetsJSON: true,
-		StatsJSON:           true,
-		RisksExcel:          true,
-		TagsExcel:           true,
-		ReportPDF:           true,
-		ReportADOC:          true,
+		DataFlowDiagram:        true,
+		DataAssetDiagram:       true,
+		RiskOverlayDiagram:     true,
+		TechnicalAssetDiagrams: true,
+		RisksJSON:              true,
+		RisksSARIF:             true,
+		TechnicalAssetsJSON:    true,
+		StatsJSON:              true,
+		AttackPathsJSON:        true,
+		AttackTrees:            true,
+		LossExposureJSON:       true,
+		RisksExcel:             true,
+		TagsExcel:              true,
+		ReportPDF:              true,
+		ReportADOC:             true,
+		ReportHTML:             true,
 	}
 	return c
 }
@@ -53,12 +69,20 @@ type reportConfigReader interface {
 	GetDataAssetDiagramFilenamePNG() string
 	GetDataFlowDiagramFilenameDOT() string
 	GetDataAssetDiagramFilenameDOT() string
+	GetRiskOverlayDiagramFilenamePNG() string
+	GetRiskOverlayDiagramFilenameDOT() string
 	GetReportFilename() string
+	GetHtmlReportFilename() string
 	GetExcelRisksFilename() string
 	GetExcelTagsFilename() string
 	GetJsonRisksFilename() string
+	GetSarifRisksFilename() string
 	GetJsonTechnicalAssetsFilename() string
 	GetJsonStatsFilename() string
+	GetJsonAttackPathsFilename() string
+	GetJsonAttackTreesFilename() string
+	GetJsonLossExposureFilename() string
+	GetComplianceFilename() string
 	GetTemplateFilename() string
 	GetReportLogoImagePath() string
 
@@ -73,6 +97,11 @@ type reportConfigReader interface {
 	GetDiagramDPI() int
 	GetMinGraphvizDPI() int
 	GetMaxGraphvizDPI() int
+	GetDiagramRenderer() string
+	GetRiskOverlaySTRIDE() string
+	GetDataFlowDiagramPerTrustBoundary() bool
+	GetDataFlowDiagramTechnicalAssets() []string
+	GetDataFlowDiagramHops() int
 
 	GetKeepDiagramSourceFiles() bool
 	GetAddModelTitle() bool
@@ -110,6 +139,15 @@ func Generate(config reportConfigReader, readResult *model.ReadResult, commands
 	} else if diagramDPI > config.GetMaxGraphvizDPI() {
 		diagramDPI = config.GetMaxGraphvizDPI()
 	}
+	useGraphviz, err := UseGraphviz(config.GetDiagramRenderer())
+	if err != nil {
+		return err
+	}
+	dataFlowDiagramScopes, err := dataFlowDiagramScopes(config, readResult.ParsedModel)
+	if err != nil {
+		return err
+	}
+	var dataFlowDiagramDOT, dataAssetDiagramDOT, riskOverlayDiagramDOT string
 	// Data-flow Diagram rendering
 	if generateDataFlowDiagram {
 		gvFile := filepath.Join(config.GetOutputFolder(), config.GetDataFlowDiagramFilenameDOT())
@@ -121,16 +159,31 @@ func Generate(config reportConfigReader, readResult *model.ReadResult, commands
 			gvFile = tmpFileGV.Name()
 			defer func() { _ = os.Remove(gvFile) }()
 		}
-		dotFile, err := WriteDataFlowDiagramGraphvizDOT(readResult.ParsedModel, gvFile, diagramDPI, config.GetAddModelTitle(), config.GetAddLegend(), progressReporter)
+		dotFile, err := WriteDataFlowDiagramGraphvizDOT(readResult.ParsedModel, gvFile, diagramDPI, config.GetAddModelTitle(), config.GetAddLegend(), DiagramScope{}, progressReporter)
 		if err != nil {
 			return fmt.Errorf("error while generating data flow diagram: %w", err)
 		}
+		dataFlowDiagramDOT = gvFile
 
-		err = GenerateDataFlowDiagramGraphvizImage(dotFile, config.GetOutputFolder(),
-			config.GetTempFolder(), config.GetDataFlowDiagramFilenamePNG(), progressReporter, config.GetKeepDiagramSourceFiles())
+		if useGraphviz {
+			err = GenerateDataFlowDiagramGraphvizImage(dotFile, config.GetOutputFolder(),
+				config.GetTempFolder(), config.GetDataFlowDiagramFilenamePNG(), progressReporter, config.GetKeepDiagramSourceFiles())
+		} else {
+			err = GenerateDataFlowDiagramImage(readResult.ParsedModel, config.GetOutputFolder(),
+				config.GetDataFlowDiagramFilenamePNG(), diagramDPI, config.GetAddModelTitle(), DiagramScope{}, progressReporter)
+		}
 		if err != nil {
 			progressReporter.Warn(err)
 		}
+
+		// Data-flow Diagrams per trust boundary and around the chosen technical assets
+		for _, scope := range dataFlowDiagramScopes {
+			err := generateScopedDataFlowDiagram(config, readResult.ParsedModel, scope, diagramDPI, useGraphviz,
+				config.GetOutputFolder(), scope.Filename(config.GetDataFlowDiagramFilenamePNG()), config.GetKeepDiagramSourceFiles(), progressReporter)
+			if err != nil {
+				progressReporter.Warn(err)
+			}
+		}
 	}
 	// Data Asset Diagram rendering
 	if generateDataAssetsDiagram {
@@ -147,8 +200,41 @@ func Generate(config reportConfigReader, readResult *model.ReadResult, commands
 		if err != nil {
 			return fmt.Errorf("error while generating data asset diagram: %w", err)
 		}
-		err = GenerateDataAssetDiagramGraphvizImage(dotFile, config.GetOutputFolder(),
-			config.GetTempFolder(), config.GetDataAssetDiagramFilenamePNG(), progressReporter)
+		dataAssetDiagramDOT = gvFile
+		if useGraphviz {
+			err = GenerateDataAssetDiagramGraphvizImage(dotFile, config.GetOutputFolder(),
+				config.GetTempFolder(), config.GetDataAssetDiagramFilenamePNG(), progressReporter)
+		} else {
+			err = GenerateDataAssetDiagramImage(readResult.ParsedModel, config.GetOutputFolder(),
+				config.GetDataAssetDiagramFilenamePNG(), diagramDPI, progressReporter)
+		}
+		if err != nil {
+			progressReporter.Warn(err)
+		}
+	}
+
+	// Risk Overlay Diagram rendering
+	if commands.RiskOverlayDiagram {
+		gvFile := filepath.Join(config.GetOutputFolder(), config.GetRiskOverlayDiagramFilenameDOT())
+		if !config.GetKeepDiagramSourceFiles() {
+			tmpFile, err := os.CreateTemp(config.GetTempFolder(), config.GetRiskOverlayDiagramFilenameDOT())
+			if err != nil {
+				return err
+			}
+			gvFile = tmpFile.Name()
+			defer func() { _ = os.Remove(gvFile) }()
+		}
+		dotFile, err := WriteRiskOverlayDiagramGraphvizDOT(readResult.ParsedModel, gvFile, diagramDPI, config.GetRiskOverlaySTRIDE(), config.GetAddModelTitle(), progressReporter)
+		if err != nil {
+			return fmt.Errorf("error while generating risk overlay diagram: %w", err)
+		}
+		riskOverlayDiagramDOT = gvFile
+		if useGraphviz {
+			err = GenerateRiskOverlayDiagramGraphvizImage(dotFile, config.GetOutputFolder(), config.GetRiskOverlayDiagramFilenamePNG(), progressReporter)
+		} else {
+			err = GenerateRiskOverlayDiagramImage(readResult.ParsedModel, config.GetOutputFolder(),
+				config.GetRiskOverlayDiagramFilenamePNG(), diagramDPI, config.GetRiskOverlaySTRIDE(), config.GetAddModelTitle(), progressReporter)
+		}
 		if err != nil {
 			progressReporter.Warn(err)
 		}
@@ -163,6 +249,15 @@ func Generate(config reportConfigReader, readResult *model.ReadResult, commands
 		}
 	}
 
+	// risks as SARIF
+	if commands.RisksSARIF {
+		progressReporter.Info("Writing risks sarif")
+		err := WriteRisksSARIF(readResult.ParsedModel, config.GetInputFile(), config.GetThreagileVersion(), filepath.Join(config.GetOutputFolder(), config.GetSarifRisksFilename()))
+		if err != nil {
+			return fmt.Errorf("error while writing risks sarif: %w", err)
+		}
+	}
+
 	// technical assets json
 	if commands.TechnicalAssetsJSON {
 		progressReporter.Info("Writing technical assets json")
@@ -181,10 +276,71 @@ func Generate(config reportConfigReader, readResult *model.ReadResult, commands
 		}
 	}
 
+	// attack paths json
+	if commands.AttackPathsJSON {
+		progressReporter.Info("Writing attack paths json")
+		err := WriteAttackPathsJSON(readResult.ParsedModel, filepath.Join(config.GetOutputFolder(), config.GetJsonAttackPathsFilename()))
+		if err != nil {
+			return fmt.Errorf("error while writing attack paths json: %w", err)
+		}
+	}
+
+	// attack trees json and diagrams
+	if commands.AttackTrees {
+		progressReporter.Info("Writing attack trees json")
+		err := WriteAttackTreesJSON(readResult.ParsedModel, filepath.Join(config.GetOutputFolder(), config.GetJsonAttackTreesFilename()))
+		if err != nil {
+			return fmt.Errorf("error while writing attack trees json: %w", err)
+		}
+		for _, tree := range model.AttackTrees(readResult.ParsedModel) {
+			gvFile := filepath.Join(config.GetOutputFolder(), AttackTreeFilename(tree.AbuseCase, ".gv"))
+			if !config.GetKeepDiagramSourceFiles() {
+				tmpFile, err := os.CreateTemp(config.GetTempFolder(), AttackTreeFilename(tree.AbuseCase, ".gv"))
+				if err != nil {
+					return err
+				}
+				gvFile = tmpFile.Name()
+				defer func() { _ = os.Remove(gvFile) }()
+			}
+			dotFile, err := WriteAttackTreeGraphvizDOT(tree, gvFile, diagramDPI, progressReporter)
+			if err != nil {
+				return fmt.Errorf("error while generating attack tree: %w", err)
+			}
+			if useGraphviz {
+				err = GenerateAttackTreeGraphvizImage(dotFile, config.GetOutputFolder(), AttackTreeFilename(tree.AbuseCase, ".svg"), progressReporter)
+			} else {
+				err = GenerateAttackTreeImage(tree, config.GetOutputFolder(), AttackTreeFilename(tree.AbuseCase, ".svg"), diagramDPI, progressReporter)
+			}
+			if err != nil {
+				progressReporter.Warn(err)
+			}
+		}
+	}
+
+	// loss exposure json
+	if commands.LossExposureJSON {
+		progressReporter.Info("Writing loss exposure json")
+		err := WriteLossExposureJSON(readResult.ParsedModel, filepath.Join(config.GetOutputFolder(), config.GetJsonLossExposureFilename()))
+		if err != nil {
+			return fmt.Errorf("error while writing loss exposure json: %w", err)
+		}
+	}
+
+	// compliance coverage, shown by the risks Excel and the report pdf
+	var compliance []*model.ComplianceFrameworkCoverage
+	if commands.RisksExcel || commands.ReportPDF {
+		complianceMap := make(types.ComplianceMap)
+		err := complianceMap.LoadWithConfig(config, "compliance.yaml")
+		if err != nil {
+			return err
+		}
+		compliance = model.ComplianceCoverage(readResult.ParsedModel, complianceMap, config.GetSkipRiskRules())
+	}
+
 	// risks Excel
 	if commands.RisksExcel {
 		progressReporter.Info("Writing risks excel")
-		err := WriteRisksExcelToFile(readResult.ParsedModel, filepath.Join(config.GetOutputFolder(), config.GetExcelRisksFilename()), config)
+		err := WriteRisksExcelToFile(readResult.ParsedModel, compliance, filepath.Join(config.GetOutputFolder(), config.GetExcelRisksFilename()), config)
 		if err != nil {
 			return err
 		}
@@ -214,11 +370,32 @@ func Generate(config reportConfigReader, readResult *model.ReadResult, commands
 		// report PDF
 		progressReporter.Info("Writing report pdf")
 
+		technicalAssetDiagrams := make(map[string]string)
+		if commands.TechnicalAssetDiagrams {
+			diagramFolder, err := os.MkdirTemp(config.GetTempFolder(), "technical-asset-diagrams-*")
+			if err != nil {
+				return err
+			}
+			defer func() { _ = os.RemoveAll(diagramFolder) }()
+			for _, id := range readResult.ParsedModel.SortedTechnicalAssetIDs() {
+				scope := NeighbourhoodDiagramScope(id, config.GetDataFlowDiagramHops())
+				filename := scope.Filename(filepath.Base(config.GetDataFlowDiagramFilenamePNG()))
+				err := generateScopedDataFlowDiagram(config, readResult.ParsedModel, scope, diagramDPI, useGraphviz, diagramFolder, filename, false, progressReporter)
+				if err != nil {
+					progressReporter.Warn(err)
+					continue
+				}
+				technicalAssetDiagrams[id] = filepath.Join(diagramFolder, filename)
+			}
+		}
+
 		pdfReporter := newPdfReporter(riskRules)
 		err = pdfReporter.WriteReportPDF(filepath.Join(config.GetOutputFolder(), config.GetReportFilename()),
 			filepath.Join(config.GetAppFolder(), config.GetTemplateFilename()),
 			filepath.Join(config.GetOutputFolder(), config.GetDataFlowDiagramFilenamePNG()),
 			filepath.Join(config.GetOutputFolder(), config.GetDataAssetDiagramFilenamePNG()),
+			technicalAssetDiagrams,
+			compliance,
 			config.GetInputFile(),
 			config.GetSkipRiskRules(),
 			config.GetBuildTimestamp(),
@@ -267,9 +444,184 @@ func Generate(config reportConfigReader, readResult *model.ReadResult, commands
 		}
 	}
 
+	if commands.ReportHTML {
+		// hash the YAML input file
+		f, err := os.Open(config.GetInputFile())
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		hasher := sha256.New()
+		if _, err := io.Copy(hasher, f); err != nil {
+			return err
+		}
+
+		modelHash := hex.EncodeToString(hasher.Sum(nil))
+		var dataFlowDiagramSVG, dataAssetDiagramSVG, riskOverlayDiagramSVG []byte
+		if useGraphviz {
+			// diagram sources are needed to embed the diagrams as SVG
+			if len(dataFlowDiagramDOT) == 0 {
+				tmpFile, err := os.CreateTemp(config.GetTempFolder(), config.GetDataFlowDiagramFilenameDOT())
+				if err != nil {
+					return err
+				}
+				dataFlowDiagramDOT = tmpFile.Name()
+				defer func() { _ = os.Remove(dataFlowDiagramDOT) }()
+				_, err = WriteDataFlowDiagramGraphvizDOT(readResult.ParsedModel, dataFlowDiagramDOT, diagramDPI, config.GetAddModelTitle(), config.GetAddLegend(), DiagramScope{}, progressReporter)
+				if err != nil {
+					return fmt.Errorf("error while generating data flow diagram: %w", err)
+				}
+			}
+			if len(dataAssetDiagramDOT) == 0 {
+				tmpFile, err := os.CreateTemp(config.GetTempFolder(), config.GetDataAssetDiagramFilenameDOT())
+				if err != nil {
+					return err
+				}
+				dataAssetDiagramDOT = tmpFile.Name()
+				defer func() { _ = os.Remove(dataAssetDiagramDOT) }()
+				_, err = WriteDataAssetDiagramGraphvizDOT(readResult.ParsedModel, dataAssetDiagramDOT, diagramDPI, progressReporter)
+				if err != nil {
+					return fmt.Errorf("error while generating data asset diagram: %w", err)
+				}
+			}
+			if len(riskOverlayDiagramDOT) == 0 {
+				tmpFile, err := os.CreateTemp(config.GetTempFolder(), config.GetRiskOverlayDiagramFilenameDOT())
+				if err != nil {
+					return err
+				}
+				riskOverlayDiagramDOT = tmpFile.Name()
+				defer func() { _ = os.Remove(riskOverlayDiagramDOT) }()
+				_, err = WriteRiskOverlayDiagramGraphvizDOT(readResult.ParsedModel, riskOverlayDiagramDOT, diagramDPI, config.GetRiskOverlaySTRIDE(), config.GetAddModelTitle(), progressReporter)
+				if err != nil {
+					return fmt.Errorf("error while generating risk overlay diagram: %w", err)
+				}
+			}
+			dataFlowDiagramSVG = renderGraphvizSVG(dataFlowDiagramDOT, "Data-Flow Diagram", progressReporter)
+			dataAssetDiagramSVG = renderGraphvizSVG(dataAssetDiagramDOT, "Data-Asset Diagram", progressReporter)
+			riskOverlayDiagramSVG = renderGraphvizSVG(riskOverlayDiagramDOT, "Risk Overlay Diagram", progressReporter)
+		} else {
+			dataFlowDiagramSVG, err = RenderDataFlowDiagram(readResult.ParsedModel, "svg", diagramDPI, config.GetAddModelTitle(), DiagramScope{})
+			if err != nil {
+				progressReporter.Warn(fmt.Sprintf("unable to render Data-Flow Diagram as SVG, falling back to PNG: %v", err))
+			}
+			dataAssetDiagramSVG, err = RenderDataAssetDiagram(readResult.ParsedModel, "svg", diagramDPI)
+			if err != nil {
+				progressReporter.Warn(fmt.Sprintf("unable to render Data-Asset Diagram as SVG, falling back to PNG: %v", err))
+			}
+			riskOverlayDiagramSVG, err = RenderRiskOverlayDiagram(readResult.ParsedModel, "svg", diagramDPI, config.GetRiskOverlaySTRIDE(), config.GetAddModelTitle())
+			if err != nil {
+				progressReporter.Warn(fmt.Sprintf("unable to render Risk Overlay Diagram as SVG, falling back to PNG: %v", err))
+			}
+		}
+		technicalAssetDiagramSVGs := make(map[string][]byte)
+		if commands.TechnicalAssetDiagrams {
+			for _, id := range readResult.ParsedModel.SortedTechnicalAssetIDs() {
+				svg, err := renderScopedDataFlowDiagramSVG(config, readResult.ParsedModel, NeighbourhoodDiagramScope(id, config.GetDataFlowDiagramHops()), diagramDPI, useGraphviz, progressReporter)
+				if err != nil {
+					progressReporter.Warn(err)
+					continue
+				}
+				technicalAssetDiagramSVGs[id] = svg
+			}
+		}
+		// report HTML
+		progressReporter.Info("Writing report html")
+		htmlReporter := NewHtmlReport()
+		err = htmlReporter.WriteReport(readResult.ParsedModel,
+			filepath.Join(config.GetOutputFolder(), config.GetHtmlReportFilename()),
+			HtmlReportDiagrams{
+				DataFlowDiagramSVG:            dataFlowDiagramSVG,
+				DataFlowDiagramFilenamePNG:    filepath.Join(config.GetOutputFolder(), config.GetDataFlowDiagramFilenamePNG()),
+				DataAssetDiagramSVG:           dataAssetDiagramSVG,
+				DataAssetDiagramFilenamePNG:   filepath.Join(config.GetOutputFolder(), config.GetDataAssetDiagramFilenamePNG()),
+				RiskOverlayDiagramSVG:         riskOverlayDiagramSVG,
+				RiskOverlayDiagramFilenamePNG: filepath.Join(config.GetOutputFolder(), config.GetRiskOverlayDiagramFilenamePNG()),
+				TechnicalAssetDiagramSVGs:     technicalAssetDiagramSVGs,
+			},
+			config.GetBuildTimestamp(),
+			config.GetThreagileVersion(),
+			modelHash,
+			readResult.IntroTextRAA,
+			readResult.CustomRiskRules,
+			progressReporter)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// dataFlowDiagramScopes returns the scopes of the additional data flow diagrams per trust boundary and around the
+// chosen technical assets, unknown technical assets and a negative number of hops are errors
+func dataFlowDiagramScopes(config reportConfigReader, parsedModel *types.Model) ([]DiagramScope, error) {
+	if config.GetDataFlowDiagramHops() < 0 {
+		return nil, fmt.Errorf("negative number of hops for data flow diagrams: %v", config.GetDataFlowDiagramHops())
+	}
+
+	scopes := make([]DiagramScope, 0)
+	if config.GetDataFlowDiagramPerTrustBoundary() {
+		for _, id := range sortedKeysOfTrustBoundaries(parsedModel) {
+			if len(parsedModel.RecursivelyAllTechnicalAssetIDsInside(parsedModel.TrustBoundaries[id])) > 0 {
+				scopes = append(scopes, TrustBoundaryDiagramScope(id))
+			}
+		}
+	}
+	for _, id := range config.GetDataFlowDiagramTechnicalAssets() {
+		if id = strings.TrimSpace(id); len(id) > 0 {
+			scope := NeighbourhoodDiagramScope(id, config.GetDataFlowDiagramHops())
+			if _, err := scope.filter(parsedModel); err != nil {
+				return nil, err
+			}
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+// generateScopedDataFlowDiagram renders the data flow diagram of a part of the model as PNG, the DOT file is kept next to
+// the PNG file when wanted
+func generateScopedDataFlowDiagram(config reportConfigReader, parsedModel *types.Model, scope DiagramScope, dpi int, useGraphviz bool,
+	targetDir string, filenamePNG string, keepDiagramSourceFile bool, progressReporter progressReporter) error {
+	if !useGraphviz {
+		return GenerateDataFlowDiagramImage(parsedModel, targetDir, filenamePNG, dpi, config.GetAddModelTitle(), scope, progressReporter)
+	}
+
+	gvFile := filepath.Join(targetDir, scope.Filename(filepath.Base(config.GetDataFlowDiagramFilenameDOT())))
+	if !keepDiagramSourceFile {
+		tmpFile, err := os.CreateTemp(config.GetTempFolder(), config.GetDataFlowDiagramFilenameDOT())
+		if err != nil {
+			return err
+		}
+		gvFile = tmpFile.Name()
+		defer func() { _ = os.Remove(gvFile) }()
+	}
+	dotFile, err := WriteDataFlowDiagramGraphvizDOT(parsedModel, gvFile, dpi, config.GetAddModelTitle(), false, scope, progressReporter)
+	if err != nil {
+		return fmt.Errorf("error while generating data flow diagram %v: %w", filenamePNG, err)
+	}
+	return GenerateDataFlowDiagramGraphvizImage(dotFile, targetDir, config.GetTempFolder(), filenamePNG, progressReporter, false)
+}
+
+// renderScopedDataFlowDiagramSVG renders the data flow diagram of a part of the model as SVG
+func renderScopedDataFlowDiagramSVG(config reportConfigReader, parsedModel *types.Model, scope DiagramScope, dpi int, useGraphviz bool,
+	progressReporter progressReporter) ([]byte, error) {
+	if !useGraphviz {
+		return RenderDataFlowDiagram(parsedModel, "svg", dpi, config.GetAddModelTitle(), scope)
+	}
+
+	tmpFile, err := os.CreateTemp(config.GetTempFolder(), config.GetDataFlowDiagramFilenameDOT())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.Remove(tmpFile.Name()) }()
+	_, err = WriteDataFlowDiagramGraphvizDOT(parsedModel, tmpFile.Name(), dpi, config.GetAddModelTitle(), false, scope, progressReporter)
+	if err != nil {
+		return nil, fmt.Errorf("error while generating data flow diagram: %w", err)
+	}
+	return renderGraphvizSVG(tmpFile.Name(), "Data-Flow Diagram", progressReporter), nil
+}
+
 type progressReporter interface {
 	Info(a ...any)
 	Warn(a ...any)
@@ -1,6 +1,7 @@
 package report
 
 import (
+	"bytes"
 	"fmt"
 	"hash/fnv"
 	"os"
@@ -14,11 +15,17 @@ import (
 	"github.com/threagile/threagile/pkg/types"
 )
 
+// WriteDataFlowDiagramGraphvizDOT writes the data flow diagram of the whole model, or of the part selected by the scope
 func WriteDataFlowDiagramGraphvizDOT(parsedModel *types.Model,
-	diagramFilenameDOT string, dpi int, addModelTitle bool, addLegend bool,
+	diagramFilenameDOT string, dpi int, addModelTitle bool, addLegend bool, scope DiagramScope,
 	progressReporter progressReporter) (*os.File, error) {
 	progressReporter.Info("Writing data flow diagram input")
 
+	filter, err := scope.filter(parsedModel)
+	if err != nil {
+		return nil, err
+	}
+
 	var dotContent strings.Builder
 	dotContent.WriteString("digraph generatedModel { concentrate=false \n")
 
@@ -107,8 +114,8 @@ func WriteDataFlowDiagramGraphvizDOT(parsedModel *types.Model,
 		rankdir = "LR"
 	}
 	modelTitle := ""
-	if addModelTitle {
-		modelTitle = `label="` + parsedModel.Title + `"`
+	if title := scope.diagramTitle(parsedModel, addModelTitle); len(title) > 0 {
+		modelTitle = `label="` + title + `"`
 	}
 	dotContent.WriteString(`	graph [ ` + modelTitle + `
 		labelloc=t
@@ -143,7 +150,7 @@ func WriteDataFlowDiagramGraphvizDOT(parsedModel *types.Model,
 	for _, key := range keys {
 		trustBoundary := parsedModel.TrustBoundaries[key]
 		var snippet strings.Builder
-		if len(trustBoundary.TechnicalAssetsInside) > 0 || len(trustBoundary.TrustBoundariesNested) > 0 {
+		if (len(trustBoundary.TechnicalAssetsInside) > 0 || len(trustBoundary.TrustBoundariesNested) > 0) && filter.includesTrustBoundary(parsedModel, trustBoundary) {
 			if drawSpaceLinesForLayoutUnfortunatelyFurtherSeparatesAllRanks {
 				// see https://stackoverflow.com/questions/17247455/how-do-i-add-extra-space-between-clusters?noredirect=1&lq=1
 				snippet.WriteString("\n subgraph cluster_space_boundary_for_layout_only_1" + hash(trustBoundary.Id) + " {\n")
@@ -193,6 +200,9 @@ func WriteDataFlowDiagramGraphvizDOT(parsedModel *types.Model,
 			keys := trustBoundary.TechnicalAssetsInside
 			sort.Strings(keys)
 			for _, technicalAssetInside := range keys {
+				if !filter.includesTechnicalAsset(technicalAssetInside) {
+					continue
+				}
 				//log.Println("About to add technical asset link to trust boundary: ", technicalAssetInside)
 				technicalAsset := parsedModel.TechnicalAssets[technicalAssetInside]
 				snippet.WriteString(hash(technicalAsset.Id))
@@ -203,6 +213,9 @@ func WriteDataFlowDiagramGraphvizDOT(parsedModel *types.Model,
 			for _, trustBoundaryNested := range keys {
 				//log.Println("About to add nested trust boundary to trust boundary: ", trustBoundaryNested)
 				trustBoundaryNested := parsedModel.TrustBoundaries[trustBoundaryNested]
+				if !filter.includesTrustBoundary(parsedModel, trustBoundaryNested) {
+					continue
+				}
 				snippet.WriteString("LINK-NEEDS-REPLACED-BY-cluster_" + hash(trustBoundaryNested.Id))
 				snippet.WriteString(";\n")
 			}
@@ -248,6 +261,9 @@ func WriteDataFlowDiagramGraphvizDOT(parsedModel *types.Model,
 	}
 	sort.Sort(types.ByOrderAndIdSort(techAssets))
 	for _, technicalAsset := range techAssets {
+		if !filter.includesTechnicalAsset(technicalAsset.Id) {
+			continue
+		}
 		dotContent.WriteString(makeTechAssetNode(parsedModel, technicalAsset, false))
 		dotContent.WriteString("\n")
 	}
@@ -255,6 +271,9 @@ func WriteDataFlowDiagramGraphvizDOT(parsedModel *types.Model,
 	// Data Flows (Technical Communication Links) ===============================================================================
 	for _, technicalAsset := range techAssets {
 		for _, dataFlow := range technicalAsset.CommunicationLinks {
+			if !filter.includesCommunicationLink(dataFlow) {
+				continue
+			}
 			sourceId := technicalAsset.Id
 			targetId := dataFlow.TargetId
 			//log.Println("About to add link from", sourceId, "to", targetId, "with id", dataFlow.ID)
@@ -289,13 +308,13 @@ func WriteDataFlowDiagramGraphvizDOT(parsedModel *types.Model,
 		}
 	}
 
-	diagramInvisibleConnectionsTweaks, err := makeDiagramInvisibleConnectionsTweaks(parsedModel)
+	diagramInvisibleConnectionsTweaks, err := makeDiagramInvisibleConnectionsTweaks(parsedModel, filter)
 	if err != nil {
 		return nil, fmt.Errorf("error while making diagram invisible connections tweaks: %w", err)
 	}
 	dotContent.WriteString(diagramInvisibleConnectionsTweaks)
 
-	diagramSameRankNodeTweaks, err := makeDiagramSameRankNodeTweaks(parsedModel)
+	diagramSameRankNodeTweaks, err := makeDiagramSameRankNodeTweaks(parsedModel, filter)
 	if err != nil {
 		return nil, fmt.Errorf("error while making diagram same-rank node tweaks: %w", err)
 	}
@@ -468,14 +487,27 @@ func GenerateDataFlowDiagramGraphvizImage(dotFile *os.File, targetDir string,
 	return nil
 }
 
-func makeDiagramSameRankNodeTweaks(parsedModel *types.Model) (string, error) {
+// GenerateGraphvizSVG renders a DOT file as SVG via graphviz
+func GenerateGraphvizSVG(dotFilename string) ([]byte, error) {
+	var output bytes.Buffer
+	cmd := exec.Command("dot", "-Tsvg", dotFilename) // #nosec G204
+	cmd.Stdout = &output
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	if err != nil {
+		return nil, fmt.Errorf("graph rendering call failed with error: %w", err)
+	}
+	return output.Bytes(), nil
+}
+
+func makeDiagramSameRankNodeTweaks(parsedModel *types.Model, filter *diagramScopeFilter) (string, error) {
 	// see https://stackoverflow.com/questions/25734244/how-do-i-place-nodes-on-the-same-level-in-dot
 	tweak := ""
 	if len(parsedModel.DiagramTweakSameRankAssets) > 0 {
 		for _, sameRank := range parsedModel.DiagramTweakSameRankAssets {
 			assetIDs := strings.Split(sameRank, ":")
 			if len(assetIDs) > 0 {
-				tweak += "{ rank=same; "
+				sameRankTweak := "{ rank=same; "
 				for _, id := range assetIDs {
 					err := parsedModel.CheckTechnicalAssetExists(id, "diagram tweak same-rank", true)
 					if err != nil {
@@ -484,16 +516,18 @@ func makeDiagramSameRankNodeTweaks(parsedModel *types.Model) (string, error) {
 					if len(parsedModel.GetTechnicalAssetTrustBoundaryId(parsedModel.TechnicalAssets[id])) > 0 {
 						return "", fmt.Errorf("technical assets (referenced in same rank diagram tweak) are inside trust boundaries: %v", parsedModel.DiagramTweakSameRankAssets)
 					}
-					tweak += " " + hash(id) + "; "
+					if filter.includesTechnicalAsset(id) {
+						sameRankTweak += " " + hash(id) + "; "
+					}
 				}
-				tweak += " }"
+				tweak += sameRankTweak + " }"
 			}
 		}
 	}
 	return tweak, nil
 }
 
-func makeDiagramInvisibleConnectionsTweaks(parsedModel *types.Model) (string, error) {
+func makeDiagramInvisibleConnectionsTweaks(parsedModel *types.Model, filter *diagramScopeFilter) (string, error) {
 	// see https://stackoverflow.com/questions/2476575/how-to-control-node-placement-in-graphviz-i-e-avoid-edge-crossings
 	tweak := ""
 	if len(parsedModel.DiagramTweakInvisibleConnectionsBetweenAssets) > 0 {
@@ -509,7 +543,9 @@ func makeDiagramInvisibleConnectionsTweaks(parsedModel *types.Model) (string, er
 					return "", fmt.Errorf("error while checking technical asset existence: %w", err)
 				}
 
-				tweak += "\n" + hash(assetIDs[0]) + " -> " + hash(assetIDs[1]) + " [style=invis]; \n"
+				if filter.includesTechnicalAsset(assetIDs[0]) && filter.includesTechnicalAsset(assetIDs[1]) {
+					tweak += "\n" + hash(assetIDs[0]) + " -> " + hash(assetIDs[1]) + " [style=invis]; \n"
+				}
 			}
 		}
 	}
@@ -0,0 +1,546 @@
+package report
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/types"
+)
+
+type htmlReport struct {
+}
+
+type htmlReportData struct {
+	Title               string
+	Author              string
+	Date                string
+	ThreagileVersion    string
+	BuildTimestamp      string
+	ModelHash           string
+	ManagementSummary   string
+	IntroTextRAA        string
+	SeverityCounts      []htmlCount
+	StatusCounts        []htmlCount
+	DataFlowDiagram     template.HTML
+	DataAssetDiagram    template.HTML
+	RiskOverlayDiagram  template.HTML
+	DiagramTargets      template.JS
+	Filters             htmlFilters
+	Risks               []htmlRisk
+	TechnicalAssets     []htmlTechnicalAsset
+	Categories          []htmlCategory
+	CustomRiskRuleCount int
+}
+
+type htmlCount struct {
+	Title string
+	Color string
+	Count int
+}
+
+type htmlOption struct {
+	Value string
+	Title string
+}
+
+type htmlFilters struct {
+	Severities      []htmlOption
+	STRIDE          []htmlOption
+	Functions       []htmlOption
+	Statuses        []htmlOption
+	TrustBoundaries []htmlOption
+}
+
+type htmlRisk struct {
+	Id                  string
+	Title               string
+	CategoryId          string
+	CategoryTitle       string
+	Severity            string
+	SeverityTitle       string
+	SeverityColor       string
+	STRIDE              string
+	STRIDETitle         string
+	Function            string
+	FunctionTitle       string
+	Status              string
+	StatusTitle         string
+	StatusColor         string
+	Likelihood          string
+	Impact              string
+	TechnicalAssetId    string
+	TechnicalAssetTitle string
+	TrustBoundaryId     string
+	TrustBoundaryTitle  string
+}
+
+type htmlTechnicalAsset struct {
+	Id            string
+	Title         string
+	Description   string
+	Type          string
+	Technologies  string
+	TrustBoundary string
+	OutOfScope    bool
+	Diagram       template.HTML
+	Risks         []htmlRisk
+}
+
+type htmlCategory struct {
+	Id          string
+	Title       string
+	Description string
+	Impact      string
+	Mitigation  string
+	Check       string
+	STRIDE      string
+	Function    string
+	CWE         int
+	ASVS        string
+	CheatSheet  string
+	RiskCount   int
+}
+
+// HtmlReportDiagrams are the diagrams embedded into the html report: the rendered SVG, or the PNG file if there is no SVG
+type HtmlReportDiagrams struct {
+	DataFlowDiagramSVG            []byte
+	DataFlowDiagramFilenamePNG    string
+	DataAssetDiagramSVG           []byte
+	DataAssetDiagramFilenamePNG   string
+	RiskOverlayDiagramSVG         []byte
+	RiskOverlayDiagramFilenamePNG string
+	TechnicalAssetDiagramSVGs     map[string][]byte // by technical asset id
+}
+
+func NewHtmlReport() htmlReport {
+	return htmlReport{}
+}
+
+// WriteReport writes a single self-contained html file, diagrams are embedded as SVG (or as PNG if no SVG is available)
+func (r htmlReport) WriteReport(parsedModel *types.Model,
+	reportFilename string,
+	diagrams HtmlReportDiagrams,
+	buildTimestamp string,
+	threagileVersion string,
+	modelHash string,
+	introTextRAA string,
+	customRiskRules types.RiskRules,
+	progressReporter progressReporter) error {
+	data := r.createReportData(parsedModel, buildTimestamp, threagileVersion, modelHash, introTextRAA, customRiskRules)
+	data.DataFlowDiagram = embedDiagram(diagrams.DataFlowDiagramSVG, diagrams.DataFlowDiagramFilenamePNG, "Data-Flow Diagram")
+	data.DataAssetDiagram = embedDiagram(diagrams.DataAssetDiagramSVG, diagrams.DataAssetDiagramFilenamePNG, "Data-Asset Diagram")
+	data.RiskOverlayDiagram = embedDiagram(diagrams.RiskOverlayDiagramSVG, diagrams.RiskOverlayDiagramFilenamePNG, "Risk Overlay Diagram")
+	for n, asset := range data.TechnicalAssets {
+		if svg := diagrams.TechnicalAssetDiagramSVGs[asset.Id]; len(svg) > 0 {
+			data.TechnicalAssets[n].Diagram = embedDiagram(svg, "", asset.Title)
+		}
+	}
+
+	htmlTemplate, err := template.New("report").Parse(htmlReportTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse html report template: %w", err)
+	}
+
+	file, err := os.Create(filepath.Clean(reportFilename))
+	if err != nil {
+		return fmt.Errorf("failed to create html report %q: %w", reportFilename, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	err = htmlTemplate.Execute(file, data)
+	if err != nil {
+		return fmt.Errorf("failed to write html report: %w", err)
+	}
+
+	return nil
+}
+
+func (r htmlReport) createReportData(parsedModel *types.Model, buildTimestamp string, threagileVersion string, modelHash string, introTextRAA string, customRiskRules types.RiskRules) htmlReportData {
+	data := htmlReportData{
+		Title:               parsedModel.Title,
+		ThreagileVersion:    threagileVersion,
+		BuildTimestamp:      buildTimestamp,
+		ModelHash:           modelHash,
+		ManagementSummary:   htmlText(parsedModel.ManagementSummaryComment),
+		IntroTextRAA:        htmlText(introTextRAA),
+		CustomRiskRuleCount: len(customRiskRules),
+	}
+
+	if parsedModel.Author != nil {
+		data.Author = parsedModel.Author.Name
+	}
+	if !parsedModel.Date.IsZero() {
+		data.Date = parsedModel.Date.Format("2006-01-02")
+	}
+
+	risksByCategory := parsedModel.GeneratedRisksByCategoryWithCurrentStatus()
+	allRisks := make([]*types.Risk, 0)
+	for _, risks := range risksByCategory {
+		allRisks = append(allRisks, risks...)
+	}
+	types.SortByRiskSeverity(allRisks)
+
+	risksByTechnicalAsset := make(map[string][]htmlRisk)
+	for _, risk := range allRisks {
+		category := parsedModel.GetRiskCategory(risk.CategoryId)
+		if category == nil {
+			continue
+		}
+
+		entry := createHtmlRisk(parsedModel, category, risk)
+		data.Risks = append(data.Risks, entry)
+		if len(entry.TechnicalAssetId) > 0 {
+			risksByTechnicalAsset[entry.TechnicalAssetId] = append(risksByTechnicalAsset[entry.TechnicalAssetId], entry)
+		}
+	}
+
+	for _, severity := range []types.RiskSeverity{types.CriticalSeverity, types.HighSeverity, types.ElevatedSeverity, types.MediumSeverity, types.LowSeverity} {
+		count := 0
+		for _, risk := range types.ReduceToOnlyStillAtRisk(allRisks) {
+			if risk.Severity == severity {
+				count++
+			}
+		}
+		data.SeverityCounts = append(data.SeverityCounts, htmlCount{Title: severity.Title(), Color: rgbHexColorOfSeverity(severity), Count: count})
+	}
+
+	for _, value := range types.RiskStatusValues() {
+		status := value.(types.RiskStatus)
+		count := 0
+		for _, risk := range allRisks {
+			if risk.RiskStatus == status {
+				count++
+			}
+		}
+		data.StatusCounts = append(data.StatusCounts, htmlCount{Title: status.Title(), Color: rgbHexColorOfRiskStatus(status), Count: count})
+	}
+
+	data.Filters = createHtmlFilters(data.Risks)
+
+	for _, techAsset := range sortedTechnicalAssetsByTitle(parsedModel) {
+		asset := htmlTechnicalAsset{
+			Id:           techAsset.Id,
+			Title:        techAsset.Title,
+			Description:  htmlText(techAsset.Description),
+			Type:         techAsset.Type.String(),
+			Technologies: techAsset.Technologies.String(),
+			OutOfScope:   techAsset.OutOfScope,
+			Risks:        risksByTechnicalAsset[techAsset.Id],
+		}
+		if trustBoundary, ok := parsedModel.TrustBoundaries[parsedModel.GetTechnicalAssetTrustBoundaryId(techAsset)]; ok {
+			asset.TrustBoundary = trustBoundary.Title
+		}
+		data.TechnicalAssets = append(data.TechnicalAssets, asset)
+	}
+
+	for _, category := range parsedModel.SortedRiskCategories() {
+		data.Categories = append(data.Categories, htmlCategory{
+			Id:          category.ID,
+			Title:       category.Title,
+			Description: htmlText(category.Description),
+			Impact:      htmlText(category.Impact),
+			Mitigation:  htmlText(category.Mitigation),
+			Check:       htmlText(category.Check),
+			STRIDE:      category.STRIDE.Title(),
+			Function:    category.Function.Title(),
+			CWE:         category.CWE,
+			ASVS:        category.ASVS,
+			CheatSheet:  category.CheatSheet,
+			RiskCount:   len(risksByCategory[category.ID]),
+		})
+	}
+
+	targets := make(map[string]string)
+	for id := range parsedModel.TechnicalAssets {
+		targets[hash(id)] = "asset-" + id
+	}
+	targetsJSON, _ := json.Marshal(targets)
+	data.DiagramTargets = template.JS(targetsJSON) // #nosec G203 marshalled JSON is safe as JS literal
+
+	return data
+}
+
+func createHtmlRisk(parsedModel *types.Model, category *types.RiskCategory, risk *types.Risk) htmlRisk {
+	entry := htmlRisk{
+		Id:            risk.SyntheticId,
+		Title:         removeFormattingTags(risk.Title),
+		CategoryId:    category.ID,
+		CategoryTitle: category.Title,
+		Severity:      risk.Severity.String(),
+		SeverityTitle: risk.Severity.Title(),
+		SeverityColor: rgbHexColorOfSeverity(risk.Severity),
+		STRIDE:        category.STRIDE.String(),
+		STRIDETitle:   category.STRIDE.Title(),
+		Function:      category.Function.String(),
+		FunctionTitle: category.Function.Title(),
+		Status:        risk.RiskStatus.String(),
+		StatusTitle:   risk.RiskStatus.Title(),
+		StatusColor:   rgbHexColorOfRiskStatus(risk.RiskStatus),
+		Likelihood:    risk.ExploitationLikelihood.Title(),
+		Impact:        risk.ExploitationImpact.Title(),
+	}
+
+	if techAsset, ok := parsedModel.TechnicalAssets[risk.MostRelevantTechnicalAssetId]; ok {
+		entry.TechnicalAssetId = techAsset.Id
+		entry.TechnicalAssetTitle = techAsset.Title
+		entry.TrustBoundaryId = parsedModel.GetTechnicalAssetTrustBoundaryId(techAsset)
+	}
+	if len(risk.MostRelevantTrustBoundaryId) > 0 {
+		entry.TrustBoundaryId = risk.MostRelevantTrustBoundaryId
+	}
+	if trustBoundary, ok := parsedModel.TrustBoundaries[entry.TrustBoundaryId]; ok {
+		entry.TrustBoundaryTitle = trustBoundary.Title
+	}
+
+	return entry
+}
+
+func createHtmlFilters(risks []htmlRisk) htmlFilters {
+	filters := htmlFilters{}
+	for _, value := range types.RiskSeverityValues() {
+		severity := value.(types.RiskSeverity)
+		filters.Severities = append(filters.Severities, htmlOption{Value: severity.String(), Title: severity.Title()})
+	}
+	for _, value := range types.STRIDEValues() {
+		stride := value.(types.STRIDE)
+		filters.STRIDE = append(filters.STRIDE, htmlOption{Value: stride.String(), Title: stride.Title()})
+	}
+	for _, value := range types.RiskFunctionValues() {
+		function := value.(types.RiskFunction)
+		filters.Functions = append(filters.Functions, htmlOption{Value: function.String(), Title: function.Title()})
+	}
+	for _, value := range types.RiskStatusValues() {
+		status := value.(types.RiskStatus)
+		filters.Statuses = append(filters.Statuses, htmlOption{Value: status.String(), Title: status.Title()})
+	}
+
+	trustBoundaries := make(map[string]string)
+	for _, risk := range risks {
+		if len(risk.TrustBoundaryId) > 0 {
+			trustBoundaries[risk.TrustBoundaryId] = risk.TrustBoundaryTitle
+		}
+	}
+	for id, title := range trustBoundaries {
+		filters.TrustBoundaries = append(filters.TrustBoundaries, htmlOption{Value: id, Title: title})
+	}
+	sort.Slice(filters.TrustBoundaries, func(i, j int) bool {
+		return filters.TrustBoundaries[i].Title < filters.TrustBoundaries[j].Title
+	})
+
+	return filters
+}
+
+// renderGraphvizSVG renders the DOT file as SVG, nil makes the html report fall back to the PNG file
+func renderGraphvizSVG(dotFilename string, title string, progressReporter progressReporter) []byte {
+	svg, err := GenerateGraphvizSVG(dotFilename)
+	if err != nil {
+		progressReporter.Warn(fmt.Sprintf("unable to render %v as SVG, falling back to PNG: %v", title, err))
+		return nil
+	}
+	return svg
+}
+
+// embedDiagram embeds the SVG inline, falling back to the PNG file as data URI
+func embedDiagram(svg []byte, pngFilename string, title string) template.HTML {
+	if len(svg) > 0 {
+		return template.HTML(stripXMLProlog(string(svg))) // #nosec G203 SVG is generated by graphviz or the built-in renderer from escaped input
+	}
+
+	if len(pngFilename) > 0 {
+		png, err := os.ReadFile(filepath.Clean(pngFilename))
+		if err == nil {
+			return template.HTML(`<img alt="` + template.HTMLEscapeString(title) + `" src="data:image/png;base64,` + base64.StdEncoding.EncodeToString(png) + `">`) // #nosec G203 only escaped and base64 content
+		}
+	}
+
+	return template.HTML(`<p class="missing">` + template.HTMLEscapeString(title) + ` not available.</p>`) // #nosec G203 only escaped content
+}
+
+var xmlPrologExpression = regexp.MustCompile(`(?s)^.*?(<svg)`)
+
+func stripXMLProlog(svg string) string {
+	return xmlPrologExpression.ReplaceAllString(svg, "$1")
+}
+
+// htmlText turns the basic formatting used in rule texts into plain text with line breaks
+func htmlText(value string) string {
+	result := strings.NewReplacer("<br>", "\n", "<br/>", "\n", "</br>", "\n").Replace(value)
+	result = removeFormattingTags(result)
+	return strings.TrimSpace(result)
+}
+
+const htmlReportTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="generator" content="Threagile {{.ThreagileVersion}}">
+<title>Threat Model Report: {{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; color: #222; }
+header { background: #1a3a5a; color: #fff; padding: 1.5em 2em; }
+header h1 { margin: 0 0 .3em 0; }
+header .meta { font-size: .9em; opacity: .8; }
+nav { position: sticky; top: 0; background: #f4f6f8; border-bottom: 1px solid #d0d7de; padding: .5em 2em; z-index: 10; }
+nav a { margin-right: 1.5em; color: #1a3a5a; text-decoration: none; font-weight: 600; }
+main { padding: 1em 2em 4em 2em; }
+section { margin-bottom: 3em; }
+.text { white-space: pre-line; }
+.counts { display: flex; flex-wrap: wrap; gap: 1em; }
+.count { border: 1px solid #d0d7de; border-radius: 6px; padding: .6em 1em; min-width: 8em; }
+.count .number { font-size: 1.8em; font-weight: 700; }
+.diagram { overflow: auto; border: 1px solid #d0d7de; border-radius: 6px; padding: 1em; margin-bottom: 1.5em; }
+.diagram svg { max-width: 100%; height: auto; }
+.diagram g.node.linked { cursor: pointer; }
+.diagram g.node.linked:hover polygon, .diagram g.node.linked:hover ellipse, .diagram g.node.linked:hover path { stroke-width: 4; }
+.filters { display: flex; flex-wrap: wrap; gap: 1em; margin-bottom: 1em; align-items: end; }
+.filters label { display: flex; flex-direction: column; font-size: .85em; font-weight: 600; }
+table { border-collapse: collapse; width: 100%; font-size: .9em; }
+th, td { border-bottom: 1px solid #e1e4e8; padding: .4em .5em; text-align: left; vertical-align: top; }
+th { background: #f4f6f8; position: sticky; top: 2.6em; }
+.badge { display: inline-block; color: #fff; border-radius: 4px; padding: 0 .4em; font-size: .85em; white-space: nowrap; }
+.id { font-family: monospace; font-size: .85em; color: #555; }
+.asset, .category { border-left: 4px solid #1a3a5a; padding-left: 1em; margin-bottom: 1.5em; }
+.asset:target, .category:target { background: #fff8c5; }
+.out-of-scope { opacity: .6; }
+.missing { color: #888; font-style: italic; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Title}}</h1>
+<div class="meta">{{if .Author}}{{.Author}} &middot; {{end}}{{if .Date}}{{.Date}} &middot; {{end}}Threagile {{.ThreagileVersion}} ({{.BuildTimestamp}}){{if .ModelHash}} &middot; model SHA-256 <span class="id">{{.ModelHash}}</span>{{end}}</div>
+</header>
+<nav>
+<a href="#summary">Summary</a>
+<a href="#diagrams">Diagrams</a>
+<a href="#risks">Risks</a>
+<a href="#technical-assets">Technical Assets</a>
+<a href="#categories">Risk Categories</a>
+</nav>
+<main>
+<section id="summary">
+<h2>Management Summary</h2>
+{{if .ManagementSummary}}<p class="text">{{.ManagementSummary}}</p>{{end}}
+<h3>Unmitigated risks by severity</h3>
+<div class="counts">
+{{range .SeverityCounts}}<div class="count" style="border-top: 4px solid {{.Color}}"><div class="number">{{.Count}}</div>{{.Title}}</div>
+{{end}}</div>
+<h3>Risks by status</h3>
+<div class="counts">
+{{range .StatusCounts}}<div class="count" style="border-top: 4px solid {{.Color}}"><div class="number">{{.Count}}</div>{{.Title}}</div>
+{{end}}</div>
+{{if .CustomRiskRuleCount}}<p>{{.CustomRiskRuleCount}} custom risk rules were applied.</p>{{end}}
+</section>
+<section id="diagrams">
+<h2>Diagrams</h2>
+<p>Click a technical asset in a diagram to jump to its risks.</p>
+<h3>Data-Flow Diagram</h3>
+<div class="diagram">{{.DataFlowDiagram}}</div>
+<h3>Data-Asset Diagram</h3>
+<div class="diagram">{{.DataAssetDiagram}}</div>
+<h3>Risk Overlay Diagram</h3>
+<p>Technical assets and communication links colored by the highest severity of their unmitigated risks.</p>
+<div class="diagram">{{.RiskOverlayDiagram}}</div>
+{{if .IntroTextRAA}}<p class="text">{{.IntroTextRAA}}</p>{{end}}
+</section>
+<section id="risks">
+<h2>Risks</h2>
+<div class="filters">
+<label>Severity<select data-filter="severity"><option value="">all</option>{{range .Filters.Severities}}<option value="{{.Value}}">{{.Title}}</option>{{end}}</select></label>
+<label>STRIDE<select data-filter="stride"><option value="">all</option>{{range .Filters.STRIDE}}<option value="{{.Value}}">{{.Title}}</option>{{end}}</select></label>
+<label>Function<select data-filter="function"><option value="">all</option>{{range .Filters.Functions}}<option value="{{.Value}}">{{.Title}}</option>{{end}}</select></label>
+<label>Status<select data-filter="status"><option value="">all</option>{{range .Filters.Statuses}}<option value="{{.Value}}">{{.Title}}</option>{{end}}</select></label>
+<label>Trust boundary<select data-filter="trust-boundary"><option value="">all</option>{{range .Filters.TrustBoundaries}}<option value="{{.Value}}">{{.Title}}</option>{{end}}</select></label>
+<label>Technical asset<select data-filter="asset"><option value="">all</option>{{range .TechnicalAssets}}<option value="{{.Id}}">{{.Title}}</option>{{end}}</select></label>
+<label>Search<input type="search" data-filter="text" placeholder="title or id"></label>
+<span id="risk-count"></span>
+</div>
+<table id="risk-table">
+<thead><tr><th>Severity</th><th>Risk</th><th>STRIDE</th><th>Function</th><th>Status</th><th>Likelihood</th><th>Impact</th><th>Technical asset</th><th>Trust boundary</th></tr></thead>
+<tbody>
+{{range .Risks}}<tr data-severity="{{.Severity}}" data-stride="{{.STRIDE}}" data-function="{{.Function}}" data-status="{{.Status}}" data-trust-boundary="{{.TrustBoundaryId}}" data-asset="{{.TechnicalAssetId}}">
+<td><span class="badge" style="background: {{.SeverityColor}}">{{.SeverityTitle}}</span></td>
+<td>{{.Title}}<br><a class="id" href="#category-{{.CategoryId}}">{{.Id}}</a></td>
+<td>{{.STRIDETitle}}</td>
+<td>{{.FunctionTitle}}</td>
+<td><span class="badge" style="background: {{.StatusColor}}">{{.StatusTitle}}</span></td>
+<td>{{.Likelihood}}</td>
+<td>{{.Impact}}</td>
+<td>{{if .TechnicalAssetId}}<a href="#asset-{{.TechnicalAssetId}}">{{.TechnicalAssetTitle}}</a>{{end}}</td>
+<td>{{.TrustBoundaryTitle}}</td>
+</tr>
+{{end}}</tbody>
+</table>
+</section>
+<section id="technical-assets">
+<h2>Technical Assets</h2>
+{{range .TechnicalAssets}}<div class="asset{{if .OutOfScope}} out-of-scope{{end}}" id="asset-{{.Id}}">
+<h3>{{.Title}} <span class="id">{{.Id}}</span></h3>
+<p>{{.Type}}{{if .Technologies}} &middot; {{.Technologies}}{{end}}{{if .TrustBoundary}} &middot; inside {{.TrustBoundary}}{{end}}{{if .OutOfScope}} &middot; out of scope{{end}}</p>
+{{if .Description}}<p class="text">{{.Description}}</p>{{end}}
+{{if .Diagram}}<div class="diagram">{{.Diagram}}</div>{{end}}
+{{if .Risks}}<ul>{{range .Risks}}<li><span class="badge" style="background: {{.SeverityColor}}">{{.SeverityTitle}}</span> <span class="badge" style="background: {{.StatusColor}}">{{.StatusTitle}}</span> {{.Title}} <a class="id" href="#category-{{.CategoryId}}">{{.Id}}</a></li>{{end}}</ul>
+<p><a href="#risks" data-show-asset="{{.Id}}">Show in risk table</a></p>{{else}}<p class="missing">No risks identified.</p>{{end}}
+</div>
+{{end}}</section>
+<section id="categories">
+<h2>Risk Categories</h2>
+{{range .Categories}}<div class="category" id="category-{{.Id}}">
+<h3>{{.Title}} <span class="id">{{.Id}}</span></h3>
+<p>{{.STRIDE}} &middot; {{.Function}} &middot; {{.RiskCount}} risks{{if .CWE}} &middot; <a href="https://cwe.mitre.org/data/definitions/{{.CWE}}.html">CWE-{{.CWE}}</a>{{end}}</p>
+{{if .Description}}<h4>Description</h4><p class="text">{{.Description}}</p>{{end}}
+{{if .Impact}}<h4>Impact</h4><p class="text">{{.Impact}}</p>{{end}}
+{{if .Mitigation}}<h4>Mitigation</h4><p class="text">{{.Mitigation}}</p>{{end}}
+{{if .ASVS}}<p>ASVS: {{.ASVS}}</p>{{end}}
+{{if .CheatSheet}}<p>Cheat Sheet: <a href="{{.CheatSheet}}">{{.CheatSheet}}</a></p>{{end}}
+{{if .Check}}<h4>Check</h4><p class="text">{{.Check}}</p>{{end}}
+</div>
+{{end}}</section>
+</main>
+<script>
+(function () {
+  var targets = {{.DiagramTargets}};
+  var rows = Array.prototype.slice.call(document.querySelectorAll("#risk-table tbody tr"));
+  var filters = Array.prototype.slice.call(document.querySelectorAll("[data-filter]"));
+  function applyFilters() {
+    var shown = 0;
+    rows.forEach(function (row) {
+      var visible = filters.every(function (filter) {
+        var value = filter.value.toLowerCase();
+        if (!value) { return true; }
+        if (filter.dataset.filter === "text") { return row.textContent.toLowerCase().indexOf(value) >= 0; }
+        return (row.dataset[filter.dataset.filter.replace(/-([a-z])/g, function (m, c) { return c.toUpperCase(); })] || "") === filter.value;
+      });
+      row.style.display = visible ? "" : "none";
+      if (visible) { shown++; }
+    });
+    document.getElementById("risk-count").textContent = shown + " of " + rows.length + " risks";
+  }
+  filters.forEach(function (filter) { filter.addEventListener("input", applyFilters); });
+  Array.prototype.slice.call(document.querySelectorAll("[data-show-asset]")).forEach(function (link) {
+    link.addEventListener("click", function () {
+      filters.forEach(function (filter) { filter.value = filter.dataset.filter === "asset" ? link.dataset.showAsset : ""; });
+      applyFilters();
+    });
+  });
+  Array.prototype.slice.call(document.querySelectorAll(".diagram g.node")).forEach(function (node) {
+    var title = node.querySelector("title");
+    var target = title ? targets[title.textContent.trim()] : undefined;
+    if (!target) { return; }
+    node.classList.add("linked");
+    node.addEventListener("click", function () { window.location.hash = target; });
+  });
+  applyFilters();
+})();
+</script>
+</body>
+</html>
+`
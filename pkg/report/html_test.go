@@ -0,0 +1,78 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+func createHtmlTestModel() *types.Model {
+	return &types.Model{
+		Title: "HTML Test",
+		TechnicalAssets: map[string]*types.TechnicalAsset{
+			"web-server": {Id: "web-server", Title: "Web Server"},
+			"database":   {Id: "database", Title: "Database"},
+		},
+		TrustBoundaries: map[string]*types.TrustBoundary{
+			"dmz": {Id: "dmz", Title: "DMZ", TechnicalAssetsInside: []string{"web-server"}},
+		},
+		BuiltInRiskCategories: types.RiskCategories{
+			{ID: "test-category", Title: "Test Category", STRIDE: types.InformationDisclosure, Function: types.Architecture},
+		},
+		RiskTracking: map[string]*types.RiskTracking{
+			"test-category@database": {SyntheticRiskId: "test-category@database", Status: types.Mitigated},
+		},
+		GeneratedRisksByCategory: map[string][]*types.Risk{"test-category": {
+			{CategoryId: "test-category", SyntheticId: "test-category@database", Title: "<b>Database</b> risk", Severity: types.LowSeverity, MostRelevantTechnicalAssetId: "database"},
+			{CategoryId: "test-category", SyntheticId: "test-category@web-server", Title: "Web Server risk", Severity: types.HighSeverity, MostRelevantTechnicalAssetId: "web-server"},
+		}},
+	}
+}
+
+func TestCreateHtmlReportDataGroupsRisksByTechnicalAsset(t *testing.T) {
+	data := NewHtmlReport().createReportData(createHtmlTestModel(), "", "1.0.0", "", "", nil)
+
+	assert.Len(t, data.Risks, 2)
+	assert.Equal(t, "test-category@web-server", data.Risks[0].Id)
+	assert.Equal(t, "dmz", data.Risks[0].TrustBoundaryId)
+	assert.Equal(t, "Database risk", data.Risks[1].Title)
+	assert.Equal(t, types.Mitigated.String(), data.Risks[1].Status)
+
+	assert.Len(t, data.TechnicalAssets, 2)
+	assert.Equal(t, "database", data.TechnicalAssets[0].Id)
+	assert.Len(t, data.TechnicalAssets[0].Risks, 1)
+	assert.Equal(t, "DMZ", data.TechnicalAssets[1].TrustBoundary)
+
+	assert.Equal(t, []htmlOption{{Value: "dmz", Title: "DMZ"}}, data.Filters.TrustBoundaries)
+	for _, count := range data.SeverityCounts {
+		if count.Title == types.HighSeverity.Title() {
+			assert.Equal(t, 1, count.Count)
+		} else {
+			assert.Equal(t, 0, count.Count, count.Title)
+		}
+	}
+	assert.Contains(t, string(data.DiagramTargets), `"`+hash("web-server")+`":"asset-web-server"`)
+}
+
+func TestHtmlReportIsSelfContained(t *testing.T) {
+	reportFilename := filepath.Join(t.TempDir(), "report.html")
+	err := NewHtmlReport().WriteReport(createHtmlTestModel(), reportFilename, HtmlReportDiagrams{}, "", "1.0.0", "", "", nil, mockProgressReporter{})
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(reportFilename)
+	assert.NoError(t, err)
+	html := string(content)
+	assert.Contains(t, html, `id="asset-web-server"`)
+	assert.Contains(t, html, "test-category@web-server")
+	assert.False(t, strings.Contains(html, `src="http`), "report must not load external resources")
+}
+
+type mockProgressReporter struct{}
+
+func (mockProgressReporter) Info(...any)  {}
+func (mockProgressReporter) Warn(...any)  {}
+func (mockProgressReporter) Error(...any) {}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/threagile/threagile/pkg/model"
 	"github.com/threagile/threagile/pkg/types"
 )
 
@@ -20,6 +21,22 @@ func WriteRisksJSON(parsedModel *types.Model, filename string) error {
 	return nil
 }
 
+// WriteRisksSARIF writes the risks as SARIF 2.1.0 log: each risk category becomes a rule and each risk a result
+// located at the most relevant element in the model yaml file, tracked risks are reported as suppressed. The model
+// file is referenced relative to the working directory, which code-scanning dashboards take as repository root.
+func WriteRisksSARIF(parsedModel *types.Model, modelFilename string, threagileVersion string, filename string) error {
+	sourceRoot, _ := os.Getwd()
+	jsonBytes, err := json.MarshalIndent(createSarifLog(parsedModel, modelFilename, sourceRoot, threagileVersion), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal risks to SARIF: %w", err)
+	}
+	err = os.WriteFile(filename, jsonBytes, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write risks to SARIF file: %w", err)
+	}
+	return nil
+}
+
 // TODO: also a "data assets" json?
 
 func WriteTechnicalAssetsJSON(parsedModel *types.Model, filename string) error {
@@ -46,6 +63,44 @@ func WriteStatsJSON(parsedModel *types.Model, filename string) error {
 	return nil
 }
 
+// WriteAttackPathsJSON writes the attack paths from the entry points to the technical assets storing the most
+// sensitive data, ranked from the easiest to the hardest
+func WriteAttackPathsJSON(parsedModel *types.Model, filename string) error {
+	jsonBytes, err := json.Marshal(model.AttackPaths(parsedModel))
+	if err != nil {
+		return fmt.Errorf("failed to marshal attack paths to JSON: %w", err)
+	}
+	err = os.WriteFile(filename, jsonBytes, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write attack paths to JSON file: %w", err)
+	}
+	return nil
+}
+
+func WriteAttackTreesJSON(parsedModel *types.Model, filename string) error {
+	jsonBytes, err := json.Marshal(model.AttackTrees(parsedModel))
+	if err != nil {
+		return fmt.Errorf("failed to marshal attack trees to JSON: %w", err)
+	}
+	err = os.WriteFile(filename, jsonBytes, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write attack trees to JSON file: %w", err)
+	}
+	return nil
+}
+
+func WriteLossExposureJSON(parsedModel *types.Model, filename string) error {
+	jsonBytes, err := json.Marshal(model.EstimateLossExposure(parsedModel))
+	if err != nil {
+		return fmt.Errorf("failed to marshal loss exposure to JSON: %w", err)
+	}
+	err = os.WriteFile(filename, jsonBytes, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write loss exposure to JSON file: %w", err)
+	}
+	return nil
+}
+
 func overallRiskStatistics(parsedModel *types.Model) riskStatistics {
 	result := riskStatistics{}
 	result.Risks = make(map[string]map[string]int)
@@ -96,3 +151,17 @@ type riskStatistics struct {
 	// TODO add also some more like before / after (i.e. with mitigation applied)
 	Risks map[string]map[string]int `yaml:"risks" json:"risks"`
 }
+
+// ReadRisksJSON reads a risks JSON file as written by WriteRisksJSON, e.g. to be used as a baseline
+func ReadRisksJSON(filename string) ([]*types.Risk, error) {
+	jsonBytes, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read risks JSON file: %w", err)
+	}
+	risks := make([]*types.Risk, 0)
+	err = json.Unmarshal(jsonBytes, &risks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal risks from JSON file %q: %w", filename, err)
+	}
+	return risks, nil
+}
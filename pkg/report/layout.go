@@ -0,0 +1,565 @@
+package report
+
+import (
+	"math"
+	"sort"
+)
+
+// layoutGraph is laid out in layers (Sugiyama style) from top to bottom, or from left to right:
+// cycles are broken by reversing edges, nodes are assigned to layers by their longest path, edges spanning several
+// layers are routed via dummy nodes and the nodes of each cluster are ordered by the barycenters of their neighbours
+// while being packed side by side, so that clusters are never interleaved and nested clusters stay inside their parents
+type layoutGraph struct {
+	nodes    []*layoutNode
+	edges    []*layoutEdge
+	clusters []*layoutCluster
+
+	leftToRight        bool
+	nodeSeparation     float64
+	rankSeparation     float64
+	clusterPadding     float64
+	clusterLabelHeight float64
+	margin             float64
+
+	width  float64
+	height float64
+}
+
+type layoutNode struct {
+	id      string
+	width   float64
+	height  float64
+	cluster *layoutCluster
+
+	// center after layout
+	x float64
+	y float64
+
+	layer int
+	dummy bool
+	key   float64
+	left  float64
+}
+
+type layoutEdge struct {
+	from   *layoutNode
+	to     *layoutNode
+	points []layoutPoint
+
+	reversed bool
+	chain    []*layoutNode
+}
+
+type layoutCluster struct {
+	id         string
+	parent     *layoutCluster
+	labelWidth float64
+
+	// box after layout
+	x      float64
+	y      float64
+	width  float64
+	height float64
+
+	children   []*layoutCluster
+	nodes      []*layoutNode
+	minLayer   int
+	maxLayer   int
+	startDepth int
+	endDepth   int
+	key        float64
+	left       float64
+}
+
+type layoutPoint struct {
+	x float64
+	y float64
+}
+
+// layoutItem is either a node or a cluster packed into its parent
+type layoutItem struct {
+	node    *layoutNode
+	cluster *layoutCluster
+}
+
+func newLayoutGraph(leftToRight bool) *layoutGraph {
+	return &layoutGraph{
+		leftToRight:        leftToRight,
+		nodeSeparation:     40,
+		rankSeparation:     70,
+		clusterPadding:     20,
+		clusterLabelHeight: 24,
+		margin:             20,
+	}
+}
+
+func (what *layoutGraph) addCluster(id string, parent *layoutCluster, labelWidth float64) *layoutCluster {
+	cluster := &layoutCluster{id: id, parent: parent, labelWidth: labelWidth}
+	what.clusters = append(what.clusters, cluster)
+	return cluster
+}
+
+func (what *layoutGraph) addNode(id string, width, height float64, cluster *layoutCluster) *layoutNode {
+	node := &layoutNode{id: id, width: width, height: height, cluster: cluster}
+	what.nodes = append(what.nodes, node)
+	return node
+}
+
+func (what *layoutGraph) addEdge(from, to *layoutNode) *layoutEdge {
+	edge := &layoutEdge{from: from, to: to}
+	what.edges = append(what.edges, edge)
+	return edge
+}
+
+// layout assigns the node centers, cluster boxes and edge points as well as the size of the whole graph
+func (what *layoutGraph) layout() {
+	if what.leftToRight {
+		what.transpose(true)
+	}
+
+	what.breakCycles()
+	what.assignLayers()
+	what.insertDummyNodes()
+	root := what.buildClusterTree()
+
+	// the order is found with compact packing, only the final pass moves the items towards their neighbours
+	for iteration := 0; iteration < 8; iteration++ {
+		if iteration > 0 {
+			what.updateKeys(root)
+		}
+		what.pack(root, false)
+		what.place(root, 0)
+	}
+	what.updateKeys(root)
+	what.pack(root, true)
+	what.place(root, 0)
+
+	what.assignRanks(root)
+	what.routeEdges()
+
+	if what.leftToRight {
+		what.transpose(false)
+	}
+}
+
+// breakCycles reverses the edges closing a cycle in a depth first search
+func (what *layoutGraph) breakCycles() {
+	outgoing := what.outgoing()
+	state := make(map[*layoutNode]int)
+	var visit func(node *layoutNode)
+	visit = func(node *layoutNode) {
+		state[node] = 1
+		for _, edge := range outgoing[node] {
+			switch state[edge.to] {
+			case 0:
+				visit(edge.to)
+			case 1:
+				edge.reversed = true
+			}
+		}
+		state[node] = 2
+	}
+
+	for _, node := range what.nodes {
+		if state[node] == 0 {
+			visit(node)
+		}
+	}
+}
+
+// assignLayers puts each node one layer below its lowest predecessor and pulls sources down towards their successors
+func (what *layoutGraph) assignLayers() {
+	predecessors := make(map[*layoutNode][]*layoutNode)
+	successors := make(map[*layoutNode][]*layoutNode)
+	for _, edge := range what.edges {
+		from, to := edge.tail(), edge.head()
+		if from == to {
+			continue
+		}
+		predecessors[to] = append(predecessors[to], from)
+		successors[from] = append(successors[from], to)
+	}
+
+	assigned := make(map[*layoutNode]bool)
+	var assign func(node *layoutNode) int
+	assign = func(node *layoutNode) int {
+		if assigned[node] {
+			return node.layer
+		}
+		assigned[node] = true
+		node.layer = 0
+		for _, predecessor := range predecessors[node] {
+			node.layer = max(node.layer, assign(predecessor)+1)
+		}
+		return node.layer
+	}
+
+	for _, node := range what.nodes {
+		assign(node)
+	}
+
+	for _, node := range what.nodes {
+		if len(predecessors[node]) > 0 || len(successors[node]) == 0 {
+			continue
+		}
+		lowest := math.MaxInt
+		for _, successor := range successors[node] {
+			lowest = min(lowest, successor.layer)
+		}
+		node.layer = max(node.layer, lowest-1)
+	}
+}
+
+// insertDummyNodes splits edges spanning several layers, the dummy nodes belong to the innermost common cluster
+func (what *layoutGraph) insertDummyNodes() {
+	for _, edge := range what.edges {
+		from, to := edge.tail(), edge.head()
+		cluster := commonCluster(from.cluster, to.cluster)
+		for layer := from.layer + 1; layer < to.layer; layer++ {
+			dummy := what.addNode("", 0, 0, cluster)
+			dummy.layer = layer
+			dummy.dummy = true
+			edge.chain = append(edge.chain, dummy)
+		}
+	}
+}
+
+func (what *layoutGraph) buildClusterTree() *layoutCluster {
+	root := &layoutCluster{}
+	for _, cluster := range what.clusters {
+		cluster.children, cluster.nodes = nil, nil
+		cluster.minLayer, cluster.maxLayer = math.MaxInt, -1
+	}
+	root.minLayer, root.maxLayer = math.MaxInt, -1
+
+	for _, node := range what.nodes {
+		parent := node.cluster
+		if parent == nil {
+			parent = root
+		}
+		parent.nodes = append(parent.nodes, node)
+		for cluster := node.cluster; cluster != nil; cluster = cluster.parent {
+			cluster.minLayer = min(cluster.minLayer, node.layer)
+			cluster.maxLayer = max(cluster.maxLayer, node.layer)
+		}
+		root.minLayer = min(root.minLayer, node.layer)
+		root.maxLayer = max(root.maxLayer, node.layer)
+	}
+
+	// clusters without nodes are left out
+	for _, cluster := range what.clusters {
+		if cluster.maxLayer < 0 {
+			continue
+		}
+		parent := cluster.parent
+		if parent == nil {
+			parent = root
+		}
+		parent.children = append(parent.children, cluster)
+	}
+
+	var depths func(cluster *layoutCluster)
+	depths = func(cluster *layoutCluster) {
+		cluster.startDepth, cluster.endDepth = 1, 1
+		for _, child := range cluster.children {
+			depths(child)
+			if child.minLayer == cluster.minLayer {
+				cluster.startDepth = max(cluster.startDepth, child.startDepth+1)
+			}
+			if child.maxLayer == cluster.maxLayer {
+				cluster.endDepth = max(cluster.endDepth, child.endDepth+1)
+			}
+		}
+	}
+	for _, child := range root.children {
+		depths(child)
+	}
+
+	for n, node := range what.nodes {
+		node.key = float64(n)
+	}
+	what.updateClusterKeys(root)
+	return root
+}
+
+// updateKeys sets the key of each node to the barycenter of its neighbours in the adjacent layers
+func (what *layoutGraph) updateKeys(root *layoutCluster) {
+	neighbours := make(map[*layoutNode][]*layoutNode)
+	for _, edge := range what.edges {
+		path := append(append([]*layoutNode{edge.tail()}, edge.chain...), edge.head())
+		for n := 1; n < len(path); n++ {
+			neighbours[path[n-1]] = append(neighbours[path[n-1]], path[n])
+			neighbours[path[n]] = append(neighbours[path[n]], path[n-1])
+		}
+	}
+
+	for _, node := range what.nodes {
+		if len(neighbours[node]) == 0 {
+			node.key = node.x
+			continue
+		}
+		sum := 0.0
+		for _, neighbour := range neighbours[node] {
+			sum += neighbour.x
+		}
+		node.key = sum / float64(len(neighbours[node]))
+	}
+
+	what.updateClusterKeys(root)
+}
+
+func (what *layoutGraph) updateClusterKeys(cluster *layoutCluster) (float64, int) {
+	sum, count := 0.0, 0
+	for _, node := range cluster.nodes {
+		sum += node.key
+		count++
+	}
+	for _, child := range cluster.children {
+		childSum, childCount := what.updateClusterKeys(child)
+		sum += childSum
+		count += childCount
+	}
+	if count > 0 {
+		cluster.key = sum / float64(count)
+	}
+	return sum, count
+}
+
+func (what *layoutGraph) items(cluster *layoutCluster) []layoutItem {
+	items := make([]layoutItem, 0, len(cluster.nodes)+len(cluster.children))
+	for _, node := range cluster.nodes {
+		items = append(items, layoutItem{node: node})
+	}
+	for _, child := range cluster.children {
+		items = append(items, layoutItem{cluster: child})
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].key() < items[j].key() })
+	return items
+}
+
+// pack places the nodes and clusters inside a cluster side by side in the order of their keys, aligned with their keys
+// as far as the items already placed in the same layers allow, and returns the width of the cluster
+func (what *layoutGraph) pack(cluster *layoutCluster, align bool) float64 {
+	padding := what.clusterPadding
+	if cluster.parent == nil && cluster.id == "" {
+		padding = 0
+	}
+
+	skyline := make(map[int]float64)
+	right := padding
+	for _, item := range what.items(cluster) {
+		width, minLayer, maxLayer := item.extent(what, align)
+		left := padding
+		for layer := minLayer; layer <= maxLayer; layer++ {
+			if edge, ok := skyline[layer]; ok {
+				left = max(left, edge+what.separation(item))
+			}
+		}
+		if align {
+			left = max(left, item.key()-width/2-cluster.x)
+		}
+		item.setLeft(left)
+		for layer := minLayer; layer <= maxLayer; layer++ {
+			skyline[layer] = left + width
+		}
+		right = max(right, left+width)
+	}
+
+	cluster.width = max(right+padding, cluster.labelWidth+2*what.clusterPadding)
+	return cluster.width
+}
+
+func (what *layoutGraph) separation(item layoutItem) float64 {
+	if item.node != nil && item.node.dummy {
+		return what.nodeSeparation / 2
+	}
+	return what.nodeSeparation
+}
+
+// place turns the positions relative to the clusters into absolute ones
+func (what *layoutGraph) place(cluster *layoutCluster, left float64) {
+	cluster.x = left
+	for _, node := range cluster.nodes {
+		node.x = left + node.left + node.width/2
+	}
+	for _, child := range cluster.children {
+		what.place(child, left+child.left)
+	}
+}
+
+// assignRanks sets the vertical positions: layers leave room for the borders and labels of the clusters starting
+// and ending in them
+func (what *layoutGraph) assignRanks(root *layoutCluster) {
+	layers := root.maxLayer + 1
+	heights := make([]float64, layers)
+	topDepth := make([]int, layers)
+	bottomDepth := make([]int, layers)
+	for _, node := range what.nodes {
+		heights[node.layer] = max(heights[node.layer], node.height)
+	}
+	for _, cluster := range what.clusters {
+		if cluster.maxLayer < 0 {
+			continue
+		}
+		topDepth[cluster.minLayer] = max(topDepth[cluster.minLayer], cluster.startDepth)
+		bottomDepth[cluster.maxLayer] = max(bottomDepth[cluster.maxLayer], cluster.endDepth)
+	}
+
+	top := make([]float64, layers)
+	cursor := what.margin
+	for layer := 0; layer < layers; layer++ {
+		cursor += float64(topDepth[layer]) * what.clusterTop()
+		top[layer] = cursor
+		cursor += heights[layer] + float64(bottomDepth[layer])*what.clusterPadding + what.rankSeparation
+	}
+
+	for _, node := range what.nodes {
+		node.y = top[node.layer] + heights[node.layer]/2
+		if node.dummy {
+			node.height = heights[node.layer]
+		}
+	}
+
+	what.width = root.width + 2*what.margin
+	what.height = cursor - what.rankSeparation + what.margin
+	for _, cluster := range what.clusters {
+		if cluster.maxLayer < 0 {
+			continue
+		}
+		cluster.x += what.margin
+		cluster.y = top[cluster.minLayer] - float64(cluster.startDepth)*what.clusterTop()
+		cluster.height = top[cluster.maxLayer] + heights[cluster.maxLayer] + float64(cluster.endDepth)*what.clusterPadding - cluster.y
+		if what.leftToRight {
+			// the label is on top of the transposed cluster
+			cluster.x -= what.clusterLabelHeight
+			cluster.width += what.clusterLabelHeight
+		}
+	}
+	for _, node := range what.nodes {
+		node.x += what.margin
+	}
+	if what.leftToRight {
+		what.width += what.clusterLabelHeight
+		for _, node := range what.nodes {
+			node.x += what.clusterLabelHeight
+		}
+		for _, cluster := range what.clusters {
+			cluster.x += what.clusterLabelHeight
+		}
+	}
+}
+
+// clusterTop is the room above the nodes of a cluster, which holds the label unless the graph is transposed
+func (what *layoutGraph) clusterTop() float64 {
+	if what.leftToRight {
+		return what.clusterPadding
+	}
+	return what.clusterPadding + what.clusterLabelHeight
+}
+
+// routeEdges connects the bottom of the source with the top of the target via the dummy nodes in between
+func (what *layoutGraph) routeEdges() {
+	for _, edge := range what.edges {
+		from, to := edge.tail(), edge.head()
+		if from == to {
+			edge.points = nil
+			continue
+		}
+
+		points := []layoutPoint{{from.x, from.y + from.height/2}}
+		for _, dummy := range edge.chain {
+			points = append(points, layoutPoint{dummy.x, dummy.y - dummy.height/2}, layoutPoint{dummy.x, dummy.y + dummy.height/2})
+		}
+		points = append(points, layoutPoint{to.x, to.y - to.height/2})
+
+		if edge.reversed {
+			for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+				points[i], points[j] = points[j], points[i]
+			}
+		}
+		edge.points = points
+	}
+}
+
+// transpose swaps the axes, before the layout only the node sizes and after it all coordinates
+func (what *layoutGraph) transpose(sizesOnly bool) {
+	for _, node := range what.nodes {
+		node.width, node.height = node.height, node.width
+		if !sizesOnly {
+			node.x, node.y = node.y, node.x
+		}
+	}
+	if sizesOnly {
+		return
+	}
+
+	what.width, what.height = what.height, what.width
+	for _, cluster := range what.clusters {
+		cluster.x, cluster.y = cluster.y, cluster.x
+		cluster.width, cluster.height = cluster.height, cluster.width
+	}
+	for _, edge := range what.edges {
+		for n := range edge.points {
+			edge.points[n].x, edge.points[n].y = edge.points[n].y, edge.points[n].x
+		}
+	}
+}
+
+func (what *layoutGraph) outgoing() map[*layoutNode][]*layoutEdge {
+	outgoing := make(map[*layoutNode][]*layoutEdge)
+	for _, edge := range what.edges {
+		outgoing[edge.from] = append(outgoing[edge.from], edge)
+	}
+	return outgoing
+}
+
+// tail is the upper end of the edge after breaking the cycles
+func (what *layoutEdge) tail() *layoutNode {
+	if what.reversed {
+		return what.to
+	}
+	return what.from
+}
+
+// head is the lower end of the edge after breaking the cycles
+func (what *layoutEdge) head() *layoutNode {
+	if what.reversed {
+		return what.from
+	}
+	return what.to
+}
+
+func (what layoutItem) key() float64 {
+	if what.node != nil {
+		return what.node.key
+	}
+	return what.cluster.key
+}
+
+func (what layoutItem) extent(graph *layoutGraph, align bool) (float64, int, int) {
+	if what.node != nil {
+		return what.node.width, what.node.layer, what.node.layer
+	}
+	return graph.pack(what.cluster, align), what.cluster.minLayer, what.cluster.maxLayer
+}
+
+func (what layoutItem) setLeft(left float64) {
+	if what.node != nil {
+		what.node.left = left
+		return
+	}
+	what.cluster.left = left
+}
+
+func commonCluster(a, b *layoutCluster) *layoutCluster {
+	ancestors := make(map[*layoutCluster]bool)
+	for cluster := a; cluster != nil; cluster = cluster.parent {
+		ancestors[cluster] = true
+	}
+	for cluster := b; cluster != nil; cluster = cluster.parent {
+		if ancestors[cluster] {
+			return cluster
+		}
+	}
+	return nil
+}
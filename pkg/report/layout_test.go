@@ -0,0 +1,98 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayoutKeepsNodesInsideTheirClustersWithoutOverlaps(t *testing.T) {
+	graph := newLayoutGraph(false)
+	outer := graph.addCluster("outer", nil, 50)
+	inner := graph.addCluster("inner", outer, 50)
+	client := graph.addNode("client", 80, 40, nil)
+	server := graph.addNode("server", 100, 50, outer)
+	database := graph.addNode("database", 90, 60, inner)
+	cache := graph.addNode("cache", 90, 60, inner)
+	monitoring := graph.addNode("monitoring", 120, 40, nil)
+	graph.addEdge(client, server)
+	graph.addEdge(server, database)
+	graph.addEdge(server, cache)
+	graph.addEdge(monitoring, database)
+	graph.layout()
+
+	assertInside := func(node *layoutNode, cluster *layoutCluster) {
+		assert.GreaterOrEqual(t, node.x-node.width/2, cluster.x, node.id)
+		assert.LessOrEqual(t, node.x+node.width/2, cluster.x+cluster.width, node.id)
+		assert.GreaterOrEqual(t, node.y-node.height/2, cluster.y, node.id)
+		assert.LessOrEqual(t, node.y+node.height/2, cluster.y+cluster.height, node.id)
+	}
+	assertInside(server, outer)
+	assertInside(database, inner)
+	assertInside(cache, inner)
+	assert.GreaterOrEqual(t, inner.x, outer.x)
+	assert.LessOrEqual(t, inner.x+inner.width, outer.x+outer.width)
+	assert.Greater(t, inner.y, outer.y)
+
+	// monitoring is outside of both clusters
+	assert.True(t, monitoring.x+monitoring.width/2 <= outer.x || monitoring.x-monitoring.width/2 >= outer.x+outer.width ||
+		monitoring.y+monitoring.height/2 <= outer.y || monitoring.y-monitoring.height/2 >= outer.y+outer.height)
+
+	nodes := []*layoutNode{client, server, database, cache, monitoring}
+	for i, a := range nodes {
+		for _, b := range nodes[i+1:] {
+			overlapping := a.x-a.width/2 < b.x+b.width/2 && b.x-b.width/2 < a.x+a.width/2 &&
+				a.y-a.height/2 < b.y+b.height/2 && b.y-b.height/2 < a.y+a.height/2
+			assert.False(t, overlapping, a.id+" and "+b.id)
+		}
+	}
+
+	assert.Less(t, client.y, server.y)
+	assert.Less(t, server.y, database.y)
+	assert.Equal(t, database.y, cache.y)
+}
+
+func TestLayoutRoutesCyclesAndLongEdges(t *testing.T) {
+	graph := newLayoutGraph(false)
+	a := graph.addNode("a", 50, 30, nil)
+	b := graph.addNode("b", 50, 30, nil)
+	c := graph.addNode("c", 50, 30, nil)
+	ab := graph.addEdge(a, b)
+	bc := graph.addEdge(b, c)
+	ca := graph.addEdge(c, a)
+	ac := graph.addEdge(a, c)
+	graph.layout()
+
+	assert.Less(t, a.y, b.y)
+	assert.Less(t, b.y, c.y)
+
+	// edges start at their source and end at their target, also when reversed to break the cycle
+	for _, edge := range []*layoutEdge{ab, bc, ca, ac} {
+		first, last := edge.points[0], edge.points[len(edge.points)-1]
+		assert.Equal(t, edge.from.x, first.x)
+		assert.Equal(t, edge.to.x, last.x)
+	}
+	assert.Equal(t, c.y-c.height/2, ca.points[0].y)
+	assert.Equal(t, a.y+a.height/2, ca.points[len(ca.points)-1].y)
+
+	// the long edges pass the middle layer via a dummy node
+	assert.Len(t, ac.points, 4)
+	assert.Len(t, ca.points, 4)
+}
+
+func TestLayoutLeftToRightTransposes(t *testing.T) {
+	graph := newLayoutGraph(true)
+	a := graph.addNode("a", 120, 30, nil)
+	b := graph.addNode("b", 80, 40, nil)
+	edge := graph.addEdge(a, b)
+	graph.layout()
+
+	assert.Equal(t, 120.0, a.width)
+	assert.Equal(t, 30.0, a.height)
+	assert.Less(t, a.x, b.x)
+	assert.Equal(t, a.y, b.y)
+	assert.Equal(t, layoutPoint{a.x + a.width/2, a.y}, edge.points[0])
+	assert.Equal(t, layoutPoint{b.x - b.width/2, b.y}, edge.points[len(edge.points)-1])
+	assert.GreaterOrEqual(t, graph.width, b.x+b.width/2)
+	assert.GreaterOrEqual(t, graph.height, a.y+a.height/2)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"image"
 	"log"
+	"math"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/jung-kurt/gofpdf"
 	"github.com/jung-kurt/gofpdf/contrib/gofpdi"
+	"github.com/threagile/threagile/pkg/model"
 	"github.com/threagile/threagile/pkg/types"
 	"github.com/wcharczuk/go-chart"
 	"github.com/wcharczuk/go-chart/drawing"
@@ -34,6 +36,8 @@ type pdfReporter struct {
 	tocLinkIdByAssetId            map[string]int
 	homeLink                      int
 	currentChapterTitleBreadcrumb string
+	technicalAssetDiagrams        map[string]string
+	compliance                    []*model.ComplianceFrameworkCoverage
 
 	riskRules types.RiskRules
 }
@@ -56,6 +60,8 @@ func (r *pdfReporter) WriteReportPDF(reportFilename string,
 	templateFilename string,
 	dataFlowDiagramFilenamePNG string,
 	dataAssetDiagramFilenamePNG string,
+	technicalAssetDiagramFilenamesPNG map[string]string,
+	compliance []*model.ComplianceFrameworkCoverage,
 	modelFilename string,
 	skipRiskRules []string,
 	buildTimestamp string,
@@ -74,6 +80,8 @@ func (r *pdfReporter) WriteReportPDF(reportFilename string,
 	}()
 
 	r.initReport()
+	r.technicalAssetDiagrams = technicalAssetDiagramFilenamesPNG
+	r.compliance = compliance
 	r.createPdfAndInitMetadata(model)
 	r.parseBackgroundTemplate(templateFilename)
 	r.createCover(model)
@@ -102,6 +110,9 @@ func (r *pdfReporter) WriteReportPDF(reportFilename string,
 	r.createSTRIDE(model)
 	r.createAssignmentByFunction(model)
 	r.createRAA(model, introTextRAA)
+	r.createAttackPaths(model)
+	r.createLossExposure(model)
+	r.createCompliance(model)
 	r.embedDataRiskMapping(dataAssetDiagramFilenamePNG, tempFolder)
 	//createDataRiskQuickWins()
 	r.createOutOfScopeAssets(model)
@@ -324,6 +335,24 @@ func (r *pdfReporter) createTableOfContents(parsedModel *types.Model) {
 	r.pdf.Line(15.6, y+1.3, 11+171.5, y+1.3)
 	r.pdf.Link(10, y-5, 172.5, 6.5, r.pdf.AddLink())
 
+	y += 6
+	r.pdf.Text(11, y, "    "+"Attack Paths")
+	r.pdf.Text(175, y, "{attack-paths}")
+	r.pdf.Line(15.6, y+1.3, 11+171.5, y+1.3)
+	r.pdf.Link(10, y-5, 172.5, 6.5, r.pdf.AddLink())
+
+	y += 6
+	r.pdf.Text(11, y, "    "+"Loss Exposure")
+	r.pdf.Text(175, y, "{loss-exposure}")
+	r.pdf.Line(15.6, y+1.3, 11+171.5, y+1.3)
+	r.pdf.Link(10, y-5, 172.5, 6.5, r.pdf.AddLink())
+
+	y += 6
+	r.pdf.Text(11, y, "    "+"Compliance")
+	r.pdf.Text(175, y, "{compliance}")
+	r.pdf.Line(15.6, y+1.3, 11+171.5, y+1.3)
+	r.pdf.Link(10, y-5, 172.5, 6.5, r.pdf.AddLink())
+
 	y += 6
 	r.pdf.Text(11, y, "    "+"Data Mapping")
 	r.pdf.Text(175, y, "{data-risk-mapping}")
@@ -1699,6 +1728,244 @@ func (r *pdfReporter) createRAA(parsedModel *types.Model, introTextRAA string) {
 	r.pdf.SetDashPattern([]float64{}, 0)
 }
 
+// maxAttackPathsInReport limits the attack paths listed in the report, the JSON export contains all of them
+const maxAttackPathsInReport = 25
+
+func (r *pdfReporter) createAttackPaths(parsedModel *types.Model) {
+	uni := r.pdf.UnicodeTranslatorFromDescriptor("")
+	r.pdf.SetTextColor(0, 0, 0)
+	chapTitle := "Attack Paths"
+	r.addHeadline(chapTitle, false)
+	r.defineLinkTarget("{attack-paths}")
+	r.currentChapterTitleBreadcrumb = chapTitle
+
+	attackPaths := model.AttackPaths(parsedModel)
+	html := r.pdf.HTMLBasicNew()
+	var strBuilder strings.Builder
+	strBuilder.WriteString("Starting from the technical assets reachable from the internet or used as clients by humans, " +
+		"the most plausible chains of communication links towards the technical assets storing <b>strictly-confidential</b> " +
+		"or <b>mission-critical</b> data assets were determined. Each communication link followed adds one to the difficulty of the " +
+		"path plus the obstacles on it: authentication, encryption, IP filtering, VPN and crossing into another network trust boundary. " +
+		"The lower the difficulty, the more plausible the path. Attack paths show chained attacks which are not covered by individual " +
+		"risk rules, and they point to the communication links where additional hardening breaks the most paths.<br><br>")
+	if len(attackPaths) == 0 {
+		strBuilder.WriteString("No attack paths from an entry point to a technical asset storing strictly-confidential or " +
+			"mission-critical data were found.")
+	} else if len(attackPaths) > maxAttackPathsInReport {
+		strBuilder.WriteString(fmt.Sprintf("The following lists the %d most plausible of %d attack paths, the JSON export contains all of them:",
+			maxAttackPathsInReport, len(attackPaths)))
+		attackPaths = attackPaths[:maxAttackPathsInReport]
+	} else {
+		strBuilder.WriteString("The following lists the attack paths from the most to the least plausible one:")
+	}
+	html.Write(5, strBuilder.String())
+	strBuilder.Reset()
+
+	for i, attackPath := range attackPaths {
+		if r.pdf.GetY() > 250 {
+			r.pageBreak()
+			r.pdf.SetY(36)
+		} else {
+			html.Write(5, "<br><br>")
+		}
+		entry := parsedModel.TechnicalAssets[attackPath.EntryTechnicalAssetId]
+		target := parsedModel.TechnicalAssets[attackPath.TargetTechnicalAssetId]
+		steps := "steps"
+		if len(attackPath.Steps) == 1 {
+			steps = "step"
+		}
+		posY := r.pdf.GetY()
+		strBuilder.WriteString(fmt.Sprintf("<b>%d. %v to %v</b>: difficulty %.1f in %d %v<br>", i+1,
+			uni(entry.Title), uni(target.Title), attackPath.Difficulty, len(attackPath.Steps), steps))
+		dataAssets := make([]string, 0)
+		for _, dataAssetId := range attackPath.TargetDataAssetIds {
+			dataAssets = append(dataAssets, uni(parsedModel.DataAssets[dataAssetId].Title))
+		}
+		html.Write(5, strBuilder.String())
+		strBuilder.Reset()
+		r.pdf.SetFont("Helvetica", "", fontSizeSmall)
+		r.pdfColorGray()
+		strBuilder.WriteString("Stores " + strings.Join(dataAssets, ", ") + "<br>")
+		html.Write(5, strBuilder.String())
+		strBuilder.Reset()
+		r.pdfColorBlack()
+		for _, step := range attackPath.Steps {
+			strBuilder.WriteString("    " + uni(parsedModel.TechnicalAssets[step.SourceId].Title) + " to " +
+				uni(parsedModel.TechnicalAssets[step.TargetId].Title))
+			if link, ok := parsedModel.CommunicationLinks[step.CommunicationLinkId]; ok {
+				strBuilder.WriteString(" via " + uni(link.Title) + " (" + link.Protocol.String() + ")")
+			}
+			if len(step.Obstacles) > 0 {
+				strBuilder.WriteString(": " + uni(strings.Join(step.Obstacles, ", ")))
+			}
+			strBuilder.WriteString("<br>")
+		}
+		html.Write(5, strBuilder.String())
+		strBuilder.Reset()
+		r.pdf.SetFont("Helvetica", "", fontSizeBody)
+		if linkId, ok := r.tocLinkIdByAssetId[target.Id]; ok {
+			r.pdf.Link(9, posY, 190, 5, linkId)
+		}
+	}
+
+	r.pdf.SetDrawColor(0, 0, 0)
+	r.pdf.SetDashPattern([]float64{}, 0)
+}
+
+// maxLossExposuresInReport limits the risks and technical assets listed in the report, the JSON export contains all of them
+const maxLossExposuresInReport = 15
+
+func (r *pdfReporter) createLossExposure(parsedModel *types.Model) {
+	uni := r.pdf.UnicodeTranslatorFromDescriptor("")
+	r.pdf.SetTextColor(0, 0, 0)
+	chapTitle := "Loss Exposure"
+	r.addHeadline(chapTitle, false)
+	r.defineLinkTarget("{loss-exposure}")
+	r.currentChapterTitleBreadcrumb = chapTitle
+
+	exposure := model.EstimateLossExposure(parsedModel)
+	html := r.pdf.HTMLBasicNew()
+	var strBuilder strings.Builder
+	strBuilder.WriteString(fmt.Sprintf("The annualised loss exposure was estimated by simulating %d years of the unmitigated risks: ", exposure.Iterations) +
+		"the number of loss events per year follows the exploitation likelihood (or the loss event frequency of the technical asset), " +
+		"each event leaks records of the data assets on the breached technical assets with the data breach probability and " +
+		"denial-of-service risks cause downtime of the technical asset. The share of leaked records and the length of the downtime " +
+		"follow the exploitation impact, the number of records follows the quantity of the data asset. The ranges show the loss " +
+		"exceeded in 90%, 50% and 10% of the simulated years (P10, P50 and P90).<br><br>")
+	if len(exposure.Risks) == 0 {
+		strBuilder.WriteString("No financial attributes (<b>record_value</b> and <b>breach_cost_per_record</b> of data assets, " +
+			"<b>downtime_cost_per_hour</b> of technical assets) affected by unmitigated risks have been modeled.")
+		html.Write(5, strBuilder.String())
+		return
+	}
+	strBuilder.WriteString(fmt.Sprintf("<b>Whole model</b>: %v per year on average (P10 %v, P50 %v, P90 %v)",
+		formatMoney(exposure.Total.Mean, exposure.Currency), formatMoney(exposure.Total.P10, exposure.Currency),
+		formatMoney(exposure.Total.P50, exposure.Currency), formatMoney(exposure.Total.P90, exposure.Currency)))
+	html.Write(5, strBuilder.String())
+	strBuilder.Reset()
+
+	html.Write(5, "<br><br><br><b>Technical assets with the highest loss exposure:</b>")
+	for i, technicalAssetExposure := range exposure.TechnicalAssets {
+		if i >= maxLossExposuresInReport {
+			break
+		}
+		if r.pdf.GetY() > 265 {
+			r.pageBreak()
+			r.pdf.SetY(36)
+		}
+		technicalAsset := parsedModel.TechnicalAssets[technicalAssetExposure.TechnicalAssetId]
+		posY := r.pdf.GetY() + 5
+		html.Write(5, fmt.Sprintf("<br>%v: %v (P10 %v, P90 %v)", uni(technicalAsset.Title),
+			formatMoney(technicalAssetExposure.AnnualizedLoss.Mean, exposure.Currency),
+			formatMoney(technicalAssetExposure.AnnualizedLoss.P10, exposure.Currency),
+			formatMoney(technicalAssetExposure.AnnualizedLoss.P90, exposure.Currency)))
+		if linkId, ok := r.tocLinkIdByAssetId[technicalAsset.Id]; ok {
+			r.pdf.Link(9, posY, 190, 5, linkId)
+		}
+	}
+
+	html.Write(5, "<br><br><br><b>Risks with the highest loss exposure:</b>")
+	for i, riskExposure := range exposure.Risks {
+		if i >= maxLossExposuresInReport {
+			break
+		}
+		if r.pdf.GetY() > 260 {
+			r.pageBreak()
+			r.pdf.SetY(36)
+		}
+		risk := parsedModel.GeneratedRisksBySyntheticId[strings.ToLower(riskExposure.SyntheticId)]
+		title := riskExposure.SyntheticId
+		if risk != nil {
+			title = risk.Title
+		}
+		html.Write(5, "<br>"+uni(title)+"<br>")
+		r.pdf.SetFont("Helvetica", "", fontSizeSmall)
+		r.pdfColorGray()
+		html.Write(5, fmt.Sprintf("    %v (P10 %v, P90 %v) at %.2f loss events per year",
+			formatMoney(riskExposure.AnnualizedLoss.Mean, exposure.Currency),
+			formatMoney(riskExposure.AnnualizedLoss.P10, exposure.Currency),
+			formatMoney(riskExposure.AnnualizedLoss.P90, exposure.Currency), riskExposure.LossEventFrequency))
+		r.pdfColorBlack()
+		r.pdf.SetFont("Helvetica", "", fontSizeBody)
+	}
+}
+
+func (r *pdfReporter) createCompliance(parsedModel *types.Model) {
+	uni := r.pdf.UnicodeTranslatorFromDescriptor("")
+	r.pdf.SetTextColor(0, 0, 0)
+	chapTitle := "Compliance"
+	r.addHeadline(chapTitle, false)
+	r.defineLinkTarget("{compliance}")
+	r.currentChapterTitleBreadcrumb = chapTitle
+
+	html := r.pdf.HTMLBasicNew()
+	html.Write(5, "The controls of the compliance frameworks below are mapped to the risk categories checking them. "+
+		"A control is <b>covered</b> when its risk categories have been checked and have no unmitigated risks, it has "+
+		"<b>open risks</b> when at least one of them has unmitigated risks and it is <b>not assessed</b> when none of "+
+		"them has been checked (for example because the risk rule was skipped or no risk category is mapped).")
+	if len(r.compliance) == 0 {
+		html.Write(5, "<br><br>No compliance frameworks have been mapped.")
+		return
+	}
+
+	for _, framework := range r.compliance {
+		if r.pdf.GetY() > 250 {
+			r.pageBreak()
+			r.pdf.SetY(36)
+		} else {
+			html.Write(5, "<br><br><br>")
+		}
+		title := framework.Title
+		if len(title) == 0 {
+			title = framework.Id
+		}
+		html.Write(5, fmt.Sprintf("<b>%v</b>: %d of %d controls covered, %d with open risks, %d not assessed",
+			uni(title), framework.Covered, len(framework.Controls), framework.OpenRisks, framework.NotAssessed))
+		for _, control := range framework.Controls {
+			if r.pdf.GetY() > 260 {
+				r.pageBreak()
+				r.pdf.SetY(36)
+			}
+			html.Write(5, "<br>"+uni(control.Id)+" "+uni(control.Title)+": ")
+			switch control.Status {
+			case model.ComplianceOpenRisks:
+				colorHighRisk(r.pdf)
+				html.Write(5, fmt.Sprintf("<b>%v</b> (%d of %d)", control.Status.Title(), len(control.OpenRisks), control.Risks))
+			case model.ComplianceCovered:
+				colorRiskStatusMitigated(r.pdf)
+				html.Write(5, "<b>"+control.Status.Title()+"</b>")
+			default:
+				r.pdfColorGray()
+				html.Write(5, "<b>"+control.Status.Title()+"</b>")
+			}
+			r.pdfColorBlack()
+			if len(control.AssessedCategories) > 0 {
+				r.pdf.SetFont("Helvetica", "", fontSizeSmall)
+				r.pdfColorGray()
+				html.Write(5, "<br>    "+uni(strings.Join(control.AssessedCategories, ", ")))
+				r.pdfColorBlack()
+				r.pdf.SetFont("Helvetica", "", fontSizeBody)
+			}
+		}
+	}
+}
+
+// formatMoney rounds the amount and groups the thousands, like "1,234,567 EUR"
+func formatMoney(amount float64, currency string) string {
+	digits := strconv.FormatFloat(math.Round(amount), 'f', 0, 64)
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteString(",")
+		}
+		grouped.WriteRune(digit)
+	}
+	if len(currency) > 0 {
+		grouped.WriteString(" " + currency)
+	}
+	return grouped.String()
+}
+
 /*
 func createDataRiskQuickWins() {
 	uni := r.pdf.UnicodeTranslatorFromDescriptor("")
@@ -2354,8 +2621,17 @@ func (r *pdfReporter) createAbuseCases(parsedModel *types.Model) {
 	r.defineLinkTarget("{abuse-cases}")
 	r.currentChapterTitleBreadcrumb = chapTitle
 
+	attackTrees := make(map[string]*model.AttackTree)
+	for _, tree := range model.AttackTrees(parsedModel) {
+		attackTrees[tree.AbuseCase] = tree
+	}
+
 	html := r.pdf.HTMLBasicNew()
 	html.Write(5, "This chapter lists the custom abuse cases which have been defined for the modeled target.")
+	if len(attackTrees) > 0 {
+		html.Write(5, " Abuse cases targeting data assets or technical assets are broken down into attack trees: "+
+			"all steps below an <b>AND</b> have to succeed, one step below an <b>OR</b> is enough.")
+	}
 	r.pdfColorBlack()
 	for _, title := range sortedKeysOfAbuseCases(parsedModel) {
 		description := parsedModel.AbuseCases[title]
@@ -2367,6 +2643,10 @@ func (r *pdfReporter) createAbuseCases(parsedModel *types.Model) {
 		}
 		html.Write(5, "<b>"+title+"</b><br>")
 		html.Write(5, description)
+		if tree, ok := attackTrees[title]; ok {
+			html.Write(5, "<br><br><i>Attack tree:</i>")
+			r.writeAttackTreeNode(tree.Root, 0)
+		}
 	}
 	if r.pdf.GetY() > 250 {
 		r.pageBreak()
@@ -2378,6 +2658,34 @@ func (r *pdfReporter) createAbuseCases(parsedModel *types.Model) {
 		"taken into account as well. Also custom individual abuse cases might exist for the project.</i>")
 }
 
+func (r *pdfReporter) writeAttackTreeNode(node *model.AttackTreeNode, depth int) {
+	uni := r.pdf.UnicodeTranslatorFromDescriptor("")
+	if r.pdf.GetY() > 265 {
+		r.pageBreak()
+		r.pdf.SetY(36)
+	}
+	oldLeft, _, _, _ := r.pdf.GetMargins()
+	r.pdf.SetLeftMargin(oldLeft + float64(depth)*5)
+	html := r.pdf.HTMLBasicNew()
+	var strBuilder strings.Builder
+	strBuilder.WriteString("<br>")
+	if len(node.Operator) > 0 {
+		strBuilder.WriteString("<b>[" + strings.ToUpper(string(node.Operator)) + "]</b> ")
+	}
+	strBuilder.WriteString(uni(node.Title))
+	if len(node.Severity) > 0 {
+		strBuilder.WriteString(" <i>(" + node.Severity + " risk)</i>")
+	}
+	if len(node.Details) > 0 {
+		strBuilder.WriteString(": " + uni(strings.Join(node.Details, ", ")))
+	}
+	html.Write(5, strBuilder.String())
+	r.pdf.SetLeftMargin(oldLeft)
+	for _, child := range node.Children {
+		r.writeAttackTreeNode(child, depth+1)
+	}
+}
+
 func sortedKeysOfAbuseCases(parsedModel *types.Model) []string {
 	keys := make([]string, 0)
 	for k := range parsedModel.AbuseCases {
@@ -2865,6 +3173,9 @@ func (r *pdfReporter) createTechnicalAssets(parsedModel *types.Model) {
 		text.Reset()
 		r.pdf.SetTextColor(0, 0, 0)
 
+		// neighbourhood of asset
+		r.embedTechnicalAssetDiagram(r.technicalAssetDiagrams[technicalAsset.Id])
+
 		// and more metadata of asset in tabular view
 		r.pdf.Ln(-1)
 		r.pdf.Ln(-1)
@@ -4585,6 +4896,33 @@ func (r *pdfReporter) embedDataFlowDiagram(diagramFilenamePNG string, tempFolder
 	}
 }
 
+// embedTechnicalAssetDiagram embeds the data flow diagram around a technical asset into its chapter
+func (r *pdfReporter) embedTechnicalAssetDiagram(diagramFilenamePNG string) {
+	if len(diagramFilenamePNG) == 0 {
+		return
+	}
+	maxWidth, maxHeight := 190.0, 110.0
+	height, err := getHeightWhenWidthIsFix(diagramFilenamePNG, maxWidth)
+	if err != nil {
+		return
+	}
+	width := maxWidth
+	if height > maxHeight {
+		width, height = maxWidth*maxHeight/height, maxHeight
+	}
+
+	r.pdf.Ln(-1)
+	r.pdf.Ln(-1)
+	if r.pdf.GetY()+height > 270 {
+		r.pageBreak()
+		r.pdf.SetY(36)
+	}
+	var options gofpdf.ImageOptions
+	options.ImageType = ""
+	r.pdf.RegisterImage(diagramFilenamePNG, "")
+	r.pdf.ImageOptions(diagramFilenamePNG, 10+(maxWidth-width)/2, r.pdf.GetY(), width, height, true, options, 0, "")
+}
+
 func (r *pdfReporter) embedDataRiskMapping(diagramFilenamePNG string, tempFolder string) {
 	r.pdf.SetTextColor(0, 0, 0)
 	title := "Data Mapping"
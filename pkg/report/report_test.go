@@ -0,0 +1,14 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatMoney(t *testing.T) {
+	assert.Equal(t, "0", formatMoney(0, ""))
+	assert.Equal(t, "999 EUR", formatMoney(999.4, "EUR"))
+	assert.Equal(t, "1,000 EUR", formatMoney(999.5, "EUR"))
+	assert.Equal(t, "1,234,567 USD", formatMoney(1234567, "USD"))
+}
@@ -0,0 +1,316 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/types"
+)
+
+// riskOverlay holds the still at risk findings per technical asset and communication link, optionally limited to a
+// single STRIDE category
+type riskOverlay struct {
+	stride              *types.STRIDE
+	byTechnicalAsset    map[string][]*types.Risk
+	byCommunicationLink map[string][]*types.Risk
+}
+
+func newRiskOverlay(parsedModel *types.Model, stride string) (*riskOverlay, error) {
+	overlay := &riskOverlay{
+		byTechnicalAsset:    make(map[string][]*types.Risk),
+		byCommunicationLink: make(map[string][]*types.Risk),
+	}
+	if len(strings.TrimSpace(stride)) > 0 {
+		value, err := types.ParseSTRIDE(stride)
+		if err != nil {
+			return nil, fmt.Errorf("unknown STRIDE category for risk overlay %q: %w", stride, err)
+		}
+		overlay.stride = &value
+	}
+
+	for _, risk := range parsedModel.AllRisks() {
+		if !parsedModel.GetRiskTrackingWithDefault(risk).Status.IsStillAtRisk() {
+			continue
+		}
+		if overlay.stride != nil {
+			category := parsedModel.GetRiskCategory(risk.CategoryId)
+			if category == nil || category.STRIDE != *overlay.stride {
+				continue
+			}
+		}
+		if len(risk.MostRelevantTechnicalAssetId) > 0 {
+			overlay.byTechnicalAsset[risk.MostRelevantTechnicalAssetId] = append(overlay.byTechnicalAsset[risk.MostRelevantTechnicalAssetId], risk)
+		}
+		if len(risk.MostRelevantCommunicationLinkId) > 0 {
+			overlay.byCommunicationLink[risk.MostRelevantCommunicationLinkId] = append(overlay.byCommunicationLink[risk.MostRelevantCommunicationLinkId], risk)
+		}
+	}
+	return overlay, nil
+}
+
+func (what *riskOverlay) title(parsedModel *types.Model, addModelTitle bool) string {
+	title := ""
+	if addModelTitle {
+		title = parsedModel.Title
+	}
+	if what.stride != nil {
+		if len(title) > 0 {
+			title += ": "
+		}
+		title += what.stride.Title()
+	}
+	return title
+}
+
+// riskOverlayColor is the color of the highest severity, or empty when there are no risks
+func riskOverlayColor(risks []*types.Risk) string {
+	if len(risks) == 0 {
+		return ""
+	}
+	return rgbHexColorOfSeverity(types.HighestSeverityStillAtRisk(risks))
+}
+
+// riskOverlayTechAssetColors returns the fill, border and text color of a technical asset, which stays gray without risks
+func riskOverlayTechAssetColors(technicalAsset *types.TechnicalAsset, risks []*types.Risk) (string, string, string) {
+	if color := riskOverlayColor(risks); len(color) > 0 {
+		return color, darkenHexColor(color), "#FFFFFF"
+	}
+	if technicalAsset.OutOfScope {
+		return rgbHexColorOutOfScope(), LightGray, Black
+	}
+	return VeryLightGray, LightGray, Black
+}
+
+// riskOverlayBadge counts the risks per severity, starting with the highest one, like "1 critical, 2 high"
+func riskOverlayBadge(risks []*types.Risk) string {
+	if len(risks) == 0 {
+		return "no open risks"
+	}
+	counts := make(map[types.RiskSeverity]int)
+	for _, risk := range risks {
+		counts[risk.Severity]++
+	}
+	severities := types.RiskSeverityValues()
+	parts := make([]string, 0)
+	for n := len(severities) - 1; n >= 0; n-- {
+		severity := severities[n].(types.RiskSeverity)
+		if counts[severity] > 0 {
+			parts = append(parts, strconv.Itoa(counts[severity])+" "+severity.String())
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// RenderRiskOverlayDiagram renders the risk overlay diagram without graphviz as "svg" or "png", where stride limits the
+// risks to a single STRIDE category unless empty
+func RenderRiskOverlayDiagram(parsedModel *types.Model, format string, dpi int, stride string, addModelTitle bool) ([]byte, error) {
+	fonts, err := loadDiagramFonts()
+	if err != nil {
+		return nil, err
+	}
+
+	overlay, err := newRiskOverlay(parsedModel, stride)
+	if err != nil {
+		return nil, err
+	}
+	diagram := newRiskOverlayDiagram(parsedModel, overlay, fonts)
+	diagram.title = overlay.title(parsedModel, addModelTitle)
+	return diagram.render(format, dpi)
+}
+
+// GenerateRiskOverlayDiagramImage is the built-in counterpart of GenerateRiskOverlayDiagramGraphvizImage
+func GenerateRiskOverlayDiagramImage(parsedModel *types.Model, targetDir string, riskOverlayDiagramFilenamePNG string, dpi int, stride string,
+	addModelTitle bool, progressReporter progressReporter) error {
+	progressReporter.Info("Rendering risk overlay diagram")
+	content, err := RenderRiskOverlayDiagram(parsedModel, "png", dpi, stride, addModelTitle)
+	if err != nil {
+		return fmt.Errorf("unable to render risk overlay diagram: %w", err)
+	}
+	return writeDiagramImage(filepath.Join(targetDir, riskOverlayDiagramFilenamePNG), content)
+}
+
+func newRiskOverlayDiagram(parsedModel *types.Model, overlay *riskOverlay, fonts *diagramFonts) *builtInDiagram {
+	return newTechAssetDiagram(parsedModel, nil, fonts,
+		func(technicalAsset *types.TechnicalAsset) *diagramNode {
+			risks := overlay.byTechnicalAsset[technicalAsset.Id]
+			fill, stroke, textColor := riskOverlayTechAssetColors(technicalAsset, risks)
+			return &diagramNode{
+				id:    technicalAsset.Id,
+				shape: techAssetDiagramShape(technicalAsset),
+				style: diagramStyle{fill: fill, stroke: stroke, width: 2},
+				lines: []diagramLine{
+					{text: technicalAsset.Title, style: diagramTextStyle{size: diagramFontSize, bold: true, color: textColor}},
+					{text: riskOverlayBadge(risks), style: diagramTextStyle{size: diagramSmallFontSize, color: textColor}},
+				},
+			}
+		},
+		func(dataFlow *types.CommunicationLink) *diagramEdge {
+			risks := overlay.byCommunicationLink[dataFlow.Id]
+			edge := &diagramEdge{style: diagramStyle{stroke: MiddleLightGray, width: 1}, hollow: dataFlow.Readonly, color: LightGray}
+			if color := riskOverlayColor(risks); len(color) > 0 {
+				edge.style, edge.color = diagramStyle{stroke: color, width: 3}, color
+			}
+			edge.label = riskOverlayEdgeLabel(parsedModel, dataFlow, risks)
+			return edge
+		})
+}
+
+func riskOverlayEdgeLabel(parsedModel *types.Model, dataFlow *types.CommunicationLink, risks []*types.Risk) string {
+	label := ""
+	if !parsedModel.DiagramTweakSuppressEdgeLabels {
+		label = dataFlow.Protocol.String()
+	}
+	if len(risks) > 0 {
+		if len(label) > 0 {
+			return label + " (" + riskOverlayBadge(risks) + ")"
+		}
+		return riskOverlayBadge(risks)
+	}
+	return label
+}
+
+// WriteRiskOverlayDiagramGraphvizDOT writes the technical assets and communication links colored by the highest
+// severity of their still at risk findings, where stride limits the risks to a single STRIDE category unless empty
+func WriteRiskOverlayDiagramGraphvizDOT(parsedModel *types.Model,
+	diagramFilenameDOT string, dpi int, stride string, addModelTitle bool,
+	progressReporter progressReporter) (*os.File, error) {
+	progressReporter.Info("Writing risk overlay diagram input")
+
+	overlay, err := newRiskOverlay(parsedModel, stride)
+	if err != nil {
+		return nil, err
+	}
+
+	rankdir := "TB"
+	if parsedModel.DiagramTweakLayoutLeftToRight {
+		rankdir = "LR"
+	}
+	var dotContent strings.Builder
+	dotContent.WriteString("digraph generatedModel { concentrate=false \n")
+	dotContent.WriteString(`	graph [ label="` + encode(overlay.title(parsedModel, addModelTitle)) + `"
+		labelloc=t
+		fontname="Verdana"
+		fontsize=40
+		outputorder="nodesfirst"
+		dpi=` + strconv.Itoa(dpi) + `
+		splines=polyline
+		rankdir="` + rankdir + `"
+	];
+	node [
+		fontname="Verdana"
+		fontsize="20"
+	];
+	edge [
+		shape="none"
+		fontname="Verdana"
+		fontsize="18"
+	];
+`)
+
+	// Trust Boundaries
+	var writeTrustBoundary func(trustBoundary *types.TrustBoundary)
+	writeTrustBoundary = func(trustBoundary *types.TrustBoundary) {
+		dotContent.WriteString("\n subgraph cluster_" + hash(trustBoundary.Id) + " {\n")
+		dotContent.WriteString(`	graph [
+      label=<<b>` + encode(trustBoundary.Title) + `</b> (` + trustBoundary.Type.String() + `)>
+      fontsize="21"
+      style="dashed"
+      color="` + rgbHexColorTwilight() + `"
+      fontcolor="` + rgbHexColorTwilight() + `"
+      fontname="Verdana"
+      penwidth="4.5"
+      margin="50.0"
+    ];
+`)
+		technicalAssetIds := append([]string{}, trustBoundary.TechnicalAssetsInside...)
+		sort.Strings(technicalAssetIds)
+		for _, technicalAssetId := range technicalAssetIds {
+			dotContent.WriteString("  " + hash(technicalAssetId) + ";\n")
+		}
+		nestedIds := append([]string{}, trustBoundary.TrustBoundariesNested...)
+		sort.Strings(nestedIds)
+		for _, nestedId := range nestedIds {
+			if nested, ok := parsedModel.TrustBoundaries[nestedId]; ok {
+				writeTrustBoundary(nested)
+			}
+		}
+		dotContent.WriteString(" }\n")
+	}
+	trustBoundaryIds := make([]string, 0, len(parsedModel.TrustBoundaries))
+	for id := range parsedModel.TrustBoundaries {
+		trustBoundaryIds = append(trustBoundaryIds, id)
+	}
+	sort.Strings(trustBoundaryIds)
+	for _, id := range trustBoundaryIds {
+		if trustBoundary := parsedModel.TrustBoundaries[id]; parsedModel.FindParentTrustBoundary(trustBoundary) == nil {
+			writeTrustBoundary(trustBoundary)
+		}
+	}
+
+	// Technical Assets
+	techAssets := make([]*types.TechnicalAsset, 0, len(parsedModel.TechnicalAssets))
+	for _, techAsset := range parsedModel.TechnicalAssets {
+		techAssets = append(techAssets, techAsset)
+	}
+	sort.Sort(types.ByOrderAndIdSort(techAssets))
+	for _, technicalAsset := range techAssets {
+		risks := overlay.byTechnicalAsset[technicalAsset.Id]
+		fill, stroke, textColor := riskOverlayTechAssetColors(technicalAsset, risks)
+		dotContent.WriteString("  " + hash(technicalAsset.Id) + ` [ shape="` + techAssetDiagramShape(technicalAsset).graphviz() + `" style="filled" penwidth="3.0"` +
+			` fillcolor="` + fill + `" color="` + stroke + `" fontcolor="` + textColor + `"` +
+			` label=<<b>` + encode(technicalAsset.Title) + `</b><br/><font point-size="15">` + riskOverlayBadge(risks) + `</font>> ];` + "\n")
+	}
+
+	// Data Flows (Technical Communication Links)
+	for _, technicalAsset := range techAssets {
+		for _, dataFlow := range technicalAsset.CommunicationLinks {
+			risks := overlay.byCommunicationLink[dataFlow.Id]
+			color, penWidth, fontColor := MiddleLightGray, "1.0", LightGray
+			if riskColor := riskOverlayColor(risks); len(riskColor) > 0 {
+				color, penWidth, fontColor = riskColor, "3.0", riskColor
+			}
+			arrowHead := "normal"
+			if dataFlow.Readonly {
+				arrowHead = "empty"
+			}
+			dotContent.WriteString("  " + hash(technicalAsset.Id) + " -> " + hash(dataFlow.TargetId) +
+				` [ color="` + color + `" penwidth="` + penWidth + `" arrowhead="` + arrowHead + `" arrowsize="2.0"`)
+			if label := riskOverlayEdgeLabel(parsedModel, dataFlow, risks); len(label) > 0 {
+				dotContent.WriteString(` xlabel="` + encode(label) + `" fontcolor="` + fontColor + `"`)
+			}
+			dotContent.WriteString(" ];\n")
+		}
+	}
+	dotContent.WriteString("}")
+
+	// Write the DOT file
+	file, err := os.Create(filepath.Clean(diagramFilenameDOT))
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s: %w", diagramFilenameDOT, err)
+	}
+	defer func() { _ = file.Close() }()
+	_, err = fmt.Fprintln(file, dotContent.String())
+	if err != nil {
+		return nil, fmt.Errorf("error writing %s: %w", diagramFilenameDOT, err)
+	}
+	return file, nil
+}
+
+func GenerateRiskOverlayDiagramGraphvizImage(dotFile *os.File, targetDir string, riskOverlayDiagramFilenamePNG string,
+	progressReporter progressReporter) error {
+	progressReporter.Info("Rendering risk overlay diagram input")
+	filename := filepath.Join(targetDir, riskOverlayDiagramFilenamePNG)
+	cmd := exec.Command("dot", "-Tpng", dotFile.Name(), "-o", filename) // #nosec G204
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	if err != nil {
+		return fmt.Errorf("graph rendering call failed with error: %w", err)
+	}
+	return nil
+}
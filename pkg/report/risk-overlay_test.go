@@ -0,0 +1,83 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+func riskOverlayTestModel() *types.Model {
+	parsedModel := diagramExportTestModel()
+	parsedModel.BuiltInRiskCategories = []*types.RiskCategory{
+		{ID: "sql-injection", STRIDE: types.Tampering},
+		{ID: "unencrypted-communication", STRIDE: types.InformationDisclosure},
+	}
+	parsedModel.GeneratedRisksByCategory = map[string][]*types.Risk{
+		"sql-injection": {
+			{CategoryId: "sql-injection", SyntheticId: "sql-injection@web-server>store", Severity: types.HighSeverity, MostRelevantTechnicalAssetId: "database", MostRelevantCommunicationLinkId: "web-server>store"},
+			{CategoryId: "sql-injection", SyntheticId: "sql-injection@database", Severity: types.CriticalSeverity, MostRelevantTechnicalAssetId: "database"},
+		},
+		"unencrypted-communication": {
+			{CategoryId: "unencrypted-communication", Severity: types.MediumSeverity, MostRelevantTechnicalAssetId: "database"},
+			{CategoryId: "unencrypted-communication", Severity: types.MediumSeverity, MostRelevantTechnicalAssetId: "web-server"},
+			{CategoryId: "unencrypted-communication", Severity: types.LowSeverity, MostRelevantTechnicalAssetId: "web-server"},
+		},
+	}
+	// mitigated through the risk tracking only, the status of the risk itself is refreshed by other outputs
+	parsedModel.RiskTracking = map[string]*types.RiskTracking{
+		"sql-injection@database": {SyntheticRiskId: "sql-injection@database", Status: types.Mitigated},
+	}
+	return parsedModel
+}
+
+func TestRiskOverlayCountsOnlyUnmitigatedRisks(t *testing.T) {
+	overlay, err := newRiskOverlay(riskOverlayTestModel(), "")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1 high, 1 medium", riskOverlayBadge(overlay.byTechnicalAsset["database"]))
+	assert.Equal(t, "1 medium, 1 low", riskOverlayBadge(overlay.byTechnicalAsset["web-server"]))
+	assert.Equal(t, "no open risks", riskOverlayBadge(overlay.byTechnicalAsset["browser"]))
+	assert.Equal(t, "1 high", riskOverlayBadge(overlay.byCommunicationLink["web-server>store"]))
+
+	assert.Equal(t, rgbHexColorHighRisk(), riskOverlayColor(overlay.byTechnicalAsset["database"]))
+	assert.Equal(t, rgbHexColorMediumRisk(), riskOverlayColor(overlay.byTechnicalAsset["web-server"]))
+	assert.Empty(t, riskOverlayColor(overlay.byTechnicalAsset["browser"]))
+}
+
+func TestRiskOverlayFiltersBySTRIDE(t *testing.T) {
+	overlay, err := newRiskOverlay(riskOverlayTestModel(), "information-disclosure")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1 medium", riskOverlayBadge(overlay.byTechnicalAsset["database"]))
+	assert.Empty(t, overlay.byCommunicationLink)
+	assert.Equal(t, "Shop: "+types.InformationDisclosure.Title(), overlay.title(riskOverlayTestModel(), true))
+
+	_, err = newRiskOverlay(riskOverlayTestModel(), "phishing")
+	assert.Error(t, err)
+}
+
+func TestRenderRiskOverlayDiagramColorsAndBadges(t *testing.T) {
+	svg, err := RenderRiskOverlayDiagram(riskOverlayTestModel(), "svg", 100, "", false)
+	assert.NoError(t, err)
+
+	content := string(svg)
+	assert.Contains(t, content, `<g class="node"><title>`+hash("database")+"</title>")
+	assert.Contains(t, content, `fill="`+rgbHexColorHighRisk()+`"`)
+	assert.Contains(t, content, ">1 high, 1 medium</text>")
+	assert.Contains(t, content, ">jdbc (1 high)</text>")
+	assert.Contains(t, content, ">no open risks</text>")
+	assert.Contains(t, content, `fill="`+rgbHexColorOutOfScope()+`"`)
+
+	filename := filepath.Join(t.TempDir(), "risk-overlay-diagram.gv")
+	_, err = WriteRiskOverlayDiagramGraphvizDOT(riskOverlayTestModel(), filename, 100, "tampering", true, &mockProgressReporter{})
+	assert.NoError(t, err)
+	dot, err := os.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.Contains(t, string(dot), `label="Shop: `+types.Tampering.Title()+`"`)
+	assert.Contains(t, string(dot), `<font point-size="15">1 high</font>`)
+	assert.Contains(t, string(dot), `xlabel="jdbc (1 high)"`)
+	assert.Contains(t, string(dot), `<font point-size="15">no open risks</font>`)
+}
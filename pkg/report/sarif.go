@@ -0,0 +1,464 @@
+package report
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/threagile/threagile/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	sarifFingerprintKey = "threagileSyntheticId/v1"
+	sarifSourceRootId   = "%SRCROOT%"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                        `json:"tool"`
+	OriginalUriBaseIds map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Artifacts          []sarifArtifact                  `json:"artifacts,omitempty"`
+	Results            []sarifResult                    `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationUri string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id                   string              `json:"id"`
+	Name                 string              `json:"name,omitempty"`
+	ShortDescription     sarifMessage        `json:"shortDescription"`
+	FullDescription      *sarifMessage       `json:"fullDescription,omitempty"`
+	Help                 *sarifMultiMessage  `json:"help,omitempty"`
+	HelpUri              string              `json:"helpUri,omitempty"`
+	DefaultConfiguration *sarifConfiguration `json:"defaultConfiguration,omitempty"`
+	Properties           *sarifProperties    `json:"properties,omitempty"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifMultiMessage struct {
+	Text     string `json:"text"`
+	Markdown string `json:"markdown,omitempty"`
+}
+
+type sarifProperties struct {
+	Tags             []string `json:"tags,omitempty"`
+	CWE              string   `json:"cwe,omitempty"`
+	ASVS             string   `json:"asvs,omitempty"`
+	CheatSheet       string   `json:"cheat_sheet,omitempty"`
+	SecuritySeverity string   `json:"security-severity,omitempty"`
+}
+
+type sarifResult struct {
+	RuleId              string             `json:"ruleId"`
+	RuleIndex           int                `json:"ruleIndex"`
+	Level               string             `json:"level"`
+	Message             sarifMessage       `json:"message"`
+	Locations           []sarifLocation    `json:"locations,omitempty"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints,omitempty"`
+	Suppressions        []sarifSuppression `json:"suppressions,omitempty"`
+	Properties          map[string]any     `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifact struct {
+	Location sarifArtifactLocation `json:"location"`
+}
+
+type sarifArtifactLocation struct {
+	Uri       string `json:"uri"`
+	UriBaseId string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Status        string `json:"status,omitempty"`
+	Justification string `json:"justification,omitempty"`
+}
+
+func createSarifLog(parsedModel *types.Model, modelFilename string, sourceRoot string, threagileVersion string) *sarifLog {
+	lines := readModelLineIndex(modelFilename)
+	modelLocation := sarifModelLocation(modelFilename, sourceRoot)
+
+	risksByCategory := parsedModel.GeneratedRisksByCategoryWithCurrentStatus()
+	rules := make([]sarifRule, 0)
+	results := make([]sarifResult, 0)
+	for _, category := range parsedModel.SortedRiskCategories() {
+		risks := risksByCategory[category.ID]
+		types.SortByRiskSeverity(risks)
+
+		ruleIndex := len(rules)
+		rules = append(rules, createSarifRule(category, types.HighestSeverityStillAtRisk(risks)))
+		for _, risk := range risks {
+			results = append(results, createSarifResult(parsedModel, category, ruleIndex, risk, modelLocation, lines))
+		}
+	}
+
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "Threagile",
+			Version:        threagileVersion,
+			InformationUri: "https://threagile.io",
+			Rules:          rules,
+		}},
+		Results: results,
+	}
+
+	if modelLocation != nil {
+		run.Artifacts = []sarifArtifact{{Location: *modelLocation}}
+		if len(modelLocation.UriBaseId) > 0 {
+			run.OriginalUriBaseIds = map[string]sarifArtifactLocation{sarifSourceRootId: {Uri: sarifFileUri(sourceRoot, true)}}
+		}
+	}
+
+	return &sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{run},
+	}
+}
+
+func createSarifRule(category *types.RiskCategory, highestSeverity types.RiskSeverity) sarifRule {
+	rule := sarifRule{
+		Id:                   category.ID,
+		Name:                 category.Title,
+		ShortDescription:     sarifMessage{Text: category.Title},
+		DefaultConfiguration: &sarifConfiguration{Level: sarifLevel(highestSeverity)},
+		Properties: &sarifProperties{
+			Tags:             []string{"security", "threat-model", category.STRIDE.String(), category.Function.String()},
+			ASVS:             category.ASVS,
+			CheatSheet:       category.CheatSheet,
+			SecuritySeverity: sarifSecuritySeverity(highestSeverity),
+		},
+	}
+
+	if len(category.Description) > 0 {
+		rule.FullDescription = &sarifMessage{Text: removeFormattingTags(category.Description)}
+	}
+
+	if category.CWE > 0 {
+		rule.Properties.CWE = fmt.Sprintf("CWE-%d", category.CWE)
+		rule.Properties.Tags = append(rule.Properties.Tags, fmt.Sprintf("external/cwe/cwe-%d", category.CWE))
+	}
+
+	if strings.HasPrefix(category.CheatSheet, "http") {
+		rule.HelpUri = category.CheatSheet
+	}
+
+	var text, markdown strings.Builder
+	if len(category.Mitigation) > 0 {
+		text.WriteString("Mitigation: " + removeFormattingTags(category.Mitigation) + "\n")
+		markdown.WriteString("**Mitigation:** " + removeFormattingTags(category.Mitigation) + "\n\n")
+	}
+	if category.CWE > 0 {
+		text.WriteString(fmt.Sprintf("CWE: CWE-%d\n", category.CWE))
+		markdown.WriteString(fmt.Sprintf("**CWE:** [CWE-%d](https://cwe.mitre.org/data/definitions/%d.html)\n\n", category.CWE, category.CWE))
+	}
+	if len(category.ASVS) > 0 {
+		text.WriteString("ASVS: " + category.ASVS + "\n")
+		markdown.WriteString("**ASVS:** " + category.ASVS + "\n\n")
+	}
+	if len(category.CheatSheet) > 0 {
+		text.WriteString("Cheat Sheet: " + category.CheatSheet + "\n")
+		markdown.WriteString("**Cheat Sheet:** " + category.CheatSheet + "\n\n")
+	}
+	if text.Len() > 0 {
+		rule.Help = &sarifMultiMessage{Text: strings.TrimSpace(text.String()), Markdown: strings.TrimSpace(markdown.String())}
+	}
+
+	return rule
+}
+
+// sarifModelLocation locates the model yaml file relative to the source root (the working directory), so code-scanning
+// dashboards can map it to the file in the repository; a model outside the source root keeps its absolute location
+func sarifModelLocation(modelFilename string, sourceRoot string) *sarifArtifactLocation {
+	if len(modelFilename) == 0 {
+		return nil
+	}
+
+	absoluteFilename, absError := filepath.Abs(modelFilename)
+	if absError != nil {
+		return &sarifArtifactLocation{Uri: filepath.ToSlash(modelFilename)}
+	}
+
+	if len(sourceRoot) > 0 {
+		relativeFilename, relError := filepath.Rel(sourceRoot, absoluteFilename)
+		if relError == nil && relativeFilename != ".." && !strings.HasPrefix(relativeFilename, ".."+string(filepath.Separator)) {
+			return &sarifArtifactLocation{Uri: (&url.URL{Path: filepath.ToSlash(relativeFilename)}).EscapedPath(), UriBaseId: sarifSourceRootId}
+		}
+	}
+
+	return &sarifArtifactLocation{Uri: sarifFileUri(absoluteFilename, false)}
+}
+
+// sarifFileUri returns the file URI of an absolute path, folders end with a slash as required for base URIs
+func sarifFileUri(path string, folder bool) string {
+	uriPath := filepath.ToSlash(path)
+	if !strings.HasPrefix(uriPath, "/") {
+		uriPath = "/" + uriPath
+	}
+	if folder && !strings.HasSuffix(uriPath, "/") {
+		uriPath += "/"
+	}
+	return (&url.URL{Scheme: "file", Path: uriPath}).String()
+}
+
+func createSarifResult(parsedModel *types.Model, category *types.RiskCategory, ruleIndex int, risk *types.Risk, modelLocation *sarifArtifactLocation, lines modelLineIndex) sarifResult {
+	result := sarifResult{
+		RuleId:              category.ID,
+		RuleIndex:           ruleIndex,
+		Level:               sarifLevel(risk.Severity),
+		Message:             sarifMessage{Text: removeFormattingTags(risk.Title)},
+		PartialFingerprints: map[string]string{sarifFingerprintKey: risk.SyntheticId},
+		Properties: map[string]any{
+			"synthetic_id":            risk.SyntheticId,
+			"severity":                risk.Severity.String(),
+			"exploitation_likelihood": risk.ExploitationLikelihood.String(),
+			"exploitation_impact":     risk.ExploitationImpact.String(),
+			"data_breach_probability": risk.DataBreachProbability.String(),
+			"risk_status":             risk.RiskStatus.String(),
+		},
+	}
+
+	location := sarifLocation{}
+	if modelLocation != nil {
+		location.PhysicalLocation = &sarifPhysicalLocation{ArtifactLocation: *modelLocation}
+		line := lines.lineOf(parsedModel, risk)
+		if line > 0 {
+			location.PhysicalLocation.Region = &sarifRegion{StartLine: line}
+		}
+	}
+	location.LogicalLocations = sarifLogicalLocations(parsedModel, risk)
+	if location.PhysicalLocation != nil || len(location.LogicalLocations) > 0 {
+		result.Locations = []sarifLocation{location}
+	}
+
+	tracking := parsedModel.GetRiskTracking(risk)
+	if tracking != nil {
+		suppression := sarifSuppressionOf(tracking)
+		if suppression != nil {
+			result.Suppressions = []sarifSuppression{*suppression}
+		}
+	}
+
+	return result
+}
+
+func sarifLogicalLocations(parsedModel *types.Model, risk *types.Risk) []sarifLogicalLocation {
+	result := make([]sarifLogicalLocation, 0)
+	if commLink, ok := parsedModel.CommunicationLinks[risk.MostRelevantCommunicationLinkId]; ok {
+		result = append(result, sarifLogicalLocation{Name: commLink.Title, FullyQualifiedName: commLink.Id, Kind: "communication_link"})
+	}
+	if techAsset, ok := parsedModel.TechnicalAssets[risk.MostRelevantTechnicalAssetId]; ok {
+		result = append(result, sarifLogicalLocation{Name: techAsset.Title, FullyQualifiedName: techAsset.Id, Kind: "technical_asset"})
+	}
+	if trustBoundary, ok := parsedModel.TrustBoundaries[risk.MostRelevantTrustBoundaryId]; ok {
+		result = append(result, sarifLogicalLocation{Name: trustBoundary.Title, FullyQualifiedName: trustBoundary.Id, Kind: "trust_boundary"})
+	}
+	if sharedRuntime, ok := parsedModel.SharedRuntimes[risk.MostRelevantSharedRuntimeId]; ok {
+		result = append(result, sarifLogicalLocation{Name: sharedRuntime.Title, FullyQualifiedName: sharedRuntime.Id, Kind: "shared_runtime"})
+	}
+	if dataAsset, ok := parsedModel.DataAssets[risk.MostRelevantDataAssetId]; ok {
+		result = append(result, sarifLogicalLocation{Name: dataAsset.Title, FullyQualifiedName: dataAsset.Id, Kind: "data_asset"})
+	}
+	return result
+}
+
+// sarifSuppressionOf maps the risk tracking status: unchecked and in-discussion risks are not suppressed,
+// in-progress risks are under review, all other states count as accepted suppressions
+func sarifSuppressionOf(tracking *types.RiskTracking) *sarifSuppression {
+	switch tracking.Status {
+	case types.Unchecked, types.InDiscussion:
+		return nil
+
+	case types.InProgress:
+		return &sarifSuppression{Kind: "external", Status: "underReview", Justification: tracking.Justification}
+
+	default:
+		justification := tracking.Status.Title()
+		if len(tracking.Justification) > 0 {
+			justification += ": " + tracking.Justification
+		}
+		return &sarifSuppression{Kind: "external", Status: "accepted", Justification: justification}
+	}
+}
+
+func sarifLevel(severity types.RiskSeverity) string {
+	switch severity {
+	case types.CriticalSeverity, types.HighSeverity:
+		return "error"
+
+	case types.ElevatedSeverity, types.MediumSeverity:
+		return "warning"
+
+	default:
+		return "note"
+	}
+}
+
+// sarifSecuritySeverity maps the risk severity to the numeric scale used by code-scanning dashboards
+func sarifSecuritySeverity(severity types.RiskSeverity) string {
+	return [...]string{"2.0", "4.0", "6.0", "8.0", "9.5"}[severity]
+}
+
+// modelLineIndex maps model elements to the line of their definition in the model yaml file
+type modelLineIndex struct {
+	technicalAssets    map[string]int
+	communicationLinks map[string]int
+	dataAssets         map[string]int
+	trustBoundaries    map[string]int
+	sharedRuntimes     map[string]int
+}
+
+func readModelLineIndex(modelFilename string) modelLineIndex {
+	index := modelLineIndex{
+		technicalAssets:    make(map[string]int),
+		communicationLinks: make(map[string]int),
+		dataAssets:         make(map[string]int),
+		trustBoundaries:    make(map[string]int),
+		sharedRuntimes:     make(map[string]int),
+	}
+
+	if len(modelFilename) == 0 {
+		return index
+	}
+
+	data, readError := os.ReadFile(filepath.Clean(modelFilename))
+	if readError != nil {
+		return index
+	}
+
+	var document yaml.Node
+	if yaml.Unmarshal(data, &document) != nil || len(document.Content) == 0 {
+		return index
+	}
+
+	root := document.Content[0]
+	forEachMappingEntry(yamlMappingValue(root, "technical_assets"), func(key *yaml.Node, value *yaml.Node) {
+		id := yamlScalarValue(value, "id")
+		index.technicalAssets[id] = key.Line
+		forEachMappingEntry(yamlMappingValue(value, "communication_links"), func(linkKey *yaml.Node, _ *yaml.Node) {
+			index.communicationLinks[id+">"+linkKey.Value] = linkKey.Line
+		})
+	})
+	forEachMappingEntry(yamlMappingValue(root, "data_assets"), func(key *yaml.Node, value *yaml.Node) {
+		index.dataAssets[yamlScalarValue(value, "id")] = key.Line
+	})
+	forEachMappingEntry(yamlMappingValue(root, "trust_boundaries"), func(key *yaml.Node, value *yaml.Node) {
+		index.trustBoundaries[yamlScalarValue(value, "id")] = key.Line
+	})
+	forEachMappingEntry(yamlMappingValue(root, "shared_runtimes"), func(key *yaml.Node, value *yaml.Node) {
+		index.sharedRuntimes[yamlScalarValue(value, "id")] = key.Line
+	})
+
+	return index
+}
+
+// lineOf returns the line of the most relevant model element of a risk, or 0 if unknown
+func (what modelLineIndex) lineOf(parsedModel *types.Model, risk *types.Risk) int {
+	if commLink, ok := parsedModel.CommunicationLinks[risk.MostRelevantCommunicationLinkId]; ok {
+		if line, found := what.communicationLinks[commLink.SourceId+">"+commLink.Title]; found {
+			return line
+		}
+	}
+
+	candidates := []struct {
+		lines map[string]int
+		id    string
+	}{
+		{lines: what.technicalAssets, id: risk.MostRelevantTechnicalAssetId},
+		{lines: what.trustBoundaries, id: risk.MostRelevantTrustBoundaryId},
+		{lines: what.sharedRuntimes, id: risk.MostRelevantSharedRuntimeId},
+		{lines: what.dataAssets, id: risk.MostRelevantDataAssetId},
+	}
+
+	for _, candidate := range candidates {
+		if len(candidate.id) == 0 {
+			continue
+		}
+
+		if line, found := candidate.lines[candidate.id]; found {
+			return line
+		}
+	}
+
+	return 0
+}
+
+func yamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for n := 0; n+1 < len(node.Content); n += 2 {
+		if node.Content[n].Value == key {
+			return node.Content[n+1]
+		}
+	}
+
+	return nil
+}
+
+func yamlScalarValue(node *yaml.Node, key string) string {
+	value := yamlMappingValue(node, key)
+	if value == nil || value.Kind != yaml.ScalarNode {
+		return ""
+	}
+
+	return value.Value
+}
+
+func forEachMappingEntry(node *yaml.Node, handler func(key *yaml.Node, value *yaml.Node)) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+
+	for n := 0; n+1 < len(node.Content); n += 2 {
+		handler(node.Content[n], node.Content[n+1])
+	}
+}
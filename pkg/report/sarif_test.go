@@ -0,0 +1,89 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+const sarifTestModel = `title: SARIF Test
+technical_assets:
+  Web Server:
+    id: web-server
+    communication_links:
+      Database Traffic:
+        target: database
+  Database:
+    id: database
+data_assets:
+  Customer Data:
+    id: customer-data
+`
+
+func TestCreateSarifLogLocatesRisksAndSuppressesTrackedRisks(t *testing.T) {
+	sourceRoot := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(sourceRoot, "model"), 0700))
+	modelFilename := filepath.Join(sourceRoot, "model", "threagile.yaml")
+	assert.NoError(t, os.WriteFile(modelFilename, []byte(sarifTestModel), 0600))
+
+	linkRisk := &types.Risk{CategoryId: "test-category", SyntheticId: "test-category@web-server>database-traffic", Severity: types.HighSeverity,
+		MostRelevantTechnicalAssetId: "web-server", MostRelevantCommunicationLinkId: "web-server>database-traffic"}
+	assetRisk := &types.Risk{CategoryId: "test-category", SyntheticId: "test-category@database", Severity: types.LowSeverity,
+		MostRelevantTechnicalAssetId: "database"}
+
+	parsedModel := &types.Model{
+		TechnicalAssets: map[string]*types.TechnicalAsset{
+			"web-server": {Id: "web-server", Title: "Web Server"},
+			"database":   {Id: "database", Title: "Database"},
+		},
+		CommunicationLinks: map[string]*types.CommunicationLink{
+			"web-server>database-traffic": {Id: "web-server>database-traffic", Title: "Database Traffic", SourceId: "web-server", TargetId: "database"},
+		},
+		BuiltInRiskCategories: types.RiskCategories{
+			{ID: "test-category", Title: "Test Category", CWE: 89, ASVS: "V5", CheatSheet: "https://cheatsheetseries.owasp.org/"},
+		},
+		RiskTracking: map[string]*types.RiskTracking{
+			"test-category@database": {SyntheticRiskId: "test-category@database", Status: types.FalsePositive, Justification: "not reachable"},
+		},
+		GeneratedRisksByCategory: map[string][]*types.Risk{"test-category": {assetRisk, linkRisk}},
+	}
+
+	log := createSarifLog(parsedModel, modelFilename, sourceRoot, "1.0.0")
+
+	assert.Equal(t, "2.1.0", log.Version)
+	assert.Equal(t, "file://"+filepath.ToSlash(sourceRoot)+"/", log.Runs[0].OriginalUriBaseIds["%SRCROOT%"].Uri)
+	assert.Equal(t, sarifArtifactLocation{Uri: "model/threagile.yaml", UriBaseId: "%SRCROOT%"}, log.Runs[0].Artifacts[0].Location)
+	rules := log.Runs[0].Tool.Driver.Rules
+	assert.Len(t, rules, 1)
+	assert.Equal(t, "CWE-89", rules[0].Properties.CWE)
+	assert.Contains(t, rules[0].Properties.Tags, "external/cwe/cwe-89")
+	assert.Equal(t, "https://cheatsheetseries.owasp.org/", rules[0].HelpUri)
+
+	results := log.Runs[0].Results
+	assert.Len(t, results, 2)
+	assert.Equal(t, "test-category@web-server>database-traffic", results[0].PartialFingerprints[sarifFingerprintKey])
+	assert.Equal(t, "error", results[0].Level)
+	assert.Equal(t, sarifArtifactLocation{Uri: "model/threagile.yaml", UriBaseId: "%SRCROOT%"}, results[0].Locations[0].PhysicalLocation.ArtifactLocation)
+	assert.Equal(t, 6, results[0].Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Empty(t, results[0].Suppressions)
+
+	assert.Equal(t, "note", results[1].Level)
+	assert.Equal(t, 8, results[1].Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Len(t, results[1].Suppressions, 1)
+	assert.Equal(t, "accepted", results[1].Suppressions[0].Status)
+	assert.Equal(t, "False Positive: not reachable", results[1].Suppressions[0].Justification)
+}
+
+func TestSarifModelLocationOutsideSourceRootIsAbsolute(t *testing.T) {
+	sourceRoot := t.TempDir()
+	modelFilename := filepath.Join(t.TempDir(), "threagile.yaml")
+
+	location := sarifModelLocation(modelFilename, filepath.Join(sourceRoot, "repository"))
+
+	assert.Equal(t, "file://"+filepath.ToSlash(modelFilename), location.Uri)
+	assert.Empty(t, location.UriBaseId)
+	assert.Nil(t, sarifModelLocation("", sourceRoot))
+}
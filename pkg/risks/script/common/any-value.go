@@ -62,6 +62,32 @@ func NilValue() Value {
 	return &AnyValue{}
 }
 
+// IsBlank tells whether a value is missing, nil, an empty text or an empty list
+func IsBlank(value Value) bool {
+	if value == nil {
+		return true
+	}
+
+	switch castValue := value.Value().(type) {
+	case nil:
+		return true
+
+	case string:
+		return len(castValue) == 0
+
+	case []Value:
+		return len(castValue) == 0
+
+	case []any:
+		return len(castValue) == 0
+
+	case Value:
+		return IsBlank(castValue)
+	}
+
+	return false
+}
+
 func SomeValue(anyValue any, event *Event) Value {
 	switch castValue := anyValue.(type) {
 	case string:
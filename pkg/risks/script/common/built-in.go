@@ -2,37 +2,109 @@ package common
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
 	"github.com/shopspring/decimal"
 	"github.com/threagile/threagile/pkg/types"
 )
 
 const (
 	calculateSeverity = "calculate_severity"
+
+	lowerText   = "lower"
+	upperText   = "upper"
+	containsAny = "contains"
+	matchText   = "match"
+	joinItems   = "join"
+	splitText   = "split"
+	formatText  = "format"
+
+	uniqueItems       = "unique"
+	sortItems         = "sort"
+	filterItems       = "filter"
+	mapItems          = "map"
+	lengthOf          = "length"
+	intersectionItems = "intersection"
+
+	highestConfidentiality           = "highest_confidentiality"
+	highestIntegrity                 = "highest_integrity"
+	highestAvailability              = "highest_availability"
+	incomingLinks                    = "incoming_links"
+	outgoingLinks                    = "outgoing_links"
+	trustBoundaryOf                  = "trust_boundary"
+	isAcrossTrustBoundaryNetworkOnly = "is_across_trust_boundary_network_only"
+
+	reachableAssets        = "reachable"
+	reachableFromInternet  = "reachable_from_internet"
+	callingAssets          = "callers"
+	crossedTrustBoundaries = "crossed_trust_boundaries"
 )
 
 var (
+	callers map[string]builtInFunc
+)
+
+// callers is set up in init, since filter and map call built-ins themselves
+func init() {
 	callers = map[string]builtInFunc{
 		calculateSeverity: calculateSeverityFunc,
+
+		lowerText:   lowerFunc,
+		upperText:   upperFunc,
+		containsAny: containsFunc,
+		matchText:   matchFunc,
+		joinItems:   joinFunc,
+		splitText:   splitFunc,
+		formatText:  formatFunc,
+
+		uniqueItems:       uniqueFunc,
+		sortItems:         sortFunc,
+		filterItems:       filterFunc,
+		mapItems:          mapFunc,
+		lengthOf:          lengthFunc,
+		intersectionItems: intersectionFunc,
+
+		highestConfidentiality:           highestConfidentialityFunc,
+		highestIntegrity:                 highestIntegrityFunc,
+		highestAvailability:              highestAvailabilityFunc,
+		incomingLinks:                    incomingLinksFunc,
+		outgoingLinks:                    outgoingLinksFunc,
+		trustBoundaryOf:                  trustBoundaryFunc,
+		isAcrossTrustBoundaryNetworkOnly: isAcrossTrustBoundaryNetworkOnlyFunc,
+
+		reachableAssets:        reachableFunc,
+		reachableFromInternet:  reachableFromInternetFunc,
+		callingAssets:          callersFunc,
+		crossedTrustBoundaries: crossedTrustBoundariesFunc,
 	}
-)
+}
 
-type builtInFunc func(parameters []Value) (Value, error)
+type builtInFunc func(scope *Scope, parameters []Value) (Value, error)
 
 func IsBuiltIn(builtInName string) bool {
 	_, ok := callers[builtInName]
 	return ok
 }
 
-func CallBuiltIn(builtInName string, parameters ...Value) (Value, error) {
+func CallBuiltIn(scope *Scope, builtInName string, parameters ...Value) (Value, error) {
 	caller, ok := callers[builtInName]
 	if !ok {
 		return nil, fmt.Errorf("unknown built-in %v", builtInName)
 	}
 
-	return caller(parameters)
+	if scope == nil {
+		scope = new(Scope)
+	}
+
+	return caller(scope, parameters)
 }
 
-func calculateSeverityFunc(parameters []Value) (Value, error) {
+func calculateSeverityFunc(scope *Scope, parameters []Value) (Value, error) {
 	if len(parameters) != 2 {
 		return nil, fmt.Errorf("failed to calculate severity: expected 2 parameters, got %d", len(parameters))
 	}
@@ -49,5 +121,743 @@ func calculateSeverityFunc(parameters []Value) (Value, error) {
 	}
 	impactDecimal := impactValue.Value().(decimal.Decimal).IntPart()
 
-	return SomeStringValue(types.CalculateSeverity(types.RiskExploitationLikelihood(likelihoodDecimal), types.RiskExploitationImpact(impactDecimal)).String(), nil), nil
+	categoryId := ""
+	if scope.Category != nil {
+		categoryId = scope.Category.ID
+	}
+
+	severity := scope.SeverityMatrix.Severity(categoryId, types.RiskExploitationLikelihood(likelihoodDecimal), types.RiskExploitationImpact(impactDecimal))
+	return SomeStringValue(severity.String(), nil), nil
+}
+
+// string built-ins
+
+func lowerFunc(_ *Scope, parameters []Value) (Value, error) {
+	text, textError := textParameter(parameters, 1, 1)
+	if textError != nil {
+		return nil, textError
+	}
+
+	return SomeStringValue(strings.ToLower(text), parameters[0].Event()), nil
+}
+
+func upperFunc(_ *Scope, parameters []Value) (Value, error) {
+	text, textError := textParameter(parameters, 1, 1)
+	if textError != nil {
+		return nil, textError
+	}
+
+	return SomeStringValue(strings.ToUpper(text), parameters[0].Event()), nil
+}
+
+// containsFunc tells whether a list contains an item or a text contains another text
+func containsFunc(_ *Scope, parameters []Value) (Value, error) {
+	if len(parameters) != 2 {
+		return nil, fmt.Errorf("expected 2 parameters, got %d", len(parameters))
+	}
+
+	if text, ok := plainValue(parameters[0]).(string); ok {
+		part, partError := toText(parameters[1])
+		if partError != nil {
+			return nil, partError
+		}
+
+		return SomeBoolValue(strings.Contains(text, part), parameters[0].Event()), nil
+	}
+
+	items, itemsError := toItems(parameters[0])
+	if itemsError != nil {
+		return nil, itemsError
+	}
+
+	return SomeBoolValue(indexOf(items, parameters[1]) >= 0, parameters[0].Event()), nil
+}
+
+func matchFunc(_ *Scope, parameters []Value) (Value, error) {
+	if len(parameters) != 2 {
+		return nil, fmt.Errorf("expected 2 parameters, got %d", len(parameters))
+	}
+
+	text, textError := toText(parameters[0])
+	if textError != nil {
+		return nil, textError
+	}
+
+	pattern, patternError := toText(parameters[1])
+	if patternError != nil {
+		return nil, patternError
+	}
+
+	matched, matchError := regexp.MatchString(pattern, text)
+	if matchError != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, matchError)
+	}
+
+	return SomeBoolValue(matched, parameters[0].Event()), nil
+}
+
+// joinFunc joins the items of a list with a separator, ", " by default
+func joinFunc(_ *Scope, parameters []Value) (Value, error) {
+	if len(parameters) < 1 || len(parameters) > 2 {
+		return nil, fmt.Errorf("expected 1 or 2 parameters, got %d", len(parameters))
+	}
+
+	items, itemsError := toItems(parameters[0])
+	if itemsError != nil {
+		return nil, itemsError
+	}
+
+	separator := ", "
+	if len(parameters) > 1 {
+		var separatorError error
+		separator, separatorError = toText(parameters[1])
+		if separatorError != nil {
+			return nil, separatorError
+		}
+	}
+
+	texts := make([]string, 0, len(items))
+	for _, item := range items {
+		text, textError := toText(item)
+		if textError != nil {
+			return nil, textError
+		}
+
+		texts = append(texts, text)
+	}
+
+	return SomeStringValue(strings.Join(texts, separator), parameters[0].Event()), nil
+}
+
+// splitFunc splits a text at a separator, "," by default, into a list of trimmed texts
+func splitFunc(_ *Scope, parameters []Value) (Value, error) {
+	text, textError := textParameter(parameters, 1, 2)
+	if textError != nil {
+		return nil, textError
+	}
+
+	separator := ","
+	if len(parameters) > 1 {
+		var separatorError error
+		separator, separatorError = toText(parameters[1])
+		if separatorError != nil {
+			return nil, separatorError
+		}
+	}
+
+	items := make([]Value, 0)
+	if len(text) > 0 {
+		for _, part := range strings.Split(text, separator) {
+			items = append(items, SomeStringValue(strings.TrimSpace(part), nil))
+		}
+	}
+
+	return SomeArrayValue(items, parameters[0].Event()), nil
+}
+
+// formatFunc formats the parameters following the first one like fmt.Sprintf
+func formatFunc(_ *Scope, parameters []Value) (Value, error) {
+	format, formatError := textParameter(parameters, 1, -1)
+	if formatError != nil {
+		return nil, formatError
+	}
+
+	args := make([]any, 0, len(parameters)-1)
+	for _, parameter := range parameters[1:] {
+		args = append(args, plainValue(parameter))
+	}
+
+	return SomeStringValue(fmt.Sprintf(format, args...), parameters[0].Event()), nil
+}
+
+// list built-ins
+
+func uniqueFunc(_ *Scope, parameters []Value) (Value, error) {
+	items, itemsError := itemsParameter(parameters, 1)
+	if itemsError != nil {
+		return nil, itemsError
+	}
+
+	result := make([]Value, 0, len(items))
+	for _, item := range items {
+		if indexOf(result, item) < 0 {
+			result = append(result, item)
+		}
+	}
+
+	return SomeArrayValue(result, parameters[0].Event()), nil
+}
+
+// sortFunc sorts numbers numerically and everything else by its text
+func sortFunc(_ *Scope, parameters []Value) (Value, error) {
+	items, itemsError := itemsParameter(parameters, 1)
+	if itemsError != nil {
+		return nil, itemsError
+	}
+
+	result := append(make([]Value, 0, len(items)), items...)
+	sort.SliceStable(result, func(i, j int) bool {
+		return isLess(result[i], result[j])
+	})
+
+	return SomeArrayValue(result, parameters[0].Event()), nil
+}
+
+// filterFunc keeps the items of a list for which the method (of the `utils` section) or built-in named by the second
+// parameter returns true
+func filterFunc(scope *Scope, parameters []Value) (Value, error) {
+	items, itemsError := itemsParameter(parameters, 2)
+	if itemsError != nil {
+		return nil, itemsError
+	}
+
+	result := make([]Value, 0, len(items))
+	for _, item := range items {
+		keep, callError := callMethod(scope, parameters[1], item)
+		if callError != nil {
+			return nil, callError
+		}
+
+		if isTrue(keep) {
+			result = append(result, item)
+		}
+	}
+
+	return SomeArrayValue(result, parameters[0].Event()), nil
+}
+
+// mapFunc replaces the items of a list with what the method (of the `utils` section) or built-in named by the second
+// parameter returns for them
+func mapFunc(scope *Scope, parameters []Value) (Value, error) {
+	items, itemsError := itemsParameter(parameters, 2)
+	if itemsError != nil {
+		return nil, itemsError
+	}
+
+	result := make([]Value, 0, len(items))
+	for _, item := range items {
+		mapped, callError := callMethod(scope, parameters[1], item)
+		if callError != nil {
+			return nil, callError
+		}
+
+		if mapped == nil {
+			mapped = NilValue()
+		}
+
+		result = append(result, mapped)
+	}
+
+	return SomeArrayValue(result, parameters[0].Event()), nil
+}
+
+// lengthFunc counts the items of a list or a map or the characters of a text
+func lengthFunc(_ *Scope, parameters []Value) (Value, error) {
+	if len(parameters) != 1 {
+		return nil, fmt.Errorf("expected 1 parameter, got %d", len(parameters))
+	}
+
+	if text, ok := plainValue(parameters[0]).(string); ok {
+		return SomeDecimalValue(decimal.NewFromInt(int64(utf8.RuneCountInString(text))), parameters[0].Event()), nil
+	}
+
+	items, itemsError := toItems(parameters[0])
+	if itemsError != nil {
+		return nil, itemsError
+	}
+
+	return SomeDecimalValue(decimal.NewFromInt(int64(len(items))), parameters[0].Event()), nil
+}
+
+// intersectionFunc returns the distinct items of the first list that are in all other lists
+func intersectionFunc(_ *Scope, parameters []Value) (Value, error) {
+	if len(parameters) < 2 {
+		return nil, fmt.Errorf("expected at least 2 parameters, got %d", len(parameters))
+	}
+
+	lists := make([][]Value, 0, len(parameters))
+	for _, parameter := range parameters {
+		items, itemsError := toItems(parameter)
+		if itemsError != nil {
+			return nil, itemsError
+		}
+
+		lists = append(lists, items)
+	}
+
+	result := make([]Value, 0)
+	for _, item := range lists[0] {
+		if indexOf(result, item) >= 0 {
+			continue
+		}
+
+		inAll := true
+		for _, list := range lists[1:] {
+			if indexOf(list, item) < 0 {
+				inAll = false
+				break
+			}
+		}
+
+		if inAll {
+			result = append(result, item)
+		}
+	}
+
+	return SomeArrayValue(result, parameters[0].Event()), nil
+}
+
+// model built-ins, they take technical assets and communication links either as value or by id
+
+// highestConfidentialityFunc returns the highest confidentiality of a technical asset and the data assets it
+// processes or stores
+func highestConfidentialityFunc(scope *Scope, parameters []Value) (Value, error) {
+	return highestRating(scope, parameters, confidentiality, func(text string) (int, error) {
+		value, findError := types.Confidentiality(0).Find(text)
+		return int(value), findError
+	}, func(value int) string {
+		return types.Confidentiality(value).String()
+	})
+}
+
+// highestIntegrityFunc returns the highest integrity of a technical asset and the data assets it processes or stores
+func highestIntegrityFunc(scope *Scope, parameters []Value) (Value, error) {
+	return highestRating(scope, parameters, integrity, findCriticality, criticalityName)
+}
+
+// highestAvailabilityFunc returns the highest availability of a technical asset and the data assets it processes or
+// stores
+func highestAvailabilityFunc(scope *Scope, parameters []Value) (Value, error) {
+	return highestRating(scope, parameters, availability, findCriticality, criticalityName)
+}
+
+func incomingLinksFunc(scope *Scope, parameters []Value) (Value, error) {
+	asset, assetError := technicalAssetParameter(scope, parameters)
+	if assetError != nil {
+		return nil, assetError
+	}
+
+	links := viewMap(scope.Model, "incoming_technical_communication_links_mapped_by_target_id")
+	return toArrayValue(links[viewString(asset, "id")], parameters[0].Event()), nil
+}
+
+func outgoingLinksFunc(scope *Scope, parameters []Value) (Value, error) {
+	asset, assetError := technicalAssetParameter(scope, parameters)
+	if assetError != nil {
+		return nil, assetError
+	}
+
+	return toArrayValue(asset["communication_links"], parameters[0].Event()), nil
+}
+
+// trustBoundaryFunc returns the trust boundary directly containing a technical asset, nil if there is none
+func trustBoundaryFunc(scope *Scope, parameters []Value) (Value, error) {
+	asset, assetError := technicalAssetParameter(scope, parameters)
+	if assetError != nil {
+		return nil, assetError
+	}
+
+	trustBoundary := directTrustBoundary(scope, viewString(asset, "id"))
+	if trustBoundary == nil {
+		return NilValue(), nil
+	}
+
+	return SomeValue(trustBoundary, parameters[0].Event()), nil
+}
+
+// isAcrossTrustBoundaryNetworkOnlyFunc tells whether a communication link connects technical assets in different
+// network trust boundaries, like the helper of the built-in risk rules
+func isAcrossTrustBoundaryNetworkOnlyFunc(scope *Scope, parameters []Value) (Value, error) {
+	if len(parameters) != 1 {
+		return nil, fmt.Errorf("expected 1 parameter, got %d", len(parameters))
+	}
+
+	link, linkError := findModelItem(scope, parameters[0], "communication_links", "communication link")
+	if linkError != nil {
+		return nil, linkError
+	}
+
+	sourceBoundary := directTrustBoundary(scope, viewString(link, "source_id"))
+	if sourceBoundary == nil {
+		return SomeBoolValue(false, parameters[0].Event()), nil
+	}
+
+	if !isNetworkBoundary(sourceBoundary) && parentTrustBoundary(scope, sourceBoundary) != nil {
+		return SomeBoolValue(false, parameters[0].Event()), nil
+	}
+
+	targetBoundary := directTrustBoundary(scope, viewString(link, "target_id"))
+	if targetBoundary == nil {
+		return SomeBoolValue(false, parameters[0].Event()), nil
+	}
+
+	if !isNetworkBoundary(targetBoundary) && parentTrustBoundary(scope, targetBoundary) != nil {
+		return SomeBoolValue(false, parameters[0].Event()), nil
+	}
+
+	across := viewString(sourceBoundary, "id") != viewString(targetBoundary, "id") && isNetworkBoundary(targetBoundary)
+	return SomeBoolValue(across, parameters[0].Event()), nil
+}
+
+// graph built-ins, see graph.go
+
+// reachableFunc returns the technical assets reachable from a technical asset within the number of hops given as
+// second parameter, without limit if it is left out
+func reachableFunc(scope *Scope, parameters []Value) (Value, error) {
+	if len(parameters) < 1 || len(parameters) > 2 {
+		return nil, fmt.Errorf("expected 1 or 2 parameters, got %d", len(parameters))
+	}
+
+	hops := 0
+	if len(parameters) > 1 {
+		text, textError := toText(parameters[1])
+		if textError != nil {
+			return nil, textError
+		}
+
+		var parseError error
+		hops, parseError = strconv.Atoi(text)
+		if parseError != nil {
+			return nil, fmt.Errorf("expected a number of hops, got %q", text)
+		}
+	}
+
+	reachable, reachableError := ReachableTechnicalAssets(scope, parameters[0], hops)
+	if reachableError != nil {
+		return nil, reachableError
+	}
+
+	return SomeArrayValue(reachable, parameters[0].Event()), nil
+}
+
+func reachableFromInternetFunc(scope *Scope, parameters []Value) (Value, error) {
+	if len(parameters) != 1 {
+		return nil, fmt.Errorf("expected 1 parameter, got %d", len(parameters))
+	}
+
+	reachable, reachableError := IsReachableFromInternet(scope, parameters[0])
+	if reachableError != nil {
+		return nil, reachableError
+	}
+
+	return SomeBoolValue(reachable, parameters[0].Event()), nil
+}
+
+func callersFunc(scope *Scope, parameters []Value) (Value, error) {
+	if len(parameters) != 1 {
+		return nil, fmt.Errorf("expected 1 parameter, got %d", len(parameters))
+	}
+
+	callers, callersError := CallingTechnicalAssets(scope, parameters[0])
+	if callersError != nil {
+		return nil, callersError
+	}
+
+	return SomeArrayValue(callers, parameters[0].Event()), nil
+}
+
+func crossedTrustBoundariesFunc(scope *Scope, parameters []Value) (Value, error) {
+	if len(parameters) != 1 {
+		return nil, fmt.Errorf("expected 1 parameter, got %d", len(parameters))
+	}
+
+	crossed, crossedError := TrustBoundariesCrossedBy(scope, parameters[0])
+	if crossedError != nil {
+		return nil, crossedError
+	}
+
+	return SomeArrayValue(crossed, parameters[0].Event()), nil
+}
+
+func highestRating(scope *Scope, parameters []Value, field string, find func(text string) (int, error), name func(value int) string) (Value, error) {
+	asset, assetError := technicalAssetParameter(scope, parameters)
+	if assetError != nil {
+		return nil, assetError
+	}
+
+	highest, findError := rating(asset, field, find)
+	if findError != nil {
+		return nil, findError
+	}
+
+	dataAssets := viewMap(scope.Model, "data_assets")
+	for _, dataIds := range []string{"data_assets_processed", "data_assets_stored"} {
+		for _, dataId := range viewList(asset, dataIds) {
+			dataAsset, _ := dataAssets[fmt.Sprintf("%v", dataId)].(map[string]any)
+			value, dataFindError := rating(dataAsset, field, find)
+			if dataFindError != nil {
+				return nil, dataFindError
+			}
+
+			if value > highest {
+				highest = value
+			}
+		}
+	}
+
+	return SomeStringValue(name(highest), parameters[0].Event()), nil
+}
+
+// rating finds the value of a field, fields left out of the model view have the lowest value
+func rating(item map[string]any, field string, find func(text string) (int, error)) (int, error) {
+	text := viewString(item, field)
+	if len(text) == 0 {
+		return 0, nil
+	}
+
+	return find(text)
+}
+
+func findCriticality(text string) (int, error) {
+	value, findError := types.Criticality(0).Find(text)
+	return int(value), findError
+}
+
+func criticalityName(value int) string {
+	return types.Criticality(value).String()
+}
+
+func technicalAssetParameter(scope *Scope, parameters []Value) (map[string]any, error) {
+	if len(parameters) != 1 {
+		return nil, fmt.Errorf("expected 1 parameter, got %d", len(parameters))
+	}
+
+	return findModelItem(scope, parameters[0], "technical_assets", "technical asset")
+}
+
+// findModelItem returns the value if it is a map already or looks it up by id in the given section of the model
+func findModelItem(scope *Scope, value Value, section string, kind string) (map[string]any, error) {
+	if item, ok := plainValue(value).(map[string]any); ok {
+		return item, nil
+	}
+
+	id, idError := toText(value)
+	if idError != nil {
+		return nil, fmt.Errorf("expected %v or its id: %w", kind, idError)
+	}
+
+	item, ok := viewMap(scope.Model, section)[id].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unknown %v %q", kind, id)
+	}
+
+	return item, nil
+}
+
+func directTrustBoundary(scope *Scope, technicalAssetId string) map[string]any {
+	trustBoundary, _ := viewMap(scope.Model, "direct_containing_trust_boundary_mapped_by_technical_asset_id")[technicalAssetId].(map[string]any)
+	return trustBoundary
+}
+
+func parentTrustBoundary(scope *Scope, trustBoundary map[string]any) map[string]any {
+	id := viewString(trustBoundary, "id")
+	for _, candidate := range viewMap(scope.Model, "trust_boundaries") {
+		candidateBoundary, ok := candidate.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for _, nested := range viewList(candidateBoundary, "trust_boundaries_nested") {
+			if fmt.Sprintf("%v", nested) == id {
+				return candidateBoundary
+			}
+		}
+	}
+
+	return nil
+}
+
+// isNetworkBoundary checks the type of trust boundary, the type is left out of the model view for network-on-prem
+func isNetworkBoundary(trustBoundary map[string]any) bool {
+	text := viewString(trustBoundary, "type")
+	if len(text) == 0 {
+		return types.TrustBoundaryType(0).IsNetworkBoundary()
+	}
+
+	trustBoundaryType, parseError := types.ParseTrustBoundary(text)
+	return parseError == nil && trustBoundaryType.IsNetworkBoundary()
+}
+
+func viewMap(item map[string]any, key string) map[string]any {
+	value, _ := item[key].(map[string]any)
+	return value
+}
+
+func viewList(item map[string]any, key string) []any {
+	value, _ := item[key].([]any)
+	return value
+}
+
+func viewString(item map[string]any, key string) string {
+	value, _ := item[key].(string)
+	return value
+}
+
+// helpers
+
+func textParameter(parameters []Value, minimum int, maximum int) (string, error) {
+	if len(parameters) < minimum || (maximum >= 0 && len(parameters) > maximum) {
+		switch {
+		case minimum == maximum:
+			return "", fmt.Errorf("expected %d parameter(s), got %d", minimum, len(parameters))
+
+		case maximum < 0:
+			return "", fmt.Errorf("expected at least %d parameter(s), got %d", minimum, len(parameters))
+
+		default:
+			return "", fmt.Errorf("expected %d to %d parameters, got %d", minimum, maximum, len(parameters))
+		}
+	}
+
+	return toText(parameters[0])
+}
+
+func itemsParameter(parameters []Value, count int) ([]Value, error) {
+	if len(parameters) != count {
+		return nil, fmt.Errorf("expected %d parameter(s), got %d", count, len(parameters))
+	}
+
+	return toItems(parameters[0])
+}
+
+func plainValue(value Value) any {
+	if value == nil {
+		return nil
+	}
+
+	return value.PlainValue()
+}
+
+func toText(value Value) (string, error) {
+	switch castValue := plainValue(value).(type) {
+	case nil:
+		return "", nil
+
+	case string:
+		return castValue, nil
+
+	case bool:
+		return strconv.FormatBool(castValue), nil
+
+	case decimal.Decimal:
+		return castValue.String(), nil
+
+	default:
+		return "", fmt.Errorf("expected a text, got %T", castValue)
+	}
+}
+
+// toItems returns the items of a list or the values of a map sorted by key
+func toItems(value Value) ([]Value, error) {
+	if value == nil {
+		return make([]Value, 0), nil
+	}
+
+	switch castValue := value.Value().(type) {
+	case nil:
+		return make([]Value, 0), nil
+
+	case []Value:
+		return castValue, nil
+
+	case []any:
+		return toArrayValue(castValue, value.Event()).ArrayValue(), nil
+
+	case map[string]any:
+		keys := make([]string, 0, len(castValue))
+		for key := range castValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		items := make([]Value, 0, len(keys))
+		for _, key := range keys {
+			items = append(items, SomeValue(castValue[key], value.Event()))
+		}
+
+		return items, nil
+
+	case string:
+		if len(castValue) == 0 {
+			return make([]Value, 0), nil
+		}
+
+	case Value:
+		return toItems(castValue)
+	}
+
+	return nil, fmt.Errorf("expected a list, got %T", value.Value())
+}
+
+func toArrayValue(value any, event *Event) *ArrayValue {
+	items := make([]Value, 0)
+	list, _ := value.([]any)
+	for _, item := range list {
+		items = append(items, SomeValue(item, event))
+	}
+
+	return SomeArrayValue(items, event)
+}
+
+func indexOf(items []Value, item Value) int {
+	for index, candidate := range items {
+		if isSame(candidate, item) {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// isSame compares texts, bools and numbers by their text, so that the literal `3` matches the number 3 of the model
+func isSame(first Value, second Value) bool {
+	firstText, firstError := toText(first)
+	secondText, secondError := toText(second)
+	if firstError == nil && secondError == nil {
+		return firstText == secondText
+	}
+
+	return reflect.DeepEqual(plainValue(first), plainValue(second))
+}
+
+func isLess(first Value, second Value) bool {
+	firstDecimal, firstIsDecimal := plainValue(first).(decimal.Decimal)
+	secondDecimal, secondIsDecimal := plainValue(second).(decimal.Decimal)
+	if firstIsDecimal && secondIsDecimal {
+		return firstDecimal.LessThan(secondDecimal)
+	}
+
+	return fmt.Sprintf("%v", plainValue(first)) < fmt.Sprintf("%v", plainValue(second))
+}
+
+func isTrue(value Value) bool {
+	switch castValue := plainValue(value).(type) {
+	case bool:
+		return castValue
+
+	case string:
+		boolValue, parseError := strconv.ParseBool(castValue)
+		return parseError == nil && boolValue
+
+	case decimal.Decimal:
+		return !castValue.IsZero()
+
+	default:
+		return false
+	}
+}
+
+func callMethod(scope *Scope, method Value, item Value) (Value, error) {
+	name, nameError := toText(method)
+	if nameError != nil {
+		return nil, fmt.Errorf("expected a method name: %w", nameError)
+	}
+
+	name = strings.ToLower(name)
+	if _, ok := scope.Methods[name]; !ok && IsBuiltIn(name) {
+		return CallBuiltIn(scope, name, item)
+	}
+
+	value, _, callError := scope.CallMethod(name, item)
+	return value, callError
 }
@@ -0,0 +1,118 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+type returnStatement func(args []Value) Value
+
+func (what returnStatement) Run(scope *Scope) (string, error) {
+	scope.SetReturnValue(what(scope.Args))
+	return "", nil
+}
+
+func (what returnStatement) Literal() string {
+	return ""
+}
+
+func callBuiltIn(t *testing.T, scope *Scope, name string, parameters ...any) any {
+	values := make([]Value, 0)
+	for _, parameter := range parameters {
+		values = append(values, SomeValue(parameter, nil))
+	}
+
+	value, callError := CallBuiltIn(scope, name, values...)
+	assert.NoError(t, callError, name)
+	if value == nil {
+		return nil
+	}
+
+	return value.PlainValue()
+}
+
+func TestStringBuiltIns(t *testing.T) {
+	assert.Equal(t, "web server", callBuiltIn(t, nil, "lower", "Web Server"))
+	assert.Equal(t, "WEB SERVER", callBuiltIn(t, nil, "upper", "Web Server"))
+	assert.Equal(t, true, callBuiltIn(t, nil, "contains", "Web Server", "Serv"))
+	assert.Equal(t, true, callBuiltIn(t, nil, "contains", []any{"git", 3}, "3"))
+	assert.Equal(t, false, callBuiltIn(t, nil, "contains", []any{"git"}, "svn"))
+	assert.Equal(t, true, callBuiltIn(t, nil, "match", "web-server-1", `^web-\w+-\d$`))
+	assert.Equal(t, "a, b", callBuiltIn(t, nil, "join", []any{"a", "b"}))
+	assert.Equal(t, "a-b", callBuiltIn(t, nil, "join", []any{"a", "b"}, "-"))
+	assert.Equal(t, []any{"a", "b"}, callBuiltIn(t, nil, "split", "a, b"))
+	assert.Equal(t, []any{}, callBuiltIn(t, nil, "split", ""))
+	assert.Equal(t, "web has 2 links", callBuiltIn(t, nil, "format", "%v has %v links", "web", 2))
+
+	_, matchError := CallBuiltIn(nil, "match", SomeStringValue("web", nil), SomeStringValue("(", nil))
+	assert.Error(t, matchError)
+
+	_, countError := CallBuiltIn(nil, "lower")
+	assert.Error(t, countError)
+}
+
+func TestListBuiltIns(t *testing.T) {
+	assert.Equal(t, []any{"b", "a"}, callBuiltIn(t, nil, "unique", []any{"b", "a", "b"}))
+	assert.Equal(t, []any{"a", "b", "c"}, callBuiltIn(t, nil, "sort", []any{"c", "a", "b"}))
+	assert.Equal(t, []any{decimal.NewFromInt(2), decimal.NewFromInt(10)}, callBuiltIn(t, nil, "sort", []any{10, 2}))
+	assert.Equal(t, decimal.NewFromInt(3), callBuiltIn(t, nil, "length", []any{"a", "b", "c"}))
+	assert.Equal(t, decimal.NewFromInt(2), callBuiltIn(t, nil, "length", map[string]any{"a": 1, "b": 2}))
+	assert.Equal(t, decimal.NewFromInt(4), callBuiltIn(t, nil, "length", "text"))
+	assert.Equal(t, []any{"b"}, callBuiltIn(t, nil, "intersection", []any{"a", "b", "b"}, []any{"b", "c"}))
+
+	scope := &Scope{Methods: map[string]Statement{
+		"is_short": returnStatement(func(args []Value) Value {
+			return SomeBoolValue(len(args[0].PlainValue().(string)) < 3, nil)
+		}),
+		"shout": returnStatement(func(args []Value) Value {
+			return SomeStringValue(args[0].PlainValue().(string)+"!", nil)
+		}),
+	}}
+	assert.Equal(t, []any{"ab", "c"}, callBuiltIn(t, scope, "filter", []any{"ab", "abc", "c"}, "is_short"))
+	assert.Equal(t, []any{"ab!", "c!"}, callBuiltIn(t, scope, "map", []any{"ab", "c"}, "shout"))
+
+	_, methodError := CallBuiltIn(scope, "filter", SomeValue([]any{"a"}, nil), SomeStringValue("unknown", nil))
+	assert.Error(t, methodError)
+}
+
+func TestModelBuiltIns(t *testing.T) {
+	link := &types.CommunicationLink{Id: "web>db", SourceId: "web", TargetId: "db"}
+	dmz := &types.TrustBoundary{Id: "dmz", Type: types.NetworkCloudSecurityGroup, TechnicalAssetsInside: []string{"web"}}
+	internal := &types.TrustBoundary{Id: "internal", Type: types.NetworkOnPrem, TechnicalAssetsInside: []string{"db"}}
+	view, viewError := NewView(&types.Model{
+		DataAssets: map[string]*types.DataAsset{
+			"customers": {Id: "customers", Confidentiality: types.StrictlyConfidential, Integrity: types.Critical},
+		},
+		TechnicalAssets: map[string]*types.TechnicalAsset{
+			"web": {Id: "web", Confidentiality: types.Internal, DataAssetsProcessed: []string{"customers"}, CommunicationLinks: []*types.CommunicationLink{link}},
+			"db":  {Id: "db", Confidentiality: types.Confidential, Availability: types.MissionCritical},
+		},
+		TrustBoundaries:    map[string]*types.TrustBoundary{"dmz": dmz, "internal": internal},
+		CommunicationLinks: map[string]*types.CommunicationLink{"web>db": link},
+		IncomingTechnicalCommunicationLinksMappedByTargetId:   map[string][]*types.CommunicationLink{"db": {link}},
+		DirectContainingTrustBoundaryMappedByTechnicalAssetId: map[string]*types.TrustBoundary{"web": dmz, "db": internal},
+	})
+	assert.NoError(t, viewError)
+	scope := &Scope{Model: view}
+
+	assert.Equal(t, "strictly-confidential", callBuiltIn(t, scope, "highest_confidentiality", "web"))
+	assert.Equal(t, "confidential", callBuiltIn(t, scope, "highest_confidentiality", "db"))
+	assert.Equal(t, "critical", callBuiltIn(t, scope, "highest_integrity", view["technical_assets"].(map[string]any)["web"]))
+	assert.Equal(t, "mission-critical", callBuiltIn(t, scope, "highest_availability", "db"))
+
+	incoming := callBuiltIn(t, scope, "incoming_links", "db").([]any)
+	assert.Len(t, incoming, 1)
+	assert.Equal(t, "web", incoming[0].(map[string]any)["source_id"])
+	assert.Len(t, callBuiltIn(t, scope, "outgoing_links", "web"), 1)
+	assert.Empty(t, callBuiltIn(t, scope, "incoming_links", "web"))
+
+	assert.Equal(t, "dmz", callBuiltIn(t, scope, "trust_boundary", "web").(map[string]any)["id"])
+	assert.Equal(t, true, callBuiltIn(t, scope, "is_across_trust_boundary_network_only", "web>db"))
+	assert.Equal(t, true, callBuiltIn(t, scope, "is_across_trust_boundary_network_only", incoming[0]))
+
+	_, unknownError := CallBuiltIn(scope, "highest_confidentiality", SomeStringValue("unknown", nil))
+	assert.Error(t, unknownError)
+}
@@ -42,7 +42,7 @@ var (
 type castFunc func(value Value) (Value, error)
 
 func CastValue(value Value, castType string) (Value, error) {
-	if value == nil {
+	if value == nil || value.Value() == nil {
 		return NilValue(), nil
 	}
 
@@ -0,0 +1,188 @@
+package common
+
+import (
+	"sort"
+)
+
+// ReachableTechnicalAssets returns the technical assets reachable from a technical asset along the direction of the
+// communication links within the given number of hops (without limit for 0 or less), sorted by id
+func ReachableTechnicalAssets(scope *Scope, technicalAsset Value, hops int) ([]Value, error) {
+	asset, assetError := findModelItem(scope, technicalAsset, "technical_assets", "technical asset")
+	if assetError != nil {
+		return nil, assetError
+	}
+
+	startId := viewString(asset, "id")
+	targets := linkedTechnicalAssets(scope, false)
+	reached := map[string]bool{startId: true}
+	current := []string{startId}
+	for hop := 1; len(current) > 0 && (hops <= 0 || hop <= hops); hop++ {
+		next := make([]string, 0)
+		for _, id := range current {
+			for _, targetId := range targets[id] {
+				if !reached[targetId] {
+					reached[targetId] = true
+					next = append(next, targetId)
+				}
+			}
+		}
+
+		current = next
+	}
+
+	delete(reached, startId)
+	return technicalAssetValues(scope, reached, technicalAsset.Event()), nil
+}
+
+// IsReachableFromInternet tells whether a technical asset is internet-facing itself or reachable along the direction
+// of the communication links from an internet-facing technical asset
+func IsReachableFromInternet(scope *Scope, technicalAsset Value) (bool, error) {
+	asset, assetError := findModelItem(scope, technicalAsset, "technical_assets", "technical asset")
+	if assetError != nil {
+		return false, assetError
+	}
+
+	technicalAssets := viewMap(scope.Model, "technical_assets")
+	sources := linkedTechnicalAssets(scope, true)
+	reached := map[string]bool{viewString(asset, "id"): true}
+	current := []string{viewString(asset, "id")}
+	for len(current) > 0 {
+		next := make([]string, 0)
+		for _, id := range current {
+			if internet, _ := viewMap(technicalAssets, id)["internet"].(bool); internet {
+				return true, nil
+			}
+
+			for _, sourceId := range sources[id] {
+				if !reached[sourceId] {
+					reached[sourceId] = true
+					next = append(next, sourceId)
+				}
+			}
+		}
+
+		current = next
+	}
+
+	return false, nil
+}
+
+// CallingTechnicalAssets returns the technical assets with communication links to a technical asset, sorted by id
+func CallingTechnicalAssets(scope *Scope, technicalAsset Value) ([]Value, error) {
+	asset, assetError := findModelItem(scope, technicalAsset, "technical_assets", "technical asset")
+	if assetError != nil {
+		return nil, assetError
+	}
+
+	callers := make(map[string]bool)
+	for _, sourceId := range linkedTechnicalAssets(scope, true)[viewString(asset, "id")] {
+		callers[sourceId] = true
+	}
+
+	return technicalAssetValues(scope, callers, technicalAsset.Event()), nil
+}
+
+// TrustBoundariesCrossedBy returns the trust boundaries a communication link leaves, from the innermost one around its
+// source, followed by the ones it enters, up to the innermost one around its target
+func TrustBoundariesCrossedBy(scope *Scope, communicationLink Value) ([]Value, error) {
+	link, linkError := findModelItem(scope, communicationLink, "communication_links", "communication link")
+	if linkError != nil {
+		return nil, linkError
+	}
+
+	sourceBoundaries := trustBoundaryChain(scope, viewString(link, "source_id"))
+	targetBoundaries := trustBoundaryChain(scope, viewString(link, "target_id"))
+
+	crossed := make([]Value, 0)
+	for _, trustBoundary := range sourceBoundaries {
+		if indexOfTrustBoundary(targetBoundaries, trustBoundary) < 0 {
+			crossed = append(crossed, SomeValue(trustBoundary, communicationLink.Event()))
+		}
+	}
+
+	for index := len(targetBoundaries) - 1; index >= 0; index-- {
+		if indexOfTrustBoundary(sourceBoundaries, targetBoundaries[index]) < 0 {
+			crossed = append(crossed, SomeValue(targetBoundaries[index], communicationLink.Event()))
+		}
+	}
+
+	return crossed, nil
+}
+
+// linkedTechnicalAssets maps the ids of technical assets to the sorted ids of the targets of their communication links,
+// or to the sorted ids of the sources of the communication links to them
+func linkedTechnicalAssets(scope *Scope, reverse bool) map[string][]string {
+	linked := make(map[string][]string)
+	for _, item := range viewMap(scope.Model, "technical_assets") {
+		asset, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for _, linkItem := range viewList(asset, "communication_links") {
+			link, isMap := linkItem.(map[string]any)
+			if !isMap {
+				continue
+			}
+
+			from, to := viewString(link, "source_id"), viewString(link, "target_id")
+			if len(from) == 0 {
+				from = viewString(asset, "id")
+			}
+
+			if reverse {
+				from, to = to, from
+			}
+
+			linked[from] = append(linked[from], to)
+		}
+	}
+
+	for _, ids := range linked {
+		sort.Strings(ids)
+	}
+
+	return linked
+}
+
+func technicalAssetValues(scope *Scope, ids map[string]bool, event *Event) []Value {
+	sortedIds := make([]string, 0, len(ids))
+	for id := range ids {
+		sortedIds = append(sortedIds, id)
+	}
+	sort.Strings(sortedIds)
+
+	technicalAssets := viewMap(scope.Model, "technical_assets")
+	values := make([]Value, 0, len(sortedIds))
+	for _, id := range sortedIds {
+		if asset, ok := technicalAssets[id].(map[string]any); ok {
+			values = append(values, SomeValue(asset, event))
+		}
+	}
+
+	return values
+}
+
+// trustBoundaryChain returns the trust boundary directly containing a technical asset followed by its parents
+func trustBoundaryChain(scope *Scope, technicalAssetId string) []map[string]any {
+	chain := make([]map[string]any, 0)
+	for trustBoundary := directTrustBoundary(scope, technicalAssetId); trustBoundary != nil; trustBoundary = parentTrustBoundary(scope, trustBoundary) {
+		if indexOfTrustBoundary(chain, trustBoundary) >= 0 {
+			break
+		}
+
+		chain = append(chain, trustBoundary)
+	}
+
+	return chain
+}
+
+func indexOfTrustBoundary(trustBoundaries []map[string]any, trustBoundary map[string]any) int {
+	for index, candidate := range trustBoundaries {
+		if viewString(candidate, "id") == viewString(trustBoundary, "id") {
+			return index
+		}
+	}
+
+	return -1
+}
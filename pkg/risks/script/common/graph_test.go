@@ -0,0 +1,101 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+func newGraphScope(t *testing.T) *Scope {
+	browserToWeb := &types.CommunicationLink{Id: "browser>web", SourceId: "browser", TargetId: "web"}
+	webToApi := &types.CommunicationLink{Id: "web>api", SourceId: "web", TargetId: "api"}
+	apiToDb := &types.CommunicationLink{Id: "api>db", SourceId: "api", TargetId: "db"}
+	jobToDb := &types.CommunicationLink{Id: "job>db", SourceId: "job", TargetId: "db"}
+	dmz := &types.TrustBoundary{Id: "dmz", Type: types.NetworkCloudSecurityGroup, TechnicalAssetsInside: []string{"web"}}
+	internal := &types.TrustBoundary{Id: "internal", Type: types.NetworkOnPrem, TechnicalAssetsInside: []string{"db"}, TrustBoundariesNested: []string{"cluster"}}
+	cluster := &types.TrustBoundary{Id: "cluster", Type: types.ExecutionEnvironment, TechnicalAssetsInside: []string{"api"}}
+
+	view, viewError := NewView(&types.Model{
+		TechnicalAssets: map[string]*types.TechnicalAsset{
+			"browser": {Id: "browser", Internet: true, CommunicationLinks: []*types.CommunicationLink{browserToWeb}},
+			"web":     {Id: "web", CommunicationLinks: []*types.CommunicationLink{webToApi}},
+			"api":     {Id: "api", CommunicationLinks: []*types.CommunicationLink{apiToDb}},
+			"job":     {Id: "job", CommunicationLinks: []*types.CommunicationLink{jobToDb}},
+			"db":      {Id: "db"},
+		},
+		TrustBoundaries: map[string]*types.TrustBoundary{"dmz": dmz, "internal": internal, "cluster": cluster},
+		CommunicationLinks: map[string]*types.CommunicationLink{
+			"browser>web": browserToWeb, "web>api": webToApi, "api>db": apiToDb, "job>db": jobToDb,
+		},
+		DirectContainingTrustBoundaryMappedByTechnicalAssetId: map[string]*types.TrustBoundary{"web": dmz, "db": internal, "api": cluster},
+	})
+	assert.NoError(t, viewError)
+
+	return &Scope{Model: view}
+}
+
+func ids(values []Value) []string {
+	result := make([]string, 0)
+	for _, value := range values {
+		result = append(result, value.PlainValue().(map[string]any)["id"].(string))
+	}
+
+	return result
+}
+
+func TestReachableTechnicalAssets(t *testing.T) {
+	scope := newGraphScope(t)
+
+	reachable, reachableError := ReachableTechnicalAssets(scope, SomeStringValue("web", nil), 1)
+	assert.NoError(t, reachableError)
+	assert.Equal(t, []string{"api"}, ids(reachable))
+
+	reachable, reachableError = ReachableTechnicalAssets(scope, SomeStringValue("web", nil), 0)
+	assert.NoError(t, reachableError)
+	assert.Equal(t, []string{"api", "db"}, ids(reachable))
+
+	reachable, reachableError = ReachableTechnicalAssets(scope, SomeStringValue("browser", nil), 2)
+	assert.NoError(t, reachableError)
+	assert.Equal(t, []string{"api", "web"}, ids(reachable))
+
+	_, reachableError = ReachableTechnicalAssets(scope, SomeStringValue("unknown", nil), 0)
+	assert.Error(t, reachableError)
+}
+
+func TestIsReachableFromInternet(t *testing.T) {
+	scope := newGraphScope(t)
+
+	for id, expected := range map[string]bool{"browser": true, "web": true, "api": true, "db": true, "job": false} {
+		reachable, reachableError := IsReachableFromInternet(scope, SomeStringValue(id, nil))
+		assert.NoError(t, reachableError)
+		assert.Equal(t, expected, reachable, id)
+	}
+}
+
+func TestCallingTechnicalAssets(t *testing.T) {
+	scope := newGraphScope(t)
+
+	callers, callersError := CallingTechnicalAssets(scope, SomeStringValue("db", nil))
+	assert.NoError(t, callersError)
+	assert.Equal(t, []string{"api", "job"}, ids(callers))
+
+	callers, callersError = CallingTechnicalAssets(scope, SomeStringValue("browser", nil))
+	assert.NoError(t, callersError)
+	assert.Empty(t, callers)
+}
+
+func TestTrustBoundariesCrossedBy(t *testing.T) {
+	scope := newGraphScope(t)
+
+	for id, expected := range map[string][]string{
+		"browser>web": {"dmz"},
+		"web>api":     {"dmz", "internal", "cluster"},
+		"api>db":      {"cluster"},
+		"job>db":      {"internal"},
+	} {
+		crossed, crossedError := TrustBoundariesCrossedBy(scope, SomeStringValue(id, nil))
+		assert.NoError(t, crossedError)
+		assert.Equal(t, expected, ids(crossed), id)
+	}
+}
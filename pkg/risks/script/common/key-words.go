@@ -42,4 +42,21 @@ const (
 	NotEqual       = "not-equal"
 	Or             = "or"
 	True           = "true"
+
+	Callers                = "callers"
+	CrossedTrustBoundaries = "crossed-trust-boundaries"
+	Reachable              = "reachable"
+	ReachableFromInternet  = "reachable-from-internet"
+	From                   = "from"
+	Hops                   = "hops"
+
+	Add      = "add"
+	Subtract = "subtract"
+	Multiply = "multiply"
+	Divide   = "divide"
+	Min      = "min"
+	Max      = "max"
+	Operands = "values"
+	Coalesce = "coalesce"
+	Ternary  = "ternary"
 )
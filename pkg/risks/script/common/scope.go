@@ -1,39 +1,37 @@
 package common
 
 import (
-	"gopkg.in/yaml.v3"
+	"fmt"
 	"strings"
 
 	"github.com/threagile/threagile/pkg/types"
 )
 
 type Scope struct {
-	Parent      *Scope
-	Category    *types.RiskCategory
-	Args        []Value
-	Vars        map[string]Value
-	Model       map[string]any
-	Risk        map[string]any
-	Methods     map[string]Statement
-	Deferred    []Statement
-	Explain     ExplainStatement
-	CallStack   History
-	HasReturned bool
-	item        Value
-	returnValue Value
+	Parent         *Scope
+	Category       *types.RiskCategory
+	Args           []Value
+	Vars           map[string]Value
+	Model          map[string]any
+	Risk           map[string]any
+	Methods        map[string]Statement
+	Deferred       []Statement
+	Explain        ExplainStatement
+	CallStack      History
+	HasReturned    bool
+	SeverityMatrix *types.SeverityMatrix
+	item           Value
+	returnValue    Value
 }
 
 func (what *Scope) Init(risk *types.RiskCategory, methods map[string]Statement) error {
 	if risk != nil {
-		data, marshalError := yaml.Marshal(risk)
-		if marshalError != nil {
-			return marshalError
+		view, viewError := NewView(risk)
+		if viewError != nil {
+			return viewError
 		}
 
-		unmarshalError := yaml.Unmarshal(data, &what.Risk)
-		if unmarshalError != nil {
-			return unmarshalError
-		}
+		what.Risk = view
 	}
 
 	what.Category = risk
@@ -44,20 +42,27 @@ func (what *Scope) Init(risk *types.RiskCategory, methods map[string]Statement)
 
 func (what *Scope) SetModel(model *types.Model) error {
 	if model != nil {
-		data, marshalError := yaml.Marshal(model)
-		if marshalError != nil {
-			return marshalError
+		view, viewError := NewView(model)
+		if viewError != nil {
+			return viewError
 		}
 
-		unmarshalError := yaml.Unmarshal(data, &what.Model)
-		if unmarshalError != nil {
-			return unmarshalError
-		}
+		what.Model = view
 	}
 
 	return nil
 }
 
+// SetModelView sets a view of the model created with NewView, it may be shared read-only between scopes
+func (what *Scope) SetModelView(view map[string]any) {
+	what.Model = view
+}
+
+// SetSeverityMatrix sets the matrix used to calculate severities, nil is the default matrix
+func (what *Scope) SetSeverityMatrix(matrix *types.SeverityMatrix) {
+	what.SeverityMatrix = matrix
+}
+
 func (what *Scope) Clone() (*Scope, error) {
 	varsCopy, copyError := Values(what.Vars).Copy()
 	if copyError != nil {
@@ -65,19 +70,41 @@ func (what *Scope) Clone() (*Scope, error) {
 	}
 
 	scope := Scope{
-		Parent:    what,
-		Category:  what.Category,
-		Args:      what.Args,
-		Vars:      varsCopy,
-		Model:     what.Model,
-		Risk:      what.Risk,
-		Methods:   what.Methods,
-		CallStack: what.CallStack,
+		Parent:         what,
+		Category:       what.Category,
+		Args:           what.Args,
+		Vars:           varsCopy,
+		Model:          what.Model,
+		Risk:           what.Risk,
+		Methods:        what.Methods,
+		CallStack:      what.CallStack,
+		SeverityMatrix: what.SeverityMatrix,
 	}
 
 	return &scope, nil
 }
 
+// CallMethod runs a method of the script with the given arguments in a clone of the scope and returns its return value
+func (what *Scope) CallMethod(name string, args ...Value) (Value, string, error) {
+	method, ok := what.Methods[name]
+	if !ok {
+		return NilValue(), "", fmt.Errorf("no method %q", name)
+	}
+
+	newScope, cloneError := what.Clone()
+	if cloneError != nil {
+		return NilValue(), "", fmt.Errorf("failed to clone scope: %w", cloneError)
+	}
+
+	newScope.Args = args
+	errorLiteral, runError := method.Run(newScope)
+	if runError != nil {
+		return NilValue(), errorLiteral, fmt.Errorf("failed to run method %q: %w", name, runError)
+	}
+
+	return newScope.GetReturnValue(), "", nil
+}
+
 func (what *Scope) Defer(statement Statement) {
 	what.Deferred = append(what.Deferred, statement)
 }
@@ -101,7 +128,62 @@ func (what *Scope) Set(name string, value Value) {
 	what.Vars[name] = value
 }
 
+// Get resolves a variable, `$model`, `$risk` or the current item (`.`) and a path of properties below it. A `?` after
+// a name makes that one access null-safe: if the name is missing, or holds nothing the rest of the path can be looked
+// up in, the path yields a blank value instead of failing to resolve. Names without `?` must still resolve.
 func (what *Scope) Get(name string) (Value, bool) {
+	if !strings.Contains(name, "?") {
+		return what.getPath(name)
+	}
+
+	segments := strings.Split(name, ".")
+	optional := make([]bool, len(segments))
+	for index, segment := range segments {
+		optional[index] = strings.HasSuffix(segment, "?")
+		segments[index] = strings.TrimSuffix(segment, "?")
+	}
+
+	cleanName := strings.Join(segments, ".")
+	value, ok := what.getPath(cleanName)
+	if ok {
+		return value, true
+	}
+
+	// find the first name that does not resolve: it is tolerated if it is null-safe itself, or if the name before it
+	// is null-safe and holds nothing to look it up in
+	first := 1
+	if strings.HasPrefix(segments[0], "$") {
+		first = 2
+	}
+
+	for end := first; end <= len(segments); end++ {
+		if _, prefixOk := what.getPath(strings.Join(segments[:end], ".")); prefixOk {
+			continue
+		}
+
+		if optional[end-1] {
+			return SomeValue(nil, NewEvent(NewValueProperty(nil), NewPath(cleanName))), true
+		}
+
+		if end >= 2 && optional[end-2] {
+			parent, _ := what.getPath(strings.Join(segments[:end-1], "."))
+			if parent == nil || !isMapValue(parent) {
+				return SomeValue(nil, NewEvent(NewValueProperty(nil), NewPath(cleanName))), true
+			}
+		}
+
+		break
+	}
+
+	return value, false
+}
+
+func isMapValue(value Value) bool {
+	_, isMap := value.Value().(map[string]any)
+	return isMap
+}
+
+func (what *Scope) getPath(name string) (Value, bool) {
 	path := strings.Split(name, ".")
 	if strings.HasPrefix(path[0], "$") {
 		switch strings.ToLower(path[0]) {
@@ -0,0 +1,55 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopeGetNullSafe(t *testing.T) {
+	scope := &Scope{Model: map[string]any{
+		"data_assets": map[string]any{
+			"customers": map[string]any{"id": "customers", "confidentiality": "confidential"},
+		},
+	}}
+	scope.Set("asset", SomeValue(map[string]any{"id": "web", "owner": "team"}, nil))
+
+	value, ok := scope.Get("$model.data_assets.customers?.confidentiality")
+	assert.True(t, ok)
+	assert.Equal(t, "confidential", value.PlainValue())
+
+	_, ok = scope.Get("$model.data_assets.unknown.confidentiality")
+	assert.False(t, ok)
+
+	value, ok = scope.Get("$model.data_assets.unknown?.confidentiality")
+	assert.True(t, ok)
+	assert.True(t, IsBlank(value))
+
+	_, ok = scope.Get("asset.owner.name")
+	assert.False(t, ok)
+
+	value, ok = scope.Get("asset.owner?.name")
+	assert.True(t, ok)
+	assert.True(t, IsBlank(value))
+
+	// only the accesses marked with `?` are null-safe
+	_, ok = scope.Get("$model.data_asets.unknown?.confidentiality")
+	assert.False(t, ok)
+
+	_, ok = scope.Get("$model.data_assets.customers?.confidentialty")
+	assert.False(t, ok)
+
+	_, ok = scope.Get("asset.owner?.name.first")
+	assert.True(t, ok)
+
+	_, ok = scope.Get("assets.owner?.name")
+	assert.False(t, ok)
+
+	value, ok = scope.Get("missing?")
+	assert.True(t, ok)
+	assert.True(t, IsBlank(value))
+
+	cast, castError := CastValue(value, confidentiality)
+	assert.NoError(t, castError)
+	assert.True(t, IsBlank(cast))
+}
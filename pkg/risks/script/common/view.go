@@ -0,0 +1,262 @@
+package common
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NewView converts a value into the maps, lists and scalars evaluated by the scripts. The result is the same as
+// marshalling the value to YAML and unmarshalling it into an untyped value, without the detour through YAML: fields
+// are named and omitted by their yaml tags and types marshalling themselves to YAML are converted by their marshaller.
+// Values referenced more than once are converted once and shared, the view must be treated as read-only.
+func NewView(value any) (map[string]any, error) {
+	converted, convertError := new(viewBuilder).Init().convert(reflect.ValueOf(value))
+	if convertError != nil {
+		return nil, convertError
+	}
+
+	if converted == nil {
+		return nil, nil
+	}
+
+	result, ok := converted.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected view type %T", converted)
+	}
+
+	return result, nil
+}
+
+type viewKey struct {
+	pointer uintptr
+	typ     reflect.Type
+}
+
+type viewBuilder struct {
+	converted map[viewKey]any
+}
+
+func (what *viewBuilder) Init() *viewBuilder {
+	what.converted = make(map[viewKey]any)
+	return what
+}
+
+var (
+	yamlMarshalerType = reflect.TypeOf((*yaml.Marshaler)(nil)).Elem()
+	isZeroerType      = reflect.TypeOf((*yaml.IsZeroer)(nil)).Elem()
+)
+
+func (what *viewBuilder) convert(value reflect.Value) (any, error) {
+	if !value.IsValid() {
+		return nil, nil
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		if value.IsNil() && value.Kind() != reflect.Map && value.Kind() != reflect.Slice {
+			return nil, nil
+		}
+	}
+
+	if value.Type().Implements(yamlMarshalerType) && value.CanInterface() {
+		marshalled, marshalError := value.Interface().(yaml.Marshaler).MarshalYAML()
+		if marshalError != nil {
+			return nil, marshalError
+		}
+
+		return what.convert(reflect.ValueOf(marshalled))
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer:
+		key := viewKey{pointer: value.Pointer(), typ: value.Type()}
+		if converted, ok := what.converted[key]; ok {
+			return converted, nil
+		}
+
+		converted, convertError := what.convert(value.Elem())
+		if convertError != nil {
+			return nil, convertError
+		}
+
+		what.converted[key] = converted
+		return converted, nil
+
+	case reflect.Interface:
+		return what.convert(value.Elem())
+
+	case reflect.Struct:
+		if value.Type().PkgPath() == "time" {
+			return roundTrip(value)
+		}
+
+		result := make(map[string]any)
+		return result, what.convertStruct(value, result)
+
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return roundTrip(value)
+		}
+
+		result := make(map[string]any, value.Len())
+		iterator := value.MapRange()
+		for iterator.Next() {
+			converted, convertError := what.convert(iterator.Value())
+			if convertError != nil {
+				return nil, convertError
+			}
+
+			result[iterator.Key().String()] = converted
+		}
+
+		return result, nil
+
+	case reflect.Slice, reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return roundTrip(value)
+		}
+
+		result := make([]any, value.Len())
+		for i := range result {
+			converted, convertError := what.convert(value.Index(i))
+			if convertError != nil {
+				return nil, convertError
+			}
+
+			result[i] = converted
+		}
+
+		return result, nil
+
+	case reflect.String:
+		return value.String(), nil
+
+	case reflect.Bool:
+		return value.Bool(), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(value.Int()), nil
+
+	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint8:
+		if value.Uint() > math.MaxInt {
+			return roundTrip(value)
+		}
+
+		return int(value.Uint()), nil
+
+	case reflect.Float32, reflect.Float64:
+		// YAML writes whole numbers without a fraction, they are read back as integers
+		text := strconv.FormatFloat(value.Float(), 'g', -1, value.Type().Bits())
+		if integer, parseError := strconv.ParseInt(text, 10, 0); parseError == nil {
+			return int(integer), nil
+		}
+
+		return value.Float(), nil
+	}
+
+	return roundTrip(value)
+}
+
+func (what *viewBuilder) convertStruct(value reflect.Value, result map[string]any) error {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if len(name) == 0 {
+			name = strings.ToLower(field.Name)
+		}
+
+		fieldValue := value.Field(i)
+		if strings.Contains(","+options+",", ",inline,") {
+			if fieldValue.Kind() != reflect.Struct {
+				return fmt.Errorf("unsupported inline field %q of %v", field.Name, value.Type())
+			}
+
+			inlineError := what.convertStruct(fieldValue, result)
+			if inlineError != nil {
+				return inlineError
+			}
+
+			continue
+		}
+
+		if strings.Contains(","+options+",", ",omitempty,") && isZero(fieldValue) {
+			continue
+		}
+
+		converted, convertError := what.convert(fieldValue)
+		if convertError != nil {
+			return fmt.Errorf("%v: %w", name, convertError)
+		}
+
+		result[name] = converted
+	}
+
+	return nil
+}
+
+// isZero tells whether YAML omits the value of a field tagged with omitempty
+func isZero(value reflect.Value) bool {
+	if value.Type().Implements(isZeroerType) && value.CanInterface() {
+		if (value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface) && value.IsNil() {
+			return true
+		}
+
+		return value.Interface().(yaml.IsZeroer).IsZero()
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.Len() == 0
+	case reflect.Interface, reflect.Pointer:
+		return value.IsNil()
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int() == 0
+	case reflect.Float32, reflect.Float64:
+		return value.Float() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint() == 0
+	case reflect.Bool:
+		return !value.Bool()
+	case reflect.Struct:
+		for i := value.NumField() - 1; i >= 0; i-- {
+			if value.Type().Field(i).IsExported() && !isZero(value.Field(i)) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// roundTrip converts the values without an equivalent in the view through YAML
+func roundTrip(value reflect.Value) (any, error) {
+	data, marshalError := yaml.Marshal(value.Interface())
+	if marshalError != nil {
+		return nil, marshalError
+	}
+
+	var result any
+	unmarshalError := yaml.Unmarshal(data, &result)
+	if unmarshalError != nil {
+		return nil, unmarshalError
+	}
+
+	return result, nil
+}
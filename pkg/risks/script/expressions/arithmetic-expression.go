@@ -0,0 +1,204 @@
+package expressions
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"github.com/threagile/threagile/pkg/risks/script/common"
+)
+
+// ArithmeticExpression calculates the sum (add), difference (subtract), product (multiply), quotient (divide), minimum
+// (min) or maximum (max) of its operands, given either as list or as `values` with a cast type in `as`
+type ArithmeticExpression struct {
+	literal  string
+	operator string
+	values   []common.Expression
+	as       string
+}
+
+func (what *ArithmeticExpression) ParseDecimal(script any) (common.DecimalExpression, any, error) {
+	what.literal = common.ToLiteral(script)
+
+	switch castScript := script.(type) {
+	case []any:
+		return what.parseValues(castScript)
+
+	case map[string]any:
+		for key, value := range castScript {
+			switch key {
+			case common.Operands:
+				values, ok := value.([]any)
+				if !ok {
+					return nil, value, fmt.Errorf("failed to parse %q of %v-expression: expected list, got %T", key, what.operator, value)
+				}
+
+				_, errorScript, parseError := what.parseValues(values)
+				if parseError != nil {
+					return nil, errorScript, parseError
+				}
+
+			case common.As:
+				item, ok := value.(string)
+				if !ok {
+					return nil, script, fmt.Errorf("failed to parse %v-expression: %q is not a string but %T", what.operator, key, value)
+				}
+
+				what.as = item
+
+			default:
+				return nil, script, fmt.Errorf("failed to parse %v-expression: unexpected keyword %q", what.operator, key)
+			}
+		}
+
+	default:
+		return nil, script, fmt.Errorf("failed to parse %v-expression: expected list or map[string]any, got %T", what.operator, script)
+	}
+
+	if len(what.values) == 0 {
+		return nil, script, fmt.Errorf("failed to parse %v-expression: no values", what.operator)
+	}
+
+	return what, nil, nil
+}
+
+func (what *ArithmeticExpression) parseValues(script []any) (common.DecimalExpression, any, error) {
+	for _, value := range script {
+		item, errorScript, itemError := new(ExpressionList).ParseAny(value)
+		if itemError != nil {
+			return nil, errorScript, fmt.Errorf("failed to parse value #%v of %v-expression: %w", len(what.values)+1, what.operator, itemError)
+		}
+
+		what.values = append(what.values, item)
+	}
+
+	return what, nil, nil
+}
+
+func (what *ArithmeticExpression) ParseAny(script any) (common.Expression, any, error) {
+	return what.ParseDecimal(script)
+}
+
+func (what *ArithmeticExpression) EvalDecimal(scope *common.Scope) (*common.DecimalValue, string, error) {
+	value, errorLiteral, evalError := what.eval(scope)
+	if value == nil {
+		return common.EmptyDecimalValue(), errorLiteral, evalError
+	}
+
+	return value, "", nil
+}
+
+// eval evaluates the expression to a decimal, min and max yield nil if all operands are blank
+func (what *ArithmeticExpression) eval(scope *common.Scope) (*common.DecimalValue, string, error) {
+	var result decimal.Decimal
+	operands := make([]common.Value, 0, len(what.values))
+	for index, expression := range what.values {
+		operand, errorLiteral, evalError := what.evalOperand(scope, expression)
+		if evalError != nil {
+			return nil, errorLiteral, fmt.Errorf("failed to eval value #%v of %v-expression: %w", index+1, what.operator, evalError)
+		}
+
+		if operand == nil {
+			switch {
+			case what.operator == common.Min || what.operator == common.Max:
+				// blank operands do not take part in minimum and maximum
+				continue
+
+			case what.operator == common.Divide && index > 0:
+				return nil, what.Literal(), fmt.Errorf("failed to eval divide-expression: divisor #%v is blank (missing value)", index)
+
+			default:
+				operand = common.SomeDecimalValue(decimal.Zero, nil)
+			}
+		}
+
+		operands = append(operands, operand)
+		value := operand.DecimalValue()
+		if len(operands) == 1 {
+			result = value
+			continue
+		}
+
+		switch what.operator {
+		case common.Add:
+			result = result.Add(value)
+
+		case common.Subtract:
+			result = result.Sub(value)
+
+		case common.Multiply:
+			result = result.Mul(value)
+
+		case common.Divide:
+			if value.IsZero() {
+				return nil, what.Literal(), fmt.Errorf("failed to eval divide-expression: division by zero")
+			}
+
+			result = result.Div(value)
+
+		case common.Min:
+			result = decimal.Min(result, value)
+
+		case common.Max:
+			result = decimal.Max(result, value)
+
+		default:
+			return nil, what.Literal(), fmt.Errorf("unknown arithmetic operator %q", what.operator)
+		}
+	}
+
+	if len(operands) == 0 {
+		return nil, "", nil
+	}
+
+	event := common.NewEvent(common.NewValueProperty(result), common.NewPath(what.operator+" value"))
+	return common.SomeDecimalValue(result, event.From(operands...)), "", nil
+}
+
+// evalOperand evaluates an operand to a decimal, blank operands yield nil
+func (what *ArithmeticExpression) evalOperand(scope *common.Scope, expression common.Expression) (*common.DecimalValue, string, error) {
+	value, errorLiteral, evalError := expression.EvalAny(scope)
+	if evalError != nil {
+		return nil, errorLiteral, evalError
+	}
+
+	if len(what.as) > 0 {
+		castValue, castError := common.CastValue(value, what.as)
+		if castError != nil {
+			return nil, what.Literal(), fmt.Errorf("failed to cast value to %q: %w", what.as, castError)
+		}
+
+		value = castValue
+	}
+
+	if common.IsBlank(value) {
+		return nil, "", nil
+	}
+
+	switch castValue := value.Value().(type) {
+	case decimal.Decimal:
+		return common.SomeDecimalValue(castValue, value.Event()), "", nil
+
+	case string:
+		decimalValue, parseError := decimal.NewFromString(castValue)
+		if parseError != nil {
+			return nil, what.Literal(), fmt.Errorf("failed to parse %q as decimal: %w", castValue, parseError)
+		}
+
+		return common.SomeDecimalValue(decimalValue, value.Event()), "", nil
+
+	default:
+		return nil, what.Literal(), fmt.Errorf("expected decimal, got %T", castValue)
+	}
+}
+
+func (what *ArithmeticExpression) EvalAny(scope *common.Scope) (common.Value, string, error) {
+	value, errorLiteral, evalError := what.eval(scope)
+	if value == nil {
+		return common.NilValue(), errorLiteral, evalError
+	}
+
+	return value, "", nil
+}
+
+func (what *ArithmeticExpression) Literal() string {
+	return what.literal
+}
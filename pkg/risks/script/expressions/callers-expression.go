@@ -0,0 +1,50 @@
+package expressions
+
+import (
+	"fmt"
+	"github.com/threagile/threagile/pkg/risks/script/common"
+)
+
+type CallersExpression struct {
+	literal string
+	of      common.ValueExpression
+}
+
+func (what *CallersExpression) ParseArray(script any) (common.ArrayExpression, any, error) {
+	what.literal = common.ToLiteral(script)
+
+	item, errorScript, itemError := new(ValueExpression).ParseValue(script)
+	if itemError != nil {
+		return nil, errorScript, fmt.Errorf("failed to parse callers-expression: %w", itemError)
+	}
+
+	what.of = item
+
+	return what, nil, nil
+}
+
+func (what *CallersExpression) ParseAny(script any) (common.Expression, any, error) {
+	return what.ParseArray(script)
+}
+
+func (what *CallersExpression) EvalArray(scope *common.Scope) (*common.ArrayValue, string, error) {
+	of, errorOfLiteral, ofError := what.of.EvalAny(scope)
+	if ofError != nil {
+		return common.EmptyArrayValue(), errorOfLiteral, ofError
+	}
+
+	callers, callersError := common.CallingTechnicalAssets(scope, of)
+	if callersError != nil {
+		return common.EmptyArrayValue(), what.Literal(), fmt.Errorf("failed to eval callers-expression: %w", callersError)
+	}
+
+	return common.SomeArrayValue(callers, of.Event()), "", nil
+}
+
+func (what *CallersExpression) EvalAny(scope *common.Scope) (common.Value, string, error) {
+	return what.EvalArray(scope)
+}
+
+func (what *CallersExpression) Literal() string {
+	return what.literal
+}
@@ -0,0 +1,51 @@
+package expressions
+
+import (
+	"fmt"
+	"github.com/threagile/threagile/pkg/risks/script/common"
+)
+
+// CoalesceExpression evaluates to the first of its values that is not blank
+type CoalesceExpression struct {
+	literal string
+	values  []common.Expression
+}
+
+func (what *CoalesceExpression) ParseAny(script any) (common.Expression, any, error) {
+	what.literal = common.ToLiteral(script)
+
+	values, ok := script.([]any)
+	if !ok {
+		return nil, script, fmt.Errorf("failed to parse coalesce-expression: expected list, got %T", script)
+	}
+
+	for index, value := range values {
+		item, errorScript, itemError := new(ExpressionList).ParseAny(value)
+		if itemError != nil {
+			return nil, errorScript, fmt.Errorf("failed to parse value #%v of coalesce-expression: %w", index+1, itemError)
+		}
+
+		what.values = append(what.values, item)
+	}
+
+	return what, nil, nil
+}
+
+func (what *CoalesceExpression) EvalAny(scope *common.Scope) (common.Value, string, error) {
+	for index, expression := range what.values {
+		value, errorLiteral, evalError := expression.EvalAny(scope)
+		if evalError != nil {
+			return nil, errorLiteral, fmt.Errorf("failed to eval value #%v of coalesce-expression: %w", index+1, evalError)
+		}
+
+		if !common.IsBlank(value) {
+			return value, "", nil
+		}
+	}
+
+	return common.NilValue(), "", nil
+}
+
+func (what *CoalesceExpression) Literal() string {
+	return what.literal
+}
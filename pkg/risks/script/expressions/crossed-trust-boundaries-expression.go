@@ -0,0 +1,50 @@
+package expressions
+
+import (
+	"fmt"
+	"github.com/threagile/threagile/pkg/risks/script/common"
+)
+
+type CrossedTrustBoundariesExpression struct {
+	literal string
+	link    common.ValueExpression
+}
+
+func (what *CrossedTrustBoundariesExpression) ParseArray(script any) (common.ArrayExpression, any, error) {
+	what.literal = common.ToLiteral(script)
+
+	item, errorScript, itemError := new(ValueExpression).ParseValue(script)
+	if itemError != nil {
+		return nil, errorScript, fmt.Errorf("failed to parse crossed-trust-boundaries-expression: %w", itemError)
+	}
+
+	what.link = item
+
+	return what, nil, nil
+}
+
+func (what *CrossedTrustBoundariesExpression) ParseAny(script any) (common.Expression, any, error) {
+	return what.ParseArray(script)
+}
+
+func (what *CrossedTrustBoundariesExpression) EvalArray(scope *common.Scope) (*common.ArrayValue, string, error) {
+	link, errorLinkLiteral, linkError := what.link.EvalAny(scope)
+	if linkError != nil {
+		return common.EmptyArrayValue(), errorLinkLiteral, linkError
+	}
+
+	crossed, crossedError := common.TrustBoundariesCrossedBy(scope, link)
+	if crossedError != nil {
+		return common.EmptyArrayValue(), what.Literal(), fmt.Errorf("failed to eval crossed-trust-boundaries-expression: %w", crossedError)
+	}
+
+	return common.SomeArrayValue(crossed, link.Event()), "", nil
+}
+
+func (what *CrossedTrustBoundariesExpression) EvalAny(scope *common.Scope) (common.Value, string, error) {
+	return what.EvalArray(scope)
+}
+
+func (what *CrossedTrustBoundariesExpression) Literal() string {
+	return what.literal
+}
@@ -13,6 +13,9 @@ type ExpressionList struct {
 func (what *ExpressionList) ParseExpression(script map[string]any) (common.Expression, any, error) {
 	for key, value := range script {
 		switch key {
+		case common.Add, common.Subtract, common.Multiply, common.Divide, common.Min, common.Max:
+			return (&ArithmeticExpression{operator: key}).ParseDecimal(value)
+
 		case common.All:
 			return new(AllExpression).ParseBool(value)
 
@@ -22,12 +25,21 @@ func (what *ExpressionList) ParseExpression(script map[string]any) (common.Expre
 		case common.And:
 			return new(AndExpression).ParseBool(value)
 
+		case common.Callers:
+			return new(CallersExpression).ParseArray(value)
+
+		case common.Coalesce:
+			return new(CoalesceExpression).ParseAny(value)
+
 		case common.Contains:
 			return new(ContainsExpression).ParseBool(value)
 
 		case common.Count:
 			return new(CountExpression).ParseDecimal(value)
 
+		case common.CrossedTrustBoundaries:
+			return new(CrossedTrustBoundariesExpression).ParseArray(value)
+
 		case common.Equal:
 			return new(EqualExpression).ParseBool(value)
 
@@ -52,6 +64,15 @@ func (what *ExpressionList) ParseExpression(script map[string]any) (common.Expre
 		case common.Or:
 			return new(OrExpression).ParseBool(value)
 
+		case common.Reachable:
+			return new(ReachableExpression).ParseArray(value)
+
+		case common.ReachableFromInternet:
+			return new(ReachableFromInternetExpression).ParseBool(value)
+
+		case common.Ternary:
+			return new(TernaryExpression).ParseAny(value)
+
 		case common.True:
 			return new(TrueExpression).ParseBool(value)
 
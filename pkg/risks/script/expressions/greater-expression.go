@@ -70,9 +70,14 @@ func (what *GreaterExpression) EvalBool(scope *common.Scope) (*common.BoolValue,
 		return common.EmptyBoolValue(), errorInLiteral, evalError
 	}
 
-	as, errorAsLiteral, asError := what.as.EvalString(scope)
-	if asError != nil {
-		return common.EmptyBoolValue(), errorAsLiteral, asError
+	as := common.EmptyStringValue()
+	if what.as != nil {
+		var errorAsLiteral string
+		var asError error
+		as, errorAsLiteral, asError = what.as.EvalString(scope)
+		if asError != nil {
+			return common.EmptyBoolValue(), errorAsLiteral, asError
+		}
 	}
 
 	compareValue, compareError := common.Compare(first, second, as.StringValue())
@@ -0,0 +1,92 @@
+package expressions
+
+import (
+	"fmt"
+	"github.com/threagile/threagile/pkg/risks/script/common"
+)
+
+type ReachableExpression struct {
+	literal string
+	from    common.ValueExpression
+	hops    common.ValueExpression
+}
+
+func (what *ReachableExpression) ParseArray(script any) (common.ArrayExpression, any, error) {
+	what.literal = common.ToLiteral(script)
+
+	switch script.(type) {
+	case map[string]any:
+		for key, value := range script.(map[string]any) {
+			switch key {
+			case common.From:
+				item, errorScript, itemError := new(ValueExpression).ParseValue(value)
+				if itemError != nil {
+					return nil, errorScript, fmt.Errorf("failed to parse %q of reachable-expression: %w", key, itemError)
+				}
+
+				what.from = item
+
+			case common.Hops:
+				item, errorScript, itemError := new(ValueExpression).ParseValue(value)
+				if itemError != nil {
+					return nil, errorScript, fmt.Errorf("failed to parse %q of reachable-expression: %w", key, itemError)
+				}
+
+				what.hops = item
+
+			default:
+				return nil, script, fmt.Errorf("failed to parse reachable-expression: unexpected keyword %q", key)
+			}
+		}
+
+	default:
+		item, errorScript, itemError := new(ValueExpression).ParseValue(script)
+		if itemError != nil {
+			return nil, errorScript, fmt.Errorf("failed to parse reachable-expression: %w", itemError)
+		}
+
+		what.from = item
+	}
+
+	if what.from == nil {
+		return nil, script, fmt.Errorf("failed to parse reachable-expression: missing %q", common.From)
+	}
+
+	return what, nil, nil
+}
+
+func (what *ReachableExpression) ParseAny(script any) (common.Expression, any, error) {
+	return what.ParseArray(script)
+}
+
+func (what *ReachableExpression) EvalArray(scope *common.Scope) (*common.ArrayValue, string, error) {
+	from, errorFromLiteral, fromError := what.from.EvalAny(scope)
+	if fromError != nil {
+		return common.EmptyArrayValue(), errorFromLiteral, fromError
+	}
+
+	hops := 0
+	if what.hops != nil {
+		hopsValue, errorHopsLiteral, hopsError := what.hops.EvalDecimal(scope)
+		if hopsError != nil {
+			return common.EmptyArrayValue(), errorHopsLiteral, hopsError
+		}
+
+		hops = int(hopsValue.DecimalValue().IntPart())
+	}
+
+	reachable, reachableError := common.ReachableTechnicalAssets(scope, from, hops)
+	if reachableError != nil {
+		return common.EmptyArrayValue(), what.Literal(), fmt.Errorf("failed to eval reachable-expression: %w", reachableError)
+	}
+
+	return common.SomeArrayValue(reachable, from.Event()), "", nil
+}
+
+func (what *ReachableExpression) EvalAny(scope *common.Scope) (common.Value, string, error) {
+	return what.EvalArray(scope)
+}
+
+func (what *ReachableExpression) Literal() string {
+	return what.literal
+}
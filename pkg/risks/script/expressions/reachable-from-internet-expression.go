@@ -0,0 +1,50 @@
+package expressions
+
+import (
+	"fmt"
+	"github.com/threagile/threagile/pkg/risks/script/common"
+)
+
+type ReachableFromInternetExpression struct {
+	literal string
+	to      common.ValueExpression
+}
+
+func (what *ReachableFromInternetExpression) ParseBool(script any) (common.BoolExpression, any, error) {
+	what.literal = common.ToLiteral(script)
+
+	item, errorScript, itemError := new(ValueExpression).ParseValue(script)
+	if itemError != nil {
+		return nil, errorScript, fmt.Errorf("failed to parse reachable-from-internet-expression: %w", itemError)
+	}
+
+	what.to = item
+
+	return what, nil, nil
+}
+
+func (what *ReachableFromInternetExpression) ParseAny(script any) (common.Expression, any, error) {
+	return what.ParseBool(script)
+}
+
+func (what *ReachableFromInternetExpression) EvalBool(scope *common.Scope) (*common.BoolValue, string, error) {
+	to, errorToLiteral, toError := what.to.EvalAny(scope)
+	if toError != nil {
+		return common.EmptyBoolValue(), errorToLiteral, toError
+	}
+
+	reachable, reachableError := common.IsReachableFromInternet(scope, to)
+	if reachableError != nil {
+		return common.EmptyBoolValue(), what.Literal(), fmt.Errorf("failed to eval reachable-from-internet-expression: %w", reachableError)
+	}
+
+	return common.SomeBoolValue(reachable, to.Event()), "", nil
+}
+
+func (what *ReachableFromInternetExpression) EvalAny(scope *common.Scope) (common.Value, string, error) {
+	return what.EvalBool(scope)
+}
+
+func (what *ReachableFromInternetExpression) Literal() string {
+	return what.literal
+}
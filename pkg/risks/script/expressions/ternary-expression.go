@@ -0,0 +1,76 @@
+package expressions
+
+import (
+	"fmt"
+	"github.com/threagile/threagile/pkg/risks/script/common"
+)
+
+// TernaryExpression evaluates to `then` if the bool expression `if` is true and to `else` (blank if left out) otherwise
+type TernaryExpression struct {
+	literal   string
+	condition common.BoolExpression
+	then      common.Expression
+	otherwise common.Expression
+}
+
+func (what *TernaryExpression) ParseAny(script any) (common.Expression, any, error) {
+	what.literal = common.ToLiteral(script)
+
+	castScript, ok := script.(map[string]any)
+	if !ok {
+		return nil, script, fmt.Errorf("failed to parse ternary-expression: expected map[string]any, got %T", script)
+	}
+
+	for key, value := range castScript {
+		item, errorScript, itemError := new(ExpressionList).ParseAny(value)
+		if itemError != nil {
+			return nil, errorScript, fmt.Errorf("failed to parse %q of ternary-expression: %w", key, itemError)
+		}
+
+		switch key {
+		case common.If:
+			condition, isBool := item.(common.BoolExpression)
+			if !isBool {
+				return nil, value, fmt.Errorf("failed to parse %q of ternary-expression: expected bool expression, got %T", key, item)
+			}
+
+			what.condition = condition
+
+		case common.Then:
+			what.then = item
+
+		case common.Else:
+			what.otherwise = item
+
+		default:
+			return nil, script, fmt.Errorf("failed to parse ternary-expression: unexpected keyword %q", key)
+		}
+	}
+
+	if what.condition == nil || what.then == nil {
+		return nil, script, fmt.Errorf("failed to parse ternary-expression: expected %q and %q", common.If, common.Then)
+	}
+
+	return what, nil, nil
+}
+
+func (what *TernaryExpression) EvalAny(scope *common.Scope) (common.Value, string, error) {
+	condition, errorLiteral, evalError := what.condition.EvalBool(scope)
+	if evalError != nil {
+		return nil, errorLiteral, fmt.Errorf("failed to eval condition of ternary-expression: %w", evalError)
+	}
+
+	if condition.BoolValue() {
+		return what.then.EvalAny(scope)
+	}
+
+	if what.otherwise == nil {
+		return common.NilValue(), "", nil
+	}
+
+	return what.otherwise.EvalAny(scope)
+}
+
+func (what *TernaryExpression) Literal() string {
+	return what.literal
+}
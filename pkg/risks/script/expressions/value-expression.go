@@ -85,6 +85,10 @@ func (what *ValueExpression) evalArray(scope *common.Scope, anyValue common.Valu
 			return common.EmptyArrayValue(), errorLiteral, evalError
 		}
 
+		if common.IsBlank(value) {
+			return common.EmptyArrayValue(), "", nil
+		}
+
 		arrayValue, arrayValueError := common.ToArrayValue(value)
 		return arrayValue, what.Literal(), arrayValueError
 
@@ -219,6 +223,10 @@ func (what *ValueExpression) stringToDecimal(scope *common.Scope, valueString *c
 		return common.EmptyDecimalValue(), errorLiteral, evalError
 	}
 
+	if value == nil {
+		return common.SomeDecimalValue(decimal.Zero, valueString.Event()), "", nil
+	}
+
 	switch castValue := value.Value().(type) {
 	case decimal.Decimal:
 		return common.SomeDecimalValue(castValue, value.Event()), "", nil
@@ -245,6 +253,10 @@ func (what *ValueExpression) stringToString(scope *common.Scope, valueString *co
 		return common.EmptyStringValue(), errorLiteral, evalError
 	}
 
+	if value == nil {
+		return common.EmptyStringValue(), "", nil
+	}
+
 	switch castValue := value.Value().(type) {
 	case string:
 		return common.SomeStringValue(castValue, value.Event()), "", nil
@@ -261,8 +273,28 @@ func (what *ValueExpression) stringToString(scope *common.Scope, valueString *co
 }
 
 func (what *ValueExpression) evalStringReference(scope *common.Scope, ref *common.StringValue) (common.Value, string, error) {
+	// method calls are resolved first, so that their arguments are split before text values are filled in; their
+	// results are kept in temporary variables, so that nested calls get them with their type
+	callResults := make([]string, 0)
+	defer func() {
+		for _, name := range callResults {
+			delete(scope.Vars, name)
+		}
+	}()
+
+	funcRe := `(\w+)\(([^()]+)\)`
+	resolvedValue, errorLiteral, evalError := what.resolveMethodCalls(scope, funcRe, ref, &callResults)
+	if evalError != nil {
+		return common.EmptyStringValue(), errorLiteral, evalError
+	}
+
+	if regexp.MustCompile(`^` + funcRe + `$`).MatchString(resolvedValue.StringValue()) {
+		genericValue, genericErrorLiteral, genericEvalError := what.resolveMethodCall(scope, funcRe, resolvedValue)
+		return common.SomeValue(genericValue, ref.Event()), genericErrorLiteral, genericEvalError
+	}
+
 	varRe := `\{[^{}]+}`
-	value := what.resolveStringValues(scope, varRe, ref)
+	value := what.resolveStringValues(scope, varRe, resolvedValue)
 	if regexp.MustCompile(`^` + varRe + `$`).MatchString(value.StringValue()) {
 		returnValue, ok := scope.Get(value.StringValue()[1 : len(value.StringValue())-1])
 		if ok {
@@ -273,18 +305,14 @@ func (what *ValueExpression) evalStringReference(scope *common.Scope, ref *commo
 		//		return common.SomeStringValue(value.StringValue()[1:len(value.StringValue())-1], nil), "", nil
 	}
 
-	funcRe := `(\w+)\(([^()]+)\)`
-	resolvedValue, errorLiteral, evalError := what.resolveMethodCalls(scope, funcRe, value)
-	if evalError != nil {
-		return common.EmptyStringValue(), errorLiteral, evalError
-	}
-
-	if regexp.MustCompile(`^` + funcRe + `$`).MatchString(resolvedValue.StringValue()) {
-		genericValue, genericErrorLiteral, genericEvalError := what.resolveMethodCall(scope, funcRe, resolvedValue)
-		return common.SomeValue(genericValue, ref.Event()), genericErrorLiteral, genericEvalError
+	text := value.StringValue()
+	for _, name := range callResults {
+		if callResult, ok := scope.Vars[name]; ok {
+			text = strings.ReplaceAll(text, "{"+name+"}", fmt.Sprintf("%v", callResult.PlainValue()))
+		}
 	}
 
-	return common.SomeValue(resolvedValue, ref.Event()), "", nil
+	return common.SomeValue(common.SomeStringValue(text, value.Event()), ref.Event()), "", nil
 }
 
 func (what *ValueExpression) resolveStringValues(scope *common.Scope, reString string, value *common.StringValue) *common.StringValue {
@@ -302,6 +330,14 @@ func (what *ValueExpression) resolveStringValues(scope *common.Scope, reString s
 			replacements++
 			return castItem
 
+		case nil: // blank value of a null-safe access, kept if it is the whole text to keep its type
+			if name == value.StringValue() {
+				return name
+			}
+
+			replacements++
+			return ""
+
 		case common.Value:
 			values = append(values, castItem)
 			stringValue := castItem.PlainValue()
@@ -326,7 +362,7 @@ func (what *ValueExpression) resolveStringValues(scope *common.Scope, reString s
 	return common.SomeStringValue(text, value.Event())
 }
 
-func (what *ValueExpression) resolveMethodCalls(scope *common.Scope, reString string, value *common.StringValue) (*common.StringValue, string, error) {
+func (what *ValueExpression) resolveMethodCalls(scope *common.Scope, reString string, value *common.StringValue, callResults *[]string) (*common.StringValue, string, error) {
 	replacements := 0
 	values := make([]common.Value, 0)
 	text := regexp.MustCompile(reString).ReplaceAllStringFunc(value.StringValue(), func(name string) string {
@@ -335,25 +371,32 @@ func (what *ValueExpression) resolveMethodCalls(scope *common.Scope, reString st
 			return name
 		}
 
-		if returnValue != nil {
-			stringValue, valueError := common.ToString(returnValue)
-			if valueError != nil {
-				return name
-			}
-
-			replacements++
-			values = append(values, stringValue)
-			return stringValue.StringValue()
+		if returnValue == nil {
+			returnValue = common.NilValue()
 		}
 
-		return name
+		replacements++
+		values = append(values, returnValue)
+		return "{" + what.setCallResult(scope, returnValue, callResults) + "}"
 	})
 
 	if replacements == 0 {
 		return common.SomeStringValue(text, value.Event().From(values...)), "", nil
 	}
 
-	return what.resolveMethodCalls(scope, reString, common.SomeStringValue(text, value.Event().From(values...)))
+	return what.resolveMethodCalls(scope, reString, common.SomeStringValue(text, value.Event().From(values...)), callResults)
+}
+
+// setCallResult stores the result of a method call in a new temporary variable and returns its name
+func (what *ValueExpression) setCallResult(scope *common.Scope, value common.Value, callResults *[]string) string {
+	for index := len(scope.Vars); ; index++ {
+		name := fmt.Sprintf("$call%d", index)
+		if _, ok := scope.Vars[name]; !ok {
+			scope.Set(name, value)
+			*callResults = append(*callResults, name)
+			return name
+		}
+	}
 }
 
 func (what *ValueExpression) resolveMethodCall(scope *common.Scope, reString string, value *common.StringValue) (common.Value, string, error) {
@@ -378,24 +421,17 @@ func (what *ValueExpression) resolveMethodCall(scope *common.Scope, reString str
 		}
 	}
 
-	method, ok := scope.Methods[name]
-	if ok {
-		newScope, cloneError := scope.Clone()
-		if cloneError != nil {
-			return common.NilValue(), what.Literal(), fmt.Errorf("failed to clone scope: %w", cloneError)
-		}
-
-		newScope.Args = args
-		errorLiteral, runError := method.Run(newScope)
-		if runError != nil {
-			return common.NilValue(), errorLiteral, fmt.Errorf("failed to run method %q: %w", name, runError)
+	if _, ok := scope.Methods[name]; ok {
+		returnValue, errorLiteral, callError := scope.CallMethod(name, args...)
+		if callError != nil && len(errorLiteral) == 0 {
+			errorLiteral = what.Literal()
 		}
 
-		return newScope.GetReturnValue(), "", nil
+		return returnValue, errorLiteral, callError
 	}
 
 	if common.IsBuiltIn(name) {
-		callValue, callError := common.CallBuiltIn(name, args...)
+		callValue, callError := common.CallBuiltIn(scope, name, args...)
 		if callError != nil {
 			return common.NilValue(), what.Literal(), fmt.Errorf("failed to call %q: %w", name, callError)
 		}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/threagile/threagile/pkg/input"
+	"github.com/threagile/threagile/pkg/risks/script/common"
 	"github.com/threagile/threagile/pkg/types"
 	"gopkg.in/yaml.v3"
 )
@@ -59,6 +60,23 @@ func (what *RiskRule) SupportedTags() []string {
 }
 
 func (what *RiskRule) GenerateRisks(parsedModel *types.Model) ([]*types.Risk, error) {
+	modelView, viewError := NewModelView(parsedModel)
+	if viewError != nil {
+		return nil, viewError
+	}
+
+	return what.GenerateRisksForModelView(modelView, nil)
+}
+
+// NewModelView computes the view of the model evaluated by the script risk rules. The rules of one analysis can share
+// it read-only, so the model is converted once instead of once per rule.
+func NewModelView(parsedModel *types.Model) (map[string]any, error) {
+	return common.NewView(parsedModel)
+}
+
+// GenerateRisksForModelView generates the risks of the rule for a view created with NewModelView, severities are
+// calculated with the given matrix (nil is the default matrix)
+func (what *RiskRule) GenerateRisksForModelView(modelView map[string]any, severityMatrix *types.SeverityMatrix) ([]*types.Risk, error) {
 	if what.script == nil {
 		return nil, fmt.Errorf("no script found in risk rule")
 	}
@@ -68,10 +86,8 @@ func (what *RiskRule) GenerateRisks(parsedModel *types.Model) ([]*types.Risk, er
 		return nil, scopeError
 	}
 
-	modelError := newScope.SetModel(parsedModel)
-	if modelError != nil {
-		return nil, modelError
-	}
+	newScope.SetModelView(modelView)
+	newScope.SetSeverityMatrix(severityMatrix)
 
 	newRisks, errorLiteral, riskError := what.script.GenerateRisks(newScope)
 	if riskError != nil {
@@ -0,0 +1,313 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/threagile/threagile/pkg/risks/script/common"
+	"github.com/threagile/threagile/pkg/risks/script/expressions"
+	"github.com/threagile/threagile/pkg/types"
+)
+
+const crossingLinksRule = `
+id: crossing-links
+title: Crossing Links
+function: architecture
+stride: tampering
+risk:
+  id:
+    parameter: tech_asset
+    id: "{$risk.id}@{tech_asset.id}"
+  data:
+    parameter: tech_asset
+    title: "format(%v is reached from %v, upper({tech_asset.id}), join(sort(map(crossing_links({tech_asset}), source_of))))"
+    severity: "calculate_severity(likely, medium)"
+    exploitation_likelihood: likely
+    exploitation_impact: medium
+    most_relevant_technical_asset: "{tech_asset.id}"
+  match:
+    parameter: tech_asset
+    do:
+      - if:
+          greater:
+            first: "length(crossing_links({tech_asset}))"
+            second: 0
+          then:
+            return: true
+  utils:
+    crossing_links:
+      parameters:
+        - tech_asset
+      do:
+        - return: "filter(incoming_links({tech_asset}), is_across_trust_boundary_network_only)"
+    source_of:
+      parameters:
+        - link
+      do:
+        - return: "{link.source_id}"
+`
+
+func TestRiskRuleBuiltIns(t *testing.T) {
+	rule, parseError := new(RiskRule).Init().ParseFromData([]byte(crossingLinksRule))
+	assert.NoError(t, parseError)
+
+	webToDb := &types.CommunicationLink{Id: "web>db", SourceId: "web", TargetId: "db"}
+	apiToDb := &types.CommunicationLink{Id: "api>db", SourceId: "api", TargetId: "db"}
+	jobToDb := &types.CommunicationLink{Id: "job>db", SourceId: "job", TargetId: "db"}
+	dmz := &types.TrustBoundary{Id: "dmz", Type: types.NetworkCloudSecurityGroup, TechnicalAssetsInside: []string{"web", "api"}}
+	internal := &types.TrustBoundary{Id: "internal", Type: types.NetworkOnPrem, TechnicalAssetsInside: []string{"db", "job"}}
+
+	risks, riskError := rule.GenerateRisks(&types.Model{
+		TechnicalAssets: map[string]*types.TechnicalAsset{
+			"web": {Id: "web", CommunicationLinks: []*types.CommunicationLink{webToDb}},
+			"api": {Id: "api", CommunicationLinks: []*types.CommunicationLink{apiToDb}},
+			"job": {Id: "job", CommunicationLinks: []*types.CommunicationLink{jobToDb}},
+			"db":  {Id: "db"},
+		},
+		TrustBoundaries:    map[string]*types.TrustBoundary{"dmz": dmz, "internal": internal},
+		CommunicationLinks: map[string]*types.CommunicationLink{"web>db": webToDb, "api>db": apiToDb, "job>db": jobToDb},
+		IncomingTechnicalCommunicationLinksMappedByTargetId: map[string][]*types.CommunicationLink{"db": {webToDb, apiToDb, jobToDb}},
+		DirectContainingTrustBoundaryMappedByTechnicalAssetId: map[string]*types.TrustBoundary{
+			"web": dmz, "api": dmz, "db": internal, "job": internal,
+		},
+	})
+	assert.NoError(t, riskError)
+	assert.Len(t, risks, 1)
+	assert.Equal(t, "crossing-links@db", risks[0].SyntheticId)
+	assert.Equal(t, "DB is reached from api, web", risks[0].Title)
+	assert.Equal(t, types.ElevatedSeverity, risks[0].Severity)
+}
+
+const exposedAssetsRule = `
+id: exposed-assets
+title: Exposed Assets
+function: architecture
+stride: elevation-of-privilege
+risk:
+  id:
+    parameter: tech_asset
+    id: "{$risk.id}@{tech_asset.id}"
+  data:
+    parameter: tech_asset
+    title: "get_title({tech_asset})"
+    severity: "calculate_severity(likely, medium)"
+    exploitation_likelihood: likely
+    exploitation_impact: medium
+    most_relevant_technical_asset: "{tech_asset.id}"
+  match:
+    parameter: tech_asset
+    do:
+      - if:
+          and:
+            - false: "{tech_asset.internet}"
+            - reachable-from-internet: "{tech_asset}"
+          then:
+            return: true
+  utils:
+    get_title:
+      parameters:
+        - tech_asset
+      do:
+        - assign:
+            - calling:
+                callers: "{tech_asset}"
+            - downstream:
+                reachable:
+                  from: "{tech_asset}"
+                  hops: 1
+        - loop:
+            in: "incoming_links({tech_asset})"
+            item: link
+            do:
+              - assign:
+                  crossed:
+                    crossed-trust-boundaries: "{link}"
+        - return: "format(%v: callers %v / downstream %v / crossed %v, {tech_asset.id}, join(map({calling}, get_id)), join(map({downstream}, get_id)), join(map({crossed}, get_id)))"
+    get_id:
+      parameters:
+        - item
+      do:
+        - return: "{item.id}"
+`
+
+func TestRiskRuleGraphExpressions(t *testing.T) {
+	rule, parseError := new(RiskRule).Init().ParseFromData([]byte(exposedAssetsRule))
+	assert.NoError(t, parseError)
+
+	browserToWeb := &types.CommunicationLink{Id: "browser>web", SourceId: "browser", TargetId: "web"}
+	webToApi := &types.CommunicationLink{Id: "web>api", SourceId: "web", TargetId: "api"}
+	apiToDb := &types.CommunicationLink{Id: "api>db", SourceId: "api", TargetId: "db"}
+	jobToDb := &types.CommunicationLink{Id: "job>db", SourceId: "job", TargetId: "db"}
+	dmz := &types.TrustBoundary{Id: "dmz", Type: types.NetworkCloudSecurityGroup, TechnicalAssetsInside: []string{"web"}}
+	internal := &types.TrustBoundary{Id: "internal", Type: types.NetworkOnPrem, TechnicalAssetsInside: []string{"db"}, TrustBoundariesNested: []string{"cluster"}}
+	cluster := &types.TrustBoundary{Id: "cluster", Type: types.ExecutionEnvironment, TechnicalAssetsInside: []string{"api"}}
+
+	risks, riskError := rule.GenerateRisks(&types.Model{
+		TechnicalAssets: map[string]*types.TechnicalAsset{
+			"browser": {Id: "browser", Internet: true, CommunicationLinks: []*types.CommunicationLink{browserToWeb}},
+			"web":     {Id: "web", CommunicationLinks: []*types.CommunicationLink{webToApi}},
+			"api":     {Id: "api", CommunicationLinks: []*types.CommunicationLink{apiToDb}},
+			"job":     {Id: "job", CommunicationLinks: []*types.CommunicationLink{jobToDb}},
+			"db":      {Id: "db"},
+		},
+		TrustBoundaries: map[string]*types.TrustBoundary{"dmz": dmz, "internal": internal, "cluster": cluster},
+		CommunicationLinks: map[string]*types.CommunicationLink{
+			"browser>web": browserToWeb, "web>api": webToApi, "api>db": apiToDb, "job>db": jobToDb,
+		},
+		IncomingTechnicalCommunicationLinksMappedByTargetId: map[string][]*types.CommunicationLink{
+			"web": {browserToWeb}, "api": {webToApi}, "db": {apiToDb, jobToDb},
+		},
+		DirectContainingTrustBoundaryMappedByTechnicalAssetId: map[string]*types.TrustBoundary{"web": dmz, "db": internal, "api": cluster},
+	})
+	assert.NoError(t, riskError)
+
+	titles := make(map[string]string)
+	for _, risk := range risks {
+		titles[risk.SyntheticId] = risk.Title
+	}
+
+	assert.Equal(t, map[string]string{
+		"exposed-assets@web": "web: callers browser / downstream api / crossed dmz",
+		"exposed-assets@api": "api: callers web / downstream db / crossed dmz, internal, cluster",
+		"exposed-assets@db":  "db: callers api, job / downstream  / crossed internal",
+	}, titles)
+}
+
+const weightedExposureRule = `
+id: weighted-exposure
+title: Weighted Exposure
+function: business-side
+stride: information-disclosure
+risk:
+  id:
+    parameter: tech_asset
+    id: "{$risk.id}@{tech_asset.id}"
+  data:
+    parameter: tech_asset
+    title: "get_title({tech_asset})"
+    severity: "calculate_severity(likely, medium)"
+    exploitation_likelihood: likely
+    exploitation_impact: medium
+    most_relevant_technical_asset: "{tech_asset.id}"
+  match:
+    parameter: tech_asset
+    do:
+      - return: true
+  utils:
+    get_title:
+      parameters:
+        - tech_asset
+      do:
+        - assign:
+            - score: "get_score({tech_asset})"
+        - assign:
+            - label:
+                ternary:
+                  if:
+                    greater:
+                      first: "{score}"
+                      second: 4
+                  then: high
+                  else: low
+            - owner:
+                coalesce:
+                  - "{tech_asset.owner?}"
+                  - nobody
+        - return: "format(%v scores %v [%v] owned by %v, {tech_asset.id}, {score}, {label}, {owner})"
+    get_score:
+      parameters:
+        - tech_asset
+      do:
+        - assign:
+            - score: 0
+        - loop:
+            in: "{tech_asset.data_assets_processed}"
+            item: data_id
+            do:
+              - assign:
+                  score:
+                    max:
+                      - "{score}"
+                      - multiply:
+                          - add:
+                              values:
+                                - "{$model.data_assets.{data_id}?.confidentiality}"
+                              as: confidentiality
+                          - add:
+                              values:
+                                - "{$model.data_assets.{data_id}?.quantity}"
+                              as: quantity
+                          - divide:
+                              - subtract: [10, 4]
+                              - 3
+        - return: "{score}"
+`
+
+func TestRiskRuleArithmeticExpressions(t *testing.T) {
+	rule, parseError := new(RiskRule).Init().ParseFromData([]byte(weightedExposureRule))
+	assert.NoError(t, parseError)
+
+	risks, riskError := rule.GenerateRisks(&types.Model{
+		DataAssets: map[string]*types.DataAsset{
+			"customers": {Id: "customers", Confidentiality: types.StrictlyConfidential, Quantity: types.Many},
+			"logs":      {Id: "logs", Confidentiality: types.Public, Quantity: types.VeryMany},
+		},
+		TechnicalAssets: map[string]*types.TechnicalAsset{
+			"web":   {Id: "web", Owner: "team", DataAssetsProcessed: []string{"logs", "customers", "unknown"}},
+			"batch": {Id: "batch", DataAssetsProcessed: []string{"logs"}},
+		},
+	})
+	assert.NoError(t, riskError)
+
+	titles := make(map[string]string)
+	for _, risk := range risks {
+		titles[risk.SyntheticId] = risk.Title
+	}
+
+	assert.Equal(t, map[string]string{
+		"weighted-exposure@web":   "web scores 16 [high] owned by team",
+		"weighted-exposure@batch": "batch scores 0 [low] owned by nobody",
+	}, titles)
+}
+
+func TestArithmeticExpressionsWithBlankOperands(t *testing.T) {
+	eval := func(script map[string]any) (string, error) {
+		expression, _, parseError := new(expressions.ExpressionList).ParseAny(script)
+		assert.NoError(t, parseError)
+
+		scope := new(common.Scope)
+		scope.Set("present", common.SomeValue("7", nil))
+		value, _, evalError := expression.EvalAny(scope)
+		if evalError != nil {
+			return "", evalError
+		}
+
+		if common.IsBlank(value) {
+			return "", nil
+		}
+
+		return value.(*common.DecimalValue).DecimalValue().String(), nil
+	}
+
+	value, err := eval(map[string]any{"min": []any{"{missing?}", 5}})
+	assert.NoError(t, err)
+	assert.Equal(t, "5", value)
+
+	value, err = eval(map[string]any{"max": []any{"{missing?}", "{present}", 5}})
+	assert.NoError(t, err)
+	assert.Equal(t, "7", value)
+
+	value, err = eval(map[string]any{"max": []any{"{missing?}", "{other?}"}})
+	assert.NoError(t, err)
+	assert.Empty(t, value)
+
+	value, err = eval(map[string]any{"add": []any{"{missing?}", "{present}"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "7", value)
+
+	_, err = eval(map[string]any{"divide": []any{"{present}", "{missing?}"}})
+	assert.ErrorContains(t, err, "blank")
+
+	_, err = eval(map[string]any{"divide": []any{"{present}", 0}})
+	assert.ErrorContains(t, err, "division by zero")
+}
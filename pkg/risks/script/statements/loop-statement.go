@@ -9,7 +9,7 @@ import (
 
 type LoopStatement struct {
 	literal string
-	in      common.ValueExpression
+	in      common.Expression
 	item    string
 	index   string
 	body    common.Statement
@@ -23,7 +23,7 @@ func (what *LoopStatement) Parse(script any) (common.Statement, any, error) {
 		for key, value := range script.(map[string]any) {
 			switch key {
 			case common.In:
-				item, errorExpression, itemError := new(expressions.ValueExpression).ParseValue(value)
+				item, errorExpression, itemError := new(expressions.ExpressionList).ParseAny(value)
 				if itemError != nil {
 					return nil, errorExpression, fmt.Errorf("failed to parse %q of loop-statement: %w", key, itemError)
 				}
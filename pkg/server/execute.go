@@ -187,6 +187,7 @@ func (s *server) doItViaRuntimeCall(modelFile string, outputDir string,
 		"--output", outputDir,
 		"--execute-model-macro", s.config.GetExecuteModelMacro(),
 		"--custom-risk-rules-plugin", strings.Join(s.config.GetRiskRulePlugins(), ","),
+		"--script-rules", strings.Join(s.config.GetScriptRiskRules(), ","),
 		"--skip-risk-rules", strings.Join(s.config.GetSkipRiskRules(), ","),
 		"--diagram-dpi", strconv.Itoa(dpi),
 	}
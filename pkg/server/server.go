@@ -50,6 +50,7 @@ type serverConfigReader interface {
 	GetTemplateFilename() string
 	GetTechnologyFilename() string
 	GetRiskRulePlugins() []string
+	GetScriptRiskRules() []string
 	GetSkipRiskRules() []string
 	GetExecuteModelMacro() string
 	GetServerMode() bool
@@ -62,6 +63,7 @@ type serverConfigReader interface {
 	GetAddLegend() bool
 	GetKeepDiagramSourceFiles() bool
 	GetIgnoreOrphanedRiskTracking() bool
+	GetSeverityMatrix() *types.SeverityMatrix
 	GetThreagileVersion() string
 	GetProgressReporter() types.ProgressReporter
 }
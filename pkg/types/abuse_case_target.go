@@ -0,0 +1,7 @@
+package types
+
+// AbuseCaseTarget references the data assets and technical assets an abuse case is after
+type AbuseCaseTarget struct {
+	DataAssets      []string `json:"data_assets,omitempty" yaml:"data_assets,omitempty"`
+	TechnicalAssets []string `json:"technical_assets,omitempty" yaml:"technical_assets,omitempty"`
+}
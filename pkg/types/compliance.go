@@ -0,0 +1,112 @@
+package types
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed compliance.yaml
+var complianceLocation embed.FS
+
+// ComplianceMap links the controls of compliance frameworks to the risk categories checking them, keyed by framework id
+type ComplianceMap map[string]ComplianceFramework
+
+type ComplianceFramework struct {
+	Title    string                       `json:"title,omitempty" yaml:"title,omitempty"`
+	Controls map[string]ComplianceControl `json:"controls,omitempty" yaml:"controls,omitempty"`
+}
+
+type ComplianceControl struct {
+	Title          string   `json:"title,omitempty" yaml:"title,omitempty"`
+	RiskCategories []string `json:"risk_categories,omitempty" yaml:"risk_categories,omitempty"`
+}
+
+type complianceMapConfigReader interface {
+	GetAppFolder() string
+	GetComplianceFilename() string
+}
+
+// LoadWithConfig loads the mapping from the app folder (or the built-in default) and merges the additional mapping
+// file from the config into it: frameworks are added, controls of known frameworks are added or replaced
+func (what ComplianceMap) LoadWithConfig(config complianceMapConfigReader, defaultFilename string) error {
+	complianceFilename := filepath.Join(config.GetAppFolder(), defaultFilename)
+	_, statError := os.Stat(complianceFilename)
+	if statError == nil {
+		complianceLoadError := what.LoadFromFile(complianceFilename)
+		if complianceLoadError != nil {
+			return fmt.Errorf("error loading compliance mapping: %w", complianceLoadError)
+		}
+	} else {
+		complianceLoadError := what.LoadDefault()
+		if complianceLoadError != nil {
+			return fmt.Errorf("error loading compliance mapping: %w", complianceLoadError)
+		}
+	}
+
+	if len(config.GetComplianceFilename()) > 0 {
+		additionalCompliance := make(ComplianceMap)
+		loadError := additionalCompliance.LoadFromFile(config.GetComplianceFilename())
+		if loadError != nil {
+			return fmt.Errorf("error loading additional compliance mapping from %q: %v", config.GetComplianceFilename(), loadError)
+		}
+
+		what.Merge(additionalCompliance)
+	}
+
+	return nil
+}
+
+func (what ComplianceMap) LoadDefault() error {
+	defaultComplianceFile, readError := complianceLocation.ReadFile("compliance.yaml")
+	if readError != nil {
+		return fmt.Errorf("error reading default compliance mapping: %w", readError)
+	}
+
+	unmarshalError := yaml.Unmarshal(defaultComplianceFile, &what)
+	if unmarshalError != nil {
+		return fmt.Errorf("error parsing default compliance mapping: %w", unmarshalError)
+	}
+
+	return nil
+}
+
+func (what ComplianceMap) LoadFromFile(filename string) error {
+	// #nosec G304 // fine for potential file for now because used mostly internally or as part of CI/CD
+	data, readError := os.ReadFile(filename)
+	if readError != nil {
+		return fmt.Errorf("error reading compliance mapping from %q: %w", filename, readError)
+	}
+
+	unmarshalError := yaml.Unmarshal(data, &what)
+	if unmarshalError != nil {
+		return fmt.Errorf("error parsing compliance mapping from %q: %w", filename, unmarshalError)
+	}
+
+	return nil
+}
+
+// Merge adds the frameworks of the other mapping, controls of frameworks present in both are added or replaced
+func (what ComplianceMap) Merge(other ComplianceMap) {
+	for id, framework := range other {
+		existing, ok := what[id]
+		if !ok {
+			what[id] = framework
+			continue
+		}
+
+		if len(framework.Title) > 0 {
+			existing.Title = framework.Title
+		}
+		if existing.Controls == nil {
+			existing.Controls = make(map[string]ComplianceControl)
+		}
+		for controlId, control := range framework.Controls {
+			existing.Controls[controlId] = control
+		}
+		what[id] = existing
+	}
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type complianceConfig struct {
+	appFolder          string
+	complianceFilename string
+}
+
+func (c complianceConfig) GetAppFolder() string          { return c.appFolder }
+func (c complianceConfig) GetComplianceFilename() string { return c.complianceFilename }
+
+func TestLoadDefaultComplianceMap(t *testing.T) {
+	complianceMap := make(ComplianceMap)
+	assert.NoError(t, complianceMap.LoadDefault())
+
+	for _, id := range []string{"iso-27001", "nist-800-53", "pci-dss", "soc-2", "owasp-top-10"} {
+		assert.NotEmpty(t, complianceMap[id].Title, id)
+		assert.NotEmpty(t, complianceMap[id].Controls, id)
+	}
+	assert.Contains(t, complianceMap["owasp-top-10"].Controls["A03:2021"].RiskCategories, "sql-nosql-injection")
+	assert.Contains(t, complianceMap["pci-dss"].Controls, "6.2")
+}
+
+func TestLoadComplianceMapWithAdditionalFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "additional-compliance.yaml")
+	assert.NoError(t, os.WriteFile(filename, []byte(`
+owasp-top-10:
+  controls:
+    A09:2021:
+      title: Logging
+      risk_categories: [missing-logging]
+internal-policy:
+  title: Internal Policy
+  controls:
+    SEC-1:
+      title: Encrypt everything
+      risk_categories: [unencrypted-asset, unencrypted-communication]
+`), 0600))
+
+	complianceMap := make(ComplianceMap)
+	assert.NoError(t, complianceMap.LoadWithConfig(complianceConfig{appFolder: t.TempDir(), complianceFilename: filename}, "compliance.yaml"))
+
+	assert.Equal(t, "OWASP Top 10 (2021)", complianceMap["owasp-top-10"].Title)
+	assert.Equal(t, []string{"missing-logging"}, complianceMap["owasp-top-10"].Controls["A09:2021"].RiskCategories)
+	assert.Contains(t, complianceMap["owasp-top-10"].Controls, "A03:2021")
+	assert.Equal(t, "Internal Policy", complianceMap["internal-policy"].Title)
+
+	assert.Error(t, make(ComplianceMap).LoadWithConfig(complianceConfig{appFolder: t.TempDir(), complianceFilename: filename + ".missing"}, "compliance.yaml"))
+}
@@ -17,6 +17,8 @@ type DataAsset struct {
 	Integrity              Criticality     `yaml:"integrity,omitempty" json:"integrity,omitempty"`
 	Availability           Criticality     `yaml:"availability,omitempty" json:"availability,omitempty"`
 	JustificationCiaRating string          `yaml:"justification_cia_rating,omitempty" json:"justification_cia_rating,omitempty"`
+	RecordValue            float64         `yaml:"record_value,omitempty" json:"record_value,omitempty"`
+	BreachCostPerRecord    float64         `yaml:"breach_cost_per_record,omitempty" json:"breach_cost_per_record,omitempty"`
 }
 
 func (what DataAsset) IsTaggedWithAny(tags ...string) bool {
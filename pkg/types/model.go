@@ -27,10 +27,12 @@ type Model struct {
 	BusinessOverview                              *Overview                     `json:"business_overview,omitempty" yaml:"business_overview,omitempty"`
 	TechnicalOverview                             *Overview                     `json:"technical_overview,omitempty" yaml:"technical_overview,omitempty"`
 	BusinessCriticality                           Criticality                   `json:"business_criticality,omitempty" yaml:"business_criticality,omitempty"`
+	Currency                                      string                        `json:"currency,omitempty" yaml:"currency,omitempty"`
 	ManagementSummaryComment                      string                        `json:"management_summary_comment,omitempty" yaml:"management_summary_comment,omitempty"`
 	SecurityRequirements                          map[string]string             `json:"security_requirements,omitempty" yaml:"security_requirements,omitempty"`
 	Questions                                     map[string]string             `json:"questions,omitempty" yaml:"questions,omitempty"`
 	AbuseCases                                    map[string]string             `json:"abuse_cases,omitempty" yaml:"abuse_cases,omitempty"`
+	AbuseCaseTargets                              map[string]*AbuseCaseTarget   `json:"abuse_case_targets,omitempty" yaml:"abuse_case_targets,omitempty"`
 	TagsAvailable                                 []string                      `json:"tags_available,omitempty" yaml:"tags_available,omitempty"`
 	DataAssets                                    map[string]*DataAsset         `json:"data_assets,omitempty" yaml:"data_assets,omitempty"`
 	TechnicalAssets                               map[string]*TechnicalAsset    `json:"technical_assets,omitempty" yaml:"technical_assets,omitempty"`
@@ -228,23 +230,6 @@ func (model *Model) CheckNestedTrustBoundariesExisting() error {
 	return nil
 }
 
-func CalculateSeverity(likelihood RiskExploitationLikelihood, impact RiskExploitationImpact) RiskSeverity {
-	result := likelihood.Weight() * impact.Weight()
-	if result <= 1 {
-		return LowSeverity
-	}
-	if result <= 3 {
-		return MediumSeverity
-	}
-	if result <= 8 {
-		return ElevatedSeverity
-	}
-	if result <= 12 {
-		return HighSeverity
-	}
-	return CriticalSeverity
-}
-
 func (model *Model) InScopeTechnicalAssets() []*TechnicalAsset {
 	result := make([]*TechnicalAsset, 0)
 	for _, asset := range model.TechnicalAssets {
@@ -0,0 +1,191 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SeverityMatrix maps the exploitation likelihood and impact of a risk to its severity: the product of the likelihood
+// weight and the impact weight is rated with the lowest severity whose threshold it does not exceed (critical above
+// all thresholds), cells of the matrix override the rating. Weights, thresholds and cells left out fall back to the
+// default matrix, categories override the matrix per risk category id.
+type SeverityMatrix struct {
+	LikelihoodWeights map[string]float64           `json:"LikelihoodWeights,omitempty" yaml:"LikelihoodWeights"`
+	ImpactWeights     map[string]float64           `json:"ImpactWeights,omitempty" yaml:"ImpactWeights"`
+	Thresholds        map[string]float64           `json:"Thresholds,omitempty" yaml:"Thresholds"`
+	Matrix            map[string]map[string]string `json:"Matrix,omitempty" yaml:"Matrix"`
+	Categories        map[string]*SeverityMatrix   `json:"Categories,omitempty" yaml:"Categories"`
+}
+
+// CalculateSeverity rates the likelihood and impact of a risk with the default matrix
+func CalculateSeverity(likelihood RiskExploitationLikelihood, impact RiskExploitationImpact) RiskSeverity {
+	return (*SeverityMatrix)(nil).Severity("", likelihood, impact)
+}
+
+// Severity rates the likelihood and impact of a risk of the given category, a nil matrix is the default matrix
+func (what *SeverityMatrix) Severity(categoryId string, likelihood RiskExploitationLikelihood, impact RiskExploitationImpact) RiskSeverity {
+	if what != nil {
+		if category, ok := what.category(categoryId); ok {
+			if severity, ok := category.cell(likelihood, impact); ok {
+				return severity
+			}
+		}
+		if severity, ok := what.cell(likelihood, impact); ok {
+			return severity
+		}
+	}
+
+	result := what.likelihoodWeight(categoryId, likelihood) * what.impactWeight(categoryId, impact)
+	for _, severity := range []RiskSeverity{LowSeverity, MediumSeverity, ElevatedSeverity, HighSeverity} {
+		if result <= what.threshold(categoryId, severity) {
+			return severity
+		}
+	}
+	return CriticalSeverity
+}
+
+// Validate checks the names of likelihoods, impacts and severities and that the thresholds ascend
+func (what *SeverityMatrix) Validate() error {
+	if what == nil {
+		return nil
+	}
+	if err := what.validateNames(); err != nil {
+		return err
+	}
+	if err := what.validateThresholds(""); err != nil {
+		return err
+	}
+	for categoryId, category := range what.Categories {
+		if category == nil {
+			continue
+		}
+		if len(category.Categories) > 0 {
+			return fmt.Errorf("severity matrix of category %q must not override categories", categoryId)
+		}
+		if err := category.validateNames(); err != nil {
+			return fmt.Errorf("severity matrix of category %q: %w", categoryId, err)
+		}
+		if err := what.validateThresholds(categoryId); err != nil {
+			return fmt.Errorf("severity matrix of category %q: %w", categoryId, err)
+		}
+	}
+	return nil
+}
+
+func (what *SeverityMatrix) validateNames() error {
+	for name := range what.LikelihoodWeights {
+		if _, err := ParseRiskExploitationLikelihood(name); err != nil || len(name) == 0 {
+			return fmt.Errorf("unknown likelihood %q in likelihood weights", name)
+		}
+	}
+	for name := range what.ImpactWeights {
+		if _, err := ParseRiskExploitationImpact(name); err != nil || len(name) == 0 {
+			return fmt.Errorf("unknown impact %q in impact weights", name)
+		}
+	}
+	for name := range what.Thresholds {
+		severity, err := ParseRiskSeverity(name)
+		if err != nil || len(name) == 0 || severity == CriticalSeverity {
+			return fmt.Errorf("unknown severity %q in thresholds (critical is above the threshold of high)", name)
+		}
+	}
+	for likelihoodName, impacts := range what.Matrix {
+		if _, err := ParseRiskExploitationLikelihood(likelihoodName); err != nil || len(likelihoodName) == 0 {
+			return fmt.Errorf("unknown likelihood %q in matrix", likelihoodName)
+		}
+		for impactName, severityName := range impacts {
+			if _, err := ParseRiskExploitationImpact(impactName); err != nil || len(impactName) == 0 {
+				return fmt.Errorf("unknown impact %q in matrix", impactName)
+			}
+			if _, err := ParseRiskSeverity(severityName); err != nil || len(severityName) == 0 {
+				return fmt.Errorf("unknown severity %q in matrix", severityName)
+			}
+		}
+	}
+	return nil
+}
+
+func (what *SeverityMatrix) validateThresholds(categoryId string) error {
+	previous := what.threshold(categoryId, LowSeverity)
+	for _, severity := range []RiskSeverity{MediumSeverity, ElevatedSeverity, HighSeverity} {
+		current := what.threshold(categoryId, severity)
+		if current < previous {
+			return fmt.Errorf("threshold of %v (%v) is below the threshold of the severity below (%v)", severity, current, previous)
+		}
+		previous = current
+	}
+	return nil
+}
+
+// OverridesCategory tells whether the matrix has overrides for the risk category
+func (what *SeverityMatrix) OverridesCategory(categoryId string) bool {
+	_, ok := what.category(categoryId)
+	return ok
+}
+
+func (what *SeverityMatrix) category(categoryId string) (*SeverityMatrix, bool) {
+	if what == nil || len(categoryId) == 0 {
+		return nil, false
+	}
+	for id, category := range what.Categories {
+		if category != nil && strings.EqualFold(id, categoryId) {
+			return category, true
+		}
+	}
+	return nil, false
+}
+
+func (what *SeverityMatrix) cell(likelihood RiskExploitationLikelihood, impact RiskExploitationImpact) (RiskSeverity, bool) {
+	for likelihoodName, impacts := range what.Matrix {
+		if !strings.EqualFold(likelihoodName, likelihood.String()) {
+			continue
+		}
+		for impactName, severityName := range impacts {
+			if strings.EqualFold(impactName, impact.String()) {
+				if severity, err := ParseRiskSeverity(severityName); err == nil {
+					return severity, true
+				}
+			}
+		}
+	}
+	return LowSeverity, false
+}
+
+// lookup finds the value of the category, the matrix itself or the default, in that order
+func (what *SeverityMatrix) lookup(categoryId string, values func(matrix *SeverityMatrix) map[string]float64, name string, defaultValue float64) float64 {
+	if category, ok := what.category(categoryId); ok {
+		if value, ok := findWeight(values(category), name); ok {
+			return value
+		}
+	}
+	if what != nil {
+		if value, ok := findWeight(values(what), name); ok {
+			return value
+		}
+	}
+	return defaultValue
+}
+
+func findWeight(values map[string]float64, name string) (float64, bool) {
+	for key, value := range values {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return 0, false
+}
+
+func (what *SeverityMatrix) likelihoodWeight(categoryId string, likelihood RiskExploitationLikelihood) float64 {
+	return what.lookup(categoryId, func(matrix *SeverityMatrix) map[string]float64 { return matrix.LikelihoodWeights },
+		likelihood.String(), float64(likelihood.Weight()))
+}
+
+func (what *SeverityMatrix) impactWeight(categoryId string, impact RiskExploitationImpact) float64 {
+	return what.lookup(categoryId, func(matrix *SeverityMatrix) map[string]float64 { return matrix.ImpactWeights },
+		impact.String(), float64(impact.Weight()))
+}
+
+func (what *SeverityMatrix) threshold(categoryId string, severity RiskSeverity) float64 {
+	return what.lookup(categoryId, func(matrix *SeverityMatrix) map[string]float64 { return matrix.Thresholds },
+		severity.String(), [...]float64{1, 3, 8, 12}[severity])
+}
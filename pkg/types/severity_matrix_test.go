@@ -0,0 +1,55 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultSeverityMatrix(t *testing.T) {
+	expected := [4][4]RiskSeverity{
+		{LowSeverity, MediumSeverity, MediumSeverity, ElevatedSeverity},
+		{MediumSeverity, ElevatedSeverity, ElevatedSeverity, ElevatedSeverity},
+		{MediumSeverity, ElevatedSeverity, HighSeverity, HighSeverity},
+		{ElevatedSeverity, ElevatedSeverity, HighSeverity, CriticalSeverity},
+	}
+	for likelihood := Unlikely; likelihood <= Frequent; likelihood++ {
+		for impact := LowImpact; impact <= VeryHighImpact; impact++ {
+			assert.Equal(t, expected[likelihood][impact], CalculateSeverity(likelihood, impact), "%v %v", likelihood, impact)
+			assert.Equal(t, expected[likelihood][impact], (&SeverityMatrix{}).Severity("any", likelihood, impact), "%v %v", likelihood, impact)
+		}
+	}
+}
+
+func TestConfiguredSeverityMatrix(t *testing.T) {
+	matrix := &SeverityMatrix{
+		LikelihoodWeights: map[string]float64{"frequent": 5},
+		Thresholds:        map[string]float64{"high": 15},
+		Matrix:            map[string]map[string]string{"unlikely": {"very-high": "high"}},
+		Categories: map[string]*SeverityMatrix{
+			"sql-nosql-injection": {Matrix: map[string]map[string]string{"frequent": {"low": "critical"}}, ImpactWeights: map[string]float64{"very-high": 10}},
+		},
+	}
+	assert.NoError(t, matrix.Validate())
+
+	assert.Equal(t, HighSeverity, matrix.Severity("", Unlikely, VeryHighImpact))
+	assert.Equal(t, HighSeverity, matrix.Severity("", Frequent, HighImpact))
+	assert.Equal(t, CriticalSeverity, matrix.Severity("", Frequent, VeryHighImpact))
+	assert.Equal(t, ElevatedSeverity, matrix.Severity("", Frequent, LowImpact))
+	assert.Equal(t, CriticalSeverity, matrix.Severity("SQL-NoSQL-Injection", Frequent, LowImpact))
+	assert.Equal(t, CriticalSeverity, matrix.Severity("sql-nosql-injection", VeryLikely, VeryHighImpact))
+	assert.Equal(t, HighSeverity, matrix.Severity("sql-nosql-injection", Unlikely, VeryHighImpact))
+	assert.True(t, matrix.OverridesCategory("sql-nosql-injection"))
+	assert.False(t, matrix.OverridesCategory("path-traversal"))
+	assert.Equal(t, ElevatedSeverity, CalculateSeverity(Unlikely, VeryHighImpact))
+}
+
+func TestValidateSeverityMatrix(t *testing.T) {
+	assert.NoError(t, (*SeverityMatrix)(nil).Validate())
+	assert.Error(t, (&SeverityMatrix{LikelihoodWeights: map[string]float64{"sometimes": 2}}).Validate())
+	assert.Error(t, (&SeverityMatrix{ImpactWeights: map[string]float64{"huge": 2}}).Validate())
+	assert.Error(t, (&SeverityMatrix{Thresholds: map[string]float64{"critical": 20}}).Validate())
+	assert.Error(t, (&SeverityMatrix{Thresholds: map[string]float64{"elevated": 2}}).Validate())
+	assert.Error(t, (&SeverityMatrix{Matrix: map[string]map[string]string{"likely": {"low": "severe"}}}).Validate())
+	assert.Error(t, (&SeverityMatrix{Categories: map[string]*SeverityMatrix{"x": {Thresholds: map[string]float64{"medium": 0.5}}}}).Validate())
+}
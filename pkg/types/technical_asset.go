@@ -36,6 +36,8 @@ type TechnicalAsset struct {
 	DataFormatsAccepted     []DataFormat          `json:"data_formats_accepted,omitempty" yaml:"data_formats_accepted,omitempty"`
 	CommunicationLinks      []*CommunicationLink  `json:"communication_links,omitempty" yaml:"communication_links,omitempty"`
 	DiagramTweakOrder       int                   `json:"diagram_tweak_order,omitempty" yaml:"diagram_tweak_order,omitempty"`
+	DowntimeCostPerHour     float64               `json:"downtime_cost_per_hour,omitempty" yaml:"downtime_cost_per_hour,omitempty"`
+	LossEventFrequency      float64               `json:"loss_event_frequency,omitempty" yaml:"loss_event_frequency,omitempty"`
 	RAA                     float64               `json:"raa,omitempty" yaml:"raa,omitempty"` // will be set by separate calculation step
 }
 
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -115,6 +118,32 @@ func (what TechnologyMap) Get(name string) *Technology {
 	return &technology
 }
 
+// Find looks up a technology by name or alias, ignoring case. A name takes precedence over aliases, and an alias
+// shared by several technologies resolves to the first of them by name.
+func (what TechnologyMap) Find(name string) *Technology {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if technology, exists := what[name]; exists {
+		technology.Name = name
+		return &technology
+	}
+
+	keys := make([]string, 0, len(what))
+	for key := range what {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		technology := what[key]
+		if slices.ContainsFunc(technology.Aliases, func(alias string) bool { return strings.EqualFold(alias, name) }) {
+			technology.Name = key
+			return &technology
+		}
+	}
+
+	return nil
+}
+
 func (what TechnologyMap) GetAll(names ...string) ([]*Technology, error) {
 	technologies := make([]*Technology, 0)
 	for _, name := range names {
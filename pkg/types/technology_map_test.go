@@ -0,0 +1,22 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTechnologyMapFindResolvesAliasesDeterministically(t *testing.T) {
+	technologies := TechnologyMap{
+		"web-server":  {Aliases: []string{"Apache", "httpd"}},
+		"application": {Aliases: []string{"httpd"}},
+		"apache":      {Aliases: []string{"web-server"}},
+	}
+
+	assert.Equal(t, "web-server", technologies.Find(" Web-Server ").Name)
+	assert.Equal(t, "apache", technologies.Find("APACHE").Name)
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, "application", technologies.Find("httpd").Name)
+	}
+	assert.Nil(t, technologies.Find("nginx"))
+}